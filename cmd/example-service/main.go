@@ -1,6 +1,9 @@
 package main
 
 import (
+	"context"
+	"os"
+
 	"github.com/ElfAstAhe/go-service-template/internal/app"
 	"github.com/ElfAstAhe/go-service-template/internal/config"
 	"github.com/ElfAstAhe/go-service-template/pkg/errs"
@@ -52,6 +55,32 @@ func main() {
 	}
 	defer zapLogger.Close()
 
+	// 2.1. Консольная команда миграций (без запуска серверов)
+	if len(cfg.Args) > 0 && cfg.Args[0] == app.CommandMigrate {
+		startupLogger.Info("run migrate command")
+		if err := app.RunMigrate(context.Background(), cfg, zapLogger, os.Stdout, cfg.Args[1:]); err != nil {
+			startupLogger.Errorf("failed to run migrate command: %v", err)
+			zapLogger.Close()
+			startupLogger.Close()
+			os.Exit(1)
+		}
+
+		return
+	}
+
+	// 2.2. Консольная команда загрузки фикстур (dev, test)
+	if len(cfg.Args) > 0 && cfg.Args[0] == app.CommandSeed {
+		startupLogger.Info("run seed command")
+		if err := app.RunSeed(context.Background(), cfg, zapLogger, os.Stdout, cfg.Args[1:]); err != nil {
+			startupLogger.Errorf("failed to run seed command: %v", err)
+			zapLogger.Close()
+			startupLogger.Close()
+			os.Exit(1)
+		}
+
+		return
+	}
+
 	// 3. Создание приложения
 	startupLogger.Info("create application")
 	appl, err := app.NewApplication(app.WithConfig(cfg), app.WithLogger(zapLogger))
@@ -0,0 +1,44 @@
+package example_service
+
+import (
+	"embed"
+	"io/fs"
+
+	"github.com/ElfAstAhe/go-service-template/internal/domain"
+	"github.com/ElfAstAhe/go-service-template/internal/repository/postgres"
+	"github.com/ElfAstAhe/go-service-template/pkg/config"
+	"github.com/ElfAstAhe/go-service-template/pkg/db"
+	"github.com/ElfAstAhe/go-service-template/pkg/errs"
+	"github.com/ElfAstAhe/go-service-template/pkg/logger"
+	"github.com/ElfAstAhe/go-service-template/pkg/seed"
+)
+
+// Entity names
+const (
+	EntityTest string = "test"
+)
+
+// fixtures справочные и демонстрационные данные сервиса для dev и test окружений
+//
+//go:embed *.yaml
+var fixtures embed.FS
+
+// FS файловая система фикстур сервиса
+func FS() fs.FS {
+	return fixtures
+}
+
+// NewSeeder загрузчик фикстур со всеми сущностями сервиса
+func NewSeeder(env config.AppEnv, pg db.DB, log logger.Logger) (*seed.Seeder, error) {
+	testRepo, err := postgres.NewTestRepository(pg, pg)
+	if err != nil {
+		return nil, errs.NewCommonError("create seeder failed", err)
+	}
+
+	return seed.NewSeeder(
+		env,
+		db.NewTxManager(pg),
+		log,
+		seed.NewCRUDLoader[*domain.Test, string](EntityTest, testRepo, domain.NewEmptyTest, seed.NewTableTruncator(pg, "test")),
+	)
+}
@@ -23,10 +23,18 @@ func (hc *HTTPContainer) providerChiRouter() (any, error) {
 	if err != nil {
 		return nil, errs.NewContainerError(hc.GetName(), "provider: retrieve instance failed", err)
 	}
-	readyz, err := container.GetInstance[func() bool](InstanceApplicationReady)
+	appReady, err := container.GetInstance[func() bool](InstanceApplicationReady)
 	if err != nil {
 		return nil, errs.NewContainerError(hc.GetName(), "provider: retrieve instance failed", err)
 	}
+	migrationReady, err := container.GetInstance[func() bool](InstanceDBMigrationReady)
+	if err != nil {
+		return nil, errs.NewContainerError(hc.GetName(), "provider: retrieve instance failed", err)
+	}
+	// готовность только после старта приложения и миграции схемы БД
+	readyz := func() bool {
+		return appReady() && migrationReady()
+	}
 	testFacadeInst, err := container.GetInstance[facade.TestFacade](InstanceTestFacade)
 	if err != nil {
 		return nil, errs.NewContainerError(hc.GetName(), "provider: retrieve instance failed", err)
@@ -38,6 +46,8 @@ func (hc *HTTPContainer) providerChiRouter() (any, error) {
 
 	return rest.NewAppChiRouter(
 		confInst.HTTP,
+		confInst.Admin,
+		confInst.App.Env,
 		confInst.Telemetry,
 		logInst,
 		healthInst,
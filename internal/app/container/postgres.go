@@ -12,8 +12,12 @@ import (
 )
 
 const (
-	InstanceDB         string = "DB"
-	InstanceDBMigrator string = "DBMigrator"
+	InstanceDB                 string = "DB"
+	InstanceDBLocker           string = "DBLocker"
+	InstanceDBMigrator         string = "DBMigrator"
+	InstanceDBMigrationStartup string = "DBMigrationStartup"
+	InstanceDBMigrationRunner  string = "DBMigrationRunner"
+	InstanceDBMigrationReady   string = "DBMigrationReady"
 )
 
 // PgContainer database connection and data migrations
@@ -41,7 +45,9 @@ func (pc *PgContainer) Init(initCtx context.Context) error {
 	// add providers
 	err := errors.Join(
 		pc.RegisterProvider(InstanceDB, pc.providerDB),
+		pc.RegisterProvider(InstanceDBLocker, pc.providerDBLocker),
 		pc.RegisterProvider(InstanceDBMigrator, pc.providerDBMigrator),
+		pc.RegisterProvider(InstanceDBMigrationStartup, pc.providerDBMigrationStartup),
 	)
 	if err != nil {
 		return errs.NewContainerError(pc.GetName(), "container init: register providers failed", err)
@@ -57,14 +63,27 @@ func (pc *PgContainer) Init(initCtx context.Context) error {
 		return errs.NewContainerError(pc.GetName(), "container init: check db failed", err)
 	}
 	// data migration
-	migrator, err := container.GetInstance[migration.Migrator](InstanceDBMigrator)
+	startup, err := container.GetInstance[*migration.Startup](InstanceDBMigrationStartup)
 	if err != nil {
-		return errs.NewContainerError(pc.GetName(), "container init: init migrator failed", err)
+		return errs.NewContainerError(pc.GetName(), "container init: init migration startup failed", err)
 	}
-	// migrate up
-	err = migrator.Up(initCtx)
+	err = pc.RegisterInstance(InstanceDBMigrationReady, startup.IsReady)
 	if err != nil {
-		return errs.NewContainerError(pc.GetName(), "container init: up migrator failed", err)
+		return errs.NewContainerError(pc.GetName(), "container init: register migration gate failed", err)
+	}
+	// background migration, runner will be started by orchestrator
+	if startup.GetConfig().Async {
+		err = pc.RegisterProvider(InstanceDBMigrationRunner, pc.providerDBMigrationRunner)
+		if err != nil {
+			return errs.NewContainerError(pc.GetName(), "container init: register migration runner failed", err)
+		}
+
+		return nil
+	}
+	// migrate up (or wait for other replica)
+	err = startup.Run(initCtx)
+	if err != nil {
+		return errs.NewContainerError(pc.GetName(), "container init: startup migration failed", err)
 	}
 
 	return nil
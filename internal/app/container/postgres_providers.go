@@ -5,12 +5,13 @@ import (
 
 	"github.com/ElfAstAhe/go-service-template/internal/config"
 	"github.com/ElfAstAhe/go-service-template/internal/repository/postgres"
-	_ "github.com/ElfAstAhe/go-service-template/migrations/example-service"
+	migrations "github.com/ElfAstAhe/go-service-template/migrations/example-service"
 	"github.com/ElfAstAhe/go-service-template/pkg/container"
 	"github.com/ElfAstAhe/go-service-template/pkg/db"
+	"github.com/ElfAstAhe/go-service-template/pkg/db/lock"
 	"github.com/ElfAstAhe/go-service-template/pkg/errs"
 	"github.com/ElfAstAhe/go-service-template/pkg/logger"
-	"github.com/ElfAstAhe/go-service-template/pkg/migration/goose"
+	"github.com/ElfAstAhe/go-service-template/pkg/migration"
 )
 
 func (pc *PgContainer) providerDB() (any, error) {
@@ -35,7 +36,7 @@ func (pc *PgContainer) providerDBMigrator() (any, error) {
 	if err != nil {
 		return nil, errs.NewContainerError(pc.GetName(), "provider: retrieve instance failed", err)
 	}
-	res, err := goose.NewDBMigrator(dbInst, logInst)
+	res, err := migrations.NewDBMigrator(dbInst, logInst)
 	if err != nil {
 		return nil, errs.NewContainerError(pc.GetName(), fmt.Sprintf("provider: create %s instance failed", InstanceDBMigrator), err)
 	}
@@ -46,3 +47,46 @@ func (pc *PgContainer) providerDBMigrator() (any, error) {
 
 	return res, nil
 }
+
+func (pc *PgContainer) providerDBLocker() (any, error) {
+	dbInst, err := container.GetInstance[db.DB](InstanceDB)
+	if err != nil {
+		return nil, errs.NewContainerError(pc.GetName(), "provider: retrieve instance failed", err)
+	}
+
+	return lock.NewAdvisoryLocker(dbInst, lock.DefaultRetryInterval), nil
+}
+
+func (pc *PgContainer) providerDBMigrationStartup() (any, error) {
+	confInst, err := container.GetInstance[*config.Config](InstanceConfig)
+	if err != nil {
+		return nil, errs.NewContainerError(pc.GetName(), "provider: retrieve instance failed", err)
+	}
+	logInst, err := container.GetInstance[logger.Logger](InstanceLogger)
+	if err != nil {
+		return nil, errs.NewContainerError(pc.GetName(), "provider: retrieve instance failed", err)
+	}
+	migratorInst, err := container.GetInstance[migration.Migrator](InstanceDBMigrator)
+	if err != nil {
+		return nil, errs.NewContainerError(pc.GetName(), "provider: retrieve instance failed", err)
+	}
+	lockerInst, err := container.GetInstance[lock.Locker](InstanceDBLocker)
+	if err != nil {
+		return nil, errs.NewContainerError(pc.GetName(), "provider: retrieve instance failed", err)
+	}
+	res, err := migration.NewStartup("db-migration", migratorInst, lockerInst, confInst.Migration, logInst)
+	if err != nil {
+		return nil, errs.NewContainerError(pc.GetName(), fmt.Sprintf("provider: create %s instance failed", InstanceDBMigrationStartup), err)
+	}
+
+	return res, nil
+}
+
+func (pc *PgContainer) providerDBMigrationRunner() (any, error) {
+	startupInst, err := container.GetInstance[*migration.Startup](InstanceDBMigrationStartup)
+	if err != nil {
+		return nil, errs.NewContainerError(pc.GetName(), "provider: retrieve instance failed", err)
+	}
+
+	return migration.NewStartupRunner(startupInst), nil
+}
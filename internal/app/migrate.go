@@ -0,0 +1,193 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ElfAstAhe/go-service-template/internal/config"
+	"github.com/ElfAstAhe/go-service-template/internal/repository/postgres"
+	migrations "github.com/ElfAstAhe/go-service-template/migrations/example-service"
+	"github.com/ElfAstAhe/go-service-template/pkg/errs"
+	"github.com/ElfAstAhe/go-service-template/pkg/logger"
+	"github.com/ElfAstAhe/go-service-template/pkg/migration"
+	"github.com/ElfAstAhe/go-service-template/pkg/migration/goose"
+)
+
+// CommandMigrate консольная команда управления миграциями
+const CommandMigrate string = "migrate"
+
+// migrate sub commands
+const (
+	migrateUp      string = "up"
+	migrateUpTo    string = "up-to"
+	migrateDown    string = "down"
+	migrateDownTo  string = "down-to"
+	migrateRedo    string = "redo"
+	migrateReset   string = "reset"
+	migrateStatus  string = "status"
+	migrateVersion string = "version"
+	migratePending string = "pending"
+)
+
+const migrateUsage = `usage: example-service migrate <command> [version] [flags]
+
+commands:
+  up              apply all pending migrations
+  up-to VERSION   apply pending migrations up to and including VERSION
+  down            roll back the most recently applied migration
+  down-to VERSION roll back migrations down to, but not including, VERSION
+  redo            roll back and re-apply the most recently applied migration
+  reset           roll back all migrations
+  status          show status of all migrations
+  version         show current database version
+  pending         list pending migrations without applying them (dry-run)
+`
+
+// RunMigrate выполнение команды миграции без запуска серверов приложения
+func RunMigrate(ctx context.Context, conf *config.Config, log logger.Logger, out io.Writer, args []string) error {
+	if len(args) == 0 {
+		_, _ = fmt.Fprint(out, migrateUsage)
+
+		return errs.NewInvalidArgumentError("command", "")
+	}
+	// проверяем команду до подключения к БД
+	if err := validateMigrateArgs(args[0], args[1:]); err != nil {
+		_, _ = fmt.Fprint(out, migrateUsage)
+
+		return err
+	}
+
+	pg, err := postgres.NewPgDB(conf.DB)
+	if err != nil {
+		return errs.NewCommonError("migrate: open db failed", err)
+	}
+	defer pg.Close()
+
+	pingCtx, cancel := context.WithTimeout(ctx, conf.DB.ConnTimeout)
+	defer cancel()
+	if err := pg.Ping(pingCtx); err != nil {
+		return errs.NewCommonError("migrate: check db failed", err)
+	}
+
+	migrator, err := migrations.NewDBMigrator(pg, log)
+	if err != nil {
+		return errs.NewCommonError("migrate: create migrator failed", err)
+	}
+	if err := migrator.Initialize(); err != nil {
+		return errs.NewCommonError("migrate: initialize migrator failed", err)
+	}
+
+	return runMigrateCommand(ctx, migrator, out, args[0], args[1:])
+}
+
+func runMigrateCommand(ctx context.Context, migrator migration.Migrator, out io.Writer, command string, args []string) error {
+	switch command {
+	case migrateUp:
+		return migrator.Up(ctx)
+	case migrateUpTo:
+		version, err := parseMigrateVersion(command, args)
+		if err != nil {
+			return err
+		}
+
+		return migrator.UpTo(ctx, version)
+	case migrateDown:
+		err := migrator.Down(ctx)
+		if goose.IsNoChanges(err) {
+			_, _ = fmt.Fprintln(out, "no migrations to roll back")
+
+			return nil
+		}
+
+		return err
+	case migrateDownTo:
+		version, err := parseMigrateVersion(command, args)
+		if err != nil {
+			return err
+		}
+
+		return migrator.DownTo(ctx, version)
+	case migrateRedo:
+		return migrator.Redo(ctx)
+	case migrateReset:
+		return migrator.Reset(ctx)
+	case migrateStatus:
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+
+		return printMigrateStatuses(out, statuses)
+	case migrateVersion:
+		version, err := migrator.Version(ctx)
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintf(out, "version: %d\n", version)
+
+		return nil
+	case migratePending:
+		statuses, err := migrator.Pending(ctx)
+		if err != nil {
+			return err
+		}
+		if len(statuses) == 0 {
+			_, _ = fmt.Fprintln(out, "no pending migrations")
+
+			return nil
+		}
+
+		return printMigrateStatuses(out, statuses)
+	default:
+		_, _ = fmt.Fprint(out, migrateUsage)
+
+		return errs.NewInvalidArgumentError("command", command)
+	}
+}
+
+func validateMigrateArgs(command string, args []string) error {
+	switch command {
+	case migrateUp, migrateDown, migrateRedo, migrateReset, migrateStatus, migrateVersion, migratePending:
+		return nil
+	case migrateUpTo, migrateDownTo:
+		_, err := parseMigrateVersion(command, args)
+
+		return err
+	default:
+		return errs.NewInvalidArgumentError("command", command)
+	}
+}
+
+func parseMigrateVersion(command string, args []string) (int64, error) {
+	if len(args) == 0 {
+		return 0, errs.NewInvalidArgumentError(fmt.Sprintf("%s version", command), "")
+	}
+	version, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil || version < 0 {
+		return 0, errs.NewInvalidArgumentErrorChain(fmt.Sprintf("%s version", command), args[0], err)
+	}
+
+	return version, nil
+}
+
+func printMigrateStatuses(out io.Writer, statuses []*migration.Status) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "VERSION\tTYPE\tSTATE\tAPPLIED AT\tSOURCE")
+	for _, status := range statuses {
+		appliedAt := "-"
+		if !status.AppliedAt.IsZero() {
+			appliedAt = status.AppliedAt.Format(time.DateTime)
+		}
+		source := status.Source
+		if source == "" {
+			source = "-"
+		}
+		_, _ = fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", status.Version, status.Type, status.State, appliedAt, source)
+	}
+
+	return w.Flush()
+}
@@ -0,0 +1,97 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"sort"
+
+	fixtures "github.com/ElfAstAhe/go-service-template/fixtures/example-service"
+	"github.com/ElfAstAhe/go-service-template/internal/config"
+	"github.com/ElfAstAhe/go-service-template/internal/repository/postgres"
+	conf "github.com/ElfAstAhe/go-service-template/pkg/config"
+	"github.com/ElfAstAhe/go-service-template/pkg/errs"
+	"github.com/ElfAstAhe/go-service-template/pkg/logger"
+	"github.com/ElfAstAhe/go-service-template/pkg/seed"
+)
+
+// CommandSeed консольная команда загрузки фикстур
+const CommandSeed string = "seed"
+
+const seedUsage = `usage: example-service seed <command> [dir] [flags]
+
+commands:
+  load [DIR]      load fixtures into existing data
+  reload [DIR]    delete all records of fixture entities and load fixtures again
+
+fixtures are read from DIR (*.yaml, *.yml, *.json), embedded service fixtures are used by default.
+seeding is refused in prod environment.
+`
+
+// RunSeed выполнение команды загрузки фикстур без запуска серверов приложения
+func RunSeed(ctx context.Context, cfg *config.Config, log logger.Logger, out io.Writer, args []string) error {
+	if len(args) == 0 {
+		_, _ = fmt.Fprint(out, seedUsage)
+
+		return errs.NewInvalidArgumentError("command", "")
+	}
+	mode := seed.Mode(args[0])
+	if mode != seed.ModeLoad && mode != seed.ModeReload {
+		_, _ = fmt.Fprint(out, seedUsage)
+
+		return errs.NewInvalidArgumentError("command", args[0])
+	}
+	// проверяем окружение до подключения к БД
+	if cfg.App.Env == conf.AppEnvProduction {
+		return errs.NewCommonError(fmt.Sprintf("seed: not allowed in [%s] environment", cfg.App.Env), nil)
+	}
+
+	var fsys fs.FS = fixtures.FS()
+	if len(args) > 1 {
+		fsys = os.DirFS(args[1])
+	}
+	items, err := seed.LoadFS(fsys, ".")
+	if err != nil {
+		return errs.NewCommonError("seed: read fixtures failed", err)
+	}
+
+	pg, err := postgres.NewPgDB(cfg.DB)
+	if err != nil {
+		return errs.NewCommonError("seed: open db failed", err)
+	}
+	defer pg.Close()
+
+	pingCtx, cancel := context.WithTimeout(ctx, cfg.DB.ConnTimeout)
+	defer cancel()
+	if err := pg.Ping(pingCtx); err != nil {
+		return errs.NewCommonError("seed: check db failed", err)
+	}
+
+	seeder, err := fixtures.NewSeeder(cfg.App.Env, pg, log)
+	if err != nil {
+		return errs.NewCommonError("seed: create seeder failed", err)
+	}
+	refs, err := seeder.Seed(ctx, mode, items...)
+	if err != nil {
+		return err
+	}
+
+	return printSeedRefs(out, refs)
+}
+
+func printSeedRefs(out io.Writer, refs seed.Refs) error {
+	keys := make([]string, 0, len(refs))
+	for key := range refs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if _, err := fmt.Fprintf(out, "%s\t%v\n", key, refs[key]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
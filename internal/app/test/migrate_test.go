@@ -0,0 +1,36 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/ElfAstAhe/go-service-template/internal/app"
+	"github.com/ElfAstAhe/go-service-template/internal/config"
+	logmocks "github.com/ElfAstAhe/go-service-template/pkg/logger/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunMigrate_InvalidArgs(t *testing.T) {
+	testCases := []struct {
+		name string
+		args []string
+	}{
+		{name: "no command", args: nil},
+		{name: "unknown command", args: []string{"sideways"}},
+		{name: "up-to without version", args: []string{"up-to"}},
+		{name: "down-to with invalid version", args: []string{"down-to", "latest"}},
+		{name: "negative version", args: []string{"up-to", "-1"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+
+			// DSN по умолчанию недоступен: ошибка аргументов должна вернуться до подключения к БД
+			err := app.RunMigrate(context.Background(), config.NewDefaultConfig(), logmocks.NewMockLogger(t), out, tc.args)
+			assert.Error(t, err)
+			assert.Contains(t, out.String(), "usage: example-service migrate")
+		})
+	}
+}
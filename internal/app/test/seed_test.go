@@ -0,0 +1,46 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/ElfAstAhe/go-service-template/internal/app"
+	"github.com/ElfAstAhe/go-service-template/internal/config"
+	conf "github.com/ElfAstAhe/go-service-template/pkg/config"
+	logmocks "github.com/ElfAstAhe/go-service-template/pkg/logger/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunSeed_InvalidArgs(t *testing.T) {
+	testCases := []struct {
+		name string
+		env  conf.AppEnv
+		args []string
+		want string
+		err  string
+	}{
+		{name: "no command", env: conf.AppEnvDevelopment, args: nil, want: "usage: example-service seed"},
+		{name: "unknown command", env: conf.AppEnvDevelopment, args: []string{"truncate"}, want: "usage: example-service seed"},
+		{name: "prod refused", env: conf.AppEnvProduction, args: []string{"load"}, err: "not allowed"},
+		{name: "missing dir", env: conf.AppEnvDevelopment, args: []string{"load", filepath.Join(t.TempDir(), "missing")}, err: "read fixtures"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := config.NewDefaultConfig()
+			cfg.App.Env = tc.env
+			out := &bytes.Buffer{}
+
+			// DSN по умолчанию недоступен: ошибка должна вернуться до подключения к БД
+			err := app.RunSeed(context.Background(), cfg, logmocks.NewMockLogger(t), out, tc.args)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.err)
+			if tc.want != "" {
+				assert.Contains(t, out.String(), tc.want)
+			}
+		})
+	}
+}
@@ -18,11 +18,15 @@ type Config struct {
 	App       *AppConfig            `mapstructure:"app" json:"app,omitempty" yaml:"app,omitempty"`
 	Auth      *conf.AuthConfig      `mapstructure:"auth" json:"auth,omitempty" yaml:"auth,omitempty"`
 	HTTP      *conf.HTTPConfig      `mapstructure:"http" json:"http,omitempty" yaml:"http,omitempty"`
+	Admin     *conf.AdminConfig     `mapstructure:"admin" json:"admin,omitempty" yaml:"admin,omitempty"`
 	GRPC      *conf.GRPCConfig      `mapstructure:"grpc" json:"grpc,omitempty" yaml:"grpc,omitempty"`
 	Log       *conf.LogConfig       `mapstructure:"log" json:"log,omitempty" yaml:"log,omitempty"`
 	DB        *conf.DBConfig        `mapstructure:"db" json:"db,omitempty" yaml:"db,omitempty"`
+	Migration *conf.MigrationConfig `mapstructure:"migration" json:"migration,omitempty" yaml:"migration,omitempty"`
 	Telemetry *conf.TelemetryConfig `mapstructure:"telemetry" json:"telemetry,omitempty" yaml:"telemetry,omitempty"`
-	//    Redis *RedisConfig `mapstructure:"redis"`
+	Redis     *conf.RedisConfig     `mapstructure:"redis" json:"redis,omitempty" yaml:"redis,omitempty"`
+	// Args позиционные аргументы командной строки без флагов (консольные команды migrate, seed)
+	Args []string `mapstructure:"-" json:"-" yaml:"-"`
 }
 
 // linker params
@@ -36,19 +40,25 @@ func NewConfig(
 	app *AppConfig,
 	auth *conf.AuthConfig,
 	HTTP *conf.HTTPConfig,
+	admin *conf.AdminConfig,
 	GRPC *conf.GRPCConfig,
 	log *conf.LogConfig,
 	db *conf.DBConfig,
+	migration *conf.MigrationConfig,
 	telemetry *conf.TelemetryConfig,
+	redis *conf.RedisConfig,
 ) *Config {
 	return &Config{
 		App:       app,
 		Auth:      auth,
 		HTTP:      HTTP,
+		Admin:     admin,
 		GRPC:      GRPC,
 		Log:       log,
 		DB:        db,
+		Migration: migration,
 		Telemetry: telemetry,
+		Redis:     redis,
 	}
 }
 
@@ -57,10 +67,13 @@ func NewDefaultConfig() *Config {
 		NewDefaultAppConfig(),
 		conf.NewDefaultAuthConfig(),
 		conf.NewDefaultHTTPConfig(),
+		conf.NewDefaultAdminConfig(),
 		conf.NewDefaultGRPCConfig(),
 		conf.NewDefaultLogConfig(),
 		conf.NewDefaultDBConfig(),
+		conf.NewDefaultMigrationConfig(),
 		conf.NewDefaultTelemetryConfig(),
+		conf.NewDefaultRedisConfig(),
 	)
 }
 
@@ -71,10 +84,13 @@ func NewEmptyConfig() *Config {
 		},
 		Auth:      &conf.AuthConfig{},
 		HTTP:      &conf.HTTPConfig{},
+		Admin:     &conf.AdminConfig{},
 		GRPC:      &conf.GRPCConfig{},
 		Log:       &conf.LogConfig{},
 		DB:        &conf.DBConfig{},
+		Migration: &conf.MigrationConfig{},
 		Telemetry: &conf.TelemetryConfig{},
+		Redis:     &conf.RedisConfig{},
 	}
 }
 
@@ -85,10 +101,13 @@ func (c *Config) Validate() error {
 		c.App,
 		//		c.Auth,
 		c.HTTP,
+		c.Admin,
 		c.GRPC,
 		c.Log,
 		c.DB,
+		c.Migration,
 		c.Telemetry,
+		c.Redis,
 	}
 
 	var validateErrs []error
@@ -146,6 +165,7 @@ func Load() (*Config, error) {
 	if err := v.Unmarshal(cfg); err != nil {
 		return nil, errs.NewConfigError("failed to unmarshal config struct", err)
 	}
+	cfg.Args = pFlagSet.Args()
 
 	// 8. Итоговая валидация по всем слоям
 	if err := cfg.Validate(); err != nil {
@@ -177,6 +197,9 @@ func applyDefaults(v *viper.Viper) {
 	v.SetDefault(conf.KeyHTTPSecure, conf.DefaultHTTPSecure)
 	v.SetDefault(conf.KeyHTTPMaxRequestBodySize, conf.DefaultHTTPMaxRequestBodySize)
 
+	// Admin
+	v.SetDefault(conf.KeyAdminEnabled, conf.DefaultAdminEnabled)
+
 	// gRPC
 	v.SetDefault(conf.KeyGRPCAddress, conf.DefaultGRPCAddress)
 	v.SetDefault(conf.KeyGRPCMaxConnIdle, conf.DefaultGRPCMaxConnIdle)
@@ -195,6 +218,14 @@ func applyDefaults(v *viper.Viper) {
 	v.SetDefault(conf.KeyDBConnMaxIdleLifetime, conf.DefaultDBConnMaxIdleLifetime)
 	v.SetDefault(conf.KeyDBConnTimeout, conf.DefaultDBConnTimeout)
 
+	// Migration
+	v.SetDefault(conf.KeyMigrationMode, conf.DefaultMigrationMode)
+	v.SetDefault(conf.KeyMigrationAsync, conf.DefaultMigrationAsync)
+	v.SetDefault(conf.KeyMigrationLockKey, conf.DefaultMigrationLockKey)
+	v.SetDefault(conf.KeyMigrationLockTimeout, conf.DefaultMigrationLockTimeout)
+	v.SetDefault(conf.KeyMigrationWaitTimeout, conf.DefaultMigrationWaitTimeout)
+	v.SetDefault(conf.KeyMigrationWaitInterval, conf.DefaultMigrationWaitInterval)
+
 	// Log
 	v.SetDefault(conf.KeyLogLevel, conf.DefaultLogLevel)
 	v.SetDefault(conf.KeyLogFormat, conf.DefaultLogFormat)
@@ -205,6 +236,16 @@ func applyDefaults(v *viper.Viper) {
 	v.SetDefault(conf.KeyTelemetrySampleRate, conf.DefaultTelemetrySampleRate)
 	v.SetDefault(conf.KeyTelemetryTimeout, conf.DefaultTelemetryTimeout)
 
+	// Redis
+	v.SetDefault(conf.KeyRedisEnabled, conf.DefaultRedisEnabled)
+	v.SetDefault(conf.KeyRedisHost, conf.DefaultRedisHost)
+	v.SetDefault(conf.KeyRedisPort, conf.DefaultRedisPort)
+	v.SetDefault(conf.KeyRedisDB, conf.DefaultRedisDB)
+	v.SetDefault(conf.KeyRedisPoolSize, conf.DefaultRedisPoolSize)
+	v.SetDefault(conf.KeyRedisDialTimeout, conf.DefaultRedisDialTimeout)
+	v.SetDefault(conf.KeyRedisReadTimeout, conf.DefaultRedisReadTimeout)
+	v.SetDefault(conf.KeyRedisWriteTimeout, conf.DefaultRedisWriteTimeout)
+
 	// ... и так далее для всех критичных полей
 }
 
@@ -250,6 +291,10 @@ func initFLags() (res *pflag.FlagSet, err error) {
 	res.Bool(conf.FlagHTTPSecure, conf.DefaultHTTPSecure, "http secure mode")
 	res.Int(conf.FlagHTTPMaxRequestBodySize, conf.DefaultHTTPMaxRequestBodySize, "http max request body size")
 
+	// Admin
+	res.Bool(conf.FlagAdminEnabled, conf.DefaultAdminEnabled, "admin endpoints enabled (required in prod, requires token)")
+	res.String(conf.FlagAdminToken, "", "admin endpoints access token (admin endpoints are not published without it)")
+
 	// gRPC
 	res.String(conf.FlagGRPCAddress, conf.DefaultGRPCAddress, "gRPC address")
 	res.Duration(conf.FlagGRPCMaxConnIdle, conf.DefaultGRPCMaxConnIdle, "gRPC max connection idle timeout")
@@ -268,6 +313,14 @@ func initFLags() (res *pflag.FlagSet, err error) {
 	res.Duration(conf.FlagDBMaxIdleLifetime, conf.DefaultDBConnMaxIdleLifetime, "db max idle connection lifetime")
 	res.Duration(conf.FlagDBConnTimeout, conf.DefaultDBConnTimeout, "db connection timeout)")
 
+	// Migration
+	res.String(conf.FlagMigrationMode, string(conf.DefaultMigrationMode), "db migration startup mode (migrate, wait, skip)")
+	res.Bool(conf.FlagMigrationAsync, conf.DefaultMigrationAsync, "db migration in background, readyz is false until complete")
+	res.String(conf.FlagMigrationLockKey, conf.DefaultMigrationLockKey, "db migration advisory lock key")
+	res.Duration(conf.FlagMigrationLockTimeout, conf.DefaultMigrationLockTimeout, "db migration lock wait timeout")
+	res.Duration(conf.FlagMigrationWaitTimeout, conf.DefaultMigrationWaitTimeout, "db migration schema wait timeout")
+	res.Duration(conf.FlagMigrationWaitInterval, conf.DefaultMigrationWaitInterval, "db migration schema check interval")
+
 	// Log
 	res.String(conf.FlagLogLevel, conf.DefaultLogLevel, "log level")
 	res.String(conf.FlagLogFormat, conf.DefaultLogFormat, "log format")
@@ -279,7 +332,19 @@ func initFLags() (res *pflag.FlagSet, err error) {
 	res.Float64(conf.FlagTelemetrySampleRate, conf.DefaultTelemetrySampleRate, "telemetry sample rate")
 	res.Duration(conf.FlagTelemetryTimeout, conf.DefaultTelemetryTimeout, "telemetry timeout")
 
-	// Добавь остальные pflag.String/Int/Duration для Redis, etc. ...
+	// Redis
+	res.Bool(conf.FlagRedisEnabled, conf.DefaultRedisEnabled, "redis enabled")
+	res.String(conf.FlagRedisHost, conf.DefaultRedisHost, "redis host")
+	res.String(conf.FlagRedisPort, conf.DefaultRedisPort, "redis port")
+	res.String(conf.FlagRedisUsername, "", "redis username (ACL)")
+	res.String(conf.FlagRedisPassword, "", "redis password")
+	res.Int(conf.FlagRedisDB, conf.DefaultRedisDB, "redis database number")
+	res.Int(conf.FlagRedisPoolSize, conf.DefaultRedisPoolSize, "redis connection pool size")
+	res.Duration(conf.FlagRedisDialTimeout, conf.DefaultRedisDialTimeout, "redis dial timeout")
+	res.Duration(conf.FlagRedisReadTimeout, conf.DefaultRedisReadTimeout, "redis read timeout")
+	res.Duration(conf.FlagRedisWriteTimeout, conf.DefaultRedisWriteTimeout, "redis write timeout")
+
+	// Добавь остальные pflag.String/Int/Duration ...
 	// ..
 
 	// Парсинг
@@ -315,6 +380,9 @@ func bindFlags(flags *pflag.FlagSet, v *viper.Viper) error {
 		v.BindPFlag(conf.KeyHTTPCertificatePath, flags.Lookup(conf.FlagHTTPCertificatePath)),
 		v.BindPFlag(conf.KeyHTTPSecure, flags.Lookup(conf.FlagHTTPSecure)),
 		v.BindPFlag(conf.KeyHTTPMaxRequestBodySize, flags.Lookup(conf.FlagHTTPMaxRequestBodySize)),
+		// Admin
+		v.BindPFlag(conf.KeyAdminEnabled, flags.Lookup(conf.FlagAdminEnabled)),
+		v.BindPFlag(conf.KeyAdminToken, flags.Lookup(conf.FlagAdminToken)),
 		// gRPC
 		v.BindPFlag(conf.KeyGRPCAddress, flags.Lookup(conf.FlagGRPCAddress)),
 		v.BindPFlag(conf.KeyGRPCMaxConnIdle, flags.Lookup(conf.FlagGRPCMaxConnIdle)),
@@ -334,12 +402,30 @@ func bindFlags(flags *pflag.FlagSet, v *viper.Viper) error {
 		v.BindPFlag(conf.KeyDBMaxIdleConns, flags.Lookup(conf.FlagDBMaxIdleConns)),
 		v.BindPFlag(conf.KeyDBConnMaxIdleLifetime, flags.Lookup(conf.FlagDBMaxIdleLifetime)),
 		v.BindPFlag(conf.KeyDBConnTimeout, flags.Lookup(conf.FlagDBConnTimeout)),
+		// Migration
+		v.BindPFlag(conf.KeyMigrationMode, flags.Lookup(conf.FlagMigrationMode)),
+		v.BindPFlag(conf.KeyMigrationAsync, flags.Lookup(conf.FlagMigrationAsync)),
+		v.BindPFlag(conf.KeyMigrationLockKey, flags.Lookup(conf.FlagMigrationLockKey)),
+		v.BindPFlag(conf.KeyMigrationLockTimeout, flags.Lookup(conf.FlagMigrationLockTimeout)),
+		v.BindPFlag(conf.KeyMigrationWaitTimeout, flags.Lookup(conf.FlagMigrationWaitTimeout)),
+		v.BindPFlag(conf.KeyMigrationWaitInterval, flags.Lookup(conf.FlagMigrationWaitInterval)),
 		// Telemetry
 		v.BindPFlag(conf.KeyTelemetryEnabled, flags.Lookup(conf.FlagTelemetryEnabled)),
 		v.BindPFlag(conf.KeyTelemetryExporterEndpoint, flags.Lookup(conf.FlagTelemetryExporterEndpoint)),
 		v.BindPFlag(conf.KeyTelemetryServiceName, flags.Lookup(conf.FlagTelemetryServiceName)),
 		v.BindPFlag(conf.KeyTelemetrySampleRate, flags.Lookup(conf.FlagTelemetrySampleRate)),
 		v.BindPFlag(conf.KeyTelemetryTimeout, flags.Lookup(conf.FlagTelemetryTimeout)),
+		// Redis
+		v.BindPFlag(conf.KeyRedisEnabled, flags.Lookup(conf.FlagRedisEnabled)),
+		v.BindPFlag(conf.KeyRedisHost, flags.Lookup(conf.FlagRedisHost)),
+		v.BindPFlag(conf.KeyRedisPort, flags.Lookup(conf.FlagRedisPort)),
+		v.BindPFlag(conf.KeyRedisUsername, flags.Lookup(conf.FlagRedisUsername)),
+		v.BindPFlag(conf.KeyRedisPassword, flags.Lookup(conf.FlagRedisPassword)),
+		v.BindPFlag(conf.KeyRedisDB, flags.Lookup(conf.FlagRedisDB)),
+		v.BindPFlag(conf.KeyRedisPoolSize, flags.Lookup(conf.FlagRedisPoolSize)),
+		v.BindPFlag(conf.KeyRedisDialTimeout, flags.Lookup(conf.FlagRedisDialTimeout)),
+		v.BindPFlag(conf.KeyRedisReadTimeout, flags.Lookup(conf.FlagRedisReadTimeout)),
+		v.BindPFlag(conf.KeyRedisWriteTimeout, flags.Lookup(conf.FlagRedisWriteTimeout)),
 	)
 	if err != nil {
 		return errs.NewConfigError("bind flags with keys", err)
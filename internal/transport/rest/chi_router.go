@@ -6,6 +6,7 @@ import (
 	_ "github.com/ElfAstAhe/go-service-template/docs"
 	"github.com/ElfAstAhe/go-service-template/internal/facade"
 	conf "github.com/ElfAstAhe/go-service-template/pkg/config"
+	"github.com/ElfAstAhe/go-service-template/pkg/infra/cache"
 	"github.com/ElfAstAhe/go-service-template/pkg/logger"
 	pkghttp "github.com/ElfAstAhe/go-service-template/pkg/transport/http"
 	pkgmware "github.com/ElfAstAhe/go-service-template/pkg/transport/http/middleware"
@@ -21,6 +22,8 @@ type AppChiRouter struct {
 	router          *chi.Mux
 	log             logger.Logger
 	config          *conf.HTTPConfig
+	adminConfig     *conf.AdminConfig
+	env             conf.AppEnv
 	telemetryConfig *conf.TelemetryConfig
 	health          *health.Health
 	healthz         pkghttp.HealthzFunc
@@ -32,6 +35,8 @@ var _ pkghttp.Router = (*AppChiRouter)(nil)
 
 func NewAppChiRouter(
 	config *conf.HTTPConfig,
+	adminConfig *conf.AdminConfig,
+	env conf.AppEnv,
 	telemetryConfig *conf.TelemetryConfig,
 	logger logger.Logger,
 	health *health.Health,
@@ -43,6 +48,8 @@ func NewAppChiRouter(
 		router:          chi.NewRouter(),
 		log:             logger,
 		config:          config,
+		adminConfig:     adminConfig,
+		env:             env,
 		telemetryConfig: telemetryConfig,
 		health:          health,
 		healthz:         healthz,
@@ -61,6 +68,13 @@ func NewAppChiRouter(
 	res.router.Mount("/status", res.health.Handler())
 	// mount metrics
 	res.router.Mount("/metrics", promhttp.Handler())
+	// mount cache admin (в prod только при явном включении)
+	if res.adminConfig.Published(res.env) {
+		res.router.Mount("/admin/caches", pkghttp.NewCacheAdminRouter(
+			cache.DefaultRegistry(),
+			pkgmware.NewAdminGuard(res.adminConfig.Token, logger).Handle,
+		).GetRouter())
+	}
 
 	// setup routes
 	res.setupRoutes()
@@ -91,6 +105,10 @@ func (cr *AppChiRouter) setupMiddleware(logger logger.Logger) {
 	cr.router.Use(middleware.Recoverer)
 	// timeout
 	cr.router.Use(middleware.Timeout(cr.config.ReadTimeout))
+	// conditional GET (ETag, If-None-Match, If-Modified-Since), вне compress - ETag по закодированному телу
+	cr.router.Use(pkgmware.NewResponseCache(logger, pkghttp.NewHTTPPathMatchers([]*pkghttp.PathMatcher{
+		pkghttp.NewPathMatcher(http.MethodGet, "/api/test/{id}", `^/api/test/[^/]+$`),
+	})).Handle)
 	// compress (add any content-types)
 	cr.router.Use(pkgmware.NewCompress(logger,
 		pkghttp.MediaTypeApplicationJSON,
@@ -82,6 +82,6 @@ func downDropTableTest(ctx context.Context, db *sql.DB) error {
 	return nil
 }
 
-func init() {
-	goose.AddMigrationNoTxContext(up0001, down0001)
+func migration0001() *goose.Migration {
+	return goose.NewGoMigration(1, &goose.GoFunc{RunDB: up0001}, &goose.GoFunc{RunDB: down0001})
 }
@@ -0,0 +1,16 @@
+package example_service
+
+import (
+	"github.com/ElfAstAhe/go-service-template/pkg/db/queue"
+	"github.com/pressly/goose/v3"
+)
+
+func migration0002() *goose.Migration {
+	res, err := queue.NewGoMigration(2, queue.DefaultTable)
+	if err != nil {
+		// имя таблицы по умолчанию всегда корректно
+		panic(err)
+	}
+
+	return res
+}
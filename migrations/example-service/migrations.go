@@ -0,0 +1,46 @@
+package example_service
+
+import (
+	"embed"
+	"io/fs"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/db"
+	"github.com/ElfAstAhe/go-service-template/pkg/logger"
+	migrator "github.com/ElfAstAhe/go-service-template/pkg/migration/goose"
+	"github.com/pressly/goose/v3"
+)
+
+// sqlDir каталог версионных SQL миграций вида NNNN_name.sql, ручные скрипты DBA в бинарник не попадают
+const sqlDir string = "sql"
+
+//go:embed sql
+var sqlMigrations embed.FS
+
+// FS файловая система SQL миграций сервиса
+func FS() fs.FS {
+	res, err := fs.Sub(sqlMigrations, sqlDir)
+	if err != nil {
+		// каталог встроен при компиляции
+		panic(err)
+	}
+
+	return res
+}
+
+// GoMigrations Go миграции сервиса, версии не должны пересекаться с SQL миграциями
+func GoMigrations() []*goose.Migration {
+	return []*goose.Migration{
+		migration0001(),
+		migration0002(),
+	}
+}
+
+// NewDBMigrator мигратор со всеми миграциями сервиса
+func NewDBMigrator(db db.DB, log logger.Logger) (*migrator.DBMigrator, error) {
+	return migrator.NewDBMigrator(
+		db,
+		log,
+		migrator.WithFS(FS()),
+		migrator.WithGoMigrations(GoMigrations()...),
+	)
+}
@@ -0,0 +1,39 @@
+package config
+
+import (
+	"github.com/ElfAstAhe/go-service-template/pkg/errs"
+)
+
+// AdminConfig - административные эндпоинты (кэши и т.п.)
+type AdminConfig struct {
+	// Enabled явное включение, в prod окружении без него административные эндпоинты не публикуются
+	Enabled bool `mapstructure:"enabled" json:"enabled,omitempty" yaml:"enabled,omitempty"`
+	// Token токен доступа (заголовок X-Admin-Token или Authorization: Bearer), без токена эндпоинты не публикуются
+	Token string `mapstructure:"token" json:"token,omitempty" yaml:"token,omitempty"`
+}
+
+func NewAdminConfig(enabled bool, token string) *AdminConfig {
+	return &AdminConfig{
+		Enabled: enabled,
+		Token:   token,
+	}
+}
+
+func NewDefaultAdminConfig() *AdminConfig {
+	return NewAdminConfig(DefaultAdminEnabled, "")
+}
+
+// Published административные эндпоинты публикуются: вне prod при заданном токене, в prod только при явном включении
+func (ac *AdminConfig) Published(env AppEnv) bool {
+	return ac.Enabled || (env != AppEnvProduction && ac.Token != "")
+}
+
+// Validate публикация без токена недопустима: вне prod без токена эндпоинты не публикуются,
+// явное включение требует токен в любом окружении
+func (ac *AdminConfig) Validate() error {
+	if ac.Enabled && ac.Token == "" {
+		return errs.NewConfigValidateError("admin", "token", "must not be empty when admin endpoints published", nil)
+	}
+
+	return nil
+}
@@ -38,6 +38,16 @@ const (
 	FlagDBConnTimeout     string = "db-conn-timeout"
 )
 
+// migration config flags
+const (
+	FlagMigrationMode         string = "migration-mode"
+	FlagMigrationAsync        string = "migration-async"
+	FlagMigrationLockKey      string = "migration-lock-key"
+	FlagMigrationLockTimeout  string = "migration-lock-timeout"
+	FlagMigrationWaitTimeout  string = "migration-wait-timeout"
+	FlagMigrationWaitInterval string = "migration-wait-interval"
+)
+
 // gRPC config flags
 const (
 	FlagGRPCAddress          string = "grpc-address"
@@ -63,6 +73,12 @@ const (
 	FlagHTTPMaxRequestBodySize string = "http-max-request-body-size"
 )
 
+// admin config flags
+const (
+	FlagAdminEnabled string = "admin-enabled"
+	FlagAdminToken   string = "admin-token"
+)
+
 // log config flags
 const (
 	FlagLogLevel  string = "log-level"
@@ -71,10 +87,16 @@ const (
 
 // redis config flags
 const (
-	FlagRedisHost     string = "redis-host"
-	FlagRedisPort     string = "redis-port"
-	FlagRedisPassword string = "redis-password"
-	FlagRedisDB       string = "redis-db"
+	FlagRedisEnabled      string = "redis-enabled"
+	FlagRedisHost         string = "redis-host"
+	FlagRedisPort         string = "redis-port"
+	FlagRedisUsername     string = "redis-username"
+	FlagRedisPassword     string = "redis-password"
+	FlagRedisDB           string = "redis-db"
+	FlagRedisPoolSize     string = "redis-pool-size"
+	FlagRedisDialTimeout  string = "redis-dial-timeout"
+	FlagRedisReadTimeout  string = "redis-read-timeout"
+	FlagRedisWriteTimeout string = "redis-write-timeout"
 )
 
 // telemetry
@@ -130,6 +152,16 @@ const (
 	KeyHTTPMaxRequestBodySize string = "http.max_request_body_size"
 )
 
+// Admin defaults
+const (
+	DefaultAdminEnabled bool = false
+)
+
+const (
+	KeyAdminEnabled string = "admin.enabled"
+	KeyAdminToken   string = "admin.token"
+)
+
 // gRPC defaults
 const (
 	DefaultGRPCAddress string = "localhost:50051"
@@ -186,6 +218,50 @@ const (
 	KeyDBConnTimeout         string = "db.conn_timeout"
 )
 
+// Redis defaults
+const (
+	DefaultRedisEnabled      bool          = false
+	DefaultRedisHost         string        = "localhost"
+	DefaultRedisPort         string        = "6379"
+	DefaultRedisDB           int           = 0
+	DefaultRedisPoolSize     int           = 10
+	DefaultRedisDialTimeout  time.Duration = 5 * time.Second
+	DefaultRedisReadTimeout  time.Duration = 3 * time.Second
+	DefaultRedisWriteTimeout time.Duration = 3 * time.Second
+)
+
+const (
+	KeyRedisEnabled      string = "redis.enabled"
+	KeyRedisHost         string = "redis.host"
+	KeyRedisPort         string = "redis.port"
+	KeyRedisUsername     string = "redis.username"
+	KeyRedisPassword     string = "redis.password"
+	KeyRedisDB           string = "redis.db"
+	KeyRedisPoolSize     string = "redis.pool_size"
+	KeyRedisDialTimeout  string = "redis.dial_timeout"
+	KeyRedisReadTimeout  string = "redis.read_timeout"
+	KeyRedisWriteTimeout string = "redis.write_timeout"
+)
+
+// Migration defaults
+const (
+	DefaultMigrationMode         MigrationMode = MigrationModeMigrate
+	DefaultMigrationAsync        bool          = false
+	DefaultMigrationLockKey      string        = "db-migration"
+	DefaultMigrationLockTimeout  time.Duration = 25 * time.Second
+	DefaultMigrationWaitTimeout  time.Duration = 5 * time.Minute
+	DefaultMigrationWaitInterval time.Duration = 2 * time.Second
+)
+
+const (
+	KeyMigrationMode         string = "migration.mode"
+	KeyMigrationAsync        string = "migration.async"
+	KeyMigrationLockKey      string = "migration.lock_key"
+	KeyMigrationLockTimeout  string = "migration.lock_timeout"
+	KeyMigrationWaitTimeout  string = "migration.wait_timeout"
+	KeyMigrationWaitInterval string = "migration.wait_interval"
+)
+
 // Telemetry defaults
 const (
 	DefaultTelemetryEnabled          bool          = false
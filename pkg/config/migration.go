@@ -0,0 +1,92 @@
+package config
+
+import (
+	"time"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/errs"
+)
+
+// MigrationMode - режим миграции схемы БД при старте реплики
+type MigrationMode string
+
+func (mm MigrationMode) Exists() bool {
+	_, ok := migrationModes[mm]
+
+	return ok
+}
+
+// migration mode enum
+const (
+	// MigrationModeMigrate реплика применяет миграции под advisory блокировкой
+	MigrationModeMigrate MigrationMode = "migrate"
+	// MigrationModeWait реплика не мигрирует, а ожидает применения миграций другой репликой
+	MigrationModeWait MigrationMode = "wait"
+	// MigrationModeSkip миграции при старте не выполняются и не проверяются
+	MigrationModeSkip MigrationMode = "skip"
+)
+
+var migrationModes = map[MigrationMode]struct{}{
+	MigrationModeMigrate: {},
+	MigrationModeWait:    {},
+	MigrationModeSkip:    {},
+}
+
+// MigrationConfig - миграция схемы БД при старте
+type MigrationConfig struct {
+	Mode MigrationMode `mapstructure:"mode" json:"mode,omitempty" yaml:"mode,omitempty"`
+	// Async миграция/ожидание выполняется в фоне после старта серверов, /readyz false до завершения
+	Async        bool          `mapstructure:"async" json:"async,omitempty" yaml:"async,omitempty"`
+	LockKey      string        `mapstructure:"lock_key" json:"lock_key,omitempty" yaml:"lock_key,omitempty"`
+	LockTimeout  time.Duration `mapstructure:"lock_timeout" json:"lock_timeout,omitempty" yaml:"lock_timeout,omitempty"`
+	WaitTimeout  time.Duration `mapstructure:"wait_timeout" json:"wait_timeout,omitempty" yaml:"wait_timeout,omitempty"`
+	WaitInterval time.Duration `mapstructure:"wait_interval" json:"wait_interval,omitempty" yaml:"wait_interval,omitempty"`
+}
+
+func NewMigrationConfig(
+	mode MigrationMode,
+	async bool,
+	lockKey string,
+	lockTimeout time.Duration,
+	waitTimeout time.Duration,
+	waitInterval time.Duration,
+) *MigrationConfig {
+	return &MigrationConfig{
+		Mode:         mode,
+		Async:        async,
+		LockKey:      lockKey,
+		LockTimeout:  lockTimeout,
+		WaitTimeout:  waitTimeout,
+		WaitInterval: waitInterval,
+	}
+}
+
+func NewDefaultMigrationConfig() *MigrationConfig {
+	return NewMigrationConfig(
+		DefaultMigrationMode,
+		DefaultMigrationAsync,
+		DefaultMigrationLockKey,
+		DefaultMigrationLockTimeout,
+		DefaultMigrationWaitTimeout,
+		DefaultMigrationWaitInterval,
+	)
+}
+
+func (mc *MigrationConfig) Validate() error {
+	if !mc.Mode.Exists() {
+		return errs.NewConfigValidateError("migration", "mode", "mode value not match", nil)
+	}
+	if mc.LockKey == "" {
+		return errs.NewConfigValidateError("migration", "lock_key", "must not be empty", nil)
+	}
+	if mc.LockTimeout <= 0 {
+		return errs.NewConfigValidateError("migration", "lock_timeout", "must be more than 0", nil)
+	}
+	if mc.WaitTimeout <= 0 {
+		return errs.NewConfigValidateError("migration", "wait_timeout", "must be more than 0", nil)
+	}
+	if mc.WaitInterval <= 0 {
+		return errs.NewConfigValidateError("migration", "wait_interval", "must be more than 0", nil)
+	}
+
+	return nil
+}
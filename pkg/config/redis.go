@@ -0,0 +1,92 @@
+package config
+
+import (
+	"net"
+	"time"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/errs"
+)
+
+// RedisConfig — для кеша или очередей
+type RedisConfig struct {
+	// Enabled использование redis сервисом, при выключенном настройки подключения не проверяются
+	Enabled      bool          `mapstructure:"enabled" json:"enabled,omitempty" yaml:"enabled,omitempty"`
+	Host         string        `mapstructure:"host" json:"host,omitempty" yaml:"host,omitempty"`
+	Port         string        `mapstructure:"port" json:"port,omitempty" yaml:"port,omitempty"`
+	Username     string        `mapstructure:"username" json:"username,omitempty" yaml:"username,omitempty"`
+	Password     string        `mapstructure:"password" json:"password,omitempty" yaml:"password,omitempty"`
+	DB           int           `mapstructure:"db" json:"db,omitempty" yaml:"db,omitempty"`
+	PoolSize     int           `mapstructure:"pool_size" json:"pool_size,omitempty" yaml:"pool_size,omitempty"`
+	DialTimeout  time.Duration `mapstructure:"dial_timeout" json:"dial_timeout,omitempty" yaml:"dial_timeout,omitempty"`
+	ReadTimeout  time.Duration `mapstructure:"read_timeout" json:"read_timeout,omitempty" yaml:"read_timeout,omitempty"`
+	WriteTimeout time.Duration `mapstructure:"write_timeout" json:"write_timeout,omitempty" yaml:"write_timeout,omitempty"`
+}
+
+func NewRedisConfig(
+	enabled bool,
+	host, port, username, password string,
+	db, poolSize int,
+	dialTimeout, readTimeout, writeTimeout time.Duration,
+) *RedisConfig {
+	return &RedisConfig{
+		Enabled:      enabled,
+		Host:         host,
+		Port:         port,
+		Username:     username,
+		Password:     password,
+		DB:           db,
+		PoolSize:     poolSize,
+		DialTimeout:  dialTimeout,
+		ReadTimeout:  readTimeout,
+		WriteTimeout: writeTimeout,
+	}
+}
+
+func NewDefaultRedisConfig() *RedisConfig {
+	return NewRedisConfig(
+		DefaultRedisEnabled,
+		DefaultRedisHost,
+		DefaultRedisPort,
+		"",
+		"",
+		DefaultRedisDB,
+		DefaultRedisPoolSize,
+		DefaultRedisDialTimeout,
+		DefaultRedisReadTimeout,
+		DefaultRedisWriteTimeout,
+	)
+}
+
+// Addr адрес подключения host:port
+func (rc *RedisConfig) Addr() string {
+	return net.JoinHostPort(rc.Host, rc.Port)
+}
+
+func (rc *RedisConfig) Validate() error {
+	if !rc.Enabled {
+		return nil
+	}
+	if rc.Host == "" {
+		return errs.NewConfigValidateError("redis", "host", "must not be empty", nil)
+	}
+	if rc.Port == "" {
+		return errs.NewConfigValidateError("redis", "port", "must not be empty", nil)
+	}
+	if rc.DB < 0 {
+		return errs.NewConfigValidateError("redis", "db", "must not be negative", nil)
+	}
+	if rc.PoolSize < 0 {
+		return errs.NewConfigValidateError("redis", "pool_size", "must not be negative", nil)
+	}
+	if rc.DialTimeout < 0 {
+		return errs.NewConfigValidateError("redis", "dial_timeout", "must not be negative", nil)
+	}
+	if rc.ReadTimeout < 0 {
+		return errs.NewConfigValidateError("redis", "read_timeout", "must not be negative", nil)
+	}
+	if rc.WriteTimeout < 0 {
+		return errs.NewConfigValidateError("redis", "write_timeout", "must not be negative", nil)
+	}
+
+	return nil
+}
@@ -0,0 +1,145 @@
+package lock
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/db"
+	"github.com/ElfAstAhe/go-service-template/pkg/errs"
+)
+
+// AdvisoryLock - сессионная advisory блокировка postgres, удерживает выделенное соединение пула
+type AdvisoryLock struct {
+	mu       sync.Mutex
+	key      string
+	id       int64
+	conn     *sql.Conn
+	released bool
+}
+
+var _ Lock = (*AdvisoryLock)(nil)
+
+func newAdvisoryLock(key string, id int64, conn *sql.Conn) *AdvisoryLock {
+	return &AdvisoryLock{
+		key:  key,
+		id:   id,
+		conn: conn,
+	}
+}
+
+func (al *AdvisoryLock) Key() string {
+	return al.key
+}
+
+func (al *AdvisoryLock) Ping(ctx context.Context) error {
+	al.mu.Lock()
+	defer al.mu.Unlock()
+
+	if al.released {
+		return errs.NewDalError("AdvisoryLock.Ping", fmt.Sprintf("lock [%s] already released", al.key), nil)
+	}
+	if err := al.conn.PingContext(ctx); err != nil {
+		return errs.NewDalError("AdvisoryLock.Ping", fmt.Sprintf("lock [%s] session lost", al.key), err)
+	}
+
+	return nil
+}
+
+func (al *AdvisoryLock) Unlock(ctx context.Context) error {
+	al.mu.Lock()
+	defer al.mu.Unlock()
+
+	if al.released {
+		return nil
+	}
+	al.released = true
+
+	var unlocked bool
+	if err := al.conn.QueryRowContext(ctx, sqlUnlock, al.id).Scan(&unlocked); err != nil {
+		// соединение не возвращаем в пул, блокировка уйдет вместе с сессией
+		discardConn(al.conn)
+
+		return errs.NewDalError("AdvisoryLock.Unlock", fmt.Sprintf("unlock [%s]", al.key), err)
+	}
+	if err := al.conn.Close(); err != nil {
+		return errs.NewDalError("AdvisoryLock.Unlock", fmt.Sprintf("release connection [%s]", al.key), err)
+	}
+	if !unlocked {
+		return errs.NewDalError("AdvisoryLock.Unlock", fmt.Sprintf("lock [%s] was not held", al.key), nil)
+	}
+
+	return nil
+}
+
+// AdvisoryLocker - сессионные advisory блокировки postgres (pg_try_advisory_lock)
+type AdvisoryLocker struct {
+	db            db.DB
+	retryInterval time.Duration
+}
+
+var _ Locker = (*AdvisoryLocker)(nil)
+
+func NewAdvisoryLocker(db db.DB, retryInterval time.Duration) *AdvisoryLocker {
+	if retryInterval <= 0 {
+		retryInterval = DefaultRetryInterval
+	}
+
+	return &AdvisoryLocker{
+		db:            db,
+		retryInterval: retryInterval,
+	}
+}
+
+func (al *AdvisoryLocker) TryLock(ctx context.Context, key string) (Lock, bool, error) {
+	conn, err := al.db.GetDB().Conn(ctx)
+	if err != nil {
+		return nil, false, errs.NewDalError("AdvisoryLocker.TryLock", "acquire connection", err)
+	}
+
+	id := HashKey(key)
+	locked, err := al.tryLock(ctx, conn, id)
+	if err != nil {
+		discardConn(conn)
+
+		return nil, false, errs.NewDalError("AdvisoryLocker.TryLock", fmt.Sprintf("try lock [%s]", key), err)
+	}
+	if !locked {
+		_ = conn.Close()
+
+		return nil, false, nil
+	}
+
+	return newAdvisoryLock(key, id, conn), true, nil
+}
+
+func (al *AdvisoryLocker) Lock(ctx context.Context, key string) (Lock, error) {
+	conn, err := al.db.GetDB().Conn(ctx)
+	if err != nil {
+		return nil, errs.NewDalError("AdvisoryLocker.Lock", "acquire connection", err)
+	}
+
+	id := HashKey(key)
+	// попытки выполняем на одном соединении, чтобы не гонять пул
+	err = retryLock(ctx, al.retryInterval, func() (bool, error) {
+		return al.tryLock(ctx, conn, id)
+	})
+	if err != nil {
+		discardConn(conn)
+
+		return nil, errs.NewDalError("AdvisoryLocker.Lock", fmt.Sprintf("lock [%s]", key), err)
+	}
+
+	return newAdvisoryLock(key, id, conn), nil
+}
+
+func (al *AdvisoryLocker) tryLock(ctx context.Context, conn *sql.Conn, id int64) (bool, error) {
+	var locked bool
+	if err := conn.QueryRowContext(ctx, sqlTryLock, id).Scan(&locked); err != nil {
+		return false, err
+	}
+
+	return locked, nil
+}
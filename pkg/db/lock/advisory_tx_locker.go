@@ -0,0 +1,61 @@
+package lock
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/db"
+	"github.com/ElfAstAhe/go-service-template/pkg/errs"
+)
+
+// AdvisoryTxLocker - транзакционные advisory блокировки postgres (pg_try_advisory_xact_lock)
+type AdvisoryTxLocker struct {
+	retryInterval time.Duration
+}
+
+var _ TxLocker = (*AdvisoryTxLocker)(nil)
+
+func NewAdvisoryTxLocker(retryInterval time.Duration) *AdvisoryTxLocker {
+	if retryInterval <= 0 {
+		retryInterval = DefaultRetryInterval
+	}
+
+	return &AdvisoryTxLocker{
+		retryInterval: retryInterval,
+	}
+}
+
+func (atl *AdvisoryTxLocker) TryLock(ctx context.Context, key string) (bool, error) {
+	locked, err := atl.tryLock(ctx, key)
+	if err != nil {
+		return false, errs.NewDalError("AdvisoryTxLocker.TryLock", fmt.Sprintf("try lock [%s]", key), err)
+	}
+
+	return locked, nil
+}
+
+func (atl *AdvisoryTxLocker) Lock(ctx context.Context, key string) error {
+	err := retryLock(ctx, atl.retryInterval, func() (bool, error) {
+		return atl.tryLock(ctx, key)
+	})
+	if err != nil {
+		return errs.NewDalError("AdvisoryTxLocker.Lock", fmt.Sprintf("lock [%s]", key), err)
+	}
+
+	return nil
+}
+
+func (atl *AdvisoryTxLocker) tryLock(ctx context.Context, key string) (bool, error) {
+	tx := db.GetTx(ctx)
+	if tx == nil {
+		return false, errs.NewCommonError("transaction not found in context", nil)
+	}
+
+	var locked bool
+	if err := tx.QueryRowContext(ctx, sqlTryXactLock, HashKey(key)).Scan(&locked); err != nil {
+		return false, err
+	}
+
+	return locked, nil
+}
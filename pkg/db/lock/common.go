@@ -0,0 +1,57 @@
+package lock
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"hash/fnv"
+	"time"
+)
+
+// DefaultRetryInterval - интервал повторных попыток захвата блокировки в Lock
+const DefaultRetryInterval time.Duration = 500 * time.Millisecond
+
+const (
+	sqlTryLock     string = `select pg_try_advisory_lock($1)`
+	sqlUnlock      string = `select pg_advisory_unlock($1)`
+	sqlTryXactLock string = `select pg_try_advisory_xact_lock($1)`
+)
+
+// HashKey - преобразование строкового ключа блокировки в bigint ключ advisory lock
+func HashKey(key string) int64 {
+	hasher := fnv.New64a()
+	_, _ = hasher.Write([]byte(key))
+
+	return int64(hasher.Sum64())
+}
+
+// retryLock - повторяет попытку захвата блокировки с интервалом до успеха, ошибки или отмены контекста
+func retryLock(ctx context.Context, retryInterval time.Duration, try func() (bool, error)) error {
+	ticker := time.NewTicker(retryInterval)
+	defer ticker.Stop()
+
+	for {
+		locked, err := try()
+		if err != nil {
+			return err
+		}
+		if locked {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// discardConn - закрытие соединения без возврата в пул,
+// сессионные блокировки освобождаются сервером вместе с сессией
+func discardConn(conn *sql.Conn) {
+	_ = conn.Raw(func(driverConn any) error {
+		return driver.ErrBadConn
+	})
+	_ = conn.Close()
+}
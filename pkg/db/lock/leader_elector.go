@@ -0,0 +1,296 @@
+package lock
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/container"
+	"github.com/ElfAstAhe/go-service-template/pkg/errs"
+	"github.com/ElfAstAhe/go-service-template/pkg/logger"
+	"github.com/ElfAstAhe/go-service-template/pkg/transport/worker"
+)
+
+// LeaderElectorConfig - настройки выбора лидера
+type LeaderElectorConfig struct {
+	// Key ключ блокировки лидерства, общий для всех реплик
+	Key string
+	// RetryInterval интервал попыток захвата лидерства репликой-последователем
+	RetryInterval time.Duration
+	// RenewInterval интервал продления аренды (проверки сессии) лидером
+	RenewInterval time.Duration
+	// LeaseTimeout таймаут продления аренды, по истечении лидерство снимается
+	LeaseTimeout time.Duration
+	// StopTimeout таймаут остановки
+	StopTimeout time.Duration
+}
+
+func NewLeaderElectorConfig(
+	key string,
+	retryInterval time.Duration,
+	renewInterval time.Duration,
+	leaseTimeout time.Duration,
+	stopTimeout time.Duration,
+) *LeaderElectorConfig {
+	return &LeaderElectorConfig{
+		Key:           key,
+		RetryInterval: retryInterval,
+		RenewInterval: renewInterval,
+		LeaseTimeout:  leaseTimeout,
+		StopTimeout:   stopTimeout,
+	}
+}
+
+func (lec *LeaderElectorConfig) Validate() error {
+	if lec.Key == "" {
+		return errs.NewInvalidArgumentError("Key", lec.Key)
+	}
+	if lec.RetryInterval <= 0 {
+		return errs.NewInvalidArgumentError("RetryInterval", lec.RetryInterval)
+	}
+	if lec.RenewInterval <= 0 {
+		return errs.NewInvalidArgumentError("RenewInterval", lec.RenewInterval)
+	}
+	if lec.LeaseTimeout <= 0 {
+		return errs.NewInvalidArgumentError("LeaseTimeout", lec.LeaseTimeout)
+	}
+	if lec.StopTimeout <= 0 {
+		return errs.NewInvalidArgumentError("StopTimeout", lec.StopTimeout)
+	}
+
+	return nil
+}
+
+// LeaderElector - выбор лидера среди реплик на основе сессионной advisory блокировки.
+// Лидер удерживает блокировку и периодически проверяет живость своей сессии (продление аренды),
+// при потере сессии лидерство снимается. При падении лидера сервер освобождает блокировку вместе
+// с сессией и лидерство забирает одна из оставшихся реплик.
+type LeaderElector struct {
+	name   string
+	config *LeaderElectorConfig
+	locker Locker
+	log    logger.Logger
+	// context
+	ctx    context.Context
+	cancel context.CancelFunc
+	// sync
+	wg sync.WaitGroup
+	mu sync.RWMutex
+	// leadership
+	lock         Lock
+	leaderCtx    context.Context
+	leaderCancel context.CancelFunc
+	// resignErr ошибка освобождения лидерства при завершении кампании
+	resignErr error
+	//
+	running *atomic.Bool
+}
+
+var _ worker.Leader = (*LeaderElector)(nil)
+var _ container.Runner = (*LeaderElector)(nil)
+
+func NewLeaderElector(
+	name string,
+	config *LeaderElectorConfig,
+	locker Locker,
+	log logger.Logger,
+) (*LeaderElector, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errs.NewCommonError(fmt.Sprintf("leader elector %s invalid config", name), err)
+	}
+
+	res := &LeaderElector{
+		name:    name,
+		config:  config,
+		locker:  locker,
+		log:     log.GetLogger(name),
+		running: new(atomic.Bool),
+	}
+	res.leaderCtx, res.leaderCancel = context.WithCancel(context.Background())
+	res.leaderCancel()
+	res.running.Store(false)
+
+	return res, nil
+}
+
+func (le *LeaderElector) Start(ctx context.Context) error {
+	if !le.running.CompareAndSwap(false, true) {
+		return errs.NewCommonError(fmt.Sprintf("leader elector %s already started", le.GetName()), nil)
+	}
+
+	le.log.Debugf("leader elector %s starting", le.GetName())
+	defer le.log.Debugf("leader elector %s started", le.GetName())
+
+	le.ctx, le.cancel = context.WithCancel(ctx)
+
+	le.wg.Add(1)
+	go le.campaign()
+
+	return nil
+}
+
+func (le *LeaderElector) Stop(stopCtx context.Context) error {
+	if !le.running.CompareAndSwap(true, false) {
+		return errs.NewCommonError(fmt.Sprintf("leader elector %s is not running", le.GetName()), nil)
+	}
+
+	if le.cancel != nil {
+		le.cancel()
+	}
+
+	stopChan := make(chan struct{})
+	go func() {
+		le.wg.Wait()
+		close(stopChan)
+	}()
+	// лидерство освобождает сама кампания при завершении, см. campaign
+	select {
+	case <-stopChan:
+		le.log.Debugf("leader elector %s stopped gracefully", le.GetName())
+
+		return le.resignErr
+	case <-time.After(le.config.StopTimeout):
+		le.log.Debugf("leader elector %s stop timed out", le.GetName())
+
+		return errs.NewCommonError(fmt.Sprintf("leader elector %s stop timeout", le.GetName()), nil)
+	case <-stopCtx.Done():
+		le.log.Debugf("leader elector %s stopped by stop context", le.GetName())
+
+		return errs.NewCommonError(fmt.Sprintf("leader elector %s stop canceled", le.GetName()), stopCtx.Err())
+	}
+}
+
+// IsLeader текущая реплика удерживает лидерство
+func (le *LeaderElector) IsLeader() bool {
+	le.mu.RLock()
+	defer le.mu.RUnlock()
+
+	return le.lock != nil
+}
+
+// LeaderContext контекст текущего срока лидерства, отменяется при потере лидерства
+func (le *LeaderElector) LeaderContext() context.Context {
+	le.mu.RLock()
+	defer le.mu.RUnlock()
+
+	return le.leaderCtx
+}
+
+func (le *LeaderElector) GetName() string {
+	return le.name
+}
+
+func (le *LeaderElector) IsRunning() bool {
+	return le.running.Load()
+}
+
+func (le *LeaderElector) GetConfig() *LeaderElectorConfig {
+	return le.config
+}
+
+func (le *LeaderElector) campaign() {
+	le.log.Debugf("leader elector %s campaign start", le.GetName())
+	defer le.log.Debugf("leader elector %s campaign finish", le.GetName())
+	defer le.wg.Done()
+	// лидерство освобождается после выхода из цикла, чтобы другие реплики не ждали разрыва сессии:
+	// захват, завершившийся во время остановки, не оставит блокировку на соединении пула
+	defer func() {
+		resignCtx, cancel := context.WithTimeout(context.Background(), le.config.StopTimeout)
+		defer cancel()
+
+		le.resignErr = le.resign(resignCtx)
+	}()
+
+	for {
+		interval := le.config.RetryInterval
+		if le.IsLeader() {
+			le.renew()
+			interval = le.config.RenewInterval
+		} else {
+			le.tryAcquire()
+			if le.IsLeader() {
+				interval = le.config.RenewInterval
+			}
+		}
+
+		select {
+		case <-le.ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+	}
+}
+
+func (le *LeaderElector) tryAcquire() {
+	lock, ok, err := le.locker.TryLock(le.ctx, le.config.Key)
+	if err != nil {
+		le.log.Warnf("leader elector %s acquire leadership failed: %v", le.GetName(), err)
+
+		return
+	}
+	if !ok {
+		return
+	}
+
+	le.mu.Lock()
+	le.lock = lock
+	le.leaderCtx, le.leaderCancel = context.WithCancel(le.ctx)
+	le.mu.Unlock()
+
+	le.log.Infof("leader elector %s leadership acquired", le.GetName())
+}
+
+func (le *LeaderElector) renew() {
+	le.mu.RLock()
+	lock := le.lock
+	le.mu.RUnlock()
+
+	leaseCtx, cancel := context.WithTimeout(le.ctx, le.config.LeaseTimeout)
+	defer cancel()
+
+	if err := lock.Ping(leaseCtx); err != nil {
+		if le.ctx.Err() != nil {
+			return
+		}
+		le.log.Warnf("leader elector %s lease renew failed, leadership lost: %v", le.GetName(), err)
+		le.revoke()
+	}
+}
+
+// revoke снятие лидерства без освобождения блокировки (сессия потеряна)
+func (le *LeaderElector) revoke() {
+	le.mu.Lock()
+	lock := le.lock
+	le.lock = nil
+	le.leaderCancel()
+	le.mu.Unlock()
+
+	if lock != nil {
+		// соединение уже мертво, освобождаем ресурсы пула
+		unlockCtx, cancel := context.WithTimeout(context.Background(), le.config.LeaseTimeout)
+		defer cancel()
+		_ = lock.Unlock(unlockCtx)
+	}
+}
+
+// resign добровольное освобождение лидерства
+func (le *LeaderElector) resign(ctx context.Context) error {
+	le.mu.Lock()
+	lock := le.lock
+	le.lock = nil
+	le.leaderCancel()
+	le.mu.Unlock()
+
+	if lock == nil {
+		return nil
+	}
+
+	le.log.Infof("leader elector %s leadership released", le.GetName())
+	if err := lock.Unlock(ctx); err != nil {
+		return errs.NewCommonError(fmt.Sprintf("leader elector %s release leadership failed", le.GetName()), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package lock
+
+import (
+	"context"
+)
+
+// Lock - захваченная сессионная блокировка
+type Lock interface {
+	// Key исходный строковый ключ блокировки
+	Key() string
+	// Ping проверка живости сессии, удерживающей блокировку
+	Ping(ctx context.Context) error
+	// Unlock освобождение блокировки и соединения
+	Unlock(ctx context.Context) error
+}
+
+// Locker - сессионные блокировки, живут до Unlock или до закрытия соединения
+type Locker interface {
+	// TryLock попытка захвата без ожидания
+	TryLock(ctx context.Context, key string) (Lock, bool, error)
+	// Lock захват с ожиданием до успеха или отмены контекста
+	Lock(ctx context.Context, key string) (Lock, error)
+}
+
+// TxLocker - транзакционные блокировки, освобождаются автоматически по завершении транзакции,
+// требуют наличия транзакции в контексте (см. db.TxManager)
+type TxLocker interface {
+	// TryLock попытка захвата без ожидания
+	TryLock(ctx context.Context, key string) (bool, error)
+	// Lock захват с ожиданием до успеха или отмены контекста
+	Lock(ctx context.Context, key string) error
+}
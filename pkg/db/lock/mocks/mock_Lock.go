@@ -0,0 +1,184 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockLock creates a new instance of MockLock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockLock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockLock {
+	mock := &MockLock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockLock is an autogenerated mock type for the Lock type
+type MockLock struct {
+	mock.Mock
+}
+
+type MockLock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockLock) EXPECT() *MockLock_Expecter {
+	return &MockLock_Expecter{mock: &_m.Mock}
+}
+
+// Key provides a mock function for the type MockLock
+func (_mock *MockLock) Key() string {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Key")
+	}
+
+	var r0 string
+	if returnFunc, ok := ret.Get(0).(func() string); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	return r0
+}
+
+// MockLock_Key_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Key'
+type MockLock_Key_Call struct {
+	*mock.Call
+}
+
+// Key is a helper method to define mock.On call
+func (_e *MockLock_Expecter) Key() *MockLock_Key_Call {
+	return &MockLock_Key_Call{Call: _e.mock.On("Key")}
+}
+
+func (_c *MockLock_Key_Call) Run(run func()) *MockLock_Key_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockLock_Key_Call) Return(s string) *MockLock_Key_Call {
+	_c.Call.Return(s)
+	return _c
+}
+
+func (_c *MockLock_Key_Call) RunAndReturn(run func() string) *MockLock_Key_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Ping provides a mock function for the type MockLock
+func (_mock *MockLock) Ping(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Ping")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockLock_Ping_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Ping'
+type MockLock_Ping_Call struct {
+	*mock.Call
+}
+
+// Ping is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockLock_Expecter) Ping(ctx any) *MockLock_Ping_Call {
+	return &MockLock_Ping_Call{Call: _e.mock.On("Ping", ctx)}
+}
+
+func (_c *MockLock_Ping_Call) Run(run func(ctx context.Context)) *MockLock_Ping_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLock_Ping_Call) Return(err error) *MockLock_Ping_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockLock_Ping_Call) RunAndReturn(run func(ctx context.Context) error) *MockLock_Ping_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Unlock provides a mock function for the type MockLock
+func (_mock *MockLock) Unlock(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Unlock")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockLock_Unlock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Unlock'
+type MockLock_Unlock_Call struct {
+	*mock.Call
+}
+
+// Unlock is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockLock_Expecter) Unlock(ctx any) *MockLock_Unlock_Call {
+	return &MockLock_Unlock_Call{Call: _e.mock.On("Unlock", ctx)}
+}
+
+func (_c *MockLock_Unlock_Call) Run(run func(ctx context.Context)) *MockLock_Unlock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLock_Unlock_Call) Return(err error) *MockLock_Unlock_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockLock_Unlock_Call) RunAndReturn(run func(ctx context.Context) error) *MockLock_Unlock_Call {
+	_c.Call.Return(run)
+	return _c
+}
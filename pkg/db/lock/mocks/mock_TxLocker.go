@@ -0,0 +1,161 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockTxLocker creates a new instance of MockTxLocker. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTxLocker(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTxLocker {
+	mock := &MockTxLocker{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockTxLocker is an autogenerated mock type for the TxLocker type
+type MockTxLocker struct {
+	mock.Mock
+}
+
+type MockTxLocker_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTxLocker) EXPECT() *MockTxLocker_Expecter {
+	return &MockTxLocker_Expecter{mock: &_m.Mock}
+}
+
+// Lock provides a mock function for the type MockTxLocker
+func (_mock *MockTxLocker) Lock(ctx context.Context, key string) error {
+	ret := _mock.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Lock")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTxLocker_Lock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Lock'
+type MockTxLocker_Lock_Call struct {
+	*mock.Call
+}
+
+// Lock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockTxLocker_Expecter) Lock(ctx any, key any) *MockTxLocker_Lock_Call {
+	return &MockTxLocker_Lock_Call{Call: _e.mock.On("Lock", ctx, key)}
+}
+
+func (_c *MockTxLocker_Lock_Call) Run(run func(ctx context.Context, key string)) *MockTxLocker_Lock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTxLocker_Lock_Call) Return(err error) *MockTxLocker_Lock_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTxLocker_Lock_Call) RunAndReturn(run func(ctx context.Context, key string) error) *MockTxLocker_Lock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TryLock provides a mock function for the type MockTxLocker
+func (_mock *MockTxLocker) TryLock(ctx context.Context, key string) (bool, error) {
+	ret := _mock.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TryLock")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return returnFunc(ctx, key)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = returnFunc(ctx, key)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTxLocker_TryLock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TryLock'
+type MockTxLocker_TryLock_Call struct {
+	*mock.Call
+}
+
+// TryLock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockTxLocker_Expecter) TryLock(ctx any, key any) *MockTxLocker_TryLock_Call {
+	return &MockTxLocker_TryLock_Call{Call: _e.mock.On("TryLock", ctx, key)}
+}
+
+func (_c *MockTxLocker_TryLock_Call) Run(run func(ctx context.Context, key string)) *MockTxLocker_TryLock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTxLocker_TryLock_Call) Return(b bool, err error) *MockTxLocker_TryLock_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockTxLocker_TryLock_Call) RunAndReturn(run func(ctx context.Context, key string) (bool, error)) *MockTxLocker_TryLock_Call {
+	_c.Call.Return(run)
+	return _c
+}
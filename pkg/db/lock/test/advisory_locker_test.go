@@ -0,0 +1,159 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ElfAstAhe/go-service-template/pkg/db"
+	"github.com/ElfAstAhe/go-service-template/pkg/db/lock"
+	"github.com/ElfAstAhe/go-service-template/pkg/db/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	sqlTryLock     = `select pg_try_advisory_lock($1)`
+	sqlUnlock      = `select pg_advisory_unlock($1)`
+	sqlTryXactLock = `select pg_try_advisory_xact_lock($1)`
+)
+
+func TestHashKey(t *testing.T) {
+	assert.Equal(t, lock.HashKey("janitor"), lock.HashKey("janitor"), "Хеш должен быть детерминирован")
+	assert.NotEqual(t, lock.HashKey("janitor"), lock.HashKey("outbox"))
+}
+
+func TestAdvisoryLocker_TryLock(t *testing.T) {
+	sqlDB, mockSql, err := sqlmock.New()
+	require.NoError(t, err)
+	defer sqlDB.Close()
+
+	mockDB := mocks.NewMockDB(t)
+	mockDB.On("GetDB").Return(sqlDB)
+
+	locker := lock.NewAdvisoryLocker(mockDB, 10*time.Millisecond)
+	id := lock.HashKey("test-lock")
+
+	t.Run("Acquired_And_Unlocked", func(t *testing.T) {
+		mockSql.ExpectQuery(regexp.QuoteMeta(sqlTryLock)).WithArgs(id).
+			WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(true))
+		mockSql.ExpectQuery(regexp.QuoteMeta(sqlUnlock)).WithArgs(id).
+			WillReturnRows(sqlmock.NewRows([]string{"unlocked"}).AddRow(true))
+
+		l, ok, err := locker.TryLock(context.Background(), "test-lock")
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, "test-lock", l.Key())
+
+		assert.NoError(t, l.Unlock(context.Background()))
+		// повторный Unlock безопасен
+		assert.NoError(t, l.Unlock(context.Background()))
+		assert.NoError(t, mockSql.ExpectationsWereMet())
+	})
+
+	t.Run("Busy", func(t *testing.T) {
+		mockSql.ExpectQuery(regexp.QuoteMeta(sqlTryLock)).WithArgs(id).
+			WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(false))
+
+		l, ok, err := locker.TryLock(context.Background(), "test-lock")
+		assert.NoError(t, err)
+		assert.False(t, ok)
+		assert.Nil(t, l)
+		assert.NoError(t, mockSql.ExpectationsWereMet())
+	})
+
+	t.Run("Query_Error", func(t *testing.T) {
+		mockSql.ExpectQuery(regexp.QuoteMeta(sqlTryLock)).WithArgs(id).
+			WillReturnError(errors.New("connection reset"))
+
+		_, ok, err := locker.TryLock(context.Background(), "test-lock")
+		assert.Error(t, err)
+		assert.False(t, ok)
+	})
+}
+
+func TestAdvisoryLocker_Lock(t *testing.T) {
+	sqlDB, mockSql, err := sqlmock.New()
+	require.NoError(t, err)
+	defer sqlDB.Close()
+
+	mockDB := mocks.NewMockDB(t)
+	mockDB.On("GetDB").Return(sqlDB)
+
+	locker := lock.NewAdvisoryLocker(mockDB, 10*time.Millisecond)
+	id := lock.HashKey("test-lock")
+
+	t.Run("Wait_Until_Released", func(t *testing.T) {
+		mockSql.ExpectQuery(regexp.QuoteMeta(sqlTryLock)).WithArgs(id).
+			WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(false))
+		mockSql.ExpectQuery(regexp.QuoteMeta(sqlTryLock)).WithArgs(id).
+			WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(false))
+		mockSql.ExpectQuery(regexp.QuoteMeta(sqlTryLock)).WithArgs(id).
+			WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(true))
+
+		l, err := locker.Lock(context.Background(), "test-lock")
+		require.NoError(t, err)
+		assert.NotNil(t, l)
+		assert.NoError(t, mockSql.ExpectationsWereMet())
+	})
+
+	t.Run("Context_Timeout", func(t *testing.T) {
+		sqlDB, mockSql, err := sqlmock.New()
+		require.NoError(t, err)
+		defer sqlDB.Close()
+
+		mockDB := mocks.NewMockDB(t)
+		mockDB.On("GetDB").Return(sqlDB)
+		locker := lock.NewAdvisoryLocker(mockDB, 20*time.Millisecond)
+
+		mockSql.MatchExpectationsInOrder(false)
+		for i := 0; i < 10; i++ {
+			mockSql.ExpectQuery(regexp.QuoteMeta(sqlTryLock)).WithArgs(id).
+				WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(false))
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		_, err = locker.Lock(ctx, "test-lock")
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func TestAdvisoryTxLocker(t *testing.T) {
+	sqlDB, mockSql, err := sqlmock.New()
+	require.NoError(t, err)
+	defer sqlDB.Close()
+
+	mockDB := mocks.NewMockDB(t)
+	mockDB.On("GetDB").Return(sqlDB)
+
+	tm := db.NewTxManager(mockDB)
+	locker := lock.NewAdvisoryTxLocker(10 * time.Millisecond)
+	id := lock.HashKey("tx-lock")
+
+	t.Run("Without_Transaction", func(t *testing.T) {
+		ok, err := locker.TryLock(context.Background(), "tx-lock")
+		assert.Error(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("Within_Transaction", func(t *testing.T) {
+		mockSql.ExpectBegin()
+		mockSql.ExpectQuery(regexp.QuoteMeta(sqlTryXactLock)).WithArgs(id).
+			WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(false))
+		mockSql.ExpectQuery(regexp.QuoteMeta(sqlTryXactLock)).WithArgs(id).
+			WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(true))
+		mockSql.ExpectCommit()
+
+		err := tm.WithinTransaction(context.Background(), nil, func(ctx context.Context) error {
+			return locker.Lock(ctx, "tx-lock")
+		})
+
+		assert.NoError(t, err)
+		assert.NoError(t, mockSql.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,146 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/db/lock"
+	"github.com/ElfAstAhe/go-service-template/pkg/db/lock/mocks"
+	logmocks "github.com/ElfAstAhe/go-service-template/pkg/logger/mocks"
+	"github.com/ElfAstAhe/go-service-template/pkg/transport/worker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLogger(t *testing.T) *logmocks.MockLogger {
+	log := logmocks.NewMockLogger(t)
+	log.On("GetLogger", mock.Anything).Return(log).Maybe()
+	log.On("Debugf", mock.Anything, mock.Anything).Maybe()
+	log.On("Debugf", mock.Anything, mock.Anything, mock.Anything).Maybe()
+	log.On("Infof", mock.Anything, mock.Anything).Maybe()
+	log.On("Warnf", mock.Anything, mock.Anything, mock.Anything).Maybe()
+
+	return log
+}
+
+func newTestElectorConfig() *lock.LeaderElectorConfig {
+	return lock.NewLeaderElectorConfig("leader", 10*time.Millisecond, 10*time.Millisecond, 50*time.Millisecond, time.Second)
+}
+
+func TestLeaderElector_Config_Validate(t *testing.T) {
+	_, err := lock.NewLeaderElector("elector", lock.NewLeaderElectorConfig("", time.Second, time.Second, time.Second, time.Second), mocks.NewMockLocker(t), newTestLogger(t))
+	assert.Error(t, err)
+
+	_, err = lock.NewLeaderElector("elector", lock.NewLeaderElectorConfig("leader", time.Second, time.Second, time.Second, 0), mocks.NewMockLocker(t), newTestLogger(t))
+	assert.Error(t, err, "Нулевой таймаут остановки недопустим")
+}
+
+func TestLeaderElector_AcquireAndResign(t *testing.T) {
+	mLock := mocks.NewMockLock(t)
+	mLock.On("Ping", mock.Anything).Return(nil).Maybe()
+	mLock.On("Unlock", mock.Anything).Return(nil).Once()
+
+	mLocker := mocks.NewMockLocker(t)
+	mLocker.On("TryLock", mock.Anything, "leader").Return(mLock, true, nil).Once()
+
+	elector, err := lock.NewLeaderElector("elector", newTestElectorConfig(), mLocker, newTestLogger(t))
+	require.NoError(t, err)
+	assert.False(t, elector.IsLeader())
+	assert.Error(t, elector.LeaderContext().Err(), "Контекст лидерства до избрания должен быть отменен")
+
+	require.NoError(t, elector.Start(context.Background()))
+	assert.Eventually(t, elector.IsLeader, time.Second, 5*time.Millisecond)
+	leaderCtx := elector.LeaderContext()
+	assert.NoError(t, leaderCtx.Err())
+
+	require.NoError(t, elector.Stop(context.Background()))
+	assert.False(t, elector.IsLeader())
+	assert.Error(t, leaderCtx.Err(), "Контекст лидерства должен быть отменен после остановки")
+}
+
+func TestLeaderElector_Failover(t *testing.T) {
+	first := mocks.NewMockLock(t)
+	first.On("Ping", mock.Anything).Return(errors.New("session lost")).Once()
+	first.On("Unlock", mock.Anything).Return(nil).Once()
+
+	second := mocks.NewMockLock(t)
+	second.On("Ping", mock.Anything).Return(nil).Maybe()
+	second.On("Unlock", mock.Anything).Return(nil).Once()
+
+	mLocker := mocks.NewMockLocker(t)
+	mLocker.On("TryLock", mock.Anything, "leader").Return(first, true, nil).Once()
+	mLocker.On("TryLock", mock.Anything, "leader").Return(nil, false, nil).Once()
+	mLocker.On("TryLock", mock.Anything, "leader").Return(second, true, nil).Once()
+
+	elector, err := lock.NewLeaderElector("elector", newTestElectorConfig(), mLocker, newTestLogger(t))
+	require.NoError(t, err)
+
+	require.NoError(t, elector.Start(context.Background()))
+	assert.Eventually(t, elector.IsLeader, time.Second, time.Millisecond)
+	firstTerm := elector.LeaderContext()
+
+	// первый срок обрывается по ошибке продления, затем лидерство возвращается
+	assert.Eventually(t, func() bool { return firstTerm.Err() != nil }, time.Second, time.Millisecond)
+	assert.Eventually(t, func() bool {
+		return elector.IsLeader() && elector.LeaderContext() != firstTerm
+	}, time.Second, time.Millisecond)
+
+	require.NoError(t, elector.Stop(context.Background()))
+}
+
+func TestLeaderTimerDispatcher(t *testing.T) {
+	mLock := mocks.NewMockLock(t)
+	mLock.On("Ping", mock.Anything).Return(nil).Maybe()
+	mLock.On("Unlock", mock.Anything).Return(nil).Once()
+
+	mLocker := mocks.NewMockLocker(t)
+	mLocker.On("TryLock", mock.Anything, "leader").Return(mLock, true, nil).Once()
+
+	elector, err := lock.NewLeaderElector("elector", newTestElectorConfig(), mLocker, newTestLogger(t))
+	require.NoError(t, err)
+
+	var calls atomic.Int32
+	dispatcher := worker.NewLeaderTimerDispatcher(elector, func(ctx context.Context, eventTime time.Time) error {
+		calls.Add(1)
+		return nil
+	})
+
+	// не лидер - диспетчер не вызывается
+	assert.NoError(t, dispatcher(context.Background(), time.Now()))
+	assert.Equal(t, int32(0), calls.Load())
+
+	require.NoError(t, elector.Start(context.Background()))
+	assert.Eventually(t, elector.IsLeader, time.Second, 5*time.Millisecond)
+
+	assert.NoError(t, dispatcher(context.Background(), time.Now()))
+	assert.Equal(t, int32(1), calls.Load())
+
+	require.NoError(t, elector.Stop(context.Background()))
+}
+
+func TestLeaderElector_AcquiredDuringStop(t *testing.T) {
+	mLock := mocks.NewMockLock(t)
+	mLock.On("Unlock", mock.Anything).Return(nil).Once()
+
+	// захват завершается успешно уже после отмены контекста кампании
+	acquiring := make(chan struct{})
+	mLocker := mocks.NewMockLocker(t)
+	mLocker.On("TryLock", mock.Anything, "leader").
+		Run(func(args mock.Arguments) {
+			close(acquiring)
+			<-args.Get(0).(context.Context).Done()
+		}).
+		Return(mLock, true, nil).Once()
+
+	elector, err := lock.NewLeaderElector("elector", newTestElectorConfig(), mLocker, newTestLogger(t))
+	require.NoError(t, err)
+
+	require.NoError(t, elector.Start(context.Background()))
+	<-acquiring
+	require.NoError(t, elector.Stop(context.Background()))
+	assert.False(t, elector.IsLeader(), "Блокировка, захваченная во время остановки, должна быть освобождена")
+}
@@ -0,0 +1,115 @@
+// Package queue долговременная очередь заданий на postgres (select ... for update skip locked), общая для всех реплик
+package queue
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/errs"
+)
+
+const (
+	DefaultTable       = "job_queue"
+	DefaultQueue       = "default"
+	DefaultMaxAttempts = 5
+)
+
+// Статусы задания, успешно выполненные задания удаляются
+const (
+	StatusPending = "pending"
+	StatusRunning = "running"
+	StatusDead    = "dead"
+)
+
+// tableNamePattern имя таблицы подставляется в SQL, поэтому допускаются только идентификаторы (опционально со схемой)
+var tableNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+const (
+	sqlEnqueue = `insert into %s (queue, job_type, payload, priority, max_attempts, run_at)
+values ($1, $2, $3::jsonb, $4, $5, coalesce($6::timestamptz, now()))
+returning id`
+
+	// sqlClaim захват готовых заданий и заданий с истекшей арендой (упавший обработчик)
+	sqlClaim = `update %[1]s j
+set status = 'running', attempts = j.attempts + 1, locked_by = $3,
+    locked_until = now() + $4::bigint * interval '1 millisecond', modified_at = now()
+where j.id in (
+    select id from %[1]s
+    where queue = $1
+      and ((status = 'pending' and run_at <= now()) or (status = 'running' and locked_until < now()))
+    order by priority desc, run_at, id
+    limit $2
+    for update skip locked
+)
+returning j.id, j.queue, j.job_type, j.payload, j.priority, j.attempts, j.max_attempts, j.run_at, j.created_at`
+
+	sqlHeartbeat = `update %s
+set locked_until = now() + $2::bigint * interval '1 millisecond', modified_at = now()
+where locked_by = $1 and status = 'running'
+returning id`
+
+	sqlComplete = `delete from %s where id = $1 and locked_by = $2`
+
+	sqlRetry = `update %s
+set status = 'pending', run_at = now() + $3::bigint * interval '1 millisecond', last_error = $4,
+    locked_by = null, locked_until = null, modified_at = now()
+where id = $1 and locked_by = $2`
+
+	sqlBury = `update %s
+set status = 'dead', last_error = $3, locked_by = null, locked_until = null, modified_at = now()
+where id = $1 and locked_by = $2`
+
+	sqlRequeue = `update %s
+set status = 'pending', attempts = 0, run_at = now(), last_error = null, modified_at = now()
+where id = $1 and status = 'dead'`
+)
+
+// Job - задание очереди
+type Job struct {
+	ID          int64
+	Queue       string
+	Type        string
+	Payload     []byte
+	Priority    int
+	Attempts    int
+	MaxAttempts int
+	RunAt       time.Time
+	CreatedAt   time.Time
+}
+
+// DecodePayload типизированное содержимое задания (JSON)
+func DecodePayload[T any](job *Job) (T, error) {
+	var res T
+	if err := json.Unmarshal(job.Payload, &res); err != nil {
+		return res, errs.NewCommonError(fmt.Sprintf("decode payload of job [%d] type [%s]", job.ID, job.Type), err)
+	}
+
+	return res, nil
+}
+
+// JobType - типизированный вид задания: связывает имя типа с типом содержимого для постановки и обработки
+type JobType[T any] struct {
+	name string
+}
+
+func NewJobType[T any](name string) JobType[T] {
+	return JobType[T]{name: name}
+}
+
+func (jt JobType[T]) Name() string {
+	return jt.name
+}
+
+func validateTableName(table string) error {
+	if !tableNamePattern.MatchString(table) {
+		return errs.NewInvalidArgumentError("table", table)
+	}
+
+	return nil
+}
+
+func millis(d time.Duration) int64 {
+	return d.Milliseconds()
+}
@@ -0,0 +1,246 @@
+package queue
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/db"
+	"github.com/ElfAstAhe/go-service-template/pkg/errs"
+	"github.com/ElfAstAhe/go-service-template/pkg/utils"
+)
+
+// JobQueue - постановка заданий в очередь и операции над ними.
+// Запросы выполняются через db.Executor, поэтому внутри TxManager.WithinTransaction задание
+// ставится в очередь атомарно с изменениями бизнес-данных
+type JobQueue struct {
+	exec  db.Executor
+	table string
+	queue string
+	sql   queueSQL
+}
+
+// queueSQL запросы с подставленным именем таблицы
+type queueSQL struct {
+	enqueue   string
+	claim     string
+	heartbeat string
+	complete  string
+	retry     string
+	bury      string
+	requeue   string
+}
+
+type Option func(*JobQueue)
+
+// WithTable имя таблицы очереди (по умолчанию DefaultTable), допускается схема: jobs.job_queue
+func WithTable(table string) Option {
+	return func(jq *JobQueue) {
+		jq.table = table
+	}
+}
+
+// WithQueueName имя логической очереди в общей таблице (по умолчанию DefaultQueue)
+func WithQueueName(queue string) Option {
+	return func(jq *JobQueue) {
+		jq.queue = queue
+	}
+}
+
+func NewJobQueue(exec db.Executor, opts ...Option) (*JobQueue, error) {
+	if utils.IsNil(exec) {
+		return nil, errs.NewInvalidArgumentError("exec", exec)
+	}
+
+	res := &JobQueue{
+		exec:  exec,
+		table: DefaultTable,
+		queue: DefaultQueue,
+	}
+	for _, opt := range opts {
+		opt(res)
+	}
+	if err := validateTableName(res.table); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(res.queue) == "" {
+		return nil, errs.NewInvalidArgumentError("queue", res.queue)
+	}
+	res.sql = queueSQL{
+		enqueue:   fmt.Sprintf(sqlEnqueue, res.table),
+		claim:     fmt.Sprintf(sqlClaim, res.table),
+		heartbeat: fmt.Sprintf(sqlHeartbeat, res.table),
+		complete:  fmt.Sprintf(sqlComplete, res.table),
+		retry:     fmt.Sprintf(sqlRetry, res.table),
+		bury:      fmt.Sprintf(sqlBury, res.table),
+		requeue:   fmt.Sprintf(sqlRequeue, res.table),
+	}
+
+	return res, nil
+}
+
+// EnqueueOptions параметры постановки задания
+type EnqueueOptions struct {
+	Priority    int
+	MaxAttempts int
+	// RunAt время, не раньше которого задание будет выполнено, нулевое - сразу (по часам БД)
+	RunAt time.Time
+}
+
+type EnqueueOption func(*EnqueueOptions)
+
+// WithPriority чем больше, тем раньше задание будет выбрано
+func WithPriority(priority int) EnqueueOption {
+	return func(eo *EnqueueOptions) {
+		eo.Priority = priority
+	}
+}
+
+func WithMaxAttempts(maxAttempts int) EnqueueOption {
+	return func(eo *EnqueueOptions) {
+		eo.MaxAttempts = maxAttempts
+	}
+}
+
+func WithRunAt(runAt time.Time) EnqueueOption {
+	return func(eo *EnqueueOptions) {
+		eo.RunAt = runAt
+	}
+}
+
+func WithDelay(delay time.Duration) EnqueueOption {
+	return func(eo *EnqueueOptions) {
+		eo.RunAt = time.Now().Add(delay)
+	}
+}
+
+// Enqueue постановка задания jobType, payload сериализуется в JSON, результат - идентификатор задания
+func (jq *JobQueue) Enqueue(ctx context.Context, jobType string, payload any, opts ...EnqueueOption) (int64, error) {
+	if strings.TrimSpace(jobType) == "" {
+		return 0, errs.NewInvalidArgumentError("jobType", jobType)
+	}
+	options := &EnqueueOptions{MaxAttempts: DefaultMaxAttempts}
+	for _, opt := range opts {
+		opt(options)
+	}
+	if options.MaxAttempts <= 0 {
+		return 0, errs.NewInvalidArgumentError("maxAttempts", options.MaxAttempts)
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return 0, errs.NewInvalidArgumentErrorChain("payload", payload, err)
+	}
+
+	var id int64
+	err = jq.exec.GetQuerier(ctx).QueryRowContext(ctx, jq.sql.enqueue,
+		jq.queue, jobType, string(data), options.Priority, options.MaxAttempts,
+		sql.NullTime{Time: options.RunAt, Valid: !options.RunAt.IsZero()},
+	).Scan(&id)
+	if err != nil {
+		return 0, errs.NewDalError("JobQueue.Enqueue", fmt.Sprintf("enqueue job type [%s]", jobType), err)
+	}
+
+	return id, nil
+}
+
+// Enqueue постановка типизированного задания
+func (jt JobType[T]) Enqueue(ctx context.Context, jq *JobQueue, payload T, opts ...EnqueueOption) (int64, error) {
+	return jq.Enqueue(ctx, jt.name, payload, opts...)
+}
+
+// Requeue возврат мертвого задания в очередь со сбросом попыток
+func (jq *JobQueue) Requeue(ctx context.Context, id int64) error {
+	res, err := jq.exec.GetQuerier(ctx).ExecContext(ctx, jq.sql.requeue, id)
+	if err != nil {
+		return errs.NewDalError("JobQueue.Requeue", fmt.Sprintf("requeue job [%d]", id), err)
+	}
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return errs.NewDalNotFoundError("dead job", id, nil)
+	}
+
+	return nil
+}
+
+func (jq *JobQueue) GetQueueName() string {
+	return jq.queue
+}
+
+func (jq *JobQueue) GetTable() string {
+	return jq.table
+}
+
+// claim захват до limit заданий владельцем owner с арендой visibility
+func (jq *JobQueue) claim(ctx context.Context, owner string, limit int, visibility time.Duration) ([]*Job, error) {
+	rows, err := jq.exec.GetQuerier(ctx).QueryContext(ctx, jq.sql.claim, jq.queue, limit, owner, millis(visibility))
+	if err != nil {
+		return nil, errs.NewDalError("JobQueue.claim", "claim jobs", err)
+	}
+	defer rows.Close()
+
+	var res []*Job
+	for rows.Next() {
+		job := &Job{}
+		if err = rows.Scan(&job.ID, &job.Queue, &job.Type, &job.Payload, &job.Priority,
+			&job.Attempts, &job.MaxAttempts, &job.RunAt, &job.CreatedAt); err != nil {
+			return nil, errs.NewDalError("JobQueue.claim", "scan job", err)
+		}
+		res = append(res, job)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, errs.NewDalError("JobQueue.claim", "read jobs", err)
+	}
+
+	return res, nil
+}
+
+// heartbeat продление аренды всех заданий владельца, результат - задания, аренда которых еще принадлежит владельцу
+func (jq *JobQueue) heartbeat(ctx context.Context, owner string, visibility time.Duration) (map[int64]struct{}, error) {
+	rows, err := jq.exec.GetQuerier(ctx).QueryContext(ctx, jq.sql.heartbeat, owner, millis(visibility))
+	if err != nil {
+		return nil, errs.NewDalError("JobQueue.heartbeat", "extend leases", err)
+	}
+	defer rows.Close()
+
+	res := make(map[int64]struct{})
+	for rows.Next() {
+		var id int64
+		if err = rows.Scan(&id); err != nil {
+			return nil, errs.NewDalError("JobQueue.heartbeat", "scan job id", err)
+		}
+		res[id] = struct{}{}
+	}
+	if err = rows.Err(); err != nil {
+		return nil, errs.NewDalError("JobQueue.heartbeat", "read job ids", err)
+	}
+
+	return res, nil
+}
+
+func (jq *JobQueue) complete(ctx context.Context, job *Job, owner string) error {
+	return jq.settle(ctx, "JobQueue.complete", job, jq.sql.complete, job.ID, owner)
+}
+
+func (jq *JobQueue) retry(ctx context.Context, job *Job, owner string, delay time.Duration, cause error) error {
+	return jq.settle(ctx, "JobQueue.retry", job, jq.sql.retry, job.ID, owner, millis(delay), cause.Error())
+}
+
+func (jq *JobQueue) bury(ctx context.Context, job *Job, owner string, cause error) error {
+	return jq.settle(ctx, "JobQueue.bury", job, jq.sql.bury, job.ID, owner, cause.Error())
+}
+
+// settle ни одной измененной строки - аренда потеряна, задание уже захвачено другим обработчиком
+func (jq *JobQueue) settle(ctx context.Context, op string, job *Job, query string, args ...any) error {
+	res, err := jq.exec.GetQuerier(ctx).ExecContext(ctx, query, args...)
+	if err != nil {
+		return errs.NewDalError(op, fmt.Sprintf("job [%d]", job.ID), err)
+	}
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return errs.NewDalError(op, fmt.Sprintf("job [%d] lease lost", job.ID), nil)
+	}
+
+	return nil
+}
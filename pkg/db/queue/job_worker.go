@@ -0,0 +1,407 @@
+package queue
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/container"
+	"github.com/ElfAstAhe/go-service-template/pkg/errs"
+	"github.com/ElfAstAhe/go-service-template/pkg/logger"
+	"github.com/ElfAstAhe/go-service-template/pkg/transport/worker"
+)
+
+const (
+	DefaultWorkerCount       = 4
+	DefaultBatchSize         = 16
+	DefaultPollInterval      = time.Second
+	DefaultVisibilityTimeout = 30 * time.Second
+	DefaultRetryBaseDelay    = time.Second
+	DefaultRetryMaxDelay     = 10 * time.Minute
+	DefaultStopTimeout       = 30 * time.Second
+	// settleTimeout таймаут фиксации результата задания, не зависит от остановки обработчика
+	settleTimeout = 5 * time.Second
+)
+
+// JobHandlerFunc обработчик задания: nil - задание удаляется, errs.TlPermanentError - задание сразу становится мертвым,
+// прочие ошибки - повтор с экспоненциальной задержкой до исчерпания MaxAttempts
+type JobHandlerFunc func(ctx context.Context, job *Job) error
+
+// JobWorkerConfig - настройки обработчика очереди
+type JobWorkerConfig struct {
+	// WorkerCount число параллельных обработчиков BasePool
+	WorkerCount int
+	// BatchSize максимальное число заданий за одну выборку, оно же емкость очереди BasePool
+	BatchSize    int
+	PollInterval time.Duration
+	// VisibilityTimeout аренда захваченного задания, продлевается heartbeat каждую треть аренды
+	VisibilityTimeout time.Duration
+	RetryBaseDelay    time.Duration
+	RetryMaxDelay     time.Duration
+	StopTimeout       time.Duration
+}
+
+func NewJobWorkerConfig(
+	workerCount int,
+	batchSize int,
+	pollInterval time.Duration,
+	visibilityTimeout time.Duration,
+	retryBaseDelay time.Duration,
+	retryMaxDelay time.Duration,
+	stopTimeout time.Duration,
+) *JobWorkerConfig {
+	return &JobWorkerConfig{
+		WorkerCount:       workerCount,
+		BatchSize:         batchSize,
+		PollInterval:      pollInterval,
+		VisibilityTimeout: visibilityTimeout,
+		RetryBaseDelay:    retryBaseDelay,
+		RetryMaxDelay:     retryMaxDelay,
+		StopTimeout:       stopTimeout,
+	}
+}
+
+func (jwc *JobWorkerConfig) applyDefaults() {
+	if jwc.WorkerCount <= 0 {
+		jwc.WorkerCount = DefaultWorkerCount
+	}
+	if jwc.BatchSize <= 0 {
+		jwc.BatchSize = DefaultBatchSize
+	}
+	if jwc.PollInterval <= 0 {
+		jwc.PollInterval = DefaultPollInterval
+	}
+	if jwc.VisibilityTimeout <= 0 {
+		jwc.VisibilityTimeout = DefaultVisibilityTimeout
+	}
+	if jwc.RetryBaseDelay <= 0 {
+		jwc.RetryBaseDelay = DefaultRetryBaseDelay
+	}
+	if jwc.RetryMaxDelay < jwc.RetryBaseDelay {
+		jwc.RetryMaxDelay = max(jwc.RetryBaseDelay, DefaultRetryMaxDelay)
+	}
+	if jwc.StopTimeout <= 0 {
+		jwc.StopTimeout = DefaultStopTimeout
+	}
+}
+
+// claimedJob захваченное задание с контекстом, отменяемым при потере аренды
+type claimedJob struct {
+	job    *Job
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// JobWorker - обработчик очереди: выборка заданий, передача их в worker.BasePool, продление аренды и фиксация результата
+type JobWorker struct {
+	name     string
+	owner    string
+	queue    *JobQueue
+	config   *JobWorkerConfig
+	pool     *worker.BasePool[*claimedJob]
+	handlers map[string]JobHandlerFunc
+	handleMu sync.RWMutex
+	claimed  map[int64]*claimedJob
+	claimMu  sync.Mutex
+	running  atomic.Bool
+	// pollCancel остановка выборки и heartbeat, jobsCancel - отмена заданий в обработке
+	pollCancel context.CancelFunc
+	jobsCtx    context.Context
+	jobsCancel context.CancelFunc
+	pollWg     sync.WaitGroup
+	beatWg     sync.WaitGroup
+	beatStop   chan struct{}
+	log        logger.Logger
+}
+
+var _ container.Runner = (*JobWorker)(nil)
+
+func NewJobWorker(name string, queue *JobQueue, config *JobWorkerConfig, log logger.Logger) (*JobWorker, error) {
+	if queue == nil {
+		return nil, errs.NewInvalidArgumentError("queue", queue)
+	}
+	if config == nil {
+		config = &JobWorkerConfig{}
+	}
+	conf := *config
+	conf.applyDefaults()
+
+	res := &JobWorker{
+		name:     name,
+		owner:    newOwnerID(name),
+		queue:    queue,
+		config:   &conf,
+		handlers: make(map[string]JobHandlerFunc),
+		claimed:  make(map[int64]*claimedJob),
+		log:      log.GetLogger(name),
+	}
+	res.pool = worker.NewBasePool[*claimedJob](
+		name,
+		worker.NewBasePoolConfig(conf.WorkerCount, conf.BatchSize, true, conf.StopTimeout),
+		res.process,
+		log,
+	)
+
+	return res, nil
+}
+
+// newOwnerID уникальный идентификатор владельца аренды: имя, хост, pid и случайный суффикс
+func newOwnerID(name string) string {
+	host, _ := os.Hostname()
+	suffix := make([]byte, 4)
+	_, _ = rand.Read(suffix)
+
+	return fmt.Sprintf("%s@%s/%d-%s", name, host, os.Getpid(), hex.EncodeToString(suffix))
+}
+
+// Handle регистрация обработчика типа задания, повторная регистрация заменяет обработчик
+func (jw *JobWorker) Handle(jobType string, handler JobHandlerFunc) {
+	jw.handleMu.Lock()
+	defer jw.handleMu.Unlock()
+
+	jw.handlers[jobType] = handler
+}
+
+// HandleType регистрация типизированного обработчика, ошибка разбора содержимого делает задание мертвым
+func HandleType[T any](jw *JobWorker, jobType JobType[T], handler func(ctx context.Context, job *Job, payload T) error) {
+	jw.Handle(jobType.Name(), func(ctx context.Context, job *Job) error {
+		payload, err := DecodePayload[T](job)
+		if err != nil {
+			return errs.NewTlPermanentError("HandleType", err)
+		}
+
+		return handler(ctx, job, payload)
+	})
+}
+
+func (jw *JobWorker) Start(ctx context.Context) error {
+	if !jw.running.CompareAndSwap(false, true) {
+		return errs.NewCommonError(fmt.Sprintf("job worker %s already started", jw.GetName()), nil)
+	}
+
+	jw.log.Debugf("job worker %s starting, owner %s", jw.GetName(), jw.owner)
+	baseCtx := context.WithoutCancel(ctx)
+	jw.jobsCtx, jw.jobsCancel = context.WithCancel(baseCtx)
+	if err := jw.pool.Start(jw.jobsCtx); err != nil {
+		jw.jobsCancel()
+		jw.running.Store(false)
+
+		return errs.NewCommonError(fmt.Sprintf("job worker %s start pool failed", jw.GetName()), err)
+	}
+
+	var pollCtx context.Context
+	pollCtx, jw.pollCancel = context.WithCancel(baseCtx)
+	jw.beatStop = make(chan struct{})
+	jw.pollWg.Add(1)
+	go jw.poll(pollCtx)
+	jw.beatWg.Add(1)
+	go jw.heartbeat(jw.jobsCtx)
+
+	return nil
+}
+
+// Stop прекращение выборки, дообработка выбранных заданий до StopTimeout/stopCtx, затем отмена оставшихся.
+// Задания, не начатые до остановки, вернутся в очередь по истечении аренды
+func (jw *JobWorker) Stop(stopCtx context.Context) error {
+	if !jw.running.CompareAndSwap(true, false) {
+		return errs.NewCommonError(fmt.Sprintf("job worker %s is not running", jw.GetName()), nil)
+	}
+
+	jw.log.Debugf("job worker %s stopping", jw.GetName())
+	defer jw.log.Debugf("job worker %s stopped", jw.GetName())
+
+	// выборка останавливается до закрытия очереди пула
+	jw.pollCancel()
+	jw.pollWg.Wait()
+
+	err := jw.pool.Stop(stopCtx)
+	jw.jobsCancel()
+	close(jw.beatStop)
+	jw.beatWg.Wait()
+
+	return err
+}
+
+func (jw *JobWorker) IsRunning() bool {
+	return jw.running.Load()
+}
+
+func (jw *JobWorker) GetName() string {
+	return jw.name
+}
+
+// GetOwner идентификатор владельца аренды в столбце locked_by
+func (jw *JobWorker) GetOwner() string {
+	return jw.owner
+}
+
+func (jw *JobWorker) poll(ctx context.Context) {
+	jw.log.Debugf("job worker %s poll start", jw.GetName())
+	defer jw.log.Debugf("job worker %s poll finish", jw.GetName())
+	defer jw.pollWg.Done()
+
+	for {
+		limit := min(jw.pool.Capacity()-jw.pool.Len(), jw.config.BatchSize)
+		claimed := 0
+		if limit > 0 {
+			claimed = jw.fetch(ctx, limit)
+		}
+		// полная выборка - вероятно, есть еще готовые задания
+		if claimed > 0 && claimed == limit {
+			if ctx.Err() != nil {
+				return
+			}
+
+			continue
+		}
+
+		timer := time.NewTimer(jw.config.PollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+func (jw *JobWorker) fetch(ctx context.Context, limit int) int {
+	jobs, err := jw.queue.claim(ctx, jw.owner, limit, jw.config.VisibilityTimeout)
+	if err != nil {
+		if ctx.Err() == nil {
+			jw.log.Warnf("job worker %s claim jobs failed: %v", jw.GetName(), err)
+		}
+
+		return 0
+	}
+
+	for _, job := range jobs {
+		cj := &claimedJob{job: job}
+		cj.ctx, cj.cancel = context.WithCancel(jw.jobsCtx)
+		jw.claimMu.Lock()
+		jw.claimed[job.ID] = cj
+		jw.claimMu.Unlock()
+
+		jw.pool.Push(cj)
+	}
+
+	return len(jobs)
+}
+
+// heartbeat продление аренды захваченных заданий, задания с потерянной арендой отменяются
+func (jw *JobWorker) heartbeat(ctx context.Context) {
+	defer jw.beatWg.Done()
+
+	ticker := time.NewTicker(max(jw.config.VisibilityTimeout/3, time.Millisecond))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-jw.beatStop:
+			return
+		case <-ticker.C:
+		}
+
+		jw.claimMu.Lock()
+		empty := len(jw.claimed) == 0
+		jw.claimMu.Unlock()
+		if empty {
+			continue
+		}
+
+		owned, err := jw.queue.heartbeat(ctx, jw.owner, jw.config.VisibilityTimeout)
+		if err != nil {
+			jw.log.Warnf("job worker %s heartbeat failed: %v", jw.GetName(), err)
+			continue
+		}
+
+		jw.claimMu.Lock()
+		for id, cj := range jw.claimed {
+			if _, ok := owned[id]; !ok {
+				jw.log.Warnf("job worker %s job [%d] lease lost, cancel processing", jw.GetName(), id)
+				cj.cancel()
+			}
+		}
+		jw.claimMu.Unlock()
+	}
+}
+
+// process обработчик BasePool: выполнение задания и фиксация результата
+func (jw *JobWorker) process(_ context.Context, workerIndex int, cj *claimedJob) error {
+	job := cj.job
+	defer func() {
+		cj.cancel()
+		jw.claimMu.Lock()
+		delete(jw.claimed, job.ID)
+		jw.claimMu.Unlock()
+	}()
+
+	settleCtx, cancel := context.WithTimeout(context.WithoutCancel(cj.ctx), settleTimeout)
+	defer cancel()
+
+	// аренда истекла у упавшего обработчика после последней попытки
+	if job.Attempts > job.MaxAttempts {
+		return jw.bury(settleCtx, job, errs.NewCommonError("max attempts exceeded", nil))
+	}
+
+	jw.handleMu.RLock()
+	handler, ok := jw.handlers[job.Type]
+	jw.handleMu.RUnlock()
+	if !ok {
+		return jw.bury(settleCtx, job, errs.NewCommonError(fmt.Sprintf("handler for job type [%s] not registered", job.Type), nil))
+	}
+
+	jw.log.Debugf("job worker %s worker %d job [%d] type [%s] attempt %d start", jw.GetName(), workerIndex, job.ID, job.Type, job.Attempts)
+	err := jw.safeHandle(cj.ctx, handler, job)
+
+	var permanent *errs.TlPermanentError
+	switch {
+	case err == nil:
+		if err = jw.queue.complete(settleCtx, job, jw.owner); err != nil {
+			return err
+		}
+		jw.log.Debugf("job worker %s job [%d] type [%s] completed", jw.GetName(), job.ID, job.Type)
+
+		return nil
+	case errors.As(err, &permanent), job.Attempts >= job.MaxAttempts:
+		return jw.bury(settleCtx, job, err)
+	default:
+		delay := jw.backoff(job.Attempts)
+		jw.log.Warnf("job worker %s job [%d] type [%s] attempt %d failed, retry in %v: %v", jw.GetName(), job.ID, job.Type, job.Attempts, delay, err)
+
+		return jw.queue.retry(settleCtx, job, jw.owner, delay, err)
+	}
+}
+
+func (jw *JobWorker) bury(ctx context.Context, job *Job, cause error) error {
+	jw.log.Errorf("job worker %s job [%d] type [%s] is dead after %d attempts: %v", jw.GetName(), job.ID, job.Type, job.Attempts, cause)
+
+	return jw.queue.bury(ctx, job, jw.owner, cause)
+}
+
+func (jw *JobWorker) safeHandle(ctx context.Context, handler JobHandlerFunc, job *Job) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = errs.NewCommonError(fmt.Sprintf("job [%d] handler panic recovery [%v]", job.ID, r), nil)
+		}
+	}()
+
+	return handler(ctx, job)
+}
+
+func (jw *JobWorker) backoff(attempt int) time.Duration {
+	shift := min(max(attempt-1, 0), 30)
+	delay := jw.config.RetryBaseDelay * time.Duration(1<<shift)
+	if delay <= 0 || delay > jw.config.RetryMaxDelay {
+		return jw.config.RetryMaxDelay
+	}
+
+	return delay
+}
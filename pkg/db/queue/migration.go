@@ -0,0 +1,79 @@
+package queue
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/errs"
+	"github.com/pressly/goose/v3"
+)
+
+const (
+	sqlCreateTableJobQueue = `
+create table if not exists %[1]s (
+    id bigserial not null,
+    queue varchar(100) not null,
+    job_type varchar(100) not null,
+    payload jsonb not null,
+    priority integer not null default 0,
+    status varchar(20) not null default 'pending',
+    attempts integer not null default 0,
+    max_attempts integer not null default 5,
+    run_at timestamptz not null default now(),
+    locked_by varchar(255) null,
+    locked_until timestamptz null,
+    last_error text null,
+    created_at timestamptz not null default now(),
+    modified_at timestamptz not null default now(),
+    constraint %[2]s_pk primary key (id),
+    constraint %[2]s_status_ck check (status in ('pending', 'running', 'dead'))
+)
+`
+	sqlDropTableJobQueue = `drop table if exists %s`
+	// sqlCreateIndexJobQueueFetch частичный индекс выборки готовых заданий, мертвые задания в него не попадают
+	sqlCreateIndexJobQueueFetch = `create index if not exists idx_%[2]s_fetch on %[1]s (queue, priority desc, run_at, id) where status in ('pending', 'running')`
+	sqlDropIndexJobQueueFetch   = `drop index if exists idx_%s_fetch`
+	sqlCreateIndexJobQueueOwner = `create index if not exists idx_%[2]s_locked_by on %[1]s (locked_by) where locked_by is not null`
+	sqlDropIndexJobQueueOwner   = `drop index if exists idx_%s_locked_by`
+)
+
+// NewGoMigration миграция таблицы очереди для подключения к миграциям сервиса, версия задается сервисом
+func NewGoMigration(version int64, table string) (*goose.Migration, error) {
+	if err := validateTableName(table); err != nil {
+		return nil, err
+	}
+	// имя без схемы для имен ограничений и индексов
+	short := table[strings.LastIndex(table, ".")+1:]
+
+	up := []migrationStep{
+		{name: "create table " + table, sql: fmt.Sprintf(sqlCreateTableJobQueue, table, short)},
+		{name: "create index idx_" + short + "_fetch", sql: fmt.Sprintf(sqlCreateIndexJobQueueFetch, table, short)},
+		{name: "create index idx_" + short + "_locked_by", sql: fmt.Sprintf(sqlCreateIndexJobQueueOwner, table, short)},
+	}
+	down := []migrationStep{
+		{name: "drop index idx_" + short + "_locked_by", sql: fmt.Sprintf(sqlDropIndexJobQueueOwner, short)},
+		{name: "drop index idx_" + short + "_fetch", sql: fmt.Sprintf(sqlDropIndexJobQueueFetch, short)},
+		{name: "drop table " + table, sql: fmt.Sprintf(sqlDropTableJobQueue, table)},
+	}
+
+	return goose.NewGoMigration(version, &goose.GoFunc{RunDB: runSteps(up)}, &goose.GoFunc{RunDB: runSteps(down)}), nil
+}
+
+type migrationStep struct {
+	name string
+	sql  string
+}
+
+func runSteps(steps []migrationStep) func(ctx context.Context, db *sql.DB) error {
+	return func(ctx context.Context, db *sql.DB) error {
+		for _, step := range steps {
+			if _, err := db.ExecContext(ctx, step.sql); err != nil {
+				return errs.NewDBMigrationError(step.name, err)
+			}
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,172 @@
+package test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ElfAstAhe/go-service-template/pkg/db"
+	"github.com/ElfAstAhe/go-service-template/pkg/db/mocks"
+	"github.com/ElfAstAhe/go-service-template/pkg/db/queue"
+	"github.com/ElfAstAhe/go-service-template/pkg/errs"
+	loggermocks "github.com/ElfAstAhe/go-service-template/pkg/logger/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	sqlEnqueue   = `insert into job_queue (queue, job_type, payload, priority, max_attempts, run_at)`
+	sqlClaim     = `update job_queue j`
+	sqlHeartbeat = `set locked_until = now() + $2::bigint * interval '1 millisecond', modified_at = now()`
+	sqlComplete  = `delete from job_queue where id = $1 and locked_by = $2`
+	sqlRetry     = `set status = 'pending', run_at = now() + $3::bigint`
+	sqlBury      = `set status = 'dead', last_error = $3`
+	sqlRequeue   = `set status = 'pending', attempts = 0`
+)
+
+var claimColumns = []string{"id", "queue", "job_type", "payload", "priority", "attempts", "max_attempts", "run_at", "created_at"}
+
+type sendEmail struct {
+	To      string `json:"to"`
+	Subject string `json:"subject"`
+}
+
+var sendEmailJob = queue.NewJobType[sendEmail]("send_email")
+
+func q(query string) string {
+	return regexp.QuoteMeta(query)
+}
+
+func newMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, *mocks.MockDB) {
+	sqlDB, mockSql, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	mockDB := mocks.NewMockDB(t)
+	mockDB.On("GetDB").Return(sqlDB).Maybe()
+	mockDB.On("GetQuerier", mock.Anything).Return(func(ctx context.Context) db.Querier {
+		if tx := db.GetTx(ctx); tx != nil {
+			return tx
+		}
+
+		return sqlDB
+	}).Maybe()
+
+	return sqlDB, mockSql, mockDB
+}
+
+func newQueueLogger() *loggermocks.MockLogger {
+	mockLog := &loggermocks.MockLogger{}
+	mockLog.On("GetLogger", mock.Anything).Return(mockLog)
+	mockLog.On("Debugf", mock.Anything, mock.Anything).Maybe()
+	mockLog.On("Warnf", mock.Anything, mock.Anything).Maybe()
+	mockLog.On("Errorf", mock.Anything, mock.Anything).Maybe()
+
+	return mockLog
+}
+
+func TestNewJobQueue_Validation(t *testing.T) {
+	_, _, mockDB := newMockDB(t)
+
+	_, err := queue.NewJobQueue(mockDB, queue.WithTable("job_queue; drop table test"))
+	assert.Error(t, err)
+
+	_, err = queue.NewJobQueue(mockDB, queue.WithQueueName(" "))
+	assert.Error(t, err)
+
+	jq, err := queue.NewJobQueue(mockDB, queue.WithTable("jobs.job_queue"), queue.WithQueueName("mail"))
+	require.NoError(t, err)
+	assert.Equal(t, "jobs.job_queue", jq.GetTable())
+	assert.Equal(t, "mail", jq.GetQueueName())
+
+	_, err = queue.NewGoMigration(2, "bad name")
+	assert.Error(t, err)
+	migration, err := queue.NewGoMigration(2, queue.DefaultTable)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), migration.Version)
+}
+
+func TestJobQueue_EnqueueWithinTransaction(t *testing.T) {
+	_, mockSql, mockDB := newMockDB(t)
+	tm := db.NewTxManager(mockDB)
+	jq, err := queue.NewJobQueue(mockDB)
+	require.NoError(t, err)
+
+	runAt := time.Date(2026, 3, 10, 3, 0, 0, 0, time.UTC)
+	mockSql.ExpectBegin()
+	mockSql.ExpectQuery(q(sqlEnqueue)).
+		WithArgs(queue.DefaultQueue, "send_email", `{"to":"a@b.c","subject":"hi"}`, 10, 3, sql.NullTime{Time: runAt, Valid: true}).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(42))
+	mockSql.ExpectCommit()
+
+	var id int64
+	err = tm.WithinTransaction(context.Background(), nil, func(ctx context.Context) error {
+		var enqueueErr error
+		id, enqueueErr = sendEmailJob.Enqueue(ctx, jq, sendEmail{To: "a@b.c", Subject: "hi"},
+			queue.WithPriority(10), queue.WithMaxAttempts(3), queue.WithRunAt(runAt))
+
+		return enqueueErr
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), id)
+	assert.NoError(t, mockSql.ExpectationsWereMet())
+}
+
+func TestJobQueue_EnqueueRollbackOnBusinessError(t *testing.T) {
+	_, mockSql, mockDB := newMockDB(t)
+	tm := db.NewTxManager(mockDB)
+	jq, err := queue.NewJobQueue(mockDB)
+	require.NoError(t, err)
+
+	mockSql.ExpectBegin()
+	mockSql.ExpectQuery(q(sqlEnqueue)).
+		WithArgs(queue.DefaultQueue, "send_email", sqlmock.AnyArg(), 0, queue.DefaultMaxAttempts, sql.NullTime{}).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mockSql.ExpectRollback()
+
+	businessErr := errors.New("order rejected")
+	err = tm.WithinTransaction(context.Background(), nil, func(ctx context.Context) error {
+		if _, enqueueErr := jq.Enqueue(ctx, "send_email", sendEmail{To: "a@b.c"}); enqueueErr != nil {
+			return enqueueErr
+		}
+
+		return businessErr
+	})
+
+	assert.ErrorIs(t, err, businessErr)
+	assert.NoError(t, mockSql.ExpectationsWereMet())
+}
+
+func TestJobQueue_EnqueueValidation(t *testing.T) {
+	_, _, mockDB := newMockDB(t)
+	jq, err := queue.NewJobQueue(mockDB)
+	require.NoError(t, err)
+
+	_, err = jq.Enqueue(context.Background(), "", nil)
+	assert.Error(t, err)
+	_, err = jq.Enqueue(context.Background(), "send_email", nil, queue.WithMaxAttempts(0))
+	assert.Error(t, err)
+	_, err = jq.Enqueue(context.Background(), "send_email", make(chan int))
+	assert.Error(t, err)
+}
+
+func TestJobQueue_Requeue(t *testing.T) {
+	_, mockSql, mockDB := newMockDB(t)
+	jq, err := queue.NewJobQueue(mockDB)
+	require.NoError(t, err)
+
+	mockSql.ExpectExec(q(sqlRequeue)).WithArgs(int64(7)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mockSql.ExpectExec(q(sqlRequeue)).WithArgs(int64(8)).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	require.NoError(t, jq.Requeue(context.Background(), 7))
+	err = jq.Requeue(context.Background(), 8)
+	var notFound *errs.DalNotFoundError
+	assert.ErrorAs(t, err, &notFound)
+	assert.NoError(t, mockSql.ExpectationsWereMet())
+}
@@ -0,0 +1,199 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ElfAstAhe/go-service-template/pkg/db/queue"
+	"github.com/ElfAstAhe/go-service-template/pkg/errs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestWorker(t *testing.T, visibility time.Duration) (*queue.JobWorker, sqlmock.Sqlmock) {
+	_, mockSql, mockDB := newMockDB(t)
+	mockSql.MatchExpectationsInOrder(false)
+
+	jq, err := queue.NewJobQueue(mockDB)
+	require.NoError(t, err)
+
+	jw, err := queue.NewJobWorker("mailer", jq, queue.NewJobWorkerConfig(
+		2, 4, 10*time.Millisecond, visibility, 2*time.Second, time.Minute, time.Second,
+	), newQueueLogger())
+	require.NoError(t, err)
+
+	return jw, mockSql
+}
+
+func claimRows(attempts int, maxAttempts int, jobType string, payload string) *sqlmock.Rows {
+	now := time.Now()
+
+	return sqlmock.NewRows(claimColumns).
+		AddRow(int64(1), queue.DefaultQueue, jobType, []byte(payload), 0, attempts, maxAttempts, now, now)
+}
+
+func runWorker(t *testing.T, jw *queue.JobWorker, mockSql sqlmock.Sqlmock) {
+	t.Helper()
+	require.NoError(t, jw.Start(context.Background()))
+	assert.True(t, jw.IsRunning())
+
+	assert.Eventually(t, func() bool {
+		return mockSql.ExpectationsWereMet() == nil
+	}, 2*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, jw.Stop(context.Background()))
+	assert.False(t, jw.IsRunning())
+}
+
+func TestJobWorker_CompletesJob(t *testing.T) {
+	jw, mockSql := newTestWorker(t, time.Hour)
+
+	received := make(chan sendEmail, 1)
+	queue.HandleType(jw, sendEmailJob, func(_ context.Context, job *queue.Job, payload sendEmail) error {
+		assert.Equal(t, 1, job.Attempts)
+		received <- payload
+
+		return nil
+	})
+
+	mockSql.ExpectQuery(q(sqlClaim)).
+		WithArgs(queue.DefaultQueue, 4, jw.GetOwner(), int64(time.Hour/time.Millisecond)).
+		WillReturnRows(claimRows(1, 3, "send_email", `{"to":"a@b.c","subject":"hi"}`))
+	mockSql.ExpectExec(q(sqlComplete)).WithArgs(int64(1), jw.GetOwner()).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	runWorker(t, jw, mockSql)
+	assert.Equal(t, sendEmail{To: "a@b.c", Subject: "hi"}, <-received)
+}
+
+func TestJobWorker_RetriesWithBackoff(t *testing.T) {
+	jw, mockSql := newTestWorker(t, time.Hour)
+	jw.Handle("send_email", func(context.Context, *queue.Job) error {
+		return errors.New("smtp unavailable")
+	})
+
+	// третья попытка из пяти: задержка base * 2^2
+	mockSql.ExpectQuery(q(sqlClaim)).WillReturnRows(claimRows(3, 5, "send_email", `{}`))
+	mockSql.ExpectExec(q(sqlRetry)).
+		WithArgs(int64(1), jw.GetOwner(), int64(8000), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	runWorker(t, jw, mockSql)
+}
+
+func TestJobWorker_DeadJobs(t *testing.T) {
+	testCases := []struct {
+		name     string
+		attempts int
+		jobType  string
+		payload  string
+		handler  queue.JobHandlerFunc
+	}{
+		{
+			name:     "max attempts reached",
+			attempts: 3,
+			jobType:  "send_email",
+			payload:  `{}`,
+			handler:  func(context.Context, *queue.Job) error { return errors.New("smtp unavailable") },
+		},
+		{
+			name:     "permanent error",
+			attempts: 1,
+			jobType:  "send_email",
+			payload:  `{}`,
+			handler: func(context.Context, *queue.Job) error {
+				return errs.NewTlPermanentError("send", errors.New("mailbox does not exist"))
+			},
+		},
+		{
+			name:     "handler panic on last attempt",
+			attempts: 3,
+			jobType:  "send_email",
+			payload:  `{}`,
+			handler:  func(context.Context, *queue.Job) error { panic("boom") },
+		},
+		{
+			name:     "unknown job type",
+			attempts: 1,
+			jobType:  "unknown",
+			payload:  `{}`,
+		},
+		{
+			name:     "lease expired after last attempt",
+			attempts: 4,
+			jobType:  "send_email",
+			payload:  `{}`,
+			handler:  func(context.Context, *queue.Job) error { t.Error("handler must not run"); return nil },
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			jw, mockSql := newTestWorker(t, time.Hour)
+			if tc.handler != nil {
+				jw.Handle("send_email", tc.handler)
+			}
+
+			mockSql.ExpectQuery(q(sqlClaim)).WillReturnRows(claimRows(tc.attempts, 3, tc.jobType, tc.payload))
+			mockSql.ExpectExec(q(sqlBury)).
+				WithArgs(int64(1), jw.GetOwner(), sqlmock.AnyArg()).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+
+			runWorker(t, jw, mockSql)
+		})
+	}
+}
+
+func TestJobWorker_BrokenPayloadIsDead(t *testing.T) {
+	jw, mockSql := newTestWorker(t, time.Hour)
+	queue.HandleType(jw, sendEmailJob, func(context.Context, *queue.Job, sendEmail) error {
+		t.Error("handler must not run")
+		return nil
+	})
+
+	mockSql.ExpectQuery(q(sqlClaim)).WillReturnRows(claimRows(1, 3, "send_email", `{broken`))
+	mockSql.ExpectExec(q(sqlBury)).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	runWorker(t, jw, mockSql)
+}
+
+func TestJobWorker_LeaseLostCancelsJob(t *testing.T) {
+	jw, mockSql := newTestWorker(t, 30*time.Millisecond)
+
+	canceled := make(chan struct{})
+	jw.Handle("send_email", func(ctx context.Context, _ *queue.Job) error {
+		<-ctx.Done()
+		close(canceled)
+
+		return ctx.Err()
+	})
+
+	mockSql.ExpectQuery(q(sqlClaim)).WillReturnRows(claimRows(1, 3, "send_email", `{}`))
+	// аренда перехвачена другой репликой: продление не вернуло задание
+	mockSql.ExpectQuery(q(sqlHeartbeat)).
+		WithArgs(jw.GetOwner(), int64(30)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mockSql.ExpectExec(q(sqlRetry)).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	require.NoError(t, jw.Start(context.Background()))
+	select {
+	case <-canceled:
+	case <-time.After(2 * time.Second):
+		t.Fatal("job was not canceled after lease loss")
+	}
+	assert.Eventually(t, func() bool {
+		return mockSql.ExpectationsWereMet() == nil
+	}, 2*time.Second, 10*time.Millisecond)
+	require.NoError(t, jw.Stop(context.Background()))
+}
+
+func TestJobWorker_StartStop(t *testing.T) {
+	jw, _ := newTestWorker(t, time.Hour)
+
+	require.NoError(t, jw.Start(context.Background()))
+	assert.Error(t, jw.Start(context.Background()))
+	require.NoError(t, jw.Stop(context.Background()))
+	assert.Error(t, jw.Stop(context.Background()))
+}
@@ -13,6 +13,7 @@ var _ error = (*DalCacheError)(nil)
 func NewDalCacheError(op string, msg string, err error) *DalCacheError {
 	return &DalCacheError{
 		op:  op,
+		msg: msg,
 		err: err,
 	}
 }
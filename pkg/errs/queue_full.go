@@ -0,0 +1,35 @@
+package errs
+
+import (
+	"fmt"
+)
+
+// QueueFullError — переполнение очереди
+type QueueFullError struct {
+	Name     string // Имя очереди (например, имя диспетчера событий)
+	Capacity int    // Емкость очереди
+	Err      error  // Исходная ошибка (опционально)
+}
+
+var _ error = (*QueueFullError)(nil)
+
+func NewQueueFullError(name string, capacity int, err error) *QueueFullError {
+	return &QueueFullError{
+		Name:     name,
+		Capacity: capacity,
+		Err:      err,
+	}
+}
+
+func (qf *QueueFullError) Error() string {
+	msg := fmt.Sprintf("CMN: queue %s is full, capacity [%d]", qf.Name, qf.Capacity)
+	if qf.Err != nil {
+		return fmt.Sprintf("%s: %v", msg, qf.Err)
+	}
+
+	return msg
+}
+
+func (qf *QueueFullError) Unwrap() error {
+	return qf.Err
+}
@@ -0,0 +1,36 @@
+package errs
+
+import (
+	"fmt"
+)
+
+// TlPermanentError transport layer permanent processing error, повторная доставка сообщения бессмысленна
+type TlPermanentError struct {
+	op  string
+	err error
+}
+
+var _ error = (*TlPermanentError)(nil)
+
+func NewTlPermanentError(op string, err error) *TlPermanentError {
+	return &TlPermanentError{
+		op:  op,
+		err: err,
+	}
+}
+
+func (tpe *TlPermanentError) Error() string {
+	msg := "TL: permanent processing error"
+	if tpe.op != "" {
+		msg = fmt.Sprintf("%s at operation %s", msg, tpe.op)
+	}
+	if tpe.err != nil {
+		msg = fmt.Sprintf("%s: %v", msg, tpe.err)
+	}
+
+	return msg
+}
+
+func (tpe *TlPermanentError) Unwrap() error {
+	return tpe.err
+}
@@ -0,0 +1,183 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+)
+
+// списки ARC
+const (
+	arcT1 uint8 = iota
+	arcT2
+	arcB1
+	arcB2
+)
+
+type arcItem[K comparable] struct {
+	key  K
+	list uint8
+}
+
+// ARCEvict - политика Adaptive Replacement Cache: резидентные ключи делятся на недавние (T1, одно обращение)
+// и частые (T2, повторные обращения), по вытесненным ключам ведутся списки-призраки (B1, B2).
+// Попадание в призрак смещает целевой размер T1 (p) в пользу соответствующего списка,
+// так политика сама подстраивается между LRU и LFU поведением и устойчива к сканированиям.
+type ARCEvict[K comparable] struct {
+	mu       sync.Mutex
+	items    map[K]*list.Element
+	t1       *list.List
+	t2       *list.List
+	b1       *list.List
+	b2       *list.List
+	capacity int
+	// p целевой размер T1
+	p        int
+	emptyKey K
+}
+
+// NewARCEvict capacity - ожидаемая емкость хранилища (кол-во элементов), определяет размер списков-призраков
+func NewARCEvict[K comparable](capacity int) *ARCEvict[K] {
+	return &ARCEvict[K]{
+		items:    make(map[K]*list.Element),
+		t1:       list.New(),
+		t2:       list.New(),
+		b1:       list.New(),
+		b2:       list.New(),
+		capacity: max(capacity, 1),
+	}
+}
+
+func (arc *ARCEvict[K]) OnGet(key K) {
+	arc.mu.Lock()
+	defer arc.mu.Unlock()
+
+	if el, ok := arc.items[key]; ok && arc.resident(el) {
+		arc.moveTo(el, arcT2)
+	}
+}
+
+func (arc *ARCEvict[K]) OnSet(key K) {
+	arc.mu.Lock()
+	defer arc.mu.Unlock()
+
+	el, ok := arc.items[key]
+	if !ok {
+		// новый ключ
+		arc.items[key] = arc.t1.PushFront(&arcItem[K]{key: key, list: arcT1})
+		arc.trimGhosts()
+
+		return
+	}
+
+	switch el.Value.(*arcItem[K]).list {
+	case arcB1:
+		// ключ недавно вытеснен из T1 - увеличиваем долю недавних
+		arc.p = min(arc.capacity, arc.p+max(arc.b2.Len()/max(arc.b1.Len(), 1), 1))
+	case arcB2:
+		// ключ недавно вытеснен из T2 - увеличиваем долю частых
+		arc.p = max(0, arc.p-max(arc.b1.Len()/max(arc.b2.Len(), 1), 1))
+	}
+	arc.moveTo(el, arcT2)
+}
+
+func (arc *ARCEvict[K]) OnRemove(key K) {
+	arc.mu.Lock()
+	defer arc.mu.Unlock()
+
+	if el, ok := arc.items[key]; ok && arc.resident(el) {
+		// ручное удаление - не вытеснение, в призраки не попадает
+		arc.listOf(el.Value.(*arcItem[K]).list).Remove(el)
+		delete(arc.items, key)
+	}
+}
+
+func (arc *ARCEvict[K]) Reset() {
+	arc.mu.Lock()
+	defer arc.mu.Unlock()
+
+	arc.items = make(map[K]*list.Element)
+	arc.t1.Init()
+	arc.t2.Init()
+	arc.b1.Init()
+	arc.b2.Init()
+	arc.p = 0
+}
+
+func (arc *ARCEvict[K]) Evict() (K, bool) {
+	arc.mu.Lock()
+	defer arc.mu.Unlock()
+
+	// REPLACE: вытесняем из T1, если он больше целевого размера, иначе из T2
+	var el *list.Element
+	var ghost uint8
+	switch {
+	case arc.t1.Len() > 0 && (arc.t1.Len() > arc.p || arc.t2.Len() == 0):
+		el, ghost = arc.t1.Back(), arcB1
+	case arc.t2.Len() > 0:
+		el, ghost = arc.t2.Back(), arcB2
+	default:
+		return arc.emptyKey, false
+	}
+
+	key := el.Value.(*arcItem[K]).key
+	arc.moveTo(el, ghost)
+	arc.trimGhosts()
+
+	return key, true
+}
+
+// --- Внутренние неблокирующие методы ---
+
+func (arc *ARCEvict[K]) resident(el *list.Element) bool {
+	l := el.Value.(*arcItem[K]).list
+
+	return l == arcT1 || l == arcT2
+}
+
+func (arc *ARCEvict[K]) listOf(l uint8) *list.List {
+	switch l {
+	case arcT1:
+		return arc.t1
+	case arcT2:
+		return arc.t2
+	case arcB1:
+		return arc.b1
+	default:
+		return arc.b2
+	}
+}
+
+func (arc *ARCEvict[K]) moveTo(el *list.Element, dst uint8) {
+	item := el.Value.(*arcItem[K])
+	if item.list == dst {
+		arc.listOf(dst).MoveToFront(el)
+
+		return
+	}
+	arc.listOf(item.list).Remove(el)
+	item.list = dst
+	arc.items[item.key] = arc.listOf(dst).PushFront(item)
+}
+
+// trimGhosts ограничение списков-призраков: |T1|+|B1| <= c, |T1|+|T2|+|B1|+|B2| <= 2c
+func (arc *ARCEvict[K]) trimGhosts() {
+	for arc.t1.Len()+arc.b1.Len() > arc.capacity && arc.b1.Len() > 0 {
+		arc.dropGhost(arc.b1)
+	}
+	for arc.t1.Len()+arc.t2.Len()+arc.b1.Len()+arc.b2.Len() > 2*arc.capacity {
+		switch {
+		case arc.b2.Len() > 0:
+			arc.dropGhost(arc.b2)
+		case arc.b1.Len() > 0:
+			arc.dropGhost(arc.b1)
+		default:
+			return
+		}
+	}
+}
+
+func (arc *ARCEvict[K]) dropGhost(ghosts *list.List) {
+	el := ghosts.Back()
+	delete(arc.items, el.Value.(*arcItem[K]).key)
+	ghosts.Remove(el)
+}
@@ -0,0 +1,77 @@
+package cache
+
+import (
+	"encoding"
+	"time"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/utils"
+)
+
+// BinaryValue - значение с собственной компактной бинарной сериализацией
+type BinaryValue interface {
+	encoding.BinaryMarshaler
+	encoding.BinaryUnmarshaler
+}
+
+// BinaryCodec - кодек для значений с собственной бинарной сериализацией (encoding.BinaryMarshaler),
+// без рефлексии и имен полей в данных, значение упаковывается в бинарный формат кэша
+type BinaryCodec[V BinaryValue] struct {
+	emptyItemFactory EmptyItemFactory[V]
+}
+
+var _ ExpiryDecoder = (*BinaryCodec[BinaryValue])(nil)
+var _ NilMarshaler = (*BinaryCodec[BinaryValue])(nil)
+
+func NewBinaryCodec[V BinaryValue](factory EmptyItemFactory[V]) *BinaryCodec[V] {
+	return &BinaryCodec[V]{
+		emptyItemFactory: factory,
+	}
+}
+
+func (bc *BinaryCodec[V]) Marshal(value V, ttl time.Duration) ([]byte, error) {
+	header := frameHeader{dieAt: frameDieAt(ttl)}
+	// пустое значение упаковывается без полезной нагрузки, чтобы сохранить TTL
+	if utils.IsNil(value) {
+		header.flags |= frameFlagNil
+
+		return encodeFrame(header, nil), nil
+	}
+	payload, err := value.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	return encodeFrame(header, payload), nil
+}
+
+// MarshalNil упаковка негативного результата: кадр с признаком Nil без полезной нагрузки
+func (bc *BinaryCodec[V]) MarshalNil(ttl time.Duration) ([]byte, error) {
+	return encodeFrame(frameHeader{flags: frameFlagNil, dieAt: frameDieAt(ttl)}, nil), nil
+}
+
+func (bc *BinaryCodec[V]) Unmarshal(buf []byte) (*Envelope[V], error) {
+	if len(buf) == 0 {
+		return &Envelope[V]{}, nil
+	}
+	header, payload, err := decodeFrame(buf)
+	if err != nil {
+		return &Envelope[V]{}, err
+	}
+	env := &Envelope[V]{
+		DieAt: header.dieAt,
+		Nil:   header.has(frameFlagNil),
+	}
+	if env.Nil {
+		return env, nil
+	}
+	env.Value = bc.emptyItemFactory()
+	if err := env.Value.UnmarshalBinary(payload); err != nil {
+		return env, err
+	}
+
+	return env, nil
+}
+
+func (bc *BinaryCodec[V]) DecodeExpiry(buf []byte) (int64, error) {
+	return frameExpiry(buf)
+}
@@ -0,0 +1,91 @@
+package cache
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/errs"
+	"github.com/ElfAstAhe/go-service-template/pkg/utils"
+)
+
+// CipherCodec - кодек-обертка, шифрующий упакованное вложенным кодеком значение (utils.Cipher).
+// Момент истечения хранится в заголовке открыто, поэтому сборщик просроченных записей и redis TTL
+// работают без расшифровки. Для сжатия вместе с шифрованием сжатие должно быть вложенным:
+// NewCipherCodec(NewCompressCodec(codec, ...), cipher).
+type CipherCodec[V any] struct {
+	inner  Codec[V]
+	cipher utils.Cipher
+}
+
+var _ ExpiryDecoder = (*CipherCodec[any])(nil)
+var _ NilMarshaler = (*CipherCodec[any])(nil)
+
+func NewCipherCodec[V any](inner Codec[V], cipher utils.Cipher) (*CipherCodec[V], error) {
+	if utils.IsNil(inner) {
+		return nil, errs.NewInvalidArgumentError("inner", inner)
+	}
+	if utils.IsNil(cipher) {
+		return nil, errs.NewInvalidArgumentError("cipher", cipher)
+	}
+
+	return &CipherCodec[V]{
+		inner:  inner,
+		cipher: cipher,
+	}, nil
+}
+
+func (cc *CipherCodec[V]) Marshal(value V, ttl time.Duration) ([]byte, error) {
+	packed, err := cc.inner.Marshal(value, ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	return cc.wrap(packed, ttl)
+}
+
+// MarshalNil упаковка негативного результата вложенным кодеком (он должен реализовывать NilMarshaler)
+func (cc *CipherCodec[V]) MarshalNil(ttl time.Duration) ([]byte, error) {
+	inner, ok := cc.inner.(NilMarshaler)
+	if !ok {
+		return nil, errors.New("inner codec does not support nil marshaling")
+	}
+	packed, err := inner.MarshalNil(ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	return cc.wrap(packed, ttl)
+}
+
+func (cc *CipherCodec[V]) wrap(packed []byte, ttl time.Duration) ([]byte, error) {
+	encrypted, err := cc.cipher.Encrypt(packed)
+	if err != nil {
+		return nil, fmt.Errorf("encrypt: %w", err)
+	}
+
+	return encodeFrame(frameHeader{flags: frameFlagEncrypted, dieAt: wrappedDieAt(cc.inner, packed, ttl)}, encrypted), nil
+}
+
+func (cc *CipherCodec[V]) Unmarshal(buf []byte) (*Envelope[V], error) {
+	if len(buf) == 0 {
+		return cc.inner.Unmarshal(buf)
+	}
+	header, payload, err := decodeFrame(buf)
+	if err != nil {
+		return &Envelope[V]{}, err
+	}
+	if !header.has(frameFlagEncrypted) {
+		return &Envelope[V]{}, errors.New("cache value is not encrypted")
+	}
+	decrypted, err := cc.cipher.Decrypt(payload)
+	if err != nil {
+		return &Envelope[V]{}, fmt.Errorf("decrypt: %w", err)
+	}
+
+	return cc.inner.Unmarshal(decrypted)
+}
+
+func (cc *CipherCodec[V]) DecodeExpiry(buf []byte) (int64, error) {
+	return frameExpiry(buf)
+}
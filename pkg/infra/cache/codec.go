@@ -8,3 +8,31 @@ type Codec[V any] interface {
 	Marshal(v V, ttl time.Duration) ([]byte, error)
 	Unmarshal(b []byte) (*Envelope[V], error)
 }
+
+// NilMarshaler - кодек, упаковывающий явный негативный результат (Envelope.Nil) для любого V,
+// в том числе не допускающего nil (структуры). Нужен для негативного кэширования таких значений.
+type NilMarshaler interface {
+	MarshalNil(ttl time.Duration) ([]byte, error)
+}
+
+// ExpiryDecoder - кодек, читающий момент истечения (unix nano, 0 - бессрочно) без распаковки значения.
+// Используется сборщиком просроченных записей и внешними хранилищами вместо полного Unmarshal.
+type ExpiryDecoder interface {
+	DecodeExpiry(b []byte) (int64, error)
+}
+
+// decodeExpiry момент истечения упакованного значения, через ExpiryDecoder, если кодек его поддерживает
+func decodeExpiry[V any](codec Codec[V], b []byte) (int64, error) {
+	if decoder, ok := codec.(ExpiryDecoder); ok {
+		return decoder.DecodeExpiry(b)
+	}
+	env, err := codec.Unmarshal(b)
+	if err != nil {
+		return 0, err
+	}
+	if env == nil {
+		return 0, nil
+	}
+
+	return env.DieAt, nil
+}
@@ -0,0 +1,87 @@
+package cache
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+// Компактный бинарный формат значения кэша (ProtoCodec, BinaryCodec и кодеки-обертки):
+// [0] версия формата, [1] флаги, [2:10] момент истечения (unix nano, big-endian), далее полезная нагрузка.
+// Момент истечения лежит в заголовке открыто, поэтому читается без распаковки и расшифровки значения.
+const (
+	// FrameVersion версия бинарного формата значения
+	FrameVersion byte = 1
+	// frameHeaderSize размер заголовка
+	frameHeaderSize = 10
+)
+
+// флаги заголовка
+const (
+	// frameFlagNil пустое (nil) значение, полезной нагрузки нет
+	frameFlagNil byte = 1 << iota
+	// frameFlagGzip полезная нагрузка сжата gzip
+	frameFlagGzip
+	// frameFlagBrotli полезная нагрузка сжата brotli
+	frameFlagBrotli
+	// frameFlagEncrypted полезная нагрузка зашифрована
+	frameFlagEncrypted
+)
+
+type frameHeader struct {
+	flags byte
+	dieAt int64
+}
+
+func (fh frameHeader) has(flag byte) bool {
+	return fh.flags&flag != 0
+}
+
+// frameDieAt момент истечения по ttl, 0 - бессрочно
+func frameDieAt(ttl time.Duration) int64 {
+	if ttl > 0 {
+		return time.Now().Add(ttl).UnixNano()
+	}
+
+	return 0
+}
+
+// encodeFrame упаковка заголовка и полезной нагрузки
+func encodeFrame(header frameHeader, payload []byte) []byte {
+	res := make([]byte, frameHeaderSize+len(payload))
+	res[0] = FrameVersion
+	res[1] = header.flags
+	binary.BigEndian.PutUint64(res[2:frameHeaderSize], uint64(header.dieAt))
+	copy(res[frameHeaderSize:], payload)
+
+	return res
+}
+
+// decodeFrame разбор заголовка, полезная нагрузка возвращается без копирования
+func decodeFrame(b []byte) (frameHeader, []byte, error) {
+	if len(b) < frameHeaderSize {
+		return frameHeader{}, nil, fmt.Errorf("cache frame too short [%d]", len(b))
+	}
+	if b[0] != FrameVersion {
+		return frameHeader{}, nil, fmt.Errorf("unsupported cache frame version [%d], expected [%d]", b[0], FrameVersion)
+	}
+	header := frameHeader{
+		flags: b[1],
+		dieAt: int64(binary.BigEndian.Uint64(b[2:frameHeaderSize])),
+	}
+
+	return header, b[frameHeaderSize:], nil
+}
+
+// frameExpiry момент истечения из заголовка без разбора полезной нагрузки
+func frameExpiry(b []byte) (int64, error) {
+	if len(b) == 0 {
+		return 0, nil
+	}
+	header, _, err := decodeFrame(b)
+	if err != nil {
+		return 0, err
+	}
+
+	return header.dieAt, nil
+}
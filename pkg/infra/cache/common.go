@@ -1,9 +1,19 @@
 package cache
 
+import (
+	"context"
+)
+
 // EmptyItemFactory необходим для создания чистого экземпляра V перед десериализацией.
 type EmptyItemFactory[V any] func() V
 
 type Envelope[V any] struct {
 	Value V
 	DieAt int64
+	// Nil признак пустого (nil) значения - негативный результат, при чтении (Get) считается промахом
+	Nil bool `json:",omitempty"`
 }
+
+// Loader - загрузка значения из источника при промахе кэша (см. Cache.GetOrLoad),
+// found=false означает отсутствие значения в источнике (негативный результат)
+type Loader[K comparable, V any] func(ctx context.Context, key K) (value V, found bool, err error)
@@ -0,0 +1,168 @@
+package cache
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/errs"
+	"github.com/ElfAstAhe/go-service-template/pkg/utils"
+	"github.com/andybalholm/brotli"
+)
+
+// DefaultCompressThreshold размер упакованного значения (байт), начиная с которого оно сжимается
+const DefaultCompressThreshold int = 1024
+
+// CompressCodec - кодек-обертка, сжимающий упакованное вложенным кодеком значение (gzip, brotli),
+// если его размер не меньше порога и сжатие дает выигрыш. Момент истечения хранится в заголовке открыто.
+type CompressCodec[V any] struct {
+	inner     Codec[V]
+	encoding  string
+	flag      byte
+	threshold int
+	pool      *sync.Pool
+}
+
+var _ ExpiryDecoder = (*CompressCodec[any])(nil)
+var _ NilMarshaler = (*CompressCodec[any])(nil)
+
+// NewCompressCodec encoding - utils.EncodingGzip или utils.EncodingBrotli, threshold <= 0 - сжимать всегда
+func NewCompressCodec[V any](inner Codec[V], encoding string, threshold int) (*CompressCodec[V], error) {
+	if utils.IsNil(inner) {
+		return nil, errs.NewInvalidArgumentError("inner", inner)
+	}
+	var flag byte
+	switch encoding {
+	case utils.EncodingGzip:
+		flag = frameFlagGzip
+	case utils.EncodingBrotli:
+		flag = frameFlagBrotli
+	default:
+		return nil, errs.NewInvalidArgumentError("encoding", encoding)
+	}
+
+	return &CompressCodec[V]{
+		inner:     inner,
+		encoding:  encoding,
+		flag:      flag,
+		threshold: threshold,
+		pool: &sync.Pool{
+			New: func() interface{} {
+				return new(bytes.Buffer)
+			},
+		},
+	}, nil
+}
+
+func (cc *CompressCodec[V]) Marshal(value V, ttl time.Duration) ([]byte, error) {
+	packed, err := cc.inner.Marshal(value, ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	return cc.wrap(packed, ttl)
+}
+
+// MarshalNil упаковка негативного результата вложенным кодеком (он должен реализовывать NilMarshaler)
+func (cc *CompressCodec[V]) MarshalNil(ttl time.Duration) ([]byte, error) {
+	inner, ok := cc.inner.(NilMarshaler)
+	if !ok {
+		return nil, errors.New("inner codec does not support nil marshaling")
+	}
+	packed, err := inner.MarshalNil(ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	return cc.wrap(packed, ttl)
+}
+
+func (cc *CompressCodec[V]) wrap(packed []byte, ttl time.Duration) ([]byte, error) {
+	header := frameHeader{dieAt: wrappedDieAt(cc.inner, packed, ttl)}
+	if len(packed) < cc.threshold {
+		return encodeFrame(header, packed), nil
+	}
+
+	buf := cc.pool.Get().(*bytes.Buffer)
+	buf.Reset()
+	defer cc.pool.Put(buf)
+	if err := cc.compress(buf, packed); err != nil {
+		return nil, err
+	}
+	// несжимаемые данные хранятся как есть
+	if buf.Len() >= len(packed) {
+		return encodeFrame(header, packed), nil
+	}
+	header.flags |= cc.flag
+
+	return encodeFrame(header, buf.Bytes()), nil
+}
+
+func (cc *CompressCodec[V]) Unmarshal(buf []byte) (*Envelope[V], error) {
+	if len(buf) == 0 {
+		return cc.inner.Unmarshal(buf)
+	}
+	header, payload, err := decodeFrame(buf)
+	if err != nil {
+		return &Envelope[V]{}, err
+	}
+	if header.has(frameFlagGzip) || header.has(frameFlagBrotli) {
+		payload, err = cc.decompress(header, payload)
+		if err != nil {
+			return &Envelope[V]{}, err
+		}
+	}
+
+	return cc.inner.Unmarshal(payload)
+}
+
+func (cc *CompressCodec[V]) DecodeExpiry(buf []byte) (int64, error) {
+	return frameExpiry(buf)
+}
+
+func (cc *CompressCodec[V]) compress(dst io.Writer, data []byte) error {
+	var writer io.WriteCloser
+	if cc.encoding == utils.EncodingBrotli {
+		writer = brotli.NewWriter(dst)
+	} else {
+		writer = gzip.NewWriter(dst)
+	}
+	if _, err := writer.Write(data); err != nil {
+		_ = writer.Close()
+
+		return fmt.Errorf("compress %s: %w", cc.encoding, err)
+	}
+
+	return writer.Close()
+}
+
+// decompress распаковка по флагу заголовка, а не по настройке кодека: смена алгоритма не ломает уже записанные значения
+func (cc *CompressCodec[V]) decompress(header frameHeader, payload []byte) ([]byte, error) {
+	encoding := utils.EncodingGzip
+	if header.has(frameFlagBrotli) {
+		encoding = utils.EncodingBrotli
+	}
+	reader, err := utils.NewDecompressReader(encoding, io.NopCloser(bytes.NewReader(payload)))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = reader.Close() }()
+
+	return io.ReadAll(reader)
+}
+
+// wrappedDieAt момент истечения для заголовка кодека-обертки: из вложенного значения, если кодек читает его дешево,
+// иначе по ttl
+func wrappedDieAt[V any](inner Codec[V], packed []byte, ttl time.Duration) int64 {
+	if decoder, ok := inner.(ExpiryDecoder); ok {
+		if dieAt, err := decoder.DecodeExpiry(packed); err == nil {
+			return dieAt
+		}
+	}
+
+	return frameDieAt(ttl)
+}
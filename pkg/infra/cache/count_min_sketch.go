@@ -0,0 +1,92 @@
+package cache
+
+import (
+	"hash/maphash"
+)
+
+const (
+	// sketchDepth кол-во строк (хеш-функций) count-min sketch
+	sketchDepth = 4
+	// sketchMaxCount предел счетчика (4 бита достаточно для оценки популярности)
+	sketchMaxCount = 15
+	// sketchMinWidth минимальная ширина строки
+	sketchMinWidth = 16
+)
+
+// countMinSketch - вероятностная оценка частоты обращений к ключам с периодическим старением
+// (все счетчики делятся пополам после sampleSize инкрементов). Не потокобезопасен.
+type countMinSketch[K comparable] struct {
+	seed       maphash.Seed
+	rows       [sketchDepth][]uint8
+	mask       uint64
+	additions  int
+	sampleSize int
+}
+
+func newCountMinSketch[K comparable](capacity int) *countMinSketch[K] {
+	width := uint64(sketchMinWidth)
+	for width < uint64(capacity) {
+		width <<= 1
+	}
+	res := &countMinSketch[K]{
+		seed:       maphash.MakeSeed(),
+		mask:       width - 1,
+		sampleSize: 10 * int(width),
+	}
+	for i := range res.rows {
+		res.rows[i] = make([]uint8, width)
+	}
+
+	return res
+}
+
+// increment учет обращения к ключу
+func (cms *countMinSketch[K]) increment(key K) {
+	h1, h2 := cms.hash(key)
+	for i := range cms.rows {
+		idx := (h1 + uint64(i)*h2) & cms.mask
+		if cms.rows[i][idx] < sketchMaxCount {
+			cms.rows[i][idx]++
+		}
+	}
+	cms.additions++
+	if cms.additions >= cms.sampleSize {
+		cms.age()
+	}
+}
+
+// estimate оценка частоты обращений к ключу (не меньше реальной с учетом старения)
+func (cms *countMinSketch[K]) estimate(key K) uint8 {
+	h1, h2 := cms.hash(key)
+	res := uint8(sketchMaxCount)
+	for i := range cms.rows {
+		idx := (h1 + uint64(i)*h2) & cms.mask
+		res = min(res, cms.rows[i][idx])
+	}
+
+	return res
+}
+
+func (cms *countMinSketch[K]) reset() {
+	for i := range cms.rows {
+		clear(cms.rows[i])
+	}
+	cms.additions = 0
+}
+
+// age старение: недавние обращения весят больше давних
+func (cms *countMinSketch[K]) age() {
+	for i := range cms.rows {
+		for j := range cms.rows[i] {
+			cms.rows[i][j] >>= 1
+		}
+	}
+	cms.additions /= 2
+}
+
+// hash двойное хеширование: h1 + i*h2 дает независимые индексы строк из одного хеша
+func (cms *countMinSketch[K]) hash(key K) (uint64, uint64) {
+	h := maphash.Comparable(cms.seed, key)
+
+	return h, (h >> 32) | 1
+}
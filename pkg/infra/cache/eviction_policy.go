@@ -8,3 +8,7 @@ type EvictionPolicy[K comparable] interface {
 	Reset()
 	Evict() (K, bool) // Удаляем жертву
 }
+
+// EvictionPolicyFactory - создание экземпляра политики вытеснения для хранилища (шарда) емкостью capacity элементов.
+// Экземпляр политики принадлежит одному хранилищу и не разделяется между шардами.
+type EvictionPolicyFactory[K comparable] func(capacity int) EvictionPolicy[K]
@@ -0,0 +1,106 @@
+package cache
+
+// expiryItem - элемент индекса просрочки
+type expiryItem[K comparable] struct {
+	key   K
+	dieAt int64
+	// index позиция в куче, -1 - элемент вне индекса
+	index int
+}
+
+// expiryIndex - min-heap ключей по моменту истечения, не потокобезопасен (защищается блокировкой хранилища).
+// Бессрочные значения (dieAt = 0) в индекс не попадают.
+type expiryIndex[K comparable] struct {
+	items []*expiryItem[K]
+}
+
+func newExpiryIndex[K comparable]() *expiryIndex[K] {
+	return &expiryIndex[K]{}
+}
+
+func (ei *expiryIndex[K]) Len() int {
+	return len(ei.items)
+}
+
+// push добавление элемента, O(log n)
+func (ei *expiryIndex[K]) push(item *expiryItem[K]) {
+	item.index = len(ei.items)
+	ei.items = append(ei.items, item)
+	ei.up(item.index)
+}
+
+// remove исключение элемента из индекса, O(log n)
+func (ei *expiryIndex[K]) remove(item *expiryItem[K]) {
+	i := item.index
+	if i < 0 || i >= len(ei.items) || ei.items[i] != item {
+		return
+	}
+	last := len(ei.items) - 1
+	if i != last {
+		ei.swap(i, last)
+	}
+	ei.items[last] = nil
+	ei.items = ei.items[:last]
+	item.index = -1
+	if i != last {
+		if !ei.down(i) {
+			ei.up(i)
+		}
+	}
+}
+
+// peek элемент с ближайшим моментом истечения
+func (ei *expiryIndex[K]) peek() (*expiryItem[K], bool) {
+	if len(ei.items) == 0 {
+		return nil, false
+	}
+
+	return ei.items[0], true
+}
+
+func (ei *expiryIndex[K]) reset() {
+	ei.items = nil
+}
+
+func (ei *expiryIndex[K]) less(i, j int) bool {
+	return ei.items[i].dieAt < ei.items[j].dieAt
+}
+
+func (ei *expiryIndex[K]) swap(i, j int) {
+	ei.items[i], ei.items[j] = ei.items[j], ei.items[i]
+	ei.items[i].index = i
+	ei.items[j].index = j
+}
+
+func (ei *expiryIndex[K]) up(j int) {
+	for j > 0 {
+		i := (j - 1) / 2
+		if !ei.less(j, i) {
+			break
+		}
+		ei.swap(i, j)
+		j = i
+	}
+}
+
+func (ei *expiryIndex[K]) down(i0 int) bool {
+	n := len(ei.items)
+	i := i0
+	for {
+		left := 2*i + 1
+		if left >= n {
+			break
+		}
+		j := left
+		if right := left + 1; right < n && ei.less(right, left) {
+			j = right
+		}
+		if !ei.less(j, i) {
+			break
+		}
+		ei.swap(i, j)
+		i = j
+	}
+
+	return i > i0
+}
@@ -2,19 +2,45 @@ package cache
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/ElfAstAhe/go-service-template/pkg/errs"
+	"github.com/ElfAstAhe/go-service-template/pkg/infra/metrics"
+	"github.com/ElfAstAhe/go-service-template/pkg/logger"
 	"github.com/ElfAstAhe/go-service-template/pkg/utils"
+	"github.com/redis/go-redis/v9"
 )
 
+// DefaultEvictPolicyCapacity емкость политик вытеснения, зависящих от емкости, для хранилищ без ограничения кол-ва
+const DefaultEvictPolicyCapacity int = 10000
+
+type redisFactoryConfig[K comparable] struct {
+	client redis.UniversalClient
+	prefix string
+	opts   []RedisStorageOption[K]
+}
+
+type objectFactoryConfig[V any] struct {
+	clone CloneFunc[V]
+}
+
 type factoryConfig[K comparable, V any] struct {
 	l2             bool
 	shardCount     uint64
 	shardFactory   ShardFactory[K]
 	maxSize        int
-	policy         EvictionPolicy[K]
+	maxBytes       int64
+	maxItemBytes   int64
+	policyFactory  EvictionPolicyFactory[K]
 	codec          Codec[V]
 	janitorMaxSize int
+	redis          *redisFactoryConfig[K]
+	negativeTTL    *time.Duration
+	metricsName    string
+	name           string
+	refresher      *Refresher[K, V]
+	object         *objectFactoryConfig[V]
+	log            logger.Logger
 }
 
 func (fc *factoryConfig[K, V]) Validate() error {
@@ -22,6 +48,13 @@ func (fc *factoryConfig[K, V]) Validate() error {
 	if fc.maxSize < 0 {
 		return errs.NewCommonError("max cache size must be greater or equal zero", nil)
 	}
+	// max bytes
+	if fc.maxBytes < 0 {
+		return errs.NewCommonError("max cache bytes must be greater or equal zero", nil)
+	}
+	if fc.maxItemBytes < 0 {
+		return errs.NewCommonError("max cache item bytes must be greater or equal zero", nil)
+	}
 	// shard count
 	if fc.shardCount == 0 {
 		return errs.NewCommonError("cache shard count must be greater zero", nil)
@@ -30,10 +63,32 @@ func (fc *factoryConfig[K, V]) Validate() error {
 	if utils.IsNil(fc.shardFactory) {
 		return errs.NewCommonError("cache shard factory must be applied", nil)
 	}
-	// codec
-	if utils.IsNil(fc.codec) {
+	// codec (кэш объектов не сериализует значения)
+	if fc.object == nil && utils.IsNil(fc.codec) {
 		return errs.NewCommonError("cache codec not applied", nil)
 	}
+	// object storage
+	if fc.object != nil {
+		switch {
+		case fc.redis != nil:
+			return errs.NewCommonError("cache object storage is not compatible with redis storage", nil)
+		case fc.l2:
+			return errs.NewCommonError("cache object storage is not compatible with L2 cache", nil)
+		case fc.maxBytes > 0 || fc.maxItemBytes > 0:
+			return errs.NewCommonError("cache object storage does not support byte budget", nil)
+		case fc.refresher != nil:
+			return errs.NewCommonError("cache object storage does not support refresher", nil)
+		}
+	}
+	// redis
+	if fc.redis != nil {
+		if utils.IsNil(fc.redis.client) {
+			return errs.NewCommonError("cache redis client must be applied", nil)
+		}
+		if fc.redis.prefix == "" {
+			return errs.NewCommonError("cache redis key prefix must not be empty", nil)
+		}
+	}
 	// janitor max capacity
 	if fc.janitorMaxSize <= 0 {
 		return errs.NewCommonError("janitor max size must be greater than zero", nil)
@@ -42,6 +97,56 @@ func (fc *factoryConfig[K, V]) Validate() error {
 	return nil
 }
 
+// registryName имя кэша в DefaultRegistry (или имя метрик), "" - кэш не регистрируется
+func (fc *factoryConfig[K, V]) registryName() string {
+	if fc.name != "" {
+		return fc.name
+	}
+
+	return fc.metricsName
+}
+
+// statsRecorder приемник событий кэша: метрики (WithMetrics) и счетчики для реестра, nil - события не нужны
+func (fc *factoryConfig[K, V]) statsRecorder(policyName string) (StatsRecorder, *StatsCounter) {
+	var recorder StatsRecorder
+	if fc.metricsName != "" {
+		recorder = NewMetricsRecorder(fc.metricsName, policyName)
+	}
+	if fc.registryName() == "" {
+		return recorder, nil
+	}
+	counter := NewStatsCounter(recorder)
+
+	return counter, counter
+}
+
+// register регистрация кэша в DefaultRegistry со счетчиками событий, без имени кэш не регистрируется
+func (fc *factoryConfig[K, V]) register(cache Cache[K, V], counter *StatsCounter) Cache[K, V] {
+	if name := fc.registryName(); name != "" {
+		RegisterCache[K, V](DefaultRegistry(), name, cache, WithAdminCacheStats(counter))
+	}
+
+	return cache
+}
+
+// redisErrorsName имя кэша в метрике ошибок redis: имя в реестре, иначе префикс ключей
+func (fc *factoryConfig[K, V]) redisErrorsName() string {
+	if name := fc.registryName(); name != "" {
+		return name
+	}
+
+	return fc.redis.prefix
+}
+
+// storageLogger журнал кэша (WithLogger), по умолчанию стартовый журнал
+func (fc *factoryConfig[K, V]) storageLogger() logger.Logger {
+	if !utils.IsNil(fc.log) {
+		return fc.log
+	}
+
+	return logger.NewStartupZapLogger()
+}
+
 type Option[K comparable, V any] func(config *factoryConfig[K, V])
 
 func defaultFactoryConfig[K comparable, V any]() *factoryConfig[K, V] {
@@ -56,6 +161,8 @@ func defaultFactoryConfig[K comparable, V any]() *factoryConfig[K, V] {
 	}
 }
 
+// CacheFactory сборка кэша по опциям. Кэш с именем (WithName, иначе имя метрик WithMetrics)
+// регистрируется в DefaultRegistry для администрирования.
 func CacheFactory[K comparable, V any](opts ...Option[K, V]) (Cache[K, V], error) {
 	// default config
 	conf := defaultFactoryConfig[K, V]()
@@ -71,28 +178,90 @@ func CacheFactory[K comparable, V any](opts ...Option[K, V]) (Cache[K, V], error
 	}
 
 	// check policy
-	if utils.IsNil(conf.policy) {
-		conf.policy = NewLRUEvict[K]()
+	if conf.policyFactory == nil {
+		conf.policyFactory = lruEvictFactory[K]
+	}
+
+	// object cache
+	if conf.object != nil {
+		res, counter := newObjectCache(conf)
+
+		return conf.register(res, counter), nil
+	}
+
+	// byte budget
+	shardFactory := conf.shardFactory
+	var budgetErr error
+	if conf.maxBytes > 0 || conf.maxItemBytes > 0 {
+		shardBytes := ShardByteBudget(conf.maxBytes, conf.shardCount)
+		shardFactory = func(maxSize int, policy EvictionPolicy[K]) Storage[K] {
+			shard := conf.shardFactory(maxSize, policy)
+			budgeter, ok := shard.(ByteBudgeter)
+			if !ok {
+				budgetErr = errs.NewCommonError(fmt.Sprintf("cache storage [%s] does not support byte budget", utils.GetTypeName(shard)), nil)
+
+				return shard
+			}
+			budgeter.SetByteBudget(shardBytes, conf.maxItemBytes)
+
+			return shard
+		}
 	}
 
 	// storage
 	var storage Storage[K]
 	switch {
+	case conf.redis != nil:
+		// TTL на стороне сервера по конверту кодека и журналирование ошибок, явные опции имеют приоритет
+		opts := append([]RedisStorageOption[K]{
+			WithRedisExpiry[K](CodecExpiry[V](conf.codec)),
+			WithRedisErrorHandler[K](NewRedisErrorReporter(conf.redisErrorsName(), conf.storageLogger())),
+		}, conf.redis.opts...)
+		storage = NewRedisStorage[K](conf.redis.client, conf.redis.prefix, opts...)
 	case conf.shardCount == 1:
-		storage = conf.shardFactory(conf.maxSize, conf.policy)
+		storage = shardFactory(conf.maxSize, conf.policyFactory(policyCapacity(conf.maxSize)))
 	case conf.shardCount > 1:
-		storage = NewShardStorage[K](conf.shardCount, conf.shardFactory, conf.maxSize, conf.policy)
+		storage = NewShardStorage[K](conf.shardCount, shardFactory, conf.maxSize, conf.policyFactory)
 	default:
 		return nil, errs.NewCommonError(fmt.Sprintf("invalid shard count [%d]", conf.shardCount), nil)
 	}
+	if budgetErr != nil {
+		return nil, errs.NewCommonError("cache factory invalid config", budgetErr)
+	}
+
+	// manager options
+	var managerOpts []ManagerOption[K, V]
+	if conf.negativeTTL != nil {
+		managerOpts = append(managerOpts, WithManagerNegativeTTL[K, V](*conf.negativeTTL))
+	}
+	// имя политики по пробному экземпляру
+	policyName := PolicyName(conf.policyFactory(1))
+	if conf.redis != nil {
+		policyName = "redis"
+	}
+	recorder, counter := conf.statsRecorder(policyName)
+	if recorder != nil {
+		if conf.metricsName != "" {
+			RegisterStorageMetrics[K](conf.metricsName, storage, recorder)
+		} else if reporter, ok := storage.(EvictionReporter[K]); ok {
+			reporter.SetEvictionListener(func(K) {
+				recorder.Evicted()
+			})
+		}
+		managerOpts = append(managerOpts, WithManagerStats[K, V](recorder))
+	}
+
+	if conf.refresher != nil {
+		managerOpts = append(managerOpts, WithManagerRefresher[K, V](conf.refresher))
+	}
 
 	// L2 cache
 	if conf.l2 {
-		return NewL2[K, V](storage, conf.codec, conf.janitorMaxSize), nil
+		return conf.register(NewL2[K, V](storage, conf.codec, conf.janitorMaxSize, managerOpts...), counter), nil
 	}
 
 	// cache
-	return New[K, V](storage, conf.codec, conf.janitorMaxSize), nil
+	return conf.register(New[K, V](storage, conf.codec, conf.janitorMaxSize, managerOpts...), counter), nil
 }
 
 func WithL2Cache[K comparable, V any]() Option[K, V] {
@@ -113,27 +282,71 @@ func WithShardFactory[K comparable, V any](shardFactory ShardFactory[K]) Option[
 	}
 }
 
+// WithMaxSize емкость кэша (кол-во элементов) на каждый шард, 0 - без ограничения
 func WithMaxSize[K comparable, V any](maxSize int) Option[K, V] {
 	return func(config *factoryConfig[K, V]) {
 		config.maxSize = maxSize
 	}
 }
 
+// WithMaxBytes бюджет суммарного размера сериализованных значений в байтах (делится поровну между шардами),
+// при превышении значения вытесняются политикой вытеснения, 0 - без ограничения. Ограничение по количеству (WithMaxSize)
+// продолжает действовать, WithMaxSize(0) отключает его. Для redis хранилища не применяется.
+func WithMaxBytes[K comparable, V any](maxBytes int64) Option[K, V] {
+	return func(config *factoryConfig[K, V]) {
+		config.maxBytes = maxBytes
+	}
+}
+
+// WithMaxItemBytes ограничение размера одного сериализованного значения в байтах,
+// значение больше ограничения не кэшируется (Set возвращает ошибку), 0 - без ограничения
+func WithMaxItemBytes[K comparable, V any](maxItemBytes int64) Option[K, V] {
+	return func(config *factoryConfig[K, V]) {
+		config.maxItemBytes = maxItemBytes
+	}
+}
+
 func WithLRUEvictPolicy[K comparable, V any]() Option[K, V] {
-	return WithCustomEvictPolicy[K, V](NewLRUEvict[K]())
+	return WithCustomEvictPolicy[K, V](lruEvictFactory[K])
 }
 
 func WithLFUEvictPolicy[K comparable, V any]() Option[K, V] {
-	return WithCustomEvictPolicy[K, V](NewLFUEvict[K]())
+	return WithCustomEvictPolicy[K, V](func(int) EvictionPolicy[K] {
+		return NewLFUEvict[K]()
+	})
 }
 
 func WithFIFOEvictPolicy[K comparable, V any]() Option[K, V] {
-	return WithCustomEvictPolicy[K, V](NewFIFOEvict[K]())
+	return WithCustomEvictPolicy[K, V](func(int) EvictionPolicy[K] {
+		return NewFIFOEvict[K]()
+	})
+}
+
+// WithTinyLFUEvictPolicy политика W-TinyLFU
+func WithTinyLFUEvictPolicy[K comparable, V any]() Option[K, V] {
+	return WithCustomEvictPolicy[K, V](func(capacity int) EvictionPolicy[K] {
+		return NewTinyLFUEvict[K](capacity)
+	})
 }
 
-func WithCustomEvictPolicy[K comparable, V any](policy EvictionPolicy[K]) Option[K, V] {
+// WithARCEvictPolicy политика ARC
+func WithARCEvictPolicy[K comparable, V any]() Option[K, V] {
+	return WithCustomEvictPolicy[K, V](func(capacity int) EvictionPolicy[K] {
+		return NewARCEvict[K](capacity)
+	})
+}
+
+// WithS3FIFOEvictPolicy политика S3-FIFO
+func WithS3FIFOEvictPolicy[K comparable, V any]() Option[K, V] {
+	return WithCustomEvictPolicy[K, V](func(capacity int) EvictionPolicy[K] {
+		return NewS3FIFOEvict[K](capacity)
+	})
+}
+
+// WithCustomEvictPolicy фабрика политики вытеснения, экземпляр создается на каждый шард с емкостью шарда
+func WithCustomEvictPolicy[K comparable, V any](policyFactory EvictionPolicyFactory[K]) Option[K, V] {
 	return func(config *factoryConfig[K, V]) {
-		config.policy = policy
+		config.policyFactory = policyFactory
 	}
 }
 
@@ -148,3 +361,97 @@ func WithJanitorMaxSize[K comparable, V any](janitorMaxSize int) Option[K, V] {
 		config.janitorMaxSize = janitorMaxSize
 	}
 }
+
+// WithRedisStorage хранилище кэша в redis, настройки шардирования, размера и политики вытеснения не применяются
+func WithRedisStorage[K comparable, V any](client redis.UniversalClient, prefix string, opts ...RedisStorageOption[K]) Option[K, V] {
+	return func(config *factoryConfig[K, V]) {
+		config.redis = &redisFactoryConfig[K]{
+			client: client,
+			prefix: prefix,
+			opts:   opts,
+		}
+	}
+}
+
+// WithNegativeTTL время жизни негативного результата GetOrLoad (и L2 Get), 0 - негативные результаты не кэшируются
+func WithNegativeTTL[K comparable, V any](ttl time.Duration) Option[K, V] {
+	return func(config *factoryConfig[K, V]) {
+		config.negativeTTL = &ttl
+	}
+}
+
+// WithName имя кэша в DefaultRegistry (по умолчанию имя метрик WithMetrics)
+func WithName[K comparable, V any](name string) Option[K, V] {
+	return func(config *factoryConfig[K, V]) {
+		config.name = name
+	}
+}
+
+// WithMetrics публикация метрик кэша в prometheus под именем name
+func WithMetrics[K comparable, V any](name string) Option[K, V] {
+	return func(config *factoryConfig[K, V]) {
+		config.metricsName = name
+	}
+}
+
+// WithLogger журнал ошибок хранилища (redis), по умолчанию стартовый журнал
+func WithLogger[K comparable, V any](log logger.Logger) Option[K, V] {
+	return func(config *factoryConfig[K, V]) {
+		config.log = log
+	}
+}
+
+// WithRefresher фоновое обновление записей (refresh-ahead, stale-while-revalidate), пул refresher запускается отдельно
+func WithRefresher[K comparable, V any](refresher *Refresher[K, V]) Option[K, V] {
+	return func(config *factoryConfig[K, V]) {
+		config.refresher = refresher
+	}
+}
+
+// WithObjectStorage кэш объектов без сериализации (ObjectManager), кодек не требуется.
+// clone - копирование значений при записи и чтении, nil - кэш отдает хранимый объект как есть.
+// Не совместим с redis, L2, бюджетом объема и фоновым обновлением, фабрика шардов не применяется.
+func WithObjectStorage[K comparable, V any](clone CloneFunc[V]) Option[K, V] {
+	return func(config *factoryConfig[K, V]) {
+		config.object = &objectFactoryConfig[V]{clone: clone}
+	}
+}
+
+// newObjectCache кэш объектов по конфигурации фабрики и его счетчики событий для реестра
+func newObjectCache[K comparable, V any](conf *factoryConfig[K, V]) (*ObjectManager[K, V], *StatsCounter) {
+	var opts []ObjectManagerOption[K, V]
+	if conf.object.clone != nil {
+		opts = append(opts, WithObjectClone[K, V](conf.object.clone))
+	}
+	if conf.negativeTTL != nil {
+		opts = append(opts, WithObjectNegativeTTL[K, V](*conf.negativeTTL))
+	}
+	recorder, counter := conf.statsRecorder(PolicyName(conf.policyFactory(1)))
+	if recorder != nil {
+		opts = append(opts, WithObjectStats[K, V](recorder))
+	}
+	res := NewObjectManager[K, V](conf.shardCount, conf.maxSize, conf.policyFactory, conf.janitorMaxSize, opts...)
+	if recorder != nil {
+		res.SetEvictionListener(func(K) {
+			recorder.Evicted()
+		})
+	}
+	if conf.metricsName != "" {
+		metrics.RegisterCacheSize(conf.metricsName, res.Size, nil)
+	}
+
+	return res, counter
+}
+
+func lruEvictFactory[K comparable](int) EvictionPolicy[K] {
+	return NewLRUEvict[K]()
+}
+
+// policyCapacity емкость политики вытеснения, учитывающей емкость хранилища (без ограничения кол-ва - по умолчанию)
+func policyCapacity(maxSize int) int {
+	if maxSize > 0 {
+		return maxSize
+	}
+
+	return DefaultEvictPolicyCapacity
+}
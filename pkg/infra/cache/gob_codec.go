@@ -14,6 +14,8 @@ type GobCodec[V any] struct {
 	emptyItemFactory EmptyItemFactory[V]
 }
 
+var _ NilMarshaler = (*GobCodec[any])(nil)
+
 func NewGobCodec[V any](factory EmptyItemFactory[V]) *GobCodec[V] {
 	return &GobCodec[V]{
 		pool: &sync.Pool{
@@ -26,21 +28,30 @@ func NewGobCodec[V any](factory EmptyItemFactory[V]) *GobCodec[V] {
 }
 
 func (gc *GobCodec[V]) Marshal(value V, ttl time.Duration) ([]byte, error) {
+	env := &Envelope[V]{
+		Value: value,
+		DieAt: frameDieAt(ttl),
+	}
+	// пустое значение упаковывается в конверт без значения, чтобы сохранить TTL
 	if utils.IsNil(value) {
-		return nil, nil
+		var nilValue V
+		env.Value = nilValue
+		env.Nil = true
 	}
+
+	return gc.encode(env)
+}
+
+// MarshalNil упаковка негативного результата: конверт с признаком Nil без значения
+func (gc *GobCodec[V]) MarshalNil(ttl time.Duration) ([]byte, error) {
+	return gc.encode(&Envelope[V]{DieAt: frameDieAt(ttl), Nil: true})
+}
+
+func (gc *GobCodec[V]) encode(env *Envelope[V]) ([]byte, error) {
 	buf := gc.pool.Get().(*bytes.Buffer)
 	buf.Reset()
 	defer gc.pool.Put(buf)
 
-	var dieAt int64 = 0
-	if ttl > 0 {
-		dieAt = time.Now().Add(ttl).UnixNano()
-	}
-	env := &Envelope[V]{
-		Value: value,
-		DieAt: dieAt,
-	}
 	if err := gob.NewEncoder(buf).Encode(env); err != nil {
 		return nil, err
 	}
@@ -58,7 +69,13 @@ func (gc *GobCodec[V]) Unmarshal(buf []byte) (*Envelope[V], error) {
 	env := &Envelope[V]{
 		Value: gc.emptyItemFactory(),
 	}
-	err := gob.NewDecoder(bytes.NewReader(buf)).Decode(env)
+	if err := gob.NewDecoder(bytes.NewReader(buf)).Decode(env); err != nil {
+		return env, err
+	}
+	if env.Nil {
+		var nilValue V
+		env.Value = nilValue
+	}
 
-	return env, err
+	return env, nil
 }
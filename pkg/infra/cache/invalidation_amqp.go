@@ -0,0 +1,166 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/container"
+	"github.com/ElfAstAhe/go-service-template/pkg/errs"
+	"github.com/ElfAstAhe/go-service-template/pkg/infra/pubsub"
+	"github.com/ElfAstAhe/go-service-template/pkg/logger"
+	"github.com/ElfAstAhe/go-service-template/pkg/transport/amqp"
+)
+
+// amqpReceiveRetryInterval пауза перед повторным чтением после ошибки получения
+const amqpReceiveRetryInterval = time.Second
+
+// AMQPMessageFactory - создание AMQP сообщения из полезной нагрузки (например, azure.NewMessage)
+type AMQPMessageFactory func(payload []byte) amqp.Message
+
+// AMQPInvalidationBus - рассылка инвалидаций между репликами через AMQP топик.
+// Каждая реплика должна читать собственную подписку топика (fan-out настраивается на брокере),
+// полученные сообщения раздаются локальным наблюдателям.
+type AMQPInvalidationBus[K comparable, SendOpts any, ReceiveOpts any] struct {
+	name        string
+	dispatcher  *pubsub.EventDispatcher[*Invalidation[K]]
+	sender      amqp.Sender[SendOpts]
+	sendOpts    SendOpts
+	receiver    amqp.Receiver[ReceiveOpts]
+	receiveOpts ReceiveOpts
+	newMessage  AMQPMessageFactory
+	log         logger.Logger
+	// context
+	ctx    context.Context
+	cancel context.CancelFunc
+	// sync
+	wg sync.WaitGroup
+	//
+	running *atomic.Bool
+}
+
+var _ InvalidationPublisher[string] = (*AMQPInvalidationBus[string, any, any])(nil)
+var _ container.Runner = (*AMQPInvalidationBus[string, any, any])(nil)
+
+func NewAMQPInvalidationBus[K comparable, SendOpts any, ReceiveOpts any](
+	name string,
+	sender amqp.Sender[SendOpts],
+	sendOpts SendOpts,
+	receiver amqp.Receiver[ReceiveOpts],
+	receiveOpts ReceiveOpts,
+	newMessage AMQPMessageFactory,
+	log logger.Logger,
+) *AMQPInvalidationBus[K, SendOpts, ReceiveOpts] {
+	return &AMQPInvalidationBus[K, SendOpts, ReceiveOpts]{
+		name:        name,
+		dispatcher:  pubsub.NewEventDispatcher[*Invalidation[K]](name, pubsub.DefaultNotifyTimeout, log),
+		sender:      sender,
+		sendOpts:    sendOpts,
+		receiver:    receiver,
+		receiveOpts: receiveOpts,
+		newMessage:  newMessage,
+		log:         log.GetLogger(name),
+		running:     new(atomic.Bool),
+	}
+}
+
+func (ab *AMQPInvalidationBus[K, SendOpts, ReceiveOpts]) Register(observer pubsub.Observer[*Invalidation[K]]) {
+	ab.dispatcher.Register(observer)
+}
+
+func (ab *AMQPInvalidationBus[K, SendOpts, ReceiveOpts]) Unregister(observer pubsub.Observer[*Invalidation[K]]) {
+	ab.dispatcher.Unregister(observer)
+}
+
+func (ab *AMQPInvalidationBus[K, SendOpts, ReceiveOpts]) Notify(ctx context.Context, msg *Invalidation[K]) {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		ab.log.Errorf("invalidation bus %s marshal failed: %v", ab.GetName(), err)
+
+		return
+	}
+	if err := ab.sender.Publish(context.WithoutCancel(ctx), ab.newMessage(payload), ab.sendOpts); err != nil {
+		ab.log.Errorf("invalidation bus %s publish failed: %v", ab.GetName(), err)
+	}
+}
+
+func (ab *AMQPInvalidationBus[K, SendOpts, ReceiveOpts]) Start(ctx context.Context) error {
+	if !ab.running.CompareAndSwap(false, true) {
+		return errs.NewCommonError(fmt.Sprintf("invalidation bus %s already started", ab.GetName()), nil)
+	}
+
+	ab.ctx, ab.cancel = context.WithCancel(ctx)
+
+	ab.wg.Add(1)
+	go ab.listen()
+
+	return nil
+}
+
+func (ab *AMQPInvalidationBus[K, SendOpts, ReceiveOpts]) Stop(stopCtx context.Context) error {
+	if !ab.running.CompareAndSwap(true, false) {
+		return errs.NewCommonError(fmt.Sprintf("invalidation bus %s is not running", ab.GetName()), nil)
+	}
+
+	ab.cancel()
+
+	stopChan := make(chan struct{})
+	go func() {
+		ab.wg.Wait()
+		close(stopChan)
+	}()
+	select {
+	case <-stopChan:
+	case <-stopCtx.Done():
+		return errs.NewCommonError(fmt.Sprintf("invalidation bus %s stop timed out", ab.GetName()), stopCtx.Err())
+	}
+
+	return nil
+}
+
+func (ab *AMQPInvalidationBus[K, SendOpts, ReceiveOpts]) GetName() string {
+	return ab.name
+}
+
+func (ab *AMQPInvalidationBus[K, SendOpts, ReceiveOpts]) IsRunning() bool {
+	return ab.running.Load()
+}
+
+func (ab *AMQPInvalidationBus[K, SendOpts, ReceiveOpts]) listen() {
+	defer ab.wg.Done()
+
+	for {
+		message, err := ab.receiver.Receive(ab.ctx, ab.receiveOpts)
+		if err != nil {
+			if ab.ctx.Err() != nil || errors.Is(err, context.Canceled) {
+				return
+			}
+			ab.log.Warnf("invalidation bus %s receive failed: %v", ab.GetName(), err)
+			select {
+			case <-ab.ctx.Done():
+				return
+			case <-time.After(amqpReceiveRetryInterval):
+			}
+
+			continue
+		}
+
+		msg := new(Invalidation[K])
+		if err := json.Unmarshal(message.GetPayload(), msg); err != nil {
+			ab.log.Warnf("invalidation bus %s reject malformed message: %v", ab.GetName(), err)
+			if err := ab.receiver.Reject(ab.ctx, message, err); err != nil {
+				ab.log.Warnf("invalidation bus %s reject failed: %v", ab.GetName(), err)
+			}
+
+			continue
+		}
+		ab.dispatcher.Notify(ab.ctx, msg)
+		if err := ab.receiver.Accept(ab.ctx, message); err != nil {
+			ab.log.Warnf("invalidation bus %s accept failed: %v", ab.GetName(), err)
+		}
+	}
+}
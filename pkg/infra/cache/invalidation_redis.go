@@ -0,0 +1,149 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/container"
+	"github.com/ElfAstAhe/go-service-template/pkg/errs"
+	"github.com/ElfAstAhe/go-service-template/pkg/infra/pubsub"
+	"github.com/ElfAstAhe/go-service-template/pkg/logger"
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisInvalidationBus - рассылка инвалидаций между репликами через redis pub/sub.
+// Notify публикует сообщение в канал, полученные из канала сообщения (включая собственные)
+// раздаются локальным наблюдателям, собственные сообщения наблюдатели отбрасывают по Source.
+// Подписка на канал выполняется в Start, экземпляр регистрируется как container.Runner.
+type RedisInvalidationBus[K comparable] struct {
+	dispatcher *pubsub.EventDispatcher[*Invalidation[K]]
+	client     redis.UniversalClient
+	channel    string
+	timeout    time.Duration
+	log        logger.Logger
+	// context
+	ctx    context.Context
+	cancel context.CancelFunc
+	sub    *redis.PubSub
+	// sync
+	wg sync.WaitGroup
+	//
+	running *atomic.Bool
+}
+
+var _ InvalidationPublisher[string] = (*RedisInvalidationBus[string])(nil)
+var _ container.Runner = (*RedisInvalidationBus[string])(nil)
+
+func NewRedisInvalidationBus[K comparable](
+	client redis.UniversalClient,
+	channel string,
+	log logger.Logger,
+) *RedisInvalidationBus[K] {
+	return &RedisInvalidationBus[K]{
+		dispatcher: pubsub.NewEventDispatcher[*Invalidation[K]](channel, pubsub.DefaultNotifyTimeout, log),
+		client:     client,
+		channel:    channel,
+		timeout:    DefaultRedisTimeout,
+		log:        log.GetLogger(channel),
+		running:    new(atomic.Bool),
+	}
+}
+
+func (rb *RedisInvalidationBus[K]) Register(observer pubsub.Observer[*Invalidation[K]]) {
+	rb.dispatcher.Register(observer)
+}
+
+func (rb *RedisInvalidationBus[K]) Unregister(observer pubsub.Observer[*Invalidation[K]]) {
+	rb.dispatcher.Unregister(observer)
+}
+
+func (rb *RedisInvalidationBus[K]) Notify(ctx context.Context, msg *Invalidation[K]) {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		rb.log.Errorf("invalidation bus %s marshal failed: %v", rb.GetName(), err)
+
+		return
+	}
+	pubCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), rb.timeout)
+	defer cancel()
+
+	if err := rb.client.Publish(pubCtx, rb.channel, payload).Err(); err != nil {
+		rb.log.Errorf("invalidation bus %s publish failed: %v", rb.GetName(), err)
+	}
+}
+
+func (rb *RedisInvalidationBus[K]) Start(ctx context.Context) error {
+	if !rb.running.CompareAndSwap(false, true) {
+		return errs.NewCommonError(fmt.Sprintf("invalidation bus %s already started", rb.GetName()), nil)
+	}
+
+	rb.ctx, rb.cancel = context.WithCancel(ctx)
+	rb.sub = rb.client.Subscribe(rb.ctx, rb.channel)
+	// ожидаем подтверждения подписки, иначе ранние инвалидации будут потеряны
+	subCtx, cancel := context.WithTimeout(rb.ctx, rb.timeout)
+	defer cancel()
+	if _, err := rb.sub.Receive(subCtx); err != nil {
+		_ = rb.sub.Close()
+		rb.cancel()
+		rb.running.Store(false)
+
+		return errs.NewCommonError(fmt.Sprintf("invalidation bus %s subscribe failed", rb.GetName()), err)
+	}
+
+	rb.wg.Add(1)
+	go rb.listen(rb.sub.Channel())
+
+	return nil
+}
+
+func (rb *RedisInvalidationBus[K]) Stop(stopCtx context.Context) error {
+	if !rb.running.CompareAndSwap(true, false) {
+		return errs.NewCommonError(fmt.Sprintf("invalidation bus %s is not running", rb.GetName()), nil)
+	}
+
+	rb.cancel()
+	// закрытие подписки закрывает канал сообщений и завершает listen
+	err := rb.sub.Close()
+
+	stopChan := make(chan struct{})
+	go func() {
+		rb.wg.Wait()
+		close(stopChan)
+	}()
+	select {
+	case <-stopChan:
+	case <-stopCtx.Done():
+		return errs.NewCommonError(fmt.Sprintf("invalidation bus %s stop timed out", rb.GetName()), stopCtx.Err())
+	}
+	if err != nil {
+		return errs.NewCommonError(fmt.Sprintf("invalidation bus %s unsubscribe failed", rb.GetName()), err)
+	}
+
+	return nil
+}
+
+func (rb *RedisInvalidationBus[K]) GetName() string {
+	return rb.channel
+}
+
+func (rb *RedisInvalidationBus[K]) IsRunning() bool {
+	return rb.running.Load()
+}
+
+func (rb *RedisInvalidationBus[K]) listen(messages <-chan *redis.Message) {
+	defer rb.wg.Done()
+
+	for message := range messages {
+		msg := new(Invalidation[K])
+		if err := json.Unmarshal([]byte(message.Payload), msg); err != nil {
+			rb.log.Warnf("invalidation bus %s skip malformed message: %v", rb.GetName(), err)
+
+			continue
+		}
+		rb.dispatcher.Notify(rb.ctx, msg)
+	}
+}
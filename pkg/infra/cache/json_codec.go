@@ -14,6 +14,8 @@ type JSONCodec[V any] struct {
 	emptyItemFactory EmptyItemFactory[V]
 }
 
+var _ NilMarshaler = (*JSONCodec[any])(nil)
+
 func NewJSONCodec[V any](factory EmptyItemFactory[V]) *JSONCodec[V] {
 	return &JSONCodec[V]{
 		pool: &sync.Pool{
@@ -26,21 +28,30 @@ func NewJSONCodec[V any](factory EmptyItemFactory[V]) *JSONCodec[V] {
 }
 
 func (jc *JSONCodec[V]) Marshal(value V, ttl time.Duration) ([]byte, error) {
+	env := &Envelope[V]{
+		Value: value,
+		DieAt: frameDieAt(ttl),
+	}
+	// пустое значение упаковывается в конверт без значения, чтобы сохранить TTL
 	if utils.IsNil(value) {
-		return nil, nil
+		var nilValue V
+		env.Value = nilValue
+		env.Nil = true
 	}
+
+	return jc.encode(env)
+}
+
+// MarshalNil упаковка негативного результата: конверт с признаком Nil без значения
+func (jc *JSONCodec[V]) MarshalNil(ttl time.Duration) ([]byte, error) {
+	return jc.encode(&Envelope[V]{DieAt: frameDieAt(ttl), Nil: true})
+}
+
+func (jc *JSONCodec[V]) encode(env *Envelope[V]) ([]byte, error) {
 	buf := jc.pool.Get().(*bytes.Buffer)
 	buf.Reset()
 	defer jc.pool.Put(buf)
 
-	var dieAt int64 = 0
-	if ttl > 0 {
-		dieAt = time.Now().Add(ttl).UnixNano()
-	}
-	env := &Envelope[V]{
-		Value: value,
-		DieAt: dieAt,
-	}
 	if err := json.NewEncoder(buf).Encode(env); err != nil {
 		return nil, err
 	}
@@ -59,7 +70,13 @@ func (jc *JSONCodec[V]) Unmarshal(buf []byte) (*Envelope[V], error) {
 	env := &Envelope[V]{
 		Value: jc.emptyItemFactory(),
 	}
-	err := json.NewDecoder(bytes.NewReader(buf)).Decode(env)
+	if err := json.NewDecoder(bytes.NewReader(buf)).Decode(env); err != nil {
+		return env, err
+	}
+	if env.Nil {
+		var nilValue V
+		env.Value = nilValue
+	}
 
-	return env, err
+	return env, nil
 }
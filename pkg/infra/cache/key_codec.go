@@ -0,0 +1,56 @@
+package cache
+
+import (
+	"encoding/json"
+)
+
+// KeyCodec - сериализация ключа кэша в строковый ключ внешнего хранилища (redis)
+type KeyCodec[K comparable] interface {
+	EncodeKey(key K) (string, error)
+	DecodeKey(s string) (K, error)
+}
+
+// StringKeyCodec - ключ используется как есть
+type StringKeyCodec struct{}
+
+var _ KeyCodec[string] = StringKeyCodec{}
+
+func (StringKeyCodec) EncodeKey(key string) (string, error) {
+	return key, nil
+}
+
+func (StringKeyCodec) DecodeKey(s string) (string, error) {
+	return s, nil
+}
+
+// JSONKeyCodec - ключ сериализуется в json (числа, структуры и т.п.)
+type JSONKeyCodec[K comparable] struct{}
+
+func NewJSONKeyCodec[K comparable]() *JSONKeyCodec[K] {
+	return &JSONKeyCodec[K]{}
+}
+
+func (jc *JSONKeyCodec[K]) EncodeKey(key K) (string, error) {
+	b, err := json.Marshal(key)
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
+
+func (jc *JSONKeyCodec[K]) DecodeKey(s string) (K, error) {
+	var res K
+	err := json.Unmarshal([]byte(s), &res)
+
+	return res, err
+}
+
+// DefaultKeyCodec для string ключей StringKeyCodec, для остальных JSONKeyCodec
+func DefaultKeyCodec[K comparable]() KeyCodec[K] {
+	if codec, ok := any(StringKeyCodec{}).(KeyCodec[K]); ok {
+		return codec
+	}
+
+	return NewJSONKeyCodec[K]()
+}
@@ -5,10 +5,12 @@ import (
 )
 
 const (
-	negativeGetTTL time.Duration = 2 * time.Minute
+	// DefaultNegativeTTL время жизни негативного результата L2Manager по умолчанию
+	DefaultNegativeTTL time.Duration = 2 * time.Minute
 )
 
-// L2Manager - cache manager с поведением L2, умеет запоминать негативный поиск с добавлением пустых значений
+// L2Manager - cache manager с поведением L2, умеет запоминать негативный поиск с добавлением пустых значений,
+// время жизни негативного результата задается WithManagerNegativeTTL (по умолчанию DefaultNegativeTTL)
 type L2Manager[K comparable, V any] struct {
 	*Manager[K, V]
 }
@@ -17,20 +19,31 @@ func NewL2[K comparable, V any](
 	storage Storage[K],
 	codec Codec[V],
 	janitorMaxSize int,
+	opts ...ManagerOption[K, V],
 ) *L2Manager[K, V] {
+	opts = append([]ManagerOption[K, V]{WithManagerNegativeTTL[K, V](DefaultNegativeTTL)}, opts...)
+
 	return &L2Manager[K, V]{
-		Manager: New(storage, codec, janitorMaxSize),
+		Manager: New(storage, codec, janitorMaxSize, opts...),
 	}
 }
 
+// Get чтение, промах кэшируется как негативный результат на negativeTTL (повторное чтение его не продлевает)
 func (l2m *L2Manager[K, V]) Get(key K) (V, bool, error) {
-	res, ok, err := l2m.Manager.Get(key)
+	envelope, ok, err := l2m.getEnvelope(key)
 	if err != nil {
-		return res, ok, err
+		return l2m.nilValue, false, err
 	}
 	if !ok {
-		_ = l2m.Manager.Set(key, res, negativeGetTTL)
+		if l2m.GetNegativeTTL() > 0 {
+			_ = l2m.setNegative("L2Manager.Get", key, l2m.GetNegativeTTL())
+		}
+
+		return l2m.nilValue, false, nil
+	}
+	if l2m.negative(envelope) {
+		return l2m.nilValue, false, nil
 	}
 
-	return res, ok, nil
+	return envelope.Value, true, nil
 }
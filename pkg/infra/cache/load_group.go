@@ -0,0 +1,72 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/errs"
+)
+
+// loadCall - выполняющаяся загрузка ключа
+type loadCall[V any] struct {
+	wg    sync.WaitGroup
+	value V
+	found bool
+	err   error
+}
+
+// loadGroup - дедупликация одновременных загрузок одного ключа (singleflight):
+// загрузчик выполняется одним вызывающим, остальные ожидают его результат
+type loadGroup[K comparable, V any] struct {
+	mu    sync.Mutex
+	calls map[K]*loadCall[V]
+}
+
+func newLoadGroup[K comparable, V any]() *loadGroup[K, V] {
+	return &loadGroup[K, V]{
+		calls: make(map[K]*loadCall[V]),
+	}
+}
+
+// Do выполнение fn для ключа, shared=true если результат получен от загрузки другого вызывающего.
+// Ожидающие вызовы прерываются по своему контексту, сама загрузка выполняется в контексте первого вызова.
+func (lg *loadGroup[K, V]) Do(ctx context.Context, key K, fn func() (V, bool, error)) (value V, found bool, shared bool, err error) {
+	lg.mu.Lock()
+	if call, ok := lg.calls[key]; ok {
+		lg.mu.Unlock()
+
+		done := make(chan struct{})
+		go func() {
+			call.wg.Wait()
+			close(done)
+		}()
+		select {
+		case <-done:
+			return call.value, call.found, true, call.err
+		case <-ctx.Done():
+			var nilValue V
+
+			return nilValue, false, true, ctx.Err()
+		}
+	}
+	call := &loadCall[V]{}
+	call.wg.Add(1)
+	lg.calls[key] = call
+	lg.mu.Unlock()
+
+	defer func() {
+		if r := recover(); r != nil {
+			call.err = errs.NewCommonError(fmt.Sprintf("cache load panic recovery [%v]", r), nil)
+			value, found, err = call.value, call.found, call.err
+		}
+		lg.mu.Lock()
+		delete(lg.calls, key)
+		lg.mu.Unlock()
+		call.wg.Done()
+	}()
+
+	call.value, call.found, call.err = fn()
+
+	return call.value, call.found, false, call.err
+}
@@ -2,9 +2,11 @@ package cache
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/ElfAstAhe/go-service-template/pkg/errs"
+	"github.com/ElfAstAhe/go-service-template/pkg/utils"
 )
 
 // Manager — основная реализация интерфейса Cache[K, V]
@@ -17,6 +19,48 @@ type Manager[K comparable, V any] struct {
 	nilValue V
 	// janitorMaxSize максимальное кол-во элементов на удаление по TTL
 	janitorMaxSize int
+	// negativeTTL время жизни негативного результата загрузки, 0 - негативные результаты не кэшируются
+	negativeTTL time.Duration
+	// loads дедупликация одновременных загрузок
+	loads *loadGroup[K, V]
+	// stats приемник событий кэша (метрики)
+	stats StatsRecorder
+	// expiring хранилище с индексом просрочки, nil - истечение определяется по конверту кодека
+	expiring ExpiringStorage[K]
+	// tagged хранилище с индексом тегов, nil - теги и инвалидация по префиксу не поддерживаются
+	tagged TaggedStorage[K]
+	// checked хранилище, сообщающее об ошибке записи, nil - запись ошибок не возвращает
+	checked CheckedWriter[K]
+	// refresher фоновое обновление записей (refresh-ahead, stale-while-revalidate), nil - отключено
+	refresher *Refresher[K, V]
+}
+
+var _ Cache[string, any] = (*Manager[string, any])(nil)
+var _ ExpiryReader[string, any] = (*Manager[string, any])(nil)
+
+type ManagerOption[K comparable, V any] func(*Manager[K, V])
+
+// WithManagerStats приемник событий кэша (например, MetricsRecorder)
+func WithManagerStats[K comparable, V any](stats StatsRecorder) ManagerOption[K, V] {
+	return func(cm *Manager[K, V]) {
+		cm.stats = stats
+	}
+}
+
+// WithManagerNegativeTTL кэширование негативных результатов GetOrLoad на ttl
+func WithManagerNegativeTTL[K comparable, V any](ttl time.Duration) ManagerOption[K, V] {
+	return func(cm *Manager[K, V]) {
+		cm.negativeTTL = ttl
+	}
+}
+
+// WithManagerRefresher фоновое обновление записей, refresher обслуживает только один кэш.
+// При stale-while-revalidate записи хранятся в хранилище на льготный период дольше своего TTL.
+func WithManagerRefresher[K comparable, V any](refresher *Refresher[K, V]) ManagerOption[K, V] {
+	return func(cm *Manager[K, V]) {
+		cm.refresher = refresher
+		refresher.cache = cm
+	}
 }
 
 // New создает новый экземпляр кэша
@@ -24,47 +68,297 @@ func New[K comparable, V any](
 	storage Storage[K],
 	codec Codec[V],
 	janitorMaxSize int,
+	opts ...ManagerOption[K, V],
 ) *Manager[K, V] {
-	return &Manager[K, V]{
+	res := &Manager[K, V]{
 		storage:        storage,
 		codec:          codec,
 		janitorMaxSize: janitorMaxSize,
+		loads:          newLoadGroup[K, V](),
+		stats:          nopStatsRecorder{},
+	}
+	if expiring, ok := storage.(ExpiringStorage[K]); ok && expiring.ExpiryIndexed() {
+		res.expiring = expiring
+	}
+	if tagged, ok := storage.(TaggedStorage[K]); ok && tagged.TagIndexed() {
+		res.tagged = tagged
+	}
+	if checked, ok := storage.(CheckedWriter[K]); ok {
+		res.checked = checked
 	}
+	for _, opt := range opts {
+		opt(res)
+	}
+
+	return res
 }
 
 func (cm *Manager[K, V]) Get(key K) (V, bool, error) {
-	buf, ok := cm.storage.Get(key)
+	res, _, ok, err := cm.GetWithExpiry(key)
+
+	return res, ok, err
+}
+
+// GetWithExpiry чтение значения вместе с моментом истечения (unix nano, 0 - бессрочно),
+// в льготный период stale-while-revalidate момент истечения уже в прошлом. Негативный результат - промах.
+func (cm *Manager[K, V]) GetWithExpiry(key K) (V, int64, bool, error) {
+	envelope, ok, err := cm.getEnvelope(key)
+	if err != nil || !ok || cm.negative(envelope) {
+		return cm.nilValue, 0, false, err
+	}
+
+	return envelope.Value, envelope.DieAt, true, nil
+}
+
+// getEnvelope чтение конверта значения с проверкой TTL, признак негативного результата - Envelope.Nil
+func (cm *Manager[K, V]) getEnvelope(key K) (*Envelope[V], bool, error) {
+	buf, ok := cm.getRaw(key)
 	if !ok {
-		return cm.nilValue, false, nil
+		cm.stats.Miss()
+
+		return nil, false, nil
 	}
 
 	envelope, err := cm.codec.Unmarshal(buf)
 	if err != nil {
-		return cm.nilValue, false, errs.NewCommonError("unmarshal failed", err)
+		cm.stats.Miss()
+
+		return nil, false, errs.NewCommonError("unmarshal failed", err)
 	}
 
-	// Проверка TTL (ленивое удаление)
-	if envelope.DieAt > 0 && time.Now().UnixNano() > envelope.DieAt {
+	// Проверка TTL (ленивое удаление), истекшая запись в льготный период отдается на время перезагрузки
+	now := time.Now().UnixNano()
+	if envelope.DieAt > 0 && now > envelope.DieAt {
+		if cm.refresher == nil || !cm.refresher.stale(key, envelope.DieAt, now) {
+			cm.storage.Delete(key)
+			cm.stats.Expired()
+			cm.stats.Miss()
+
+			return nil, false, nil
+		}
+	} else if cm.refresher != nil {
+		cm.refresher.ahead(key, envelope.DieAt, now)
+	}
+	cm.stats.Hit()
+
+	return envelope, true, nil
+}
+
+// GetOrLoad получение значения с загрузкой при промахе. Одновременные загрузки одного ключа
+// выполняются один раз, ошибки загрузчика возвращаются как есть и не кэшируются.
+// Негативный результат (found=false) кэшируется на negativeTTL, если он задан, явным признаком Envelope.Nil
+// (см. NilMarshaler), без поддержки кодеком - пустым значением, только для V, допускающих nil.
+// Если значение загружено, но не сохранено в кэш, возвращается значение и *errs.DalCacheError.
+func (cm *Manager[K, V]) GetOrLoad(ctx context.Context, key K, loader Loader[K, V], ttl time.Duration) (V, bool, error) {
+	if res, ok, hit := cm.getLoaded(key); hit {
+		return res, ok, nil
+	}
+
+	res, found, _, err := cm.loads.Do(ctx, key, func() (V, bool, error) {
+		// значение могло быть загружено, пока ожидали очередь
+		if res, ok, hit := cm.getLoaded(key); hit {
+			return res, ok, nil
+		}
+		res, found, err := loader(ctx, key)
+		if err != nil {
+			return cm.nilValue, false, err
+		}
+		if !found {
+			if cm.negativeTTL > 0 {
+				if err := cm.setNegative("Manager.GetOrLoad", key, cm.negativeTTL); err != nil {
+					return cm.nilValue, false, errs.NewDalCacheError("Manager.GetOrLoad", "store negative result", err)
+				}
+			}
+
+			return cm.nilValue, false, nil
+		}
+		if err := cm.Set(key, res, ttl); err != nil {
+			return res, true, errs.NewDalCacheError("Manager.GetOrLoad", "store loaded value", err)
+		}
+
+		return res, true, nil
+	})
+
+	return res, found, err
+}
+
+// getLoaded чтение для GetOrLoad, hit=false - промах (или неразборчивое значение, которое удаляется)
+func (cm *Manager[K, V]) getLoaded(key K) (res V, found bool, hit bool) {
+	envelope, ok, err := cm.getEnvelope(key)
+	if err != nil {
+		cm.storage.Delete(key)
+
+		return cm.nilValue, false, false
+	}
+	if !ok {
+		return cm.nilValue, false, false
+	}
+	if cm.negative(envelope) {
+		return cm.nilValue, false, true
+	}
+
+	return envelope.Value, true, true
+}
+
+// negative конверт негативного результата: явный признак или пустое значение при кэшировании негативных результатов
+func (cm *Manager[K, V]) negative(envelope *Envelope[V]) bool {
+	return envelope.Nil || (cm.negativeTTL > 0 && utils.IsNil(envelope.Value))
+}
+
+// getRaw чтение упакованного значения, просрочка по индексу хранилища отсекается без распаковки
+func (cm *Manager[K, V]) getRaw(key K) ([]byte, bool) {
+	if cm.expiring == nil {
+		return cm.storage.Get(key)
+	}
+	buf, dieAt, ok := cm.expiring.GetWithExpiry(key)
+	if !ok {
+		return nil, false
+	}
+	if dieAt > 0 && time.Now().UnixNano() > dieAt {
 		cm.storage.Delete(key)
-		return cm.nilValue, false, nil
+		cm.stats.Expired()
+
+		return nil, false
 	}
 
-	return envelope.Value, true, nil
+	return buf, true
 }
 
 func (cm *Manager[K, V]) Set(key K, value V, ttl time.Duration) error {
+	return cm.set("Manager.Set", key, value, ttl, nil)
+}
+
+// SetWithTags сохранение с тегами для групповой инвалидации (InvalidateTag), заменяет прежние теги ключа.
+// Обычная перезапись ключа (Set, фоновое обновление) теги сохраняет.
+func (cm *Manager[K, V]) SetWithTags(key K, value V, ttl time.Duration, tags ...string) error {
+	if cm.tagged == nil {
+		return errs.NewDalCacheError("Manager.SetWithTags", "cache storage does not support tags", nil)
+	}
+
+	if tags == nil {
+		// пустой список тегов снимает прежние теги ключа
+		tags = []string{}
+	}
+
+	return cm.set("Manager.SetWithTags", key, value, ttl, tags)
+}
+
+// InvalidateTag удаление всех значений с тегом, возвращает кол-во удаленных значений
+func (cm *Manager[K, V]) InvalidateTag(tag string) (int, error) {
+	if cm.tagged == nil {
+		return 0, errs.NewDalCacheError("Manager.InvalidateTag", "cache storage does not support tags", nil)
+	}
+
+	return cm.tagged.RemoveTag(tag), nil
+}
+
+// InvalidatePrefix удаление всех значений, ключ которых начинается с prefix (только для строковых ключей)
+func (cm *Manager[K, V]) InvalidatePrefix(prefix string) (int, error) {
+	var key K
+	if _, ok := any(key).(string); !ok {
+		return 0, errs.NewDalCacheError("Manager.InvalidatePrefix", fmt.Sprintf("cache key type [%T] is not string", key), nil)
+	}
+	if cm.tagged == nil {
+		return 0, errs.NewDalCacheError("Manager.InvalidatePrefix", "cache storage does not support prefix invalidation", nil)
+	}
+
+	return cm.tagged.RemovePrefix(prefix), nil
+}
+
+// set запись значения, tags != nil - запись с заменой тегов
+func (cm *Manager[K, V]) set(op string, key K, value V, ttl time.Duration, tags []string) error {
 	buf, err := cm.codec.Marshal(value, ttl)
 	if err != nil {
 		return errs.NewCommonError("marshal failed", err)
 	}
 
-	cm.storage.Set(key, buf)
+	return cm.store(op, key, buf, ttl, tags)
+}
+
+// setNegative сохранение негативного результата на ttl: явный признак Envelope.Nil, если кодек его поддерживает,
+// иначе пустое значение (только для V, допускающих nil, иначе его не отличить от найденного нулевого значения)
+func (cm *Manager[K, V]) setNegative(op string, key K, ttl time.Duration) error {
+	var buf []byte
+	var err error
+	switch marshaler, ok := cm.codec.(NilMarshaler); {
+	case ok:
+		buf, err = marshaler.MarshalNil(ttl)
+	case utils.IsNil(cm.nilValue):
+		buf, err = cm.codec.Marshal(cm.nilValue, ttl)
+	default:
+		return errs.NewDalCacheError(op, "codec does not support negative results for non-nillable value", nil)
+	}
+	if err != nil {
+		return errs.NewCommonError("marshal failed", err)
+	}
+
+	return cm.store(op, key, buf, ttl, nil)
+}
+
+func (cm *Manager[K, V]) store(op string, key K, buf []byte, ttl time.Duration, tags []string) error {
+	var dieAt int64
+	if ttl > 0 {
+		dieAt = time.Now().Add(ttl).UnixNano()
+	}
+	if budgeter, ok := cm.storage.(ByteBudgeter); ok {
+		if limit := budgeter.MaxItemBytes(); limit > 0 && int64(len(buf)) > limit {
+			// прежнее значение ключа устарело
+			cm.storage.Delete(key)
+
+			return errs.NewDalCacheError(op, fmt.Sprintf("value size [%d] exceeds item limit [%d] bytes", len(buf), limit), nil)
+		}
+	}
+
+	switch {
+	case tags != nil && cm.checked != nil:
+		if err := cm.checked.SetWithTagsChecked(key, buf, cm.storageDieAt(dieAt), tags); err != nil {
+			return errs.NewDalCacheError(op, "store value with tags", err)
+		}
+	case tags != nil:
+		cm.tagged.SetWithTags(key, buf, cm.storageDieAt(dieAt), tags)
+	case cm.expiring != nil && cm.checked != nil:
+		if err := cm.checked.SetWithExpiryChecked(key, buf, cm.storageDieAt(dieAt)); err != nil {
+			return errs.NewDalCacheError(op, "store value", err)
+		}
+	case cm.expiring != nil:
+		cm.expiring.SetWithExpiry(key, buf, cm.storageDieAt(dieAt))
+	default:
+		cm.storage.Set(key, buf)
+	}
+	cm.stats.Set()
 
 	return nil
 }
 
+// storageDieAt момент удаления записи из хранилища: истечение плюс льготный период stale-while-revalidate
+func (cm *Manager[K, V]) storageDieAt(dieAt int64) int64 {
+	if dieAt <= 0 || cm.refresher == nil {
+		return dieAt
+	}
+
+	return dieAt + cm.refresher.grace.Nanoseconds()
+}
+
 func (cm *Manager[K, V]) Delete(key K) {
 	cm.storage.Delete(key)
+	cm.stats.Delete()
+}
+
+// GetNegativeTTL время жизни негативного результата, 0 - не кэшируется
+func (cm *Manager[K, V]) GetNegativeTTL() time.Duration {
+	return cm.negativeTTL
+}
+
+// RangeKeys обход ключей хранилища (без чтения значений, если хранилище это поддерживает), вызывать методы кэша из fn нельзя
+func (cm *Manager[K, V]) RangeKeys(fn func(key K) bool) {
+	if ranger, ok := cm.storage.(KeyRanger[K]); ok {
+		ranger.RangeKeys(fn)
+
+		return
+	}
+	cm.storage.Range(func(key K, _ []byte) bool {
+		return fn(key)
+	})
 }
 
 func (cm *Manager[K, V]) Size() int {
@@ -75,8 +369,24 @@ func (cm *Manager[K, V]) Clear() {
 	cm.storage.Clear()
 }
 
-// CacheJanitor вызывается планировщиком для периодической очистки просрочки
-func (cm *Manager[K, V]) CacheJanitor(ctx context.Context, eventTime time.Time) error {
+// CacheJanitor вызывается планировщиком для периодической очистки просрочки.
+// Для хранилищ с индексом просрочки удаляются только истекшие ключи без распаковки значений,
+// иначе хранилище обходится целиком с распаковкой конвертов.
+func (cm *Manager[K, V]) CacheJanitor(ctx context.Context, eventTime time.Time) (err error) {
+	var removed int
+	defer func(start time.Time) {
+		cm.stats.Janitor(removed, err, start)
+	}(time.Now())
+
+	if cm.expiring != nil {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		removed = cm.expiring.RemoveExpired(eventTime.UnixNano(), cm.janitorMaxSize)
+
+		return nil
+	}
+
 	now := eventTime.UnixNano()
 	var expiredKeys []K
 	if cm.janitorMaxSize > 0 {
@@ -96,10 +406,10 @@ func (cm *Manager[K, V]) CacheJanitor(ctx context.Context, eventTime time.Time)
 		if err := ctx.Err(); err != nil {
 			return false
 		}
-		// unmarshal
-		env, err := cm.codec.Unmarshal(b)
+		// expiry
+		dieAt, err := decodeExpiry(cm.codec, b)
 		// check for removal and add into removal list
-		if err == nil && env != nil && env.DieAt > 0 && now > env.DieAt {
+		if err == nil && dieAt > 0 && now > cm.storageDieAt(dieAt) {
 			expiredKeys = append(expiredKeys, key)
 			janitorCount++
 		}
@@ -119,6 +429,7 @@ func (cm *Manager[K, V]) CacheJanitor(ctx context.Context, eventTime time.Time)
 			return ctx.Err()
 		default:
 			cm.storage.Delete(k)
+			removed++
 		}
 	}
 
@@ -0,0 +1,361 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/infra/cache"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockAdminCache creates a new instance of MockAdminCache. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAdminCache(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAdminCache {
+	mock := &MockAdminCache{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockAdminCache is an autogenerated mock type for the AdminCache type
+type MockAdminCache struct {
+	mock.Mock
+}
+
+type MockAdminCache_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAdminCache) EXPECT() *MockAdminCache_Expecter {
+	return &MockAdminCache_Expecter{mock: &_m.Mock}
+}
+
+// Clear provides a mock function for the type MockAdminCache
+func (_mock *MockAdminCache) Clear() {
+	_mock.Called()
+	return
+}
+
+// MockAdminCache_Clear_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Clear'
+type MockAdminCache_Clear_Call struct {
+	*mock.Call
+}
+
+// Clear is a helper method to define mock.On call
+func (_e *MockAdminCache_Expecter) Clear() *MockAdminCache_Clear_Call {
+	return &MockAdminCache_Clear_Call{Call: _e.mock.On("Clear")}
+}
+
+func (_c *MockAdminCache_Clear_Call) Run(run func()) *MockAdminCache_Clear_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockAdminCache_Clear_Call) Return() *MockAdminCache_Clear_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockAdminCache_Clear_Call) RunAndReturn(run func()) *MockAdminCache_Clear_Call {
+	_c.Run(run)
+	return _c
+}
+
+// Delete provides a mock function for the type MockAdminCache
+func (_mock *MockAdminCache) Delete(key string) error {
+	ret := _mock.Called(key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string) error); ok {
+		r0 = returnFunc(key)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAdminCache_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockAdminCache_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - key string
+func (_e *MockAdminCache_Expecter) Delete(key any) *MockAdminCache_Delete_Call {
+	return &MockAdminCache_Delete_Call{Call: _e.mock.On("Delete", key)}
+}
+
+func (_c *MockAdminCache_Delete_Call) Run(run func(key string)) *MockAdminCache_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAdminCache_Delete_Call) Return(err error) *MockAdminCache_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAdminCache_Delete_Call) RunAndReturn(run func(key string) error) *MockAdminCache_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetJSON provides a mock function for the type MockAdminCache
+func (_mock *MockAdminCache) GetJSON(key string) (json.RawMessage, bool, error) {
+	ret := _mock.Called(key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetJSON")
+	}
+
+	var r0 json.RawMessage
+	var r1 bool
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(string) (json.RawMessage, bool, error)); ok {
+		return returnFunc(key)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) json.RawMessage); ok {
+		r0 = returnFunc(key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(json.RawMessage)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) bool); ok {
+		r1 = returnFunc(key)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+	if returnFunc, ok := ret.Get(2).(func(string) error); ok {
+		r2 = returnFunc(key)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockAdminCache_GetJSON_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetJSON'
+type MockAdminCache_GetJSON_Call struct {
+	*mock.Call
+}
+
+// GetJSON is a helper method to define mock.On call
+//   - key string
+func (_e *MockAdminCache_Expecter) GetJSON(key any) *MockAdminCache_GetJSON_Call {
+	return &MockAdminCache_GetJSON_Call{Call: _e.mock.On("GetJSON", key)}
+}
+
+func (_c *MockAdminCache_GetJSON_Call) Run(run func(key string)) *MockAdminCache_GetJSON_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAdminCache_GetJSON_Call) Return(v json.RawMessage, b bool, err error) *MockAdminCache_GetJSON_Call {
+	_c.Call.Return(v, b, err)
+	return _c
+}
+
+func (_c *MockAdminCache_GetJSON_Call) RunAndReturn(run func(key string) (json.RawMessage, bool, error)) *MockAdminCache_GetJSON_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Info provides a mock function for the type MockAdminCache
+func (_mock *MockAdminCache) Info() cache.CacheInfo {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Info")
+	}
+
+	var r0 cache.CacheInfo
+	if returnFunc, ok := ret.Get(0).(func() cache.CacheInfo); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(cache.CacheInfo)
+	}
+	return r0
+}
+
+// MockAdminCache_Info_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Info'
+type MockAdminCache_Info_Call struct {
+	*mock.Call
+}
+
+// Info is a helper method to define mock.On call
+func (_e *MockAdminCache_Expecter) Info() *MockAdminCache_Info_Call {
+	return &MockAdminCache_Info_Call{Call: _e.mock.On("Info")}
+}
+
+func (_c *MockAdminCache_Info_Call) Run(run func()) *MockAdminCache_Info_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockAdminCache_Info_Call) Return(cacheInfo cache.CacheInfo) *MockAdminCache_Info_Call {
+	_c.Call.Return(cacheInfo)
+	return _c
+}
+
+func (_c *MockAdminCache_Info_Call) RunAndReturn(run func() cache.CacheInfo) *MockAdminCache_Info_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Janitor provides a mock function for the type MockAdminCache
+func (_mock *MockAdminCache) Janitor(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Janitor")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockAdminCache_Janitor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Janitor'
+type MockAdminCache_Janitor_Call struct {
+	*mock.Call
+}
+
+// Janitor is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockAdminCache_Expecter) Janitor(ctx any) *MockAdminCache_Janitor_Call {
+	return &MockAdminCache_Janitor_Call{Call: _e.mock.On("Janitor", ctx)}
+}
+
+func (_c *MockAdminCache_Janitor_Call) Run(run func(ctx context.Context)) *MockAdminCache_Janitor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAdminCache_Janitor_Call) Return(err error) *MockAdminCache_Janitor_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockAdminCache_Janitor_Call) RunAndReturn(run func(ctx context.Context) error) *MockAdminCache_Janitor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Keys provides a mock function for the type MockAdminCache
+func (_mock *MockAdminCache) Keys(prefix string, offset int, limit int) (*cache.KeyPage, error) {
+	ret := _mock.Called(prefix, offset, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Keys")
+	}
+
+	var r0 *cache.KeyPage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, int, int) (*cache.KeyPage, error)); ok {
+		return returnFunc(prefix, offset, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, int, int) *cache.KeyPage); ok {
+		r0 = returnFunc(prefix, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*cache.KeyPage)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, int, int) error); ok {
+		r1 = returnFunc(prefix, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAdminCache_Keys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Keys'
+type MockAdminCache_Keys_Call struct {
+	*mock.Call
+}
+
+// Keys is a helper method to define mock.On call
+//   - prefix string
+//   - offset int
+//   - limit int
+func (_e *MockAdminCache_Expecter) Keys(prefix any, offset any, limit any) *MockAdminCache_Keys_Call {
+	return &MockAdminCache_Keys_Call{Call: _e.mock.On("Keys", prefix, offset, limit)}
+}
+
+func (_c *MockAdminCache_Keys_Call) Run(run func(prefix string, offset int, limit int)) *MockAdminCache_Keys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAdminCache_Keys_Call) Return(keyPage *cache.KeyPage, err error) *MockAdminCache_Keys_Call {
+	_c.Call.Return(keyPage, err)
+	return _c
+}
+
+func (_c *MockAdminCache_Keys_Call) RunAndReturn(run func(prefix string, offset int, limit int) (*cache.KeyPage, error)) *MockAdminCache_Keys_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,142 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockBinaryValue creates a new instance of MockBinaryValue. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockBinaryValue(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockBinaryValue {
+	mock := &MockBinaryValue{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockBinaryValue is an autogenerated mock type for the BinaryValue type
+type MockBinaryValue struct {
+	mock.Mock
+}
+
+type MockBinaryValue_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockBinaryValue) EXPECT() *MockBinaryValue_Expecter {
+	return &MockBinaryValue_Expecter{mock: &_m.Mock}
+}
+
+// MarshalBinary provides a mock function for the type MockBinaryValue
+func (_mock *MockBinaryValue) MarshalBinary() ([]byte, error) {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarshalBinary")
+	}
+
+	var r0 []byte
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func() ([]byte, error)); ok {
+		return returnFunc()
+	}
+	if returnFunc, ok := ret.Get(0).(func() []byte); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func() error); ok {
+		r1 = returnFunc()
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockBinaryValue_MarshalBinary_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarshalBinary'
+type MockBinaryValue_MarshalBinary_Call struct {
+	*mock.Call
+}
+
+// MarshalBinary is a helper method to define mock.On call
+func (_e *MockBinaryValue_Expecter) MarshalBinary() *MockBinaryValue_MarshalBinary_Call {
+	return &MockBinaryValue_MarshalBinary_Call{Call: _e.mock.On("MarshalBinary")}
+}
+
+func (_c *MockBinaryValue_MarshalBinary_Call) Run(run func()) *MockBinaryValue_MarshalBinary_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockBinaryValue_MarshalBinary_Call) Return(data []byte, err error) *MockBinaryValue_MarshalBinary_Call {
+	_c.Call.Return(data, err)
+	return _c
+}
+
+func (_c *MockBinaryValue_MarshalBinary_Call) RunAndReturn(run func() ([]byte, error)) *MockBinaryValue_MarshalBinary_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UnmarshalBinary provides a mock function for the type MockBinaryValue
+func (_mock *MockBinaryValue) UnmarshalBinary(data []byte) error {
+	ret := _mock.Called(data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnmarshalBinary")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func([]byte) error); ok {
+		r0 = returnFunc(data)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockBinaryValue_UnmarshalBinary_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnmarshalBinary'
+type MockBinaryValue_UnmarshalBinary_Call struct {
+	*mock.Call
+}
+
+// UnmarshalBinary is a helper method to define mock.On call
+//   - data []byte
+func (_e *MockBinaryValue_Expecter) UnmarshalBinary(data any) *MockBinaryValue_UnmarshalBinary_Call {
+	return &MockBinaryValue_UnmarshalBinary_Call{Call: _e.mock.On("UnmarshalBinary", data)}
+}
+
+func (_c *MockBinaryValue_UnmarshalBinary_Call) Run(run func(data []byte)) *MockBinaryValue_UnmarshalBinary_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 []byte
+		if args[0] != nil {
+			arg0 = args[0].([]byte)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBinaryValue_UnmarshalBinary_Call) Return(err error) *MockBinaryValue_UnmarshalBinary_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockBinaryValue_UnmarshalBinary_Call) RunAndReturn(run func(data []byte) error) *MockBinaryValue_UnmarshalBinary_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,170 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockByteBudgeter creates a new instance of MockByteBudgeter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockByteBudgeter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockByteBudgeter {
+	mock := &MockByteBudgeter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockByteBudgeter is an autogenerated mock type for the ByteBudgeter type
+type MockByteBudgeter struct {
+	mock.Mock
+}
+
+type MockByteBudgeter_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockByteBudgeter) EXPECT() *MockByteBudgeter_Expecter {
+	return &MockByteBudgeter_Expecter{mock: &_m.Mock}
+}
+
+// Bytes provides a mock function for the type MockByteBudgeter
+func (_mock *MockByteBudgeter) Bytes() int64 {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Bytes")
+	}
+
+	var r0 int64
+	if returnFunc, ok := ret.Get(0).(func() int64); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	return r0
+}
+
+// MockByteBudgeter_Bytes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Bytes'
+type MockByteBudgeter_Bytes_Call struct {
+	*mock.Call
+}
+
+// Bytes is a helper method to define mock.On call
+func (_e *MockByteBudgeter_Expecter) Bytes() *MockByteBudgeter_Bytes_Call {
+	return &MockByteBudgeter_Bytes_Call{Call: _e.mock.On("Bytes")}
+}
+
+func (_c *MockByteBudgeter_Bytes_Call) Run(run func()) *MockByteBudgeter_Bytes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockByteBudgeter_Bytes_Call) Return(n int64) *MockByteBudgeter_Bytes_Call {
+	_c.Call.Return(n)
+	return _c
+}
+
+func (_c *MockByteBudgeter_Bytes_Call) RunAndReturn(run func() int64) *MockByteBudgeter_Bytes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MaxItemBytes provides a mock function for the type MockByteBudgeter
+func (_mock *MockByteBudgeter) MaxItemBytes() int64 {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for MaxItemBytes")
+	}
+
+	var r0 int64
+	if returnFunc, ok := ret.Get(0).(func() int64); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	return r0
+}
+
+// MockByteBudgeter_MaxItemBytes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MaxItemBytes'
+type MockByteBudgeter_MaxItemBytes_Call struct {
+	*mock.Call
+}
+
+// MaxItemBytes is a helper method to define mock.On call
+func (_e *MockByteBudgeter_Expecter) MaxItemBytes() *MockByteBudgeter_MaxItemBytes_Call {
+	return &MockByteBudgeter_MaxItemBytes_Call{Call: _e.mock.On("MaxItemBytes")}
+}
+
+func (_c *MockByteBudgeter_MaxItemBytes_Call) Run(run func()) *MockByteBudgeter_MaxItemBytes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockByteBudgeter_MaxItemBytes_Call) Return(n int64) *MockByteBudgeter_MaxItemBytes_Call {
+	_c.Call.Return(n)
+	return _c
+}
+
+func (_c *MockByteBudgeter_MaxItemBytes_Call) RunAndReturn(run func() int64) *MockByteBudgeter_MaxItemBytes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetByteBudget provides a mock function for the type MockByteBudgeter
+func (_mock *MockByteBudgeter) SetByteBudget(maxBytes int64, maxItemBytes int64) {
+	_mock.Called(maxBytes, maxItemBytes)
+	return
+}
+
+// MockByteBudgeter_SetByteBudget_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetByteBudget'
+type MockByteBudgeter_SetByteBudget_Call struct {
+	*mock.Call
+}
+
+// SetByteBudget is a helper method to define mock.On call
+//   - maxBytes int64
+//   - maxItemBytes int64
+func (_e *MockByteBudgeter_Expecter) SetByteBudget(maxBytes any, maxItemBytes any) *MockByteBudgeter_SetByteBudget_Call {
+	return &MockByteBudgeter_SetByteBudget_Call{Call: _e.mock.On("SetByteBudget", maxBytes, maxItemBytes)}
+}
+
+func (_c *MockByteBudgeter_SetByteBudget_Call) Run(run func(maxBytes int64, maxItemBytes int64)) *MockByteBudgeter_SetByteBudget_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int64
+		if args[0] != nil {
+			arg0 = args[0].(int64)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockByteBudgeter_SetByteBudget_Call) Return() *MockByteBudgeter_SetByteBudget_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockByteBudgeter_SetByteBudget_Call) RunAndReturn(run func(maxBytes int64, maxItemBytes int64)) *MockByteBudgeter_SetByteBudget_Call {
+	_c.Run(run)
+	return _c
+}
@@ -0,0 +1,80 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockByteSizer creates a new instance of MockByteSizer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockByteSizer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockByteSizer {
+	mock := &MockByteSizer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockByteSizer is an autogenerated mock type for the ByteSizer type
+type MockByteSizer struct {
+	mock.Mock
+}
+
+type MockByteSizer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockByteSizer) EXPECT() *MockByteSizer_Expecter {
+	return &MockByteSizer_Expecter{mock: &_m.Mock}
+}
+
+// Bytes provides a mock function for the type MockByteSizer
+func (_mock *MockByteSizer) Bytes() int64 {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Bytes")
+	}
+
+	var r0 int64
+	if returnFunc, ok := ret.Get(0).(func() int64); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	return r0
+}
+
+// MockByteSizer_Bytes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Bytes'
+type MockByteSizer_Bytes_Call struct {
+	*mock.Call
+}
+
+// Bytes is a helper method to define mock.On call
+func (_e *MockByteSizer_Expecter) Bytes() *MockByteSizer_Bytes_Call {
+	return &MockByteSizer_Bytes_Call{Call: _e.mock.On("Bytes")}
+}
+
+func (_c *MockByteSizer_Bytes_Call) Run(run func()) *MockByteSizer_Bytes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockByteSizer_Bytes_Call) Return(n int64) *MockByteSizer_Bytes_Call {
+	_c.Call.Return(n)
+	return _c
+}
+
+func (_c *MockByteSizer_Bytes_Call) RunAndReturn(run func() int64) *MockByteSizer_Bytes_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -8,6 +8,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/ElfAstAhe/go-service-template/pkg/infra/cache"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -236,6 +237,212 @@ func (_c *MockCache_Get_Call[K, V]) RunAndReturn(run func(key K) (V, bool, error
 	return _c
 }
 
+// GetOrLoad provides a mock function for the type MockCache
+func (_mock *MockCache[K, V]) GetOrLoad(ctx context.Context, key K, loader cache.Loader[K, V], ttl time.Duration) (V, bool, error) {
+	ret := _mock.Called(ctx, key, loader, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOrLoad")
+	}
+
+	var r0 V
+	var r1 bool
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, K, cache.Loader[K, V], time.Duration) (V, bool, error)); ok {
+		return returnFunc(ctx, key, loader, ttl)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, K, cache.Loader[K, V], time.Duration) V); ok {
+		r0 = returnFunc(ctx, key, loader, ttl)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(V)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, K, cache.Loader[K, V], time.Duration) bool); ok {
+		r1 = returnFunc(ctx, key, loader, ttl)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, K, cache.Loader[K, V], time.Duration) error); ok {
+		r2 = returnFunc(ctx, key, loader, ttl)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockCache_GetOrLoad_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOrLoad'
+type MockCache_GetOrLoad_Call[K comparable, V any] struct {
+	*mock.Call
+}
+
+// GetOrLoad is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key K
+//   - loader cache.Loader[K, V]
+//   - ttl time.Duration
+func (_e *MockCache_Expecter[K, V]) GetOrLoad(ctx any, key any, loader any, ttl any) *MockCache_GetOrLoad_Call[K, V] {
+	return &MockCache_GetOrLoad_Call[K, V]{Call: _e.mock.On("GetOrLoad", ctx, key, loader, ttl)}
+}
+
+func (_c *MockCache_GetOrLoad_Call[K, V]) Run(run func(ctx context.Context, key K, loader cache.Loader[K, V], ttl time.Duration)) *MockCache_GetOrLoad_Call[K, V] {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 K
+		if args[1] != nil {
+			arg1 = args[1].(K)
+		}
+		var arg2 cache.Loader[K, V]
+		if args[2] != nil {
+			arg2 = args[2].(cache.Loader[K, V])
+		}
+		var arg3 time.Duration
+		if args[3] != nil {
+			arg3 = args[3].(time.Duration)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCache_GetOrLoad_Call[K, V]) Return(v V, b bool, err error) *MockCache_GetOrLoad_Call[K, V] {
+	_c.Call.Return(v, b, err)
+	return _c
+}
+
+func (_c *MockCache_GetOrLoad_Call[K, V]) RunAndReturn(run func(ctx context.Context, key K, loader cache.Loader[K, V], ttl time.Duration) (V, bool, error)) *MockCache_GetOrLoad_Call[K, V] {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InvalidatePrefix provides a mock function for the type MockCache
+func (_mock *MockCache[K, V]) InvalidatePrefix(prefix string) (int, error) {
+	ret := _mock.Called(prefix)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InvalidatePrefix")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) (int, error)); ok {
+		return returnFunc(prefix)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) int); ok {
+		r0 = returnFunc(prefix)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(prefix)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCache_InvalidatePrefix_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InvalidatePrefix'
+type MockCache_InvalidatePrefix_Call[K comparable, V any] struct {
+	*mock.Call
+}
+
+// InvalidatePrefix is a helper method to define mock.On call
+//   - prefix string
+func (_e *MockCache_Expecter[K, V]) InvalidatePrefix(prefix any) *MockCache_InvalidatePrefix_Call[K, V] {
+	return &MockCache_InvalidatePrefix_Call[K, V]{Call: _e.mock.On("InvalidatePrefix", prefix)}
+}
+
+func (_c *MockCache_InvalidatePrefix_Call[K, V]) Run(run func(prefix string)) *MockCache_InvalidatePrefix_Call[K, V] {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCache_InvalidatePrefix_Call[K, V]) Return(n int, err error) *MockCache_InvalidatePrefix_Call[K, V] {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockCache_InvalidatePrefix_Call[K, V]) RunAndReturn(run func(prefix string) (int, error)) *MockCache_InvalidatePrefix_Call[K, V] {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InvalidateTag provides a mock function for the type MockCache
+func (_mock *MockCache[K, V]) InvalidateTag(tag string) (int, error) {
+	ret := _mock.Called(tag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InvalidateTag")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) (int, error)); ok {
+		return returnFunc(tag)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) int); ok {
+		r0 = returnFunc(tag)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(tag)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCache_InvalidateTag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InvalidateTag'
+type MockCache_InvalidateTag_Call[K comparable, V any] struct {
+	*mock.Call
+}
+
+// InvalidateTag is a helper method to define mock.On call
+//   - tag string
+func (_e *MockCache_Expecter[K, V]) InvalidateTag(tag any) *MockCache_InvalidateTag_Call[K, V] {
+	return &MockCache_InvalidateTag_Call[K, V]{Call: _e.mock.On("InvalidateTag", tag)}
+}
+
+func (_c *MockCache_InvalidateTag_Call[K, V]) Run(run func(tag string)) *MockCache_InvalidateTag_Call[K, V] {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCache_InvalidateTag_Call[K, V]) Return(n int, err error) *MockCache_InvalidateTag_Call[K, V] {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockCache_InvalidateTag_Call[K, V]) RunAndReturn(run func(tag string) (int, error)) *MockCache_InvalidateTag_Call[K, V] {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Set provides a mock function for the type MockCache
 func (_mock *MockCache[K, V]) Set(key K, value V, ttl time.Duration) error {
 	ret := _mock.Called(key, value, ttl)
@@ -299,6 +506,84 @@ func (_c *MockCache_Set_Call[K, V]) RunAndReturn(run func(key K, value V, ttl ti
 	return _c
 }
 
+// SetWithTags provides a mock function for the type MockCache
+func (_mock *MockCache[K, V]) SetWithTags(key K, value V, ttl time.Duration, tags ...string) error {
+	var tmpRet mock.Arguments
+	if len(tags) > 0 {
+		tmpRet = _mock.Called(key, value, ttl, tags)
+	} else {
+		tmpRet = _mock.Called(key, value, ttl)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetWithTags")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(K, V, time.Duration, ...string) error); ok {
+		r0 = returnFunc(key, value, ttl, tags...)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockCache_SetWithTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetWithTags'
+type MockCache_SetWithTags_Call[K comparable, V any] struct {
+	*mock.Call
+}
+
+// SetWithTags is a helper method to define mock.On call
+//   - key K
+//   - value V
+//   - ttl time.Duration
+//   - tags ...string
+func (_e *MockCache_Expecter[K, V]) SetWithTags(key any, value any, ttl any, tags ...any) *MockCache_SetWithTags_Call[K, V] {
+	return &MockCache_SetWithTags_Call[K, V]{Call: _e.mock.On("SetWithTags",
+		append([]any{key, value, ttl}, tags...)...)}
+}
+
+func (_c *MockCache_SetWithTags_Call[K, V]) Run(run func(key K, value V, ttl time.Duration, tags ...string)) *MockCache_SetWithTags_Call[K, V] {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 K
+		if args[0] != nil {
+			arg0 = args[0].(K)
+		}
+		var arg1 V
+		if args[1] != nil {
+			arg1 = args[1].(V)
+		}
+		var arg2 time.Duration
+		if args[2] != nil {
+			arg2 = args[2].(time.Duration)
+		}
+		var arg3 []string
+		var variadicArgs []string
+		if len(args) > 3 {
+			variadicArgs = args[3].([]string)
+		}
+		arg3 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCache_SetWithTags_Call[K, V]) Return(err error) *MockCache_SetWithTags_Call[K, V] {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockCache_SetWithTags_Call[K, V]) RunAndReturn(run func(key K, value V, ttl time.Duration, tags ...string) error) *MockCache_SetWithTags_Call[K, V] {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Size provides a mock function for the type MockCache
 func (_mock *MockCache[K, V]) Size() int {
 	ret := _mock.Called()
@@ -0,0 +1,168 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockCheckedWriter creates a new instance of MockCheckedWriter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCheckedWriter[K comparable](t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCheckedWriter[K] {
+	mock := &MockCheckedWriter[K]{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockCheckedWriter is an autogenerated mock type for the CheckedWriter type
+type MockCheckedWriter[K comparable] struct {
+	mock.Mock
+}
+
+type MockCheckedWriter_Expecter[K comparable] struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCheckedWriter[K]) EXPECT() *MockCheckedWriter_Expecter[K] {
+	return &MockCheckedWriter_Expecter[K]{mock: &_m.Mock}
+}
+
+// SetWithExpiryChecked provides a mock function for the type MockCheckedWriter
+func (_mock *MockCheckedWriter[K]) SetWithExpiryChecked(key K, b []byte, dieAt int64) error {
+	ret := _mock.Called(key, b, dieAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetWithExpiryChecked")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(K, []byte, int64) error); ok {
+		r0 = returnFunc(key, b, dieAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockCheckedWriter_SetWithExpiryChecked_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetWithExpiryChecked'
+type MockCheckedWriter_SetWithExpiryChecked_Call[K comparable] struct {
+	*mock.Call
+}
+
+// SetWithExpiryChecked is a helper method to define mock.On call
+//   - key K
+//   - b []byte
+//   - dieAt int64
+func (_e *MockCheckedWriter_Expecter[K]) SetWithExpiryChecked(key any, b any, dieAt any) *MockCheckedWriter_SetWithExpiryChecked_Call[K] {
+	return &MockCheckedWriter_SetWithExpiryChecked_Call[K]{Call: _e.mock.On("SetWithExpiryChecked", key, b, dieAt)}
+}
+
+func (_c *MockCheckedWriter_SetWithExpiryChecked_Call[K]) Run(run func(key K, b []byte, dieAt int64)) *MockCheckedWriter_SetWithExpiryChecked_Call[K] {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 K
+		if args[0] != nil {
+			arg0 = args[0].(K)
+		}
+		var arg1 []byte
+		if args[1] != nil {
+			arg1 = args[1].([]byte)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCheckedWriter_SetWithExpiryChecked_Call[K]) Return(err error) *MockCheckedWriter_SetWithExpiryChecked_Call[K] {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockCheckedWriter_SetWithExpiryChecked_Call[K]) RunAndReturn(run func(key K, b []byte, dieAt int64) error) *MockCheckedWriter_SetWithExpiryChecked_Call[K] {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetWithTagsChecked provides a mock function for the type MockCheckedWriter
+func (_mock *MockCheckedWriter[K]) SetWithTagsChecked(key K, b []byte, dieAt int64, tags []string) error {
+	ret := _mock.Called(key, b, dieAt, tags)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetWithTagsChecked")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(K, []byte, int64, []string) error); ok {
+		r0 = returnFunc(key, b, dieAt, tags)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockCheckedWriter_SetWithTagsChecked_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetWithTagsChecked'
+type MockCheckedWriter_SetWithTagsChecked_Call[K comparable] struct {
+	*mock.Call
+}
+
+// SetWithTagsChecked is a helper method to define mock.On call
+//   - key K
+//   - b []byte
+//   - dieAt int64
+//   - tags []string
+func (_e *MockCheckedWriter_Expecter[K]) SetWithTagsChecked(key any, b any, dieAt any, tags any) *MockCheckedWriter_SetWithTagsChecked_Call[K] {
+	return &MockCheckedWriter_SetWithTagsChecked_Call[K]{Call: _e.mock.On("SetWithTagsChecked", key, b, dieAt, tags)}
+}
+
+func (_c *MockCheckedWriter_SetWithTagsChecked_Call[K]) Run(run func(key K, b []byte, dieAt int64, tags []string)) *MockCheckedWriter_SetWithTagsChecked_Call[K] {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 K
+		if args[0] != nil {
+			arg0 = args[0].(K)
+		}
+		var arg1 []byte
+		if args[1] != nil {
+			arg1 = args[1].([]byte)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		var arg3 []string
+		if args[3] != nil {
+			arg3 = args[3].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCheckedWriter_SetWithTagsChecked_Call[K]) Return(err error) *MockCheckedWriter_SetWithTagsChecked_Call[K] {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockCheckedWriter_SetWithTagsChecked_Call[K]) RunAndReturn(run func(key K, b []byte, dieAt int64, tags []string) error) *MockCheckedWriter_SetWithTagsChecked_Call[K] {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,77 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"github.com/ElfAstAhe/go-service-template/pkg/infra/cache"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockEvictionReporter creates a new instance of MockEvictionReporter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockEvictionReporter[K comparable](t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockEvictionReporter[K] {
+	mock := &MockEvictionReporter[K]{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockEvictionReporter is an autogenerated mock type for the EvictionReporter type
+type MockEvictionReporter[K comparable] struct {
+	mock.Mock
+}
+
+type MockEvictionReporter_Expecter[K comparable] struct {
+	mock *mock.Mock
+}
+
+func (_m *MockEvictionReporter[K]) EXPECT() *MockEvictionReporter_Expecter[K] {
+	return &MockEvictionReporter_Expecter[K]{mock: &_m.Mock}
+}
+
+// SetEvictionListener provides a mock function for the type MockEvictionReporter
+func (_mock *MockEvictionReporter[K]) SetEvictionListener(listener cache.EvictionListener[K]) {
+	_mock.Called(listener)
+	return
+}
+
+// MockEvictionReporter_SetEvictionListener_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetEvictionListener'
+type MockEvictionReporter_SetEvictionListener_Call[K comparable] struct {
+	*mock.Call
+}
+
+// SetEvictionListener is a helper method to define mock.On call
+//   - listener cache.EvictionListener[K]
+func (_e *MockEvictionReporter_Expecter[K]) SetEvictionListener(listener any) *MockEvictionReporter_SetEvictionListener_Call[K] {
+	return &MockEvictionReporter_SetEvictionListener_Call[K]{Call: _e.mock.On("SetEvictionListener", listener)}
+}
+
+func (_c *MockEvictionReporter_SetEvictionListener_Call[K]) Run(run func(listener cache.EvictionListener[K])) *MockEvictionReporter_SetEvictionListener_Call[K] {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 cache.EvictionListener[K]
+		if args[0] != nil {
+			arg0 = args[0].(cache.EvictionListener[K])
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockEvictionReporter_SetEvictionListener_Call[K]) Return() *MockEvictionReporter_SetEvictionListener_Call[K] {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockEvictionReporter_SetEvictionListener_Call[K]) RunAndReturn(run func(listener cache.EvictionListener[K])) *MockEvictionReporter_SetEvictionListener_Call[K] {
+	_c.Run(run)
+	return _c
+}
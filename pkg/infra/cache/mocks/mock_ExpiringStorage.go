@@ -0,0 +1,573 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockExpiringStorage creates a new instance of MockExpiringStorage. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockExpiringStorage[K comparable](t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockExpiringStorage[K] {
+	mock := &MockExpiringStorage[K]{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockExpiringStorage is an autogenerated mock type for the ExpiringStorage type
+type MockExpiringStorage[K comparable] struct {
+	mock.Mock
+}
+
+type MockExpiringStorage_Expecter[K comparable] struct {
+	mock *mock.Mock
+}
+
+func (_m *MockExpiringStorage[K]) EXPECT() *MockExpiringStorage_Expecter[K] {
+	return &MockExpiringStorage_Expecter[K]{mock: &_m.Mock}
+}
+
+// Clear provides a mock function for the type MockExpiringStorage
+func (_mock *MockExpiringStorage[K]) Clear() {
+	_mock.Called()
+	return
+}
+
+// MockExpiringStorage_Clear_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Clear'
+type MockExpiringStorage_Clear_Call[K comparable] struct {
+	*mock.Call
+}
+
+// Clear is a helper method to define mock.On call
+func (_e *MockExpiringStorage_Expecter[K]) Clear() *MockExpiringStorage_Clear_Call[K] {
+	return &MockExpiringStorage_Clear_Call[K]{Call: _e.mock.On("Clear")}
+}
+
+func (_c *MockExpiringStorage_Clear_Call[K]) Run(run func()) *MockExpiringStorage_Clear_Call[K] {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockExpiringStorage_Clear_Call[K]) Return() *MockExpiringStorage_Clear_Call[K] {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockExpiringStorage_Clear_Call[K]) RunAndReturn(run func()) *MockExpiringStorage_Clear_Call[K] {
+	_c.Run(run)
+	return _c
+}
+
+// Delete provides a mock function for the type MockExpiringStorage
+func (_mock *MockExpiringStorage[K]) Delete(key K) {
+	_mock.Called(key)
+	return
+}
+
+// MockExpiringStorage_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockExpiringStorage_Delete_Call[K comparable] struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - key K
+func (_e *MockExpiringStorage_Expecter[K]) Delete(key any) *MockExpiringStorage_Delete_Call[K] {
+	return &MockExpiringStorage_Delete_Call[K]{Call: _e.mock.On("Delete", key)}
+}
+
+func (_c *MockExpiringStorage_Delete_Call[K]) Run(run func(key K)) *MockExpiringStorage_Delete_Call[K] {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 K
+		if args[0] != nil {
+			arg0 = args[0].(K)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockExpiringStorage_Delete_Call[K]) Return() *MockExpiringStorage_Delete_Call[K] {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockExpiringStorage_Delete_Call[K]) RunAndReturn(run func(key K)) *MockExpiringStorage_Delete_Call[K] {
+	_c.Run(run)
+	return _c
+}
+
+// ExpiryIndexed provides a mock function for the type MockExpiringStorage
+func (_mock *MockExpiringStorage[K]) ExpiryIndexed() bool {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExpiryIndexed")
+	}
+
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func() bool); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	return r0
+}
+
+// MockExpiringStorage_ExpiryIndexed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExpiryIndexed'
+type MockExpiringStorage_ExpiryIndexed_Call[K comparable] struct {
+	*mock.Call
+}
+
+// ExpiryIndexed is a helper method to define mock.On call
+func (_e *MockExpiringStorage_Expecter[K]) ExpiryIndexed() *MockExpiringStorage_ExpiryIndexed_Call[K] {
+	return &MockExpiringStorage_ExpiryIndexed_Call[K]{Call: _e.mock.On("ExpiryIndexed")}
+}
+
+func (_c *MockExpiringStorage_ExpiryIndexed_Call[K]) Run(run func()) *MockExpiringStorage_ExpiryIndexed_Call[K] {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockExpiringStorage_ExpiryIndexed_Call[K]) Return(b bool) *MockExpiringStorage_ExpiryIndexed_Call[K] {
+	_c.Call.Return(b)
+	return _c
+}
+
+func (_c *MockExpiringStorage_ExpiryIndexed_Call[K]) RunAndReturn(run func() bool) *MockExpiringStorage_ExpiryIndexed_Call[K] {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function for the type MockExpiringStorage
+func (_mock *MockExpiringStorage[K]) Get(key K) ([]byte, bool) {
+	ret := _mock.Called(key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 []byte
+	var r1 bool
+	if returnFunc, ok := ret.Get(0).(func(K) ([]byte, bool)); ok {
+		return returnFunc(key)
+	}
+	if returnFunc, ok := ret.Get(0).(func(K) []byte); ok {
+		r0 = returnFunc(key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(K) bool); ok {
+		r1 = returnFunc(key)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+	return r0, r1
+}
+
+// MockExpiringStorage_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockExpiringStorage_Get_Call[K comparable] struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - key K
+func (_e *MockExpiringStorage_Expecter[K]) Get(key any) *MockExpiringStorage_Get_Call[K] {
+	return &MockExpiringStorage_Get_Call[K]{Call: _e.mock.On("Get", key)}
+}
+
+func (_c *MockExpiringStorage_Get_Call[K]) Run(run func(key K)) *MockExpiringStorage_Get_Call[K] {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 K
+		if args[0] != nil {
+			arg0 = args[0].(K)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockExpiringStorage_Get_Call[K]) Return(bytes []byte, b bool) *MockExpiringStorage_Get_Call[K] {
+	_c.Call.Return(bytes, b)
+	return _c
+}
+
+func (_c *MockExpiringStorage_Get_Call[K]) RunAndReturn(run func(key K) ([]byte, bool)) *MockExpiringStorage_Get_Call[K] {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWithExpiry provides a mock function for the type MockExpiringStorage
+func (_mock *MockExpiringStorage[K]) GetWithExpiry(key K) ([]byte, int64, bool) {
+	ret := _mock.Called(key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWithExpiry")
+	}
+
+	var r0 []byte
+	var r1 int64
+	var r2 bool
+	if returnFunc, ok := ret.Get(0).(func(K) ([]byte, int64, bool)); ok {
+		return returnFunc(key)
+	}
+	if returnFunc, ok := ret.Get(0).(func(K) []byte); ok {
+		r0 = returnFunc(key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(K) int64); ok {
+		r1 = returnFunc(key)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+	if returnFunc, ok := ret.Get(2).(func(K) bool); ok {
+		r2 = returnFunc(key)
+	} else {
+		r2 = ret.Get(2).(bool)
+	}
+	return r0, r1, r2
+}
+
+// MockExpiringStorage_GetWithExpiry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWithExpiry'
+type MockExpiringStorage_GetWithExpiry_Call[K comparable] struct {
+	*mock.Call
+}
+
+// GetWithExpiry is a helper method to define mock.On call
+//   - key K
+func (_e *MockExpiringStorage_Expecter[K]) GetWithExpiry(key any) *MockExpiringStorage_GetWithExpiry_Call[K] {
+	return &MockExpiringStorage_GetWithExpiry_Call[K]{Call: _e.mock.On("GetWithExpiry", key)}
+}
+
+func (_c *MockExpiringStorage_GetWithExpiry_Call[K]) Run(run func(key K)) *MockExpiringStorage_GetWithExpiry_Call[K] {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 K
+		if args[0] != nil {
+			arg0 = args[0].(K)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockExpiringStorage_GetWithExpiry_Call[K]) Return(bytes []byte, n int64, b bool) *MockExpiringStorage_GetWithExpiry_Call[K] {
+	_c.Call.Return(bytes, n, b)
+	return _c
+}
+
+func (_c *MockExpiringStorage_GetWithExpiry_Call[K]) RunAndReturn(run func(key K) ([]byte, int64, bool)) *MockExpiringStorage_GetWithExpiry_Call[K] {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Has provides a mock function for the type MockExpiringStorage
+func (_mock *MockExpiringStorage[K]) Has(key K) bool {
+	ret := _mock.Called(key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Has")
+	}
+
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func(K) bool); ok {
+		r0 = returnFunc(key)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	return r0
+}
+
+// MockExpiringStorage_Has_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Has'
+type MockExpiringStorage_Has_Call[K comparable] struct {
+	*mock.Call
+}
+
+// Has is a helper method to define mock.On call
+//   - key K
+func (_e *MockExpiringStorage_Expecter[K]) Has(key any) *MockExpiringStorage_Has_Call[K] {
+	return &MockExpiringStorage_Has_Call[K]{Call: _e.mock.On("Has", key)}
+}
+
+func (_c *MockExpiringStorage_Has_Call[K]) Run(run func(key K)) *MockExpiringStorage_Has_Call[K] {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 K
+		if args[0] != nil {
+			arg0 = args[0].(K)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockExpiringStorage_Has_Call[K]) Return(b bool) *MockExpiringStorage_Has_Call[K] {
+	_c.Call.Return(b)
+	return _c
+}
+
+func (_c *MockExpiringStorage_Has_Call[K]) RunAndReturn(run func(key K) bool) *MockExpiringStorage_Has_Call[K] {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Len provides a mock function for the type MockExpiringStorage
+func (_mock *MockExpiringStorage[K]) Len() int {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Len")
+	}
+
+	var r0 int
+	if returnFunc, ok := ret.Get(0).(func() int); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	return r0
+}
+
+// MockExpiringStorage_Len_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Len'
+type MockExpiringStorage_Len_Call[K comparable] struct {
+	*mock.Call
+}
+
+// Len is a helper method to define mock.On call
+func (_e *MockExpiringStorage_Expecter[K]) Len() *MockExpiringStorage_Len_Call[K] {
+	return &MockExpiringStorage_Len_Call[K]{Call: _e.mock.On("Len")}
+}
+
+func (_c *MockExpiringStorage_Len_Call[K]) Run(run func()) *MockExpiringStorage_Len_Call[K] {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockExpiringStorage_Len_Call[K]) Return(n int) *MockExpiringStorage_Len_Call[K] {
+	_c.Call.Return(n)
+	return _c
+}
+
+func (_c *MockExpiringStorage_Len_Call[K]) RunAndReturn(run func() int) *MockExpiringStorage_Len_Call[K] {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Range provides a mock function for the type MockExpiringStorage
+func (_mock *MockExpiringStorage[K]) Range(fn func(key K, value []byte) bool) {
+	_mock.Called(fn)
+	return
+}
+
+// MockExpiringStorage_Range_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Range'
+type MockExpiringStorage_Range_Call[K comparable] struct {
+	*mock.Call
+}
+
+// Range is a helper method to define mock.On call
+//   - fn func(key K, value []byte) bool
+func (_e *MockExpiringStorage_Expecter[K]) Range(fn any) *MockExpiringStorage_Range_Call[K] {
+	return &MockExpiringStorage_Range_Call[K]{Call: _e.mock.On("Range", fn)}
+}
+
+func (_c *MockExpiringStorage_Range_Call[K]) Run(run func(fn func(key K, value []byte) bool)) *MockExpiringStorage_Range_Call[K] {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 func(key K, value []byte) bool
+		if args[0] != nil {
+			arg0 = args[0].(func(key K, value []byte) bool)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockExpiringStorage_Range_Call[K]) Return() *MockExpiringStorage_Range_Call[K] {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockExpiringStorage_Range_Call[K]) RunAndReturn(run func(fn func(key K, value []byte) bool)) *MockExpiringStorage_Range_Call[K] {
+	_c.Run(run)
+	return _c
+}
+
+// RemoveExpired provides a mock function for the type MockExpiringStorage
+func (_mock *MockExpiringStorage[K]) RemoveExpired(now int64, limit int) int {
+	ret := _mock.Called(now, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveExpired")
+	}
+
+	var r0 int
+	if returnFunc, ok := ret.Get(0).(func(int64, int) int); ok {
+		r0 = returnFunc(now, limit)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	return r0
+}
+
+// MockExpiringStorage_RemoveExpired_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveExpired'
+type MockExpiringStorage_RemoveExpired_Call[K comparable] struct {
+	*mock.Call
+}
+
+// RemoveExpired is a helper method to define mock.On call
+//   - now int64
+//   - limit int
+func (_e *MockExpiringStorage_Expecter[K]) RemoveExpired(now any, limit any) *MockExpiringStorage_RemoveExpired_Call[K] {
+	return &MockExpiringStorage_RemoveExpired_Call[K]{Call: _e.mock.On("RemoveExpired", now, limit)}
+}
+
+func (_c *MockExpiringStorage_RemoveExpired_Call[K]) Run(run func(now int64, limit int)) *MockExpiringStorage_RemoveExpired_Call[K] {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int64
+		if args[0] != nil {
+			arg0 = args[0].(int64)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockExpiringStorage_RemoveExpired_Call[K]) Return(n int) *MockExpiringStorage_RemoveExpired_Call[K] {
+	_c.Call.Return(n)
+	return _c
+}
+
+func (_c *MockExpiringStorage_RemoveExpired_Call[K]) RunAndReturn(run func(now int64, limit int) int) *MockExpiringStorage_RemoveExpired_Call[K] {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Set provides a mock function for the type MockExpiringStorage
+func (_mock *MockExpiringStorage[K]) Set(key K, b []byte) {
+	_mock.Called(key, b)
+	return
+}
+
+// MockExpiringStorage_Set_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Set'
+type MockExpiringStorage_Set_Call[K comparable] struct {
+	*mock.Call
+}
+
+// Set is a helper method to define mock.On call
+//   - key K
+//   - b []byte
+func (_e *MockExpiringStorage_Expecter[K]) Set(key any, b any) *MockExpiringStorage_Set_Call[K] {
+	return &MockExpiringStorage_Set_Call[K]{Call: _e.mock.On("Set", key, b)}
+}
+
+func (_c *MockExpiringStorage_Set_Call[K]) Run(run func(key K, b []byte)) *MockExpiringStorage_Set_Call[K] {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 K
+		if args[0] != nil {
+			arg0 = args[0].(K)
+		}
+		var arg1 []byte
+		if args[1] != nil {
+			arg1 = args[1].([]byte)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockExpiringStorage_Set_Call[K]) Return() *MockExpiringStorage_Set_Call[K] {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockExpiringStorage_Set_Call[K]) RunAndReturn(run func(key K, b []byte)) *MockExpiringStorage_Set_Call[K] {
+	_c.Run(run)
+	return _c
+}
+
+// SetWithExpiry provides a mock function for the type MockExpiringStorage
+func (_mock *MockExpiringStorage[K]) SetWithExpiry(key K, b []byte, dieAt int64) {
+	_mock.Called(key, b, dieAt)
+	return
+}
+
+// MockExpiringStorage_SetWithExpiry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetWithExpiry'
+type MockExpiringStorage_SetWithExpiry_Call[K comparable] struct {
+	*mock.Call
+}
+
+// SetWithExpiry is a helper method to define mock.On call
+//   - key K
+//   - b []byte
+//   - dieAt int64
+func (_e *MockExpiringStorage_Expecter[K]) SetWithExpiry(key any, b any, dieAt any) *MockExpiringStorage_SetWithExpiry_Call[K] {
+	return &MockExpiringStorage_SetWithExpiry_Call[K]{Call: _e.mock.On("SetWithExpiry", key, b, dieAt)}
+}
+
+func (_c *MockExpiringStorage_SetWithExpiry_Call[K]) Run(run func(key K, b []byte, dieAt int64)) *MockExpiringStorage_SetWithExpiry_Call[K] {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 K
+		if args[0] != nil {
+			arg0 = args[0].(K)
+		}
+		var arg1 []byte
+		if args[1] != nil {
+			arg1 = args[1].([]byte)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockExpiringStorage_SetWithExpiry_Call[K]) Return() *MockExpiringStorage_SetWithExpiry_Call[K] {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockExpiringStorage_SetWithExpiry_Call[K]) RunAndReturn(run func(key K, b []byte, dieAt int64)) *MockExpiringStorage_SetWithExpiry_Call[K] {
+	_c.Run(run)
+	return _c
+}
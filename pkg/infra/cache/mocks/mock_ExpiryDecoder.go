@@ -0,0 +1,96 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockExpiryDecoder creates a new instance of MockExpiryDecoder. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockExpiryDecoder(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockExpiryDecoder {
+	mock := &MockExpiryDecoder{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockExpiryDecoder is an autogenerated mock type for the ExpiryDecoder type
+type MockExpiryDecoder struct {
+	mock.Mock
+}
+
+type MockExpiryDecoder_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockExpiryDecoder) EXPECT() *MockExpiryDecoder_Expecter {
+	return &MockExpiryDecoder_Expecter{mock: &_m.Mock}
+}
+
+// DecodeExpiry provides a mock function for the type MockExpiryDecoder
+func (_mock *MockExpiryDecoder) DecodeExpiry(b []byte) (int64, error) {
+	ret := _mock.Called(b)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DecodeExpiry")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func([]byte) (int64, error)); ok {
+		return returnFunc(b)
+	}
+	if returnFunc, ok := ret.Get(0).(func([]byte) int64); ok {
+		r0 = returnFunc(b)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func([]byte) error); ok {
+		r1 = returnFunc(b)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockExpiryDecoder_DecodeExpiry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DecodeExpiry'
+type MockExpiryDecoder_DecodeExpiry_Call struct {
+	*mock.Call
+}
+
+// DecodeExpiry is a helper method to define mock.On call
+//   - b []byte
+func (_e *MockExpiryDecoder_Expecter) DecodeExpiry(b any) *MockExpiryDecoder_DecodeExpiry_Call {
+	return &MockExpiryDecoder_DecodeExpiry_Call{Call: _e.mock.On("DecodeExpiry", b)}
+}
+
+func (_c *MockExpiryDecoder_DecodeExpiry_Call) Run(run func(b []byte)) *MockExpiryDecoder_DecodeExpiry_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 []byte
+		if args[0] != nil {
+			arg0 = args[0].([]byte)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockExpiryDecoder_DecodeExpiry_Call) Return(n int64, err error) *MockExpiryDecoder_DecodeExpiry_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockExpiryDecoder_DecodeExpiry_Call) RunAndReturn(run func(b []byte) (int64, error)) *MockExpiryDecoder_DecodeExpiry_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,110 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockExpiryReader creates a new instance of MockExpiryReader. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockExpiryReader[K comparable, V any](t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockExpiryReader[K, V] {
+	mock := &MockExpiryReader[K, V]{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockExpiryReader is an autogenerated mock type for the ExpiryReader type
+type MockExpiryReader[K comparable, V any] struct {
+	mock.Mock
+}
+
+type MockExpiryReader_Expecter[K comparable, V any] struct {
+	mock *mock.Mock
+}
+
+func (_m *MockExpiryReader[K, V]) EXPECT() *MockExpiryReader_Expecter[K, V] {
+	return &MockExpiryReader_Expecter[K, V]{mock: &_m.Mock}
+}
+
+// GetWithExpiry provides a mock function for the type MockExpiryReader
+func (_mock *MockExpiryReader[K, V]) GetWithExpiry(key K) (V, int64, bool, error) {
+	ret := _mock.Called(key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWithExpiry")
+	}
+
+	var r0 V
+	var r1 int64
+	var r2 bool
+	var r3 error
+	if returnFunc, ok := ret.Get(0).(func(K) (V, int64, bool, error)); ok {
+		return returnFunc(key)
+	}
+	if returnFunc, ok := ret.Get(0).(func(K) V); ok {
+		r0 = returnFunc(key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(V)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(K) int64); ok {
+		r1 = returnFunc(key)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+	if returnFunc, ok := ret.Get(2).(func(K) bool); ok {
+		r2 = returnFunc(key)
+	} else {
+		r2 = ret.Get(2).(bool)
+	}
+	if returnFunc, ok := ret.Get(3).(func(K) error); ok {
+		r3 = returnFunc(key)
+	} else {
+		r3 = ret.Error(3)
+	}
+	return r0, r1, r2, r3
+}
+
+// MockExpiryReader_GetWithExpiry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWithExpiry'
+type MockExpiryReader_GetWithExpiry_Call[K comparable, V any] struct {
+	*mock.Call
+}
+
+// GetWithExpiry is a helper method to define mock.On call
+//   - key K
+func (_e *MockExpiryReader_Expecter[K, V]) GetWithExpiry(key any) *MockExpiryReader_GetWithExpiry_Call[K, V] {
+	return &MockExpiryReader_GetWithExpiry_Call[K, V]{Call: _e.mock.On("GetWithExpiry", key)}
+}
+
+func (_c *MockExpiryReader_GetWithExpiry_Call[K, V]) Run(run func(key K)) *MockExpiryReader_GetWithExpiry_Call[K, V] {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 K
+		if args[0] != nil {
+			arg0 = args[0].(K)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockExpiryReader_GetWithExpiry_Call[K, V]) Return(v V, n int64, b bool, err error) *MockExpiryReader_GetWithExpiry_Call[K, V] {
+	_c.Call.Return(v, n, b, err)
+	return _c
+}
+
+func (_c *MockExpiryReader_GetWithExpiry_Call[K, V]) RunAndReturn(run func(key K) (V, int64, bool, error)) *MockExpiryReader_GetWithExpiry_Call[K, V] {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,166 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/infra/cache"
+	"github.com/ElfAstAhe/go-service-template/pkg/infra/pubsub"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockInvalidationPublisher creates a new instance of MockInvalidationPublisher. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockInvalidationPublisher[K comparable](t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockInvalidationPublisher[K] {
+	mock := &MockInvalidationPublisher[K]{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockInvalidationPublisher is an autogenerated mock type for the InvalidationPublisher type
+type MockInvalidationPublisher[K comparable] struct {
+	mock.Mock
+}
+
+type MockInvalidationPublisher_Expecter[K comparable] struct {
+	mock *mock.Mock
+}
+
+func (_m *MockInvalidationPublisher[K]) EXPECT() *MockInvalidationPublisher_Expecter[K] {
+	return &MockInvalidationPublisher_Expecter[K]{mock: &_m.Mock}
+}
+
+// Notify provides a mock function for the type MockInvalidationPublisher
+func (_mock *MockInvalidationPublisher[K]) Notify(context1 context.Context, invalidation *cache.Invalidation[K]) {
+	_mock.Called(context1, invalidation)
+	return
+}
+
+// MockInvalidationPublisher_Notify_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Notify'
+type MockInvalidationPublisher_Notify_Call[K comparable] struct {
+	*mock.Call
+}
+
+// Notify is a helper method to define mock.On call
+//   - context1 context.Context
+//   - invalidation *cache.Invalidation[K]
+func (_e *MockInvalidationPublisher_Expecter[K]) Notify(context1 any, invalidation any) *MockInvalidationPublisher_Notify_Call[K] {
+	return &MockInvalidationPublisher_Notify_Call[K]{Call: _e.mock.On("Notify", context1, invalidation)}
+}
+
+func (_c *MockInvalidationPublisher_Notify_Call[K]) Run(run func(context1 context.Context, invalidation *cache.Invalidation[K])) *MockInvalidationPublisher_Notify_Call[K] {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *cache.Invalidation[K]
+		if args[1] != nil {
+			arg1 = args[1].(*cache.Invalidation[K])
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockInvalidationPublisher_Notify_Call[K]) Return() *MockInvalidationPublisher_Notify_Call[K] {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockInvalidationPublisher_Notify_Call[K]) RunAndReturn(run func(context1 context.Context, invalidation *cache.Invalidation[K])) *MockInvalidationPublisher_Notify_Call[K] {
+	_c.Run(run)
+	return _c
+}
+
+// Register provides a mock function for the type MockInvalidationPublisher
+func (_mock *MockInvalidationPublisher[K]) Register(observer pubsub.Observer[*cache.Invalidation[K]]) {
+	_mock.Called(observer)
+	return
+}
+
+// MockInvalidationPublisher_Register_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Register'
+type MockInvalidationPublisher_Register_Call[K comparable] struct {
+	*mock.Call
+}
+
+// Register is a helper method to define mock.On call
+//   - observer pubsub.Observer[*cache.Invalidation[K]]
+func (_e *MockInvalidationPublisher_Expecter[K]) Register(observer any) *MockInvalidationPublisher_Register_Call[K] {
+	return &MockInvalidationPublisher_Register_Call[K]{Call: _e.mock.On("Register", observer)}
+}
+
+func (_c *MockInvalidationPublisher_Register_Call[K]) Run(run func(observer pubsub.Observer[*cache.Invalidation[K]])) *MockInvalidationPublisher_Register_Call[K] {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 pubsub.Observer[*cache.Invalidation[K]]
+		if args[0] != nil {
+			arg0 = args[0].(pubsub.Observer[*cache.Invalidation[K]])
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockInvalidationPublisher_Register_Call[K]) Return() *MockInvalidationPublisher_Register_Call[K] {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockInvalidationPublisher_Register_Call[K]) RunAndReturn(run func(observer pubsub.Observer[*cache.Invalidation[K]])) *MockInvalidationPublisher_Register_Call[K] {
+	_c.Run(run)
+	return _c
+}
+
+// Unregister provides a mock function for the type MockInvalidationPublisher
+func (_mock *MockInvalidationPublisher[K]) Unregister(observer pubsub.Observer[*cache.Invalidation[K]]) {
+	_mock.Called(observer)
+	return
+}
+
+// MockInvalidationPublisher_Unregister_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Unregister'
+type MockInvalidationPublisher_Unregister_Call[K comparable] struct {
+	*mock.Call
+}
+
+// Unregister is a helper method to define mock.On call
+//   - observer pubsub.Observer[*cache.Invalidation[K]]
+func (_e *MockInvalidationPublisher_Expecter[K]) Unregister(observer any) *MockInvalidationPublisher_Unregister_Call[K] {
+	return &MockInvalidationPublisher_Unregister_Call[K]{Call: _e.mock.On("Unregister", observer)}
+}
+
+func (_c *MockInvalidationPublisher_Unregister_Call[K]) Run(run func(observer pubsub.Observer[*cache.Invalidation[K]])) *MockInvalidationPublisher_Unregister_Call[K] {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 pubsub.Observer[*cache.Invalidation[K]]
+		if args[0] != nil {
+			arg0 = args[0].(pubsub.Observer[*cache.Invalidation[K]])
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockInvalidationPublisher_Unregister_Call[K]) Return() *MockInvalidationPublisher_Unregister_Call[K] {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockInvalidationPublisher_Unregister_Call[K]) RunAndReturn(run func(observer pubsub.Observer[*cache.Invalidation[K]])) *MockInvalidationPublisher_Unregister_Call[K] {
+	_c.Run(run)
+	return _c
+}
@@ -0,0 +1,158 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockKeyCodec creates a new instance of MockKeyCodec. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockKeyCodec[K comparable](t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockKeyCodec[K] {
+	mock := &MockKeyCodec[K]{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockKeyCodec is an autogenerated mock type for the KeyCodec type
+type MockKeyCodec[K comparable] struct {
+	mock.Mock
+}
+
+type MockKeyCodec_Expecter[K comparable] struct {
+	mock *mock.Mock
+}
+
+func (_m *MockKeyCodec[K]) EXPECT() *MockKeyCodec_Expecter[K] {
+	return &MockKeyCodec_Expecter[K]{mock: &_m.Mock}
+}
+
+// DecodeKey provides a mock function for the type MockKeyCodec
+func (_mock *MockKeyCodec[K]) DecodeKey(s string) (K, error) {
+	ret := _mock.Called(s)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DecodeKey")
+	}
+
+	var r0 K
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) (K, error)); ok {
+		return returnFunc(s)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) K); ok {
+		r0 = returnFunc(s)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(K)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(s)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockKeyCodec_DecodeKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DecodeKey'
+type MockKeyCodec_DecodeKey_Call[K comparable] struct {
+	*mock.Call
+}
+
+// DecodeKey is a helper method to define mock.On call
+//   - s string
+func (_e *MockKeyCodec_Expecter[K]) DecodeKey(s any) *MockKeyCodec_DecodeKey_Call[K] {
+	return &MockKeyCodec_DecodeKey_Call[K]{Call: _e.mock.On("DecodeKey", s)}
+}
+
+func (_c *MockKeyCodec_DecodeKey_Call[K]) Run(run func(s string)) *MockKeyCodec_DecodeKey_Call[K] {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockKeyCodec_DecodeKey_Call[K]) Return(v K, err error) *MockKeyCodec_DecodeKey_Call[K] {
+	_c.Call.Return(v, err)
+	return _c
+}
+
+func (_c *MockKeyCodec_DecodeKey_Call[K]) RunAndReturn(run func(s string) (K, error)) *MockKeyCodec_DecodeKey_Call[K] {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EncodeKey provides a mock function for the type MockKeyCodec
+func (_mock *MockKeyCodec[K]) EncodeKey(key K) (string, error) {
+	ret := _mock.Called(key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EncodeKey")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(K) (string, error)); ok {
+		return returnFunc(key)
+	}
+	if returnFunc, ok := ret.Get(0).(func(K) string); ok {
+		r0 = returnFunc(key)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(K) error); ok {
+		r1 = returnFunc(key)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockKeyCodec_EncodeKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EncodeKey'
+type MockKeyCodec_EncodeKey_Call[K comparable] struct {
+	*mock.Call
+}
+
+// EncodeKey is a helper method to define mock.On call
+//   - key K
+func (_e *MockKeyCodec_Expecter[K]) EncodeKey(key any) *MockKeyCodec_EncodeKey_Call[K] {
+	return &MockKeyCodec_EncodeKey_Call[K]{Call: _e.mock.On("EncodeKey", key)}
+}
+
+func (_c *MockKeyCodec_EncodeKey_Call[K]) Run(run func(key K)) *MockKeyCodec_EncodeKey_Call[K] {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 K
+		if args[0] != nil {
+			arg0 = args[0].(K)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockKeyCodec_EncodeKey_Call[K]) Return(s string, err error) *MockKeyCodec_EncodeKey_Call[K] {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockKeyCodec_EncodeKey_Call[K]) RunAndReturn(run func(key K) (string, error)) *MockKeyCodec_EncodeKey_Call[K] {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,76 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockKeyRanger creates a new instance of MockKeyRanger. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockKeyRanger[K comparable](t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockKeyRanger[K] {
+	mock := &MockKeyRanger[K]{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockKeyRanger is an autogenerated mock type for the KeyRanger type
+type MockKeyRanger[K comparable] struct {
+	mock.Mock
+}
+
+type MockKeyRanger_Expecter[K comparable] struct {
+	mock *mock.Mock
+}
+
+func (_m *MockKeyRanger[K]) EXPECT() *MockKeyRanger_Expecter[K] {
+	return &MockKeyRanger_Expecter[K]{mock: &_m.Mock}
+}
+
+// RangeKeys provides a mock function for the type MockKeyRanger
+func (_mock *MockKeyRanger[K]) RangeKeys(fn func(key K) bool) {
+	_mock.Called(fn)
+	return
+}
+
+// MockKeyRanger_RangeKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RangeKeys'
+type MockKeyRanger_RangeKeys_Call[K comparable] struct {
+	*mock.Call
+}
+
+// RangeKeys is a helper method to define mock.On call
+//   - fn func(key K) bool
+func (_e *MockKeyRanger_Expecter[K]) RangeKeys(fn any) *MockKeyRanger_RangeKeys_Call[K] {
+	return &MockKeyRanger_RangeKeys_Call[K]{Call: _e.mock.On("RangeKeys", fn)}
+}
+
+func (_c *MockKeyRanger_RangeKeys_Call[K]) Run(run func(fn func(key K) bool)) *MockKeyRanger_RangeKeys_Call[K] {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 func(key K) bool
+		if args[0] != nil {
+			arg0 = args[0].(func(key K) bool)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockKeyRanger_RangeKeys_Call[K]) Return() *MockKeyRanger_RangeKeys_Call[K] {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockKeyRanger_RangeKeys_Call[K]) RunAndReturn(run func(fn func(key K) bool)) *MockKeyRanger_RangeKeys_Call[K] {
+	_c.Run(run)
+	return _c
+}
@@ -0,0 +1,100 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockNilMarshaler creates a new instance of MockNilMarshaler. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockNilMarshaler(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockNilMarshaler {
+	mock := &MockNilMarshaler{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockNilMarshaler is an autogenerated mock type for the NilMarshaler type
+type MockNilMarshaler struct {
+	mock.Mock
+}
+
+type MockNilMarshaler_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockNilMarshaler) EXPECT() *MockNilMarshaler_Expecter {
+	return &MockNilMarshaler_Expecter{mock: &_m.Mock}
+}
+
+// MarshalNil provides a mock function for the type MockNilMarshaler
+func (_mock *MockNilMarshaler) MarshalNil(ttl time.Duration) ([]byte, error) {
+	ret := _mock.Called(ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarshalNil")
+	}
+
+	var r0 []byte
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(time.Duration) ([]byte, error)); ok {
+		return returnFunc(ttl)
+	}
+	if returnFunc, ok := ret.Get(0).(func(time.Duration) []byte); ok {
+		r0 = returnFunc(ttl)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(time.Duration) error); ok {
+		r1 = returnFunc(ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockNilMarshaler_MarshalNil_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarshalNil'
+type MockNilMarshaler_MarshalNil_Call struct {
+	*mock.Call
+}
+
+// MarshalNil is a helper method to define mock.On call
+//   - ttl time.Duration
+func (_e *MockNilMarshaler_Expecter) MarshalNil(ttl any) *MockNilMarshaler_MarshalNil_Call {
+	return &MockNilMarshaler_MarshalNil_Call{Call: _e.mock.On("MarshalNil", ttl)}
+}
+
+func (_c *MockNilMarshaler_MarshalNil_Call) Run(run func(ttl time.Duration)) *MockNilMarshaler_MarshalNil_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 time.Duration
+		if args[0] != nil {
+			arg0 = args[0].(time.Duration)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockNilMarshaler_MarshalNil_Call) Return(bytes []byte, err error) *MockNilMarshaler_MarshalNil_Call {
+	_c.Call.Return(bytes, err)
+	return _c
+}
+
+func (_c *MockNilMarshaler_MarshalNil_Call) RunAndReturn(run func(ttl time.Duration) ([]byte, error)) *MockNilMarshaler_MarshalNil_Call {
+	_c.Call.Return(run)
+	return _c
+}
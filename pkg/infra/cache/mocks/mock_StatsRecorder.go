@@ -0,0 +1,288 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockStatsRecorder creates a new instance of MockStatsRecorder. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockStatsRecorder(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockStatsRecorder {
+	mock := &MockStatsRecorder{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockStatsRecorder is an autogenerated mock type for the StatsRecorder type
+type MockStatsRecorder struct {
+	mock.Mock
+}
+
+type MockStatsRecorder_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockStatsRecorder) EXPECT() *MockStatsRecorder_Expecter {
+	return &MockStatsRecorder_Expecter{mock: &_m.Mock}
+}
+
+// Delete provides a mock function for the type MockStatsRecorder
+func (_mock *MockStatsRecorder) Delete() {
+	_mock.Called()
+	return
+}
+
+// MockStatsRecorder_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockStatsRecorder_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+func (_e *MockStatsRecorder_Expecter) Delete() *MockStatsRecorder_Delete_Call {
+	return &MockStatsRecorder_Delete_Call{Call: _e.mock.On("Delete")}
+}
+
+func (_c *MockStatsRecorder_Delete_Call) Run(run func()) *MockStatsRecorder_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockStatsRecorder_Delete_Call) Return() *MockStatsRecorder_Delete_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockStatsRecorder_Delete_Call) RunAndReturn(run func()) *MockStatsRecorder_Delete_Call {
+	_c.Run(run)
+	return _c
+}
+
+// Evicted provides a mock function for the type MockStatsRecorder
+func (_mock *MockStatsRecorder) Evicted() {
+	_mock.Called()
+	return
+}
+
+// MockStatsRecorder_Evicted_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Evicted'
+type MockStatsRecorder_Evicted_Call struct {
+	*mock.Call
+}
+
+// Evicted is a helper method to define mock.On call
+func (_e *MockStatsRecorder_Expecter) Evicted() *MockStatsRecorder_Evicted_Call {
+	return &MockStatsRecorder_Evicted_Call{Call: _e.mock.On("Evicted")}
+}
+
+func (_c *MockStatsRecorder_Evicted_Call) Run(run func()) *MockStatsRecorder_Evicted_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockStatsRecorder_Evicted_Call) Return() *MockStatsRecorder_Evicted_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockStatsRecorder_Evicted_Call) RunAndReturn(run func()) *MockStatsRecorder_Evicted_Call {
+	_c.Run(run)
+	return _c
+}
+
+// Expired provides a mock function for the type MockStatsRecorder
+func (_mock *MockStatsRecorder) Expired() {
+	_mock.Called()
+	return
+}
+
+// MockStatsRecorder_Expired_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Expired'
+type MockStatsRecorder_Expired_Call struct {
+	*mock.Call
+}
+
+// Expired is a helper method to define mock.On call
+func (_e *MockStatsRecorder_Expecter) Expired() *MockStatsRecorder_Expired_Call {
+	return &MockStatsRecorder_Expired_Call{Call: _e.mock.On("Expired")}
+}
+
+func (_c *MockStatsRecorder_Expired_Call) Run(run func()) *MockStatsRecorder_Expired_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockStatsRecorder_Expired_Call) Return() *MockStatsRecorder_Expired_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockStatsRecorder_Expired_Call) RunAndReturn(run func()) *MockStatsRecorder_Expired_Call {
+	_c.Run(run)
+	return _c
+}
+
+// Hit provides a mock function for the type MockStatsRecorder
+func (_mock *MockStatsRecorder) Hit() {
+	_mock.Called()
+	return
+}
+
+// MockStatsRecorder_Hit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Hit'
+type MockStatsRecorder_Hit_Call struct {
+	*mock.Call
+}
+
+// Hit is a helper method to define mock.On call
+func (_e *MockStatsRecorder_Expecter) Hit() *MockStatsRecorder_Hit_Call {
+	return &MockStatsRecorder_Hit_Call{Call: _e.mock.On("Hit")}
+}
+
+func (_c *MockStatsRecorder_Hit_Call) Run(run func()) *MockStatsRecorder_Hit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockStatsRecorder_Hit_Call) Return() *MockStatsRecorder_Hit_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockStatsRecorder_Hit_Call) RunAndReturn(run func()) *MockStatsRecorder_Hit_Call {
+	_c.Run(run)
+	return _c
+}
+
+// Janitor provides a mock function for the type MockStatsRecorder
+func (_mock *MockStatsRecorder) Janitor(removed int, err error, startTime time.Time) {
+	_mock.Called(removed, err, startTime)
+	return
+}
+
+// MockStatsRecorder_Janitor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Janitor'
+type MockStatsRecorder_Janitor_Call struct {
+	*mock.Call
+}
+
+// Janitor is a helper method to define mock.On call
+//   - removed int
+//   - err error
+//   - startTime time.Time
+func (_e *MockStatsRecorder_Expecter) Janitor(removed any, err any, startTime any) *MockStatsRecorder_Janitor_Call {
+	return &MockStatsRecorder_Janitor_Call{Call: _e.mock.On("Janitor", removed, err, startTime)}
+}
+
+func (_c *MockStatsRecorder_Janitor_Call) Run(run func(removed int, err error, startTime time.Time)) *MockStatsRecorder_Janitor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		var arg1 error
+		if args[1] != nil {
+			arg1 = args[1].(error)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStatsRecorder_Janitor_Call) Return() *MockStatsRecorder_Janitor_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockStatsRecorder_Janitor_Call) RunAndReturn(run func(removed int, err error, startTime time.Time)) *MockStatsRecorder_Janitor_Call {
+	_c.Run(run)
+	return _c
+}
+
+// Miss provides a mock function for the type MockStatsRecorder
+func (_mock *MockStatsRecorder) Miss() {
+	_mock.Called()
+	return
+}
+
+// MockStatsRecorder_Miss_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Miss'
+type MockStatsRecorder_Miss_Call struct {
+	*mock.Call
+}
+
+// Miss is a helper method to define mock.On call
+func (_e *MockStatsRecorder_Expecter) Miss() *MockStatsRecorder_Miss_Call {
+	return &MockStatsRecorder_Miss_Call{Call: _e.mock.On("Miss")}
+}
+
+func (_c *MockStatsRecorder_Miss_Call) Run(run func()) *MockStatsRecorder_Miss_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockStatsRecorder_Miss_Call) Return() *MockStatsRecorder_Miss_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockStatsRecorder_Miss_Call) RunAndReturn(run func()) *MockStatsRecorder_Miss_Call {
+	_c.Run(run)
+	return _c
+}
+
+// Set provides a mock function for the type MockStatsRecorder
+func (_mock *MockStatsRecorder) Set() {
+	_mock.Called()
+	return
+}
+
+// MockStatsRecorder_Set_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Set'
+type MockStatsRecorder_Set_Call struct {
+	*mock.Call
+}
+
+// Set is a helper method to define mock.On call
+func (_e *MockStatsRecorder_Expecter) Set() *MockStatsRecorder_Set_Call {
+	return &MockStatsRecorder_Set_Call{Call: _e.mock.On("Set")}
+}
+
+func (_c *MockStatsRecorder_Set_Call) Run(run func()) *MockStatsRecorder_Set_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockStatsRecorder_Set_Call) Return() *MockStatsRecorder_Set_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockStatsRecorder_Set_Call) RunAndReturn(run func()) *MockStatsRecorder_Set_Call {
+	_c.Run(run)
+	return _c
+}
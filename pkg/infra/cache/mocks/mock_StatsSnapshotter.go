@@ -0,0 +1,81 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"github.com/ElfAstAhe/go-service-template/pkg/infra/cache"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockStatsSnapshotter creates a new instance of MockStatsSnapshotter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockStatsSnapshotter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockStatsSnapshotter {
+	mock := &MockStatsSnapshotter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockStatsSnapshotter is an autogenerated mock type for the StatsSnapshotter type
+type MockStatsSnapshotter struct {
+	mock.Mock
+}
+
+type MockStatsSnapshotter_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockStatsSnapshotter) EXPECT() *MockStatsSnapshotter_Expecter {
+	return &MockStatsSnapshotter_Expecter{mock: &_m.Mock}
+}
+
+// Stats provides a mock function for the type MockStatsSnapshotter
+func (_mock *MockStatsSnapshotter) Stats() cache.CacheStats {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Stats")
+	}
+
+	var r0 cache.CacheStats
+	if returnFunc, ok := ret.Get(0).(func() cache.CacheStats); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(cache.CacheStats)
+	}
+	return r0
+}
+
+// MockStatsSnapshotter_Stats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Stats'
+type MockStatsSnapshotter_Stats_Call struct {
+	*mock.Call
+}
+
+// Stats is a helper method to define mock.On call
+func (_e *MockStatsSnapshotter_Expecter) Stats() *MockStatsSnapshotter_Stats_Call {
+	return &MockStatsSnapshotter_Stats_Call{Call: _e.mock.On("Stats")}
+}
+
+func (_c *MockStatsSnapshotter_Stats_Call) Run(run func()) *MockStatsSnapshotter_Stats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockStatsSnapshotter_Stats_Call) Return(cacheStats cache.CacheStats) *MockStatsSnapshotter_Stats_Call {
+	_c.Call.Return(cacheStats)
+	return _c
+}
+
+func (_c *MockStatsSnapshotter_Stats_Call) RunAndReturn(run func() cache.CacheStats) *MockStatsSnapshotter_Stats_Call {
+	_c.Call.Return(run)
+	return _c
+}
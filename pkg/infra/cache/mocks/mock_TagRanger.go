@@ -0,0 +1,76 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockTagRanger creates a new instance of MockTagRanger. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTagRanger[K comparable](t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTagRanger[K] {
+	mock := &MockTagRanger[K]{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockTagRanger is an autogenerated mock type for the TagRanger type
+type MockTagRanger[K comparable] struct {
+	mock.Mock
+}
+
+type MockTagRanger_Expecter[K comparable] struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTagRanger[K]) EXPECT() *MockTagRanger_Expecter[K] {
+	return &MockTagRanger_Expecter[K]{mock: &_m.Mock}
+}
+
+// RangeWithTags provides a mock function for the type MockTagRanger
+func (_mock *MockTagRanger[K]) RangeWithTags(fn func(key K, value []byte, tags []string) bool) {
+	_mock.Called(fn)
+	return
+}
+
+// MockTagRanger_RangeWithTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RangeWithTags'
+type MockTagRanger_RangeWithTags_Call[K comparable] struct {
+	*mock.Call
+}
+
+// RangeWithTags is a helper method to define mock.On call
+//   - fn func(key K, value []byte, tags []string) bool
+func (_e *MockTagRanger_Expecter[K]) RangeWithTags(fn any) *MockTagRanger_RangeWithTags_Call[K] {
+	return &MockTagRanger_RangeWithTags_Call[K]{Call: _e.mock.On("RangeWithTags", fn)}
+}
+
+func (_c *MockTagRanger_RangeWithTags_Call[K]) Run(run func(fn func(key K, value []byte, tags []string) bool)) *MockTagRanger_RangeWithTags_Call[K] {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 func(key K, value []byte, tags []string) bool
+		if args[0] != nil {
+			arg0 = args[0].(func(key K, value []byte, tags []string) bool)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTagRanger_RangeWithTags_Call[K]) Return() *MockTagRanger_RangeWithTags_Call[K] {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockTagRanger_RangeWithTags_Call[K]) RunAndReturn(run func(fn func(key K, value []byte, tags []string) bool)) *MockTagRanger_RangeWithTags_Call[K] {
+	_c.Run(run)
+	return _c
+}
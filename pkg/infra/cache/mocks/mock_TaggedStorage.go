@@ -0,0 +1,556 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockTaggedStorage creates a new instance of MockTaggedStorage. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTaggedStorage[K comparable](t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTaggedStorage[K] {
+	mock := &MockTaggedStorage[K]{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockTaggedStorage is an autogenerated mock type for the TaggedStorage type
+type MockTaggedStorage[K comparable] struct {
+	mock.Mock
+}
+
+type MockTaggedStorage_Expecter[K comparable] struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTaggedStorage[K]) EXPECT() *MockTaggedStorage_Expecter[K] {
+	return &MockTaggedStorage_Expecter[K]{mock: &_m.Mock}
+}
+
+// Clear provides a mock function for the type MockTaggedStorage
+func (_mock *MockTaggedStorage[K]) Clear() {
+	_mock.Called()
+	return
+}
+
+// MockTaggedStorage_Clear_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Clear'
+type MockTaggedStorage_Clear_Call[K comparable] struct {
+	*mock.Call
+}
+
+// Clear is a helper method to define mock.On call
+func (_e *MockTaggedStorage_Expecter[K]) Clear() *MockTaggedStorage_Clear_Call[K] {
+	return &MockTaggedStorage_Clear_Call[K]{Call: _e.mock.On("Clear")}
+}
+
+func (_c *MockTaggedStorage_Clear_Call[K]) Run(run func()) *MockTaggedStorage_Clear_Call[K] {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockTaggedStorage_Clear_Call[K]) Return() *MockTaggedStorage_Clear_Call[K] {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockTaggedStorage_Clear_Call[K]) RunAndReturn(run func()) *MockTaggedStorage_Clear_Call[K] {
+	_c.Run(run)
+	return _c
+}
+
+// Delete provides a mock function for the type MockTaggedStorage
+func (_mock *MockTaggedStorage[K]) Delete(key K) {
+	_mock.Called(key)
+	return
+}
+
+// MockTaggedStorage_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockTaggedStorage_Delete_Call[K comparable] struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - key K
+func (_e *MockTaggedStorage_Expecter[K]) Delete(key any) *MockTaggedStorage_Delete_Call[K] {
+	return &MockTaggedStorage_Delete_Call[K]{Call: _e.mock.On("Delete", key)}
+}
+
+func (_c *MockTaggedStorage_Delete_Call[K]) Run(run func(key K)) *MockTaggedStorage_Delete_Call[K] {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 K
+		if args[0] != nil {
+			arg0 = args[0].(K)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTaggedStorage_Delete_Call[K]) Return() *MockTaggedStorage_Delete_Call[K] {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockTaggedStorage_Delete_Call[K]) RunAndReturn(run func(key K)) *MockTaggedStorage_Delete_Call[K] {
+	_c.Run(run)
+	return _c
+}
+
+// Get provides a mock function for the type MockTaggedStorage
+func (_mock *MockTaggedStorage[K]) Get(key K) ([]byte, bool) {
+	ret := _mock.Called(key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 []byte
+	var r1 bool
+	if returnFunc, ok := ret.Get(0).(func(K) ([]byte, bool)); ok {
+		return returnFunc(key)
+	}
+	if returnFunc, ok := ret.Get(0).(func(K) []byte); ok {
+		r0 = returnFunc(key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(K) bool); ok {
+		r1 = returnFunc(key)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+	return r0, r1
+}
+
+// MockTaggedStorage_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockTaggedStorage_Get_Call[K comparable] struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - key K
+func (_e *MockTaggedStorage_Expecter[K]) Get(key any) *MockTaggedStorage_Get_Call[K] {
+	return &MockTaggedStorage_Get_Call[K]{Call: _e.mock.On("Get", key)}
+}
+
+func (_c *MockTaggedStorage_Get_Call[K]) Run(run func(key K)) *MockTaggedStorage_Get_Call[K] {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 K
+		if args[0] != nil {
+			arg0 = args[0].(K)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTaggedStorage_Get_Call[K]) Return(bytes []byte, b bool) *MockTaggedStorage_Get_Call[K] {
+	_c.Call.Return(bytes, b)
+	return _c
+}
+
+func (_c *MockTaggedStorage_Get_Call[K]) RunAndReturn(run func(key K) ([]byte, bool)) *MockTaggedStorage_Get_Call[K] {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Has provides a mock function for the type MockTaggedStorage
+func (_mock *MockTaggedStorage[K]) Has(key K) bool {
+	ret := _mock.Called(key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Has")
+	}
+
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func(K) bool); ok {
+		r0 = returnFunc(key)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	return r0
+}
+
+// MockTaggedStorage_Has_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Has'
+type MockTaggedStorage_Has_Call[K comparable] struct {
+	*mock.Call
+}
+
+// Has is a helper method to define mock.On call
+//   - key K
+func (_e *MockTaggedStorage_Expecter[K]) Has(key any) *MockTaggedStorage_Has_Call[K] {
+	return &MockTaggedStorage_Has_Call[K]{Call: _e.mock.On("Has", key)}
+}
+
+func (_c *MockTaggedStorage_Has_Call[K]) Run(run func(key K)) *MockTaggedStorage_Has_Call[K] {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 K
+		if args[0] != nil {
+			arg0 = args[0].(K)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTaggedStorage_Has_Call[K]) Return(b bool) *MockTaggedStorage_Has_Call[K] {
+	_c.Call.Return(b)
+	return _c
+}
+
+func (_c *MockTaggedStorage_Has_Call[K]) RunAndReturn(run func(key K) bool) *MockTaggedStorage_Has_Call[K] {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Len provides a mock function for the type MockTaggedStorage
+func (_mock *MockTaggedStorage[K]) Len() int {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Len")
+	}
+
+	var r0 int
+	if returnFunc, ok := ret.Get(0).(func() int); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	return r0
+}
+
+// MockTaggedStorage_Len_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Len'
+type MockTaggedStorage_Len_Call[K comparable] struct {
+	*mock.Call
+}
+
+// Len is a helper method to define mock.On call
+func (_e *MockTaggedStorage_Expecter[K]) Len() *MockTaggedStorage_Len_Call[K] {
+	return &MockTaggedStorage_Len_Call[K]{Call: _e.mock.On("Len")}
+}
+
+func (_c *MockTaggedStorage_Len_Call[K]) Run(run func()) *MockTaggedStorage_Len_Call[K] {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockTaggedStorage_Len_Call[K]) Return(n int) *MockTaggedStorage_Len_Call[K] {
+	_c.Call.Return(n)
+	return _c
+}
+
+func (_c *MockTaggedStorage_Len_Call[K]) RunAndReturn(run func() int) *MockTaggedStorage_Len_Call[K] {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Range provides a mock function for the type MockTaggedStorage
+func (_mock *MockTaggedStorage[K]) Range(fn func(key K, value []byte) bool) {
+	_mock.Called(fn)
+	return
+}
+
+// MockTaggedStorage_Range_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Range'
+type MockTaggedStorage_Range_Call[K comparable] struct {
+	*mock.Call
+}
+
+// Range is a helper method to define mock.On call
+//   - fn func(key K, value []byte) bool
+func (_e *MockTaggedStorage_Expecter[K]) Range(fn any) *MockTaggedStorage_Range_Call[K] {
+	return &MockTaggedStorage_Range_Call[K]{Call: _e.mock.On("Range", fn)}
+}
+
+func (_c *MockTaggedStorage_Range_Call[K]) Run(run func(fn func(key K, value []byte) bool)) *MockTaggedStorage_Range_Call[K] {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 func(key K, value []byte) bool
+		if args[0] != nil {
+			arg0 = args[0].(func(key K, value []byte) bool)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTaggedStorage_Range_Call[K]) Return() *MockTaggedStorage_Range_Call[K] {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockTaggedStorage_Range_Call[K]) RunAndReturn(run func(fn func(key K, value []byte) bool)) *MockTaggedStorage_Range_Call[K] {
+	_c.Run(run)
+	return _c
+}
+
+// RemovePrefix provides a mock function for the type MockTaggedStorage
+func (_mock *MockTaggedStorage[K]) RemovePrefix(prefix string) int {
+	ret := _mock.Called(prefix)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemovePrefix")
+	}
+
+	var r0 int
+	if returnFunc, ok := ret.Get(0).(func(string) int); ok {
+		r0 = returnFunc(prefix)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	return r0
+}
+
+// MockTaggedStorage_RemovePrefix_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemovePrefix'
+type MockTaggedStorage_RemovePrefix_Call[K comparable] struct {
+	*mock.Call
+}
+
+// RemovePrefix is a helper method to define mock.On call
+//   - prefix string
+func (_e *MockTaggedStorage_Expecter[K]) RemovePrefix(prefix any) *MockTaggedStorage_RemovePrefix_Call[K] {
+	return &MockTaggedStorage_RemovePrefix_Call[K]{Call: _e.mock.On("RemovePrefix", prefix)}
+}
+
+func (_c *MockTaggedStorage_RemovePrefix_Call[K]) Run(run func(prefix string)) *MockTaggedStorage_RemovePrefix_Call[K] {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTaggedStorage_RemovePrefix_Call[K]) Return(n int) *MockTaggedStorage_RemovePrefix_Call[K] {
+	_c.Call.Return(n)
+	return _c
+}
+
+func (_c *MockTaggedStorage_RemovePrefix_Call[K]) RunAndReturn(run func(prefix string) int) *MockTaggedStorage_RemovePrefix_Call[K] {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveTag provides a mock function for the type MockTaggedStorage
+func (_mock *MockTaggedStorage[K]) RemoveTag(tag string) int {
+	ret := _mock.Called(tag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveTag")
+	}
+
+	var r0 int
+	if returnFunc, ok := ret.Get(0).(func(string) int); ok {
+		r0 = returnFunc(tag)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	return r0
+}
+
+// MockTaggedStorage_RemoveTag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveTag'
+type MockTaggedStorage_RemoveTag_Call[K comparable] struct {
+	*mock.Call
+}
+
+// RemoveTag is a helper method to define mock.On call
+//   - tag string
+func (_e *MockTaggedStorage_Expecter[K]) RemoveTag(tag any) *MockTaggedStorage_RemoveTag_Call[K] {
+	return &MockTaggedStorage_RemoveTag_Call[K]{Call: _e.mock.On("RemoveTag", tag)}
+}
+
+func (_c *MockTaggedStorage_RemoveTag_Call[K]) Run(run func(tag string)) *MockTaggedStorage_RemoveTag_Call[K] {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTaggedStorage_RemoveTag_Call[K]) Return(n int) *MockTaggedStorage_RemoveTag_Call[K] {
+	_c.Call.Return(n)
+	return _c
+}
+
+func (_c *MockTaggedStorage_RemoveTag_Call[K]) RunAndReturn(run func(tag string) int) *MockTaggedStorage_RemoveTag_Call[K] {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Set provides a mock function for the type MockTaggedStorage
+func (_mock *MockTaggedStorage[K]) Set(key K, b []byte) {
+	_mock.Called(key, b)
+	return
+}
+
+// MockTaggedStorage_Set_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Set'
+type MockTaggedStorage_Set_Call[K comparable] struct {
+	*mock.Call
+}
+
+// Set is a helper method to define mock.On call
+//   - key K
+//   - b []byte
+func (_e *MockTaggedStorage_Expecter[K]) Set(key any, b any) *MockTaggedStorage_Set_Call[K] {
+	return &MockTaggedStorage_Set_Call[K]{Call: _e.mock.On("Set", key, b)}
+}
+
+func (_c *MockTaggedStorage_Set_Call[K]) Run(run func(key K, b []byte)) *MockTaggedStorage_Set_Call[K] {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 K
+		if args[0] != nil {
+			arg0 = args[0].(K)
+		}
+		var arg1 []byte
+		if args[1] != nil {
+			arg1 = args[1].([]byte)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTaggedStorage_Set_Call[K]) Return() *MockTaggedStorage_Set_Call[K] {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockTaggedStorage_Set_Call[K]) RunAndReturn(run func(key K, b []byte)) *MockTaggedStorage_Set_Call[K] {
+	_c.Run(run)
+	return _c
+}
+
+// SetWithTags provides a mock function for the type MockTaggedStorage
+func (_mock *MockTaggedStorage[K]) SetWithTags(key K, b []byte, dieAt int64, tags []string) {
+	_mock.Called(key, b, dieAt, tags)
+	return
+}
+
+// MockTaggedStorage_SetWithTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetWithTags'
+type MockTaggedStorage_SetWithTags_Call[K comparable] struct {
+	*mock.Call
+}
+
+// SetWithTags is a helper method to define mock.On call
+//   - key K
+//   - b []byte
+//   - dieAt int64
+//   - tags []string
+func (_e *MockTaggedStorage_Expecter[K]) SetWithTags(key any, b any, dieAt any, tags any) *MockTaggedStorage_SetWithTags_Call[K] {
+	return &MockTaggedStorage_SetWithTags_Call[K]{Call: _e.mock.On("SetWithTags", key, b, dieAt, tags)}
+}
+
+func (_c *MockTaggedStorage_SetWithTags_Call[K]) Run(run func(key K, b []byte, dieAt int64, tags []string)) *MockTaggedStorage_SetWithTags_Call[K] {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 K
+		if args[0] != nil {
+			arg0 = args[0].(K)
+		}
+		var arg1 []byte
+		if args[1] != nil {
+			arg1 = args[1].([]byte)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		var arg3 []string
+		if args[3] != nil {
+			arg3 = args[3].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTaggedStorage_SetWithTags_Call[K]) Return() *MockTaggedStorage_SetWithTags_Call[K] {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockTaggedStorage_SetWithTags_Call[K]) RunAndReturn(run func(key K, b []byte, dieAt int64, tags []string)) *MockTaggedStorage_SetWithTags_Call[K] {
+	_c.Run(run)
+	return _c
+}
+
+// TagIndexed provides a mock function for the type MockTaggedStorage
+func (_mock *MockTaggedStorage[K]) TagIndexed() bool {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for TagIndexed")
+	}
+
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func() bool); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	return r0
+}
+
+// MockTaggedStorage_TagIndexed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TagIndexed'
+type MockTaggedStorage_TagIndexed_Call[K comparable] struct {
+	*mock.Call
+}
+
+// TagIndexed is a helper method to define mock.On call
+func (_e *MockTaggedStorage_Expecter[K]) TagIndexed() *MockTaggedStorage_TagIndexed_Call[K] {
+	return &MockTaggedStorage_TagIndexed_Call[K]{Call: _e.mock.On("TagIndexed")}
+}
+
+func (_c *MockTaggedStorage_TagIndexed_Call[K]) Run(run func()) *MockTaggedStorage_TagIndexed_Call[K] {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockTaggedStorage_TagIndexed_Call[K]) Return(b bool) *MockTaggedStorage_TagIndexed_Call[K] {
+	_c.Call.Return(b)
+	return _c
+}
+
+func (_c *MockTaggedStorage_TagIndexed_Call[K]) RunAndReturn(run func() bool) *MockTaggedStorage_TagIndexed_Call[K] {
+	_c.Call.Return(run)
+	return _c
+}
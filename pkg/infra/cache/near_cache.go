@@ -0,0 +1,319 @@
+package cache
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/errs"
+	"github.com/ElfAstAhe/go-service-template/pkg/infra/pubsub"
+	"github.com/ElfAstAhe/go-service-template/pkg/logger"
+	"github.com/google/uuid"
+)
+
+// Invalidation - сообщение об инвалидации локального кэша реплик
+type Invalidation[K comparable] struct {
+	// Cache имя кэша, реплики игнорируют сообщения чужих кэшей в общем канале
+	Cache string `json:"cache"`
+	// Source идентификатор экземпляра-источника, собственные сообщения игнорируются
+	Source string `json:"source"`
+	// Keys инвалидируемые ключи
+	Keys []K `json:"keys,omitempty"`
+	// Tags инвалидируемые теги
+	Tags []string `json:"tags,omitempty"`
+	// Prefixes инвалидируемые префиксы строковых ключей
+	Prefixes []string `json:"prefixes,omitempty"`
+	// All полная очистка (Clear)
+	All bool `json:"all,omitempty"`
+}
+
+// InvalidationPublisher - канал рассылки инвалидаций: локально pubsub.EventDispatcher,
+// между репликами RedisInvalidationBus или AMQPInvalidationBus
+type InvalidationPublisher[K comparable] interface {
+	pubsub.Publisher[*Invalidation[K]]
+}
+
+// NearCache - двухуровневый кэш: L1 в памяти процесса и L2 общий для реплик (например, на RedisStorage).
+// Чтение сначала из L1, затем из L2 с заполнением L1 на более короткий TTL (не дольше остатка жизни значения в L2).
+// Запись и удаление выполняются в оба уровня, остальным репликам рассылается инвалидация их L1.
+type NearCache[K comparable, V any] struct {
+	name      string
+	id        string
+	local     Cache[K, V]
+	shared    Cache[K, V]
+	localTTL  time.Duration
+	publisher InvalidationPublisher[K]
+	log       logger.Logger
+}
+
+var _ Cache[string, any] = (*NearCache[string, any])(nil)
+var _ pubsub.Observer[*Invalidation[string]] = (*NearCache[string, any])(nil)
+
+// NewNearCache создает двухуровневый кэш и подписывает его на инвалидации publisher,
+// localTTL верхняя граница времени жизни значения в L1 (окно возможной несогласованности)
+func NewNearCache[K comparable, V any](
+	name string,
+	local Cache[K, V],
+	shared Cache[K, V],
+	localTTL time.Duration,
+	publisher InvalidationPublisher[K],
+	log logger.Logger,
+) *NearCache[K, V] {
+	res := &NearCache[K, V]{
+		name:      name,
+		id:        uuid.NewString(),
+		local:     local,
+		shared:    shared,
+		localTTL:  localTTL,
+		publisher: publisher,
+		log:       log.GetLogger(name),
+	}
+	if res.publisher != nil {
+		res.publisher.Register(res)
+	}
+
+	return res
+}
+
+func (nc *NearCache[K, V]) Get(key K) (V, bool, error) {
+	res, ok, err := nc.local.Get(key)
+	if err == nil && ok {
+		return res, true, nil
+	}
+	if err != nil {
+		// поврежденное локальное значение не должно блокировать чтение из L2
+		nc.log.Warnf("near cache %s local get failed: %v", nc.GetName(), err)
+		nc.local.Delete(key)
+	}
+
+	res, dieAt, ok, err := nc.sharedGet(key)
+	if err != nil || !ok {
+		return res, ok, err
+	}
+	nc.fillLocal(key, res, dieAt)
+
+	return res, true, nil
+}
+
+// GetOrLoad чтение из L1, затем загрузка через L2 (дедупликация и негативное кэширование на стороне L2)
+func (nc *NearCache[K, V]) GetOrLoad(ctx context.Context, key K, loader Loader[K, V], ttl time.Duration) (V, bool, error) {
+	res, ok, err := nc.local.Get(key)
+	if err == nil && ok {
+		return res, true, nil
+	}
+	if err != nil {
+		nc.log.Warnf("near cache %s local get failed: %v", nc.GetName(), err)
+		nc.local.Delete(key)
+	}
+
+	var loaded atomic.Bool
+	res, found, err := nc.shared.GetOrLoad(ctx, key, func(ctx context.Context, key K) (V, bool, error) {
+		loaded.Store(true)
+
+		return loader(ctx, key)
+	}, ttl)
+	if err != nil || !found {
+		return res, found, err
+	}
+	if loaded.Load() {
+		// значение только что записано в L2 на ttl
+		if err := nc.local.Set(key, res, nc.boundLocalTTL(ttl)); err != nil {
+			nc.log.Warnf("near cache %s local set failed: %v", nc.GetName(), err)
+		}
+
+		return res, true, nil
+	}
+	// значение взято из L2 (или загружено другим вызовом), время жизни в L1 по остатку жизни в L2
+	if reader, ok := nc.shared.(ExpiryReader[K, V]); ok {
+		if _, dieAt, ok, err := reader.GetWithExpiry(key); err == nil && ok {
+			nc.fillLocal(key, res, dieAt)
+		}
+
+		return res, true, nil
+	}
+	if err := nc.local.Set(key, res, nc.boundLocalTTL(ttl)); err != nil {
+		nc.log.Warnf("near cache %s local set failed: %v", nc.GetName(), err)
+	}
+
+	return res, true, nil
+}
+
+func (nc *NearCache[K, V]) Set(key K, value V, ttl time.Duration) error {
+	if err := nc.shared.Set(key, value, ttl); err != nil {
+		// L1 не должен хранить значение, которого нет в L2
+		nc.local.Delete(key)
+		nc.publish(&Invalidation[K]{Keys: []K{key}})
+
+		return errs.NewCommonError("near cache shared set failed", err)
+	}
+	if err := nc.local.Set(key, value, nc.boundLocalTTL(ttl)); err != nil {
+		nc.local.Delete(key)
+		nc.log.Warnf("near cache %s local set failed: %v", nc.GetName(), err)
+	}
+	nc.publish(&Invalidation[K]{Keys: []K{key}})
+
+	return nil
+}
+
+// SetWithTags запись с тегами в L2, в L1 значение записывается без тегов (см. InvalidateTag)
+func (nc *NearCache[K, V]) SetWithTags(key K, value V, ttl time.Duration, tags ...string) error {
+	if err := nc.shared.SetWithTags(key, value, ttl, tags...); err != nil {
+		nc.local.Delete(key)
+		nc.publish(&Invalidation[K]{Keys: []K{key}})
+
+		return errs.NewCommonError("near cache shared set failed", err)
+	}
+	if err := nc.local.Set(key, value, nc.boundLocalTTL(ttl)); err != nil {
+		nc.local.Delete(key)
+		nc.log.Warnf("near cache %s local set failed: %v", nc.GetName(), err)
+	}
+	nc.publish(&Invalidation[K]{Keys: []K{key}})
+
+	return nil
+}
+
+// InvalidateTag инвалидация тега в L2, возвращает кол-во удаленных значений.
+// Теги значений, заполненных в L1 из L2, неизвестны, поэтому L1 (здесь и у реплик) очищается целиком.
+func (nc *NearCache[K, V]) InvalidateTag(tag string) (int, error) {
+	res, err := nc.shared.InvalidateTag(tag)
+	if err != nil {
+		return 0, err
+	}
+	nc.local.Clear()
+	nc.publish(&Invalidation[K]{Tags: []string{tag}})
+
+	return res, nil
+}
+
+// InvalidatePrefix инвалидация по префиксу ключа в обоих уровнях, возвращает кол-во удаленных из L2
+func (nc *NearCache[K, V]) InvalidatePrefix(prefix string) (int, error) {
+	res, err := nc.shared.InvalidatePrefix(prefix)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := nc.local.InvalidatePrefix(prefix); err != nil {
+		nc.log.Warnf("near cache %s local invalidate prefix failed: %v", nc.GetName(), err)
+	}
+	nc.publish(&Invalidation[K]{Prefixes: []string{prefix}})
+
+	return res, nil
+}
+
+func (nc *NearCache[K, V]) Delete(key K) {
+	nc.shared.Delete(key)
+	nc.local.Delete(key)
+	nc.publish(&Invalidation[K]{Keys: []K{key}})
+}
+
+// RangeKeys обход ключей общего (L2) кэша, если он поддерживает обход
+func (nc *NearCache[K, V]) RangeKeys(fn func(key K) bool) {
+	if ranger, ok := nc.shared.(KeyRanger[K]); ok {
+		ranger.RangeKeys(fn)
+	}
+}
+
+// Size размер общего (L2) кэша
+func (nc *NearCache[K, V]) Size() int {
+	return nc.shared.Size()
+}
+
+func (nc *NearCache[K, V]) Clear() {
+	nc.shared.Clear()
+	nc.local.Clear()
+	nc.publish(&Invalidation[K]{All: true})
+}
+
+func (nc *NearCache[K, V]) CacheJanitor(ctx context.Context, eventTime time.Time) error {
+	if err := nc.local.CacheJanitor(ctx, eventTime); err != nil {
+		return err
+	}
+
+	return nc.shared.CacheJanitor(ctx, eventTime)
+}
+
+// GetName имя кэша, используется также как имя наблюдателя инвалидаций
+func (nc *NearCache[K, V]) GetName() string {
+	return nc.name
+}
+
+// OnNotify применение инвалидации, полученной от другой реплики
+func (nc *NearCache[K, V]) OnNotify(ctx context.Context, msg *Invalidation[K]) error {
+	if msg == nil || msg.Cache != nc.name || msg.Source == nc.id {
+		return nil
+	}
+	if msg.All || len(msg.Tags) > 0 {
+		nc.local.Clear()
+
+		return nil
+	}
+	for _, key := range msg.Keys {
+		nc.local.Delete(key)
+	}
+	for _, prefix := range msg.Prefixes {
+		if _, err := nc.local.InvalidatePrefix(prefix); err != nil {
+			nc.log.Warnf("near cache %s local invalidate prefix failed: %v", nc.GetName(), err)
+		}
+	}
+
+	return nil
+}
+
+// Close отписка от инвалидаций
+func (nc *NearCache[K, V]) Close() error {
+	if nc.publisher != nil {
+		nc.publisher.Unregister(nc)
+	}
+
+	return nil
+}
+
+func (nc *NearCache[K, V]) GetLocal() Cache[K, V] {
+	return nc.local
+}
+
+func (nc *NearCache[K, V]) GetShared() Cache[K, V] {
+	return nc.shared
+}
+
+// sharedGet чтение из L2 с моментом истечения (unix nano, 0 - бессрочно или неизвестно)
+func (nc *NearCache[K, V]) sharedGet(key K) (V, int64, bool, error) {
+	if reader, ok := nc.shared.(ExpiryReader[K, V]); ok {
+		return reader.GetWithExpiry(key)
+	}
+	res, ok, err := nc.shared.Get(key)
+
+	return res, 0, ok, err
+}
+
+// fillLocal заполнение L1 значением из L2: не дольше localTTL и остатка жизни значения в L2,
+// значение L2 в льготный период (уже истекшее) в L1 не попадает
+func (nc *NearCache[K, V]) fillLocal(key K, value V, dieAt int64) {
+	ttl := nc.localTTL
+	if dieAt > 0 {
+		remaining := time.Until(time.Unix(0, dieAt))
+		if remaining <= 0 {
+			return
+		}
+		ttl = nc.boundLocalTTL(remaining)
+	}
+	if err := nc.local.Set(key, value, ttl); err != nil {
+		nc.log.Warnf("near cache %s local set failed: %v", nc.GetName(), err)
+	}
+}
+
+func (nc *NearCache[K, V]) boundLocalTTL(ttl time.Duration) time.Duration {
+	if ttl <= 0 || (nc.localTTL > 0 && ttl > nc.localTTL) {
+		return nc.localTTL
+	}
+
+	return ttl
+}
+
+func (nc *NearCache[K, V]) publish(msg *Invalidation[K]) {
+	if nc.publisher == nil {
+		return
+	}
+	msg.Cache = nc.name
+	msg.Source = nc.id
+	nc.publisher.Notify(context.Background(), msg)
+}
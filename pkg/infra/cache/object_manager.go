@@ -0,0 +1,339 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"hash/maphash"
+	"time"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/errs"
+	"github.com/ElfAstAhe/go-service-template/pkg/utils"
+)
+
+// CloneFunc копирование значения, защищает кэшированный объект от изменения вызывающей стороной
+type CloneFunc[V any] func(V) V
+
+// ObjectManager - реализация Cache[K, V] без сериализации: значения хранятся в памяти процесса как есть.
+// Без WithObjectClone вызывающая сторона получает тот же объект, что хранится в кэше, изменять его нельзя.
+// Фоновое обновление (Refresher) и бюджет объема в байтах доступны только для Manager.
+type ObjectManager[K comparable, V any] struct {
+	shards   []*ObjectStorage[K, objectValue[V]]
+	hashSeed maphash.Seed
+	// clone копирование значения при записи и чтении, nil - без копирования
+	clone CloneFunc[V]
+	// nilValue пустое значение
+	nilValue V
+	// janitorMaxSize максимальное кол-во элементов на удаление по TTL
+	janitorMaxSize int
+	// negativeTTL время жизни негативного результата загрузки, 0 - негативные результаты не кэшируются
+	negativeTTL time.Duration
+	// loads дедупликация одновременных загрузок
+	loads *loadGroup[K, V]
+	// stats приемник событий кэша (метрики)
+	stats StatsRecorder
+}
+
+// objectValue значение в шарде, negative - явный признак негативного результата GetOrLoad
+// (пустое значение V, не допускающего nil, не отличить от найденного нулевого значения)
+type objectValue[V any] struct {
+	value    V
+	negative bool
+}
+
+var _ Cache[string, any] = (*ObjectManager[string, any])(nil)
+var _ ExpiryReader[string, any] = (*ObjectManager[string, any])(nil)
+
+type ObjectManagerOption[K comparable, V any] func(*ObjectManager[K, V])
+
+// WithObjectClone копирование значений при записи и чтении (copy-on-read), nil значения не копируются
+func WithObjectClone[K comparable, V any](clone CloneFunc[V]) ObjectManagerOption[K, V] {
+	return func(om *ObjectManager[K, V]) {
+		om.clone = clone
+	}
+}
+
+// WithObjectStats приемник событий кэша (например, MetricsRecorder)
+func WithObjectStats[K comparable, V any](stats StatsRecorder) ObjectManagerOption[K, V] {
+	return func(om *ObjectManager[K, V]) {
+		om.stats = stats
+	}
+}
+
+// WithObjectNegativeTTL кэширование негативных результатов GetOrLoad на ttl
+func WithObjectNegativeTTL[K comparable, V any](ttl time.Duration) ObjectManagerOption[K, V] {
+	return func(om *ObjectManager[K, V]) {
+		om.negativeTTL = ttl
+	}
+}
+
+// NewObjectManager создает кэш объектов: емкость maxSize (кол-во элементов) задается на каждый из shardCount шардов,
+// каждый шард получает собственный экземпляр политики вытеснения от policyFactory (nil - LRU)
+func NewObjectManager[K comparable, V any](
+	shardCount uint64,
+	maxSize int,
+	policyFactory EvictionPolicyFactory[K],
+	janitorMaxSize int,
+	opts ...ObjectManagerOption[K, V],
+) *ObjectManager[K, V] {
+	if policyFactory == nil {
+		policyFactory = lruEvictFactory[K]
+	}
+	shardCount = max(shardCount, 1)
+	res := &ObjectManager[K, V]{
+		shards:         make([]*ObjectStorage[K, objectValue[V]], 0, shardCount),
+		hashSeed:       maphash.MakeSeed(),
+		janitorMaxSize: janitorMaxSize,
+		loads:          newLoadGroup[K, V](),
+		stats:          nopStatsRecorder{},
+	}
+	for i := uint64(0); i < shardCount; i++ {
+		res.shards = append(res.shards, NewObjectStorage[K, objectValue[V]](maxSize, policyFactory(policyCapacity(maxSize))))
+	}
+	for _, opt := range opts {
+		opt(res)
+	}
+
+	return res
+}
+
+func (om *ObjectManager[K, V]) Get(key K) (V, bool, error) {
+	res, _, ok, err := om.GetWithExpiry(key)
+
+	return res, ok, err
+}
+
+// GetWithExpiry чтение значения вместе с моментом истечения (unix nano, 0 - бессрочно), негативный результат - промах
+func (om *ObjectManager[K, V]) GetWithExpiry(key K) (V, int64, bool, error) {
+	entry, dieAt, ok := om.getEntry(key)
+	if !ok || om.negative(entry) {
+		return om.nilValue, 0, false, nil
+	}
+
+	return om.copy(entry.value), dieAt, true, nil
+}
+
+// getEntry чтение значения шарда с проверкой TTL
+func (om *ObjectManager[K, V]) getEntry(key K) (objectValue[V], int64, bool) {
+	shard := om.shard(key)
+	entry, dieAt, ok := shard.Get(key)
+	if !ok {
+		om.stats.Miss()
+
+		return entry, 0, false
+	}
+	// Проверка TTL (ленивое удаление)
+	if dieAt > 0 && time.Now().UnixNano() > dieAt {
+		shard.Delete(key)
+		om.stats.Expired()
+		om.stats.Miss()
+
+		return objectValue[V]{}, 0, false
+	}
+	om.stats.Hit()
+
+	return entry, dieAt, true
+}
+
+// GetOrLoad получение значения с загрузкой при промахе, семантика как у Manager.GetOrLoad
+func (om *ObjectManager[K, V]) GetOrLoad(ctx context.Context, key K, loader Loader[K, V], ttl time.Duration) (V, bool, error) {
+	if res, ok, hit := om.getLoaded(key); hit {
+		return res, ok, nil
+	}
+
+	res, found, shared, err := om.loads.Do(ctx, key, func() (V, bool, error) {
+		// значение могло быть загружено, пока ожидали очередь
+		if res, ok, hit := om.getLoaded(key); hit {
+			return res, ok, nil
+		}
+		res, found, err := loader(ctx, key)
+		if err != nil {
+			return om.nilValue, false, err
+		}
+		if !found {
+			if om.negativeTTL > 0 {
+				om.setNegative(key, om.negativeTTL)
+			}
+
+			return om.nilValue, false, nil
+		}
+		_ = om.Set(key, res, ttl)
+
+		return res, true, nil
+	})
+	if shared && err == nil && found {
+		// результат загрузки общий для ожидающих вызовов, каждый получает свою копию
+		return om.copy(res), true, nil
+	}
+
+	return res, found, err
+}
+
+// getLoaded чтение для GetOrLoad, hit=false - промах
+func (om *ObjectManager[K, V]) getLoaded(key K) (res V, found bool, hit bool) {
+	entry, _, ok := om.getEntry(key)
+	if !ok {
+		return om.nilValue, false, false
+	}
+	if om.negative(entry) {
+		return om.nilValue, false, true
+	}
+
+	return om.copy(entry.value), true, true
+}
+
+// negative значение негативного результата: явный признак или пустое значение при кэшировании негативных результатов
+func (om *ObjectManager[K, V]) negative(entry objectValue[V]) bool {
+	return entry.negative || (om.negativeTTL > 0 && utils.IsNil(entry.value))
+}
+
+func (om *ObjectManager[K, V]) Set(key K, value V, ttl time.Duration) error {
+	om.shard(key).Set(key, objectValue[V]{value: om.copy(value)}, expiryDieAt(ttl))
+	om.stats.Set()
+
+	return nil
+}
+
+// setNegative сохранение негативного результата на ttl с явным признаком
+func (om *ObjectManager[K, V]) setNegative(key K, ttl time.Duration) {
+	om.shard(key).Set(key, objectValue[V]{value: om.nilValue, negative: true}, expiryDieAt(ttl))
+	om.stats.Set()
+}
+
+// SetWithTags сохранение с тегами для групповой инвалидации (InvalidateTag), заменяет прежние теги ключа
+func (om *ObjectManager[K, V]) SetWithTags(key K, value V, ttl time.Duration, tags ...string) error {
+	if tags == nil {
+		// пустой список тегов снимает прежние теги ключа
+		tags = []string{}
+	}
+	om.shard(key).SetWithTags(key, objectValue[V]{value: om.copy(value)}, expiryDieAt(ttl), tags)
+	om.stats.Set()
+
+	return nil
+}
+
+// InvalidateTag удаление всех значений с тегом, возвращает кол-во удаленных значений
+func (om *ObjectManager[K, V]) InvalidateTag(tag string) (int, error) {
+	var removed int
+	for _, shard := range om.shards {
+		removed += shard.RemoveTag(tag)
+	}
+
+	return removed, nil
+}
+
+// InvalidatePrefix удаление всех значений, ключ которых начинается с prefix (только для строковых ключей)
+func (om *ObjectManager[K, V]) InvalidatePrefix(prefix string) (int, error) {
+	var key K
+	if _, ok := any(key).(string); !ok {
+		return 0, errs.NewDalCacheError("ObjectManager.InvalidatePrefix", fmt.Sprintf("cache key type [%T] is not string", key), nil)
+	}
+	var removed int
+	for _, shard := range om.shards {
+		removed += shard.RemovePrefix(prefix)
+	}
+
+	return removed, nil
+}
+
+func (om *ObjectManager[K, V]) Delete(key K) {
+	om.shard(key).Delete(key)
+	om.stats.Delete()
+}
+
+// GetNegativeTTL время жизни негативного результата, 0 - не кэшируется
+func (om *ObjectManager[K, V]) GetNegativeTTL() time.Duration {
+	return om.negativeTTL
+}
+
+// RangeKeys обход ключей шардов, вызывать методы кэша из fn нельзя
+func (om *ObjectManager[K, V]) RangeKeys(fn func(key K) bool) {
+	for _, shard := range om.shards {
+		stop := false
+		shard.Range(func(key K, _ objectValue[V]) bool {
+			if !fn(key) {
+				stop = true
+
+				return false
+			}
+
+			return true
+		})
+		if stop {
+			break
+		}
+	}
+}
+
+func (om *ObjectManager[K, V]) Size() int {
+	var total int
+	for _, shard := range om.shards {
+		total += shard.Len()
+	}
+
+	return total
+}
+
+func (om *ObjectManager[K, V]) Clear() {
+	for _, shard := range om.shards {
+		shard.Clear()
+	}
+}
+
+// SetEvictionListener установка обработчика вытеснений всем шардам
+func (om *ObjectManager[K, V]) SetEvictionListener(listener EvictionListener[K]) {
+	for _, shard := range om.shards {
+		shard.SetEvictionListener(listener)
+	}
+}
+
+// CacheJanitor вызывается планировщиком для периодической очистки просрочки по индексам шардов,
+// janitorMaxSize общий на все шарды
+func (om *ObjectManager[K, V]) CacheJanitor(ctx context.Context, eventTime time.Time) (err error) {
+	var removed int
+	defer func(start time.Time) {
+		om.stats.Janitor(removed, err, start)
+	}(time.Now())
+
+	now := eventTime.UnixNano()
+	for _, shard := range om.shards {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if om.janitorMaxSize > 0 && removed >= om.janitorMaxSize {
+			break
+		}
+		shardLimit := 0
+		if om.janitorMaxSize > 0 {
+			shardLimit = om.janitorMaxSize - removed
+		}
+		removed += shard.RemoveExpired(now, shardLimit)
+	}
+
+	return nil
+}
+
+func (om *ObjectManager[K, V]) shard(key K) *ObjectStorage[K, objectValue[V]] {
+	if len(om.shards) == 1 {
+		return om.shards[0]
+	}
+
+	return om.shards[maphash.Comparable(om.hashSeed, key)%uint64(len(om.shards))]
+}
+
+// copy копия значения через clone, nil значения (негативные результаты) не копируются
+func (om *ObjectManager[K, V]) copy(value V) V {
+	if om.clone == nil || utils.IsNil(value) {
+		return value
+	}
+
+	return om.clone(value)
+}
+
+// expiryDieAt момент истечения для ttl (unix nano), 0 - бессрочно
+func expiryDieAt(ttl time.Duration) int64 {
+	if ttl <= 0 {
+		return 0
+	}
+
+	return time.Now().Add(ttl).UnixNano()
+}
@@ -0,0 +1,233 @@
+package cache
+
+import (
+	"strings"
+	"sync"
+)
+
+// objectEntry - значение объектного хранилища с метаданными истечения и тегами
+type objectEntry[K comparable, V any] struct {
+	value  V
+	expiry expiryItem[K]
+	tags   []string
+}
+
+// ObjectStorage - хранилище кэша в памяти процесса, хранящее значения V как есть, без сериализации.
+// Как и RawStorage, ведет политику вытеснения, индекс просрочки и индекс тегов. Значения не копируются,
+// защиту от изменения кэшированных значений обеспечивает ObjectManager (WithObjectClone).
+type ObjectStorage[K comparable, V any] struct {
+	mu      sync.RWMutex
+	data    map[K]*objectEntry[K, V]
+	expiry  *expiryIndex[K]
+	tags    *tagIndex[K]
+	policy  EvictionPolicy[K]
+	maxSize int
+	// onEvict уведомление о вытеснении, вызывается под блокировкой хранилища
+	onEvict  EvictionListener[K]
+	nilValue V
+}
+
+var _ EvictionReporter[string] = (*ObjectStorage[string, any])(nil)
+
+func NewObjectStorage[K comparable, V any](maxSize int, policy EvictionPolicy[K]) *ObjectStorage[K, V] {
+	return &ObjectStorage[K, V]{
+		data:    make(map[K]*objectEntry[K, V]),
+		expiry:  newExpiryIndex[K](),
+		tags:    newTagIndex[K](),
+		policy:  policy,
+		maxSize: maxSize,
+	}
+}
+
+// Get значение и момент его истечения (unix nano, 0 - бессрочно)
+func (obs *ObjectStorage[K, V]) Get(key K) (V, int64, bool) {
+	obs.mu.Lock() // Lock, так как OnGet политики меняет ее состояние
+	defer obs.mu.Unlock()
+
+	entry, ok := obs.data[key]
+	if !ok {
+		return obs.nilValue, 0, false
+	}
+	obs.policy.OnGet(key)
+
+	return entry.value, entry.expiry.dieAt, true
+}
+
+// Set сохранение значения с моментом истечения (unix nano, 0 - бессрочно), теги ключа сохраняются
+func (obs *ObjectStorage[K, V]) Set(key K, value V, dieAt int64) {
+	obs.mu.Lock()
+	defer obs.mu.Unlock()
+
+	obs.set(key, value, dieAt, nil, false)
+}
+
+// SetWithTags сохранение значения с моментом истечения и тегами, прежние теги ключа заменяются
+func (obs *ObjectStorage[K, V]) SetWithTags(key K, value V, dieAt int64, tags []string) {
+	obs.mu.Lock()
+	defer obs.mu.Unlock()
+
+	obs.set(key, value, dieAt, tags, true)
+}
+
+func (obs *ObjectStorage[K, V]) Delete(key K) {
+	obs.mu.Lock()
+	defer obs.mu.Unlock()
+
+	obs.remove(key)
+}
+
+// Range обход значений под блокировкой чтения, вызывать методы хранилища из fn нельзя
+func (obs *ObjectStorage[K, V]) Range(fn func(key K, value V) bool) {
+	obs.mu.RLock()
+	defer obs.mu.RUnlock()
+
+	for k, entry := range obs.data {
+		if !fn(k, entry.value) {
+			break
+		}
+	}
+}
+
+// Has проверяет наличие ключа без влияния на политику вытеснения
+func (obs *ObjectStorage[K, V]) Has(key K) bool {
+	obs.mu.RLock()
+	defer obs.mu.RUnlock()
+
+	_, ok := obs.data[key]
+
+	return ok
+}
+
+func (obs *ObjectStorage[K, V]) Len() int {
+	obs.mu.RLock()
+	defer obs.mu.RUnlock()
+
+	return len(obs.data)
+}
+
+func (obs *ObjectStorage[K, V]) Clear() {
+	obs.mu.Lock()
+	defer obs.mu.Unlock()
+
+	obs.data = make(map[K]*objectEntry[K, V])
+	obs.expiry.reset()
+	obs.tags.reset()
+	obs.policy.Reset()
+}
+
+// RemoveExpired удаление значений, истекших к моменту now (unix nano), не более limit (0 - без ограничения)
+func (obs *ObjectStorage[K, V]) RemoveExpired(now int64, limit int) int {
+	obs.mu.Lock()
+	defer obs.mu.Unlock()
+
+	var removed int
+	for limit <= 0 || removed < limit {
+		item, ok := obs.expiry.peek()
+		if !ok || item.dieAt >= now {
+			break
+		}
+		obs.remove(item.key)
+		removed++
+	}
+
+	return removed
+}
+
+// RemoveTag удаление всех значений с тегом
+func (obs *ObjectStorage[K, V]) RemoveTag(tag string) int {
+	obs.mu.Lock()
+	defer obs.mu.Unlock()
+
+	keys := obs.tags.keys(tag)
+	for _, key := range keys {
+		obs.remove(key)
+	}
+
+	return len(keys)
+}
+
+// RemovePrefix удаление всех значений со строковым ключом, начинающимся с prefix, O(n) по кол-ву значений
+func (obs *ObjectStorage[K, V]) RemovePrefix(prefix string) int {
+	obs.mu.Lock()
+	defer obs.mu.Unlock()
+
+	var keys []K
+	for key := range obs.data {
+		if str, ok := any(key).(string); ok && strings.HasPrefix(str, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	for _, key := range keys {
+		obs.remove(key)
+	}
+
+	return len(keys)
+}
+
+// SetEvictionListener установка обработчика вытеснений
+func (obs *ObjectStorage[K, V]) SetEvictionListener(listener EvictionListener[K]) {
+	obs.mu.Lock()
+	defer obs.mu.Unlock()
+
+	obs.onEvict = listener
+}
+
+func (obs *ObjectStorage[K, V]) set(key K, value V, dieAt int64, tags []string, retag bool) {
+	entry, exists := obs.data[key]
+	// Выселяем, пока не освободится место
+	for !exists && obs.maxSize > 0 && len(obs.data) >= obs.maxSize {
+		victim, ok := obs.policy.Evict()
+		if !ok {
+			break
+		}
+		obs.evict(victim)
+	}
+
+	if !exists {
+		entry = &objectEntry[K, V]{expiry: expiryItem[K]{key: key, index: -1}}
+		obs.data[key] = entry
+	}
+	entry.value = value
+	obs.setExpiry(entry, dieAt)
+	if retag {
+		entry.tags = obs.tags.set(key, entry.tags, tags)
+	}
+	obs.policy.OnSet(key)
+}
+
+func (obs *ObjectStorage[K, V]) setExpiry(entry *objectEntry[K, V], dieAt int64) {
+	switch {
+	case entry.expiry.index >= 0 && entry.expiry.dieAt == dieAt:
+		return
+	case entry.expiry.index >= 0:
+		obs.expiry.remove(&entry.expiry)
+	}
+	entry.expiry.dieAt = dieAt
+	if dieAt > 0 {
+		obs.expiry.push(&entry.expiry)
+	}
+}
+
+func (obs *ObjectStorage[K, V]) remove(key K) {
+	obs.drop(key)
+	obs.policy.OnRemove(key)
+}
+
+// evict удаление выбранного политикой ключа (ключ уже исключен из политики)
+func (obs *ObjectStorage[K, V]) evict(victim K) {
+	obs.drop(victim)
+	if obs.onEvict != nil {
+		obs.onEvict(victim)
+	}
+}
+
+// drop удаление значения и его метаданных без уведомления политики
+func (obs *ObjectStorage[K, V]) drop(key K) {
+	entry, ok := obs.data[key]
+	if !ok {
+		return
+	}
+	obs.expiry.remove(&entry.expiry)
+	obs.tags.remove(key, entry.tags)
+	delete(obs.data, key)
+}
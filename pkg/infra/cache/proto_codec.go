@@ -0,0 +1,72 @@
+package cache
+
+import (
+	"time"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/utils"
+	"google.golang.org/protobuf/proto"
+)
+
+// ProtoCodec - кодек для protobuf сообщений (модели pkg/api/grpc), значение упаковывается в бинарный формат кэша
+type ProtoCodec[V proto.Message] struct {
+	emptyItemFactory EmptyItemFactory[V]
+}
+
+var _ Codec[proto.Message] = (*ProtoCodec[proto.Message])(nil)
+var _ ExpiryDecoder = (*ProtoCodec[proto.Message])(nil)
+var _ NilMarshaler = (*ProtoCodec[proto.Message])(nil)
+
+// NewProtoCodec factory - создание пустого сообщения для десериализации (например, func() *pb.Test { return &pb.Test{} })
+func NewProtoCodec[V proto.Message](factory EmptyItemFactory[V]) *ProtoCodec[V] {
+	return &ProtoCodec[V]{
+		emptyItemFactory: factory,
+	}
+}
+
+func (pc *ProtoCodec[V]) Marshal(value V, ttl time.Duration) ([]byte, error) {
+	header := frameHeader{dieAt: frameDieAt(ttl)}
+	// пустое значение упаковывается без полезной нагрузки, чтобы сохранить TTL
+	if utils.IsNil(value) {
+		header.flags |= frameFlagNil
+
+		return encodeFrame(header, nil), nil
+	}
+	payload, err := proto.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return encodeFrame(header, payload), nil
+}
+
+// MarshalNil упаковка негативного результата: кадр с признаком Nil без полезной нагрузки
+func (pc *ProtoCodec[V]) MarshalNil(ttl time.Duration) ([]byte, error) {
+	return encodeFrame(frameHeader{flags: frameFlagNil, dieAt: frameDieAt(ttl)}, nil), nil
+}
+
+func (pc *ProtoCodec[V]) Unmarshal(buf []byte) (*Envelope[V], error) {
+	if len(buf) == 0 {
+		return &Envelope[V]{}, nil
+	}
+	header, payload, err := decodeFrame(buf)
+	if err != nil {
+		return &Envelope[V]{}, err
+	}
+	env := &Envelope[V]{
+		DieAt: header.dieAt,
+		Nil:   header.has(frameFlagNil),
+	}
+	if env.Nil {
+		return env, nil
+	}
+	env.Value = pc.emptyItemFactory()
+	if err := proto.Unmarshal(payload, env.Value); err != nil {
+		return env, err
+	}
+
+	return env, nil
+}
+
+func (pc *ProtoCodec[V]) DecodeExpiry(buf []byte) (int64, error) {
+	return frameExpiry(buf)
+}
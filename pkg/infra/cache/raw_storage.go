@@ -1,50 +1,188 @@
 package cache
 
 import (
+	"strings"
 	"sync"
 )
 
+// rawEntry - значение хранилища с метаданными истечения
+type rawEntry[K comparable] struct {
+	b      []byte
+	expiry expiryItem[K]
+	tags   []string
+}
+
 type RawStorage[K comparable] struct {
-	mu      sync.RWMutex
-	data    map[K][]byte
+	mu   sync.RWMutex
+	data map[K]*rawEntry[K]
+	// expiry индекс просрочки (min-heap по моменту истечения)
+	expiry  *expiryIndex[K]
 	policy  EvictionPolicy[K]
 	maxSize int
+	// bytes суммарный размер значений
+	bytes int64
+	// maxBytes бюджет суммарного размера значений, 0 - без ограничения
+	maxBytes int64
+	// maxItemBytes ограничение размера одного значения, 0 - без ограничения
+	maxItemBytes int64
+	// onEvict уведомление о вытеснении, вызывается под блокировкой хранилища
+	onEvict EvictionListener[K]
+	// tags индекс тегов: тег -> ключи
+	tags *tagIndex[K]
+}
+
+var _ EvictionReporter[string] = (*RawStorage[string])(nil)
+var _ ByteBudgeter = (*RawStorage[string])(nil)
+var _ ExpiringStorage[string] = (*RawStorage[string])(nil)
+var _ TaggedStorage[string] = (*RawStorage[string])(nil)
+var _ TagRanger[string] = (*RawStorage[string])(nil)
+
+// RawStorageOption - опции RawStorage
+type RawStorageOption[K comparable] func(*RawStorage[K])
+
+// WithRawMaxBytes бюджет суммарного размера значений в байтах, 0 - без ограничения
+func WithRawMaxBytes[K comparable](maxBytes int64) RawStorageOption[K] {
+	return func(rs *RawStorage[K]) {
+		rs.maxBytes = max(maxBytes, 0)
+	}
+}
+
+// WithRawMaxItemBytes ограничение размера одного значения в байтах, 0 - без ограничения
+func WithRawMaxItemBytes[K comparable](maxItemBytes int64) RawStorageOption[K] {
+	return func(rs *RawStorage[K]) {
+		rs.maxItemBytes = max(maxItemBytes, 0)
+	}
 }
 
-func NewRawStorage[K comparable](maxSize int, policy EvictionPolicy[K]) *RawStorage[K] {
-	return &RawStorage[K]{
-		data:    make(map[K][]byte),
+func NewRawStorage[K comparable](maxSize int, policy EvictionPolicy[K], opts ...RawStorageOption[K]) *RawStorage[K] {
+	res := &RawStorage[K]{
+		data:    make(map[K]*rawEntry[K]),
+		expiry:  newExpiryIndex[K](),
+		tags:    newTagIndex[K](),
 		maxSize: maxSize,
 		policy:  policy,
 	}
+	for _, opt := range opts {
+		opt(res)
+	}
+
+	return res
 }
 
 func (rs *RawStorage[K]) Get(key K) ([]byte, bool) {
+	b, _, ok := rs.GetWithExpiry(key)
+
+	return b, ok
+}
+
+// GetWithExpiry значение и момент его истечения (unix nano, 0 - бессрочно) без распаковки значения
+func (rs *RawStorage[K]) GetWithExpiry(key K) ([]byte, int64, bool) {
 	rs.mu.Lock() // Lock, так как OnGet в LRU/LFU меняет состояние (двигает элементы)
 	defer rs.mu.Unlock()
 
-	b, ok := rs.data[key]
-	if ok {
-		rs.policy.OnGet(key)
+	entry, ok := rs.data[key]
+	if !ok {
+		return nil, 0, false
 	}
+	rs.policy.OnGet(key)
 
-	return b, ok
+	return entry.b, entry.expiry.dieAt, true
 }
 
 func (rs *RawStorage[K]) Set(key K, b []byte) {
+	rs.SetWithExpiry(key, b, 0)
+}
+
+// SetWithExpiry сохранение значения с моментом истечения (unix nano, 0 - бессрочно)
+func (rs *RawStorage[K]) SetWithExpiry(key K, b []byte, dieAt int64) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	rs.set(key, b, dieAt, nil, false)
+}
+
+// SetWithTags сохранение значения с моментом истечения и тегами, прежние теги ключа заменяются
+func (rs *RawStorage[K]) SetWithTags(key K, b []byte, dieAt int64, tags []string) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	rs.set(key, b, dieAt, tags, true)
+}
+
+// RemoveTag удаление всех значений с тегом
+func (rs *RawStorage[K]) RemoveTag(tag string) int {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	keys := rs.tags.keys(tag)
+	for _, key := range keys {
+		rs.remove(key)
+	}
+
+	return len(keys)
+}
+
+// RemovePrefix удаление всех значений со строковым ключом, начинающимся с prefix, O(n) по кол-ву значений
+func (rs *RawStorage[K]) RemovePrefix(prefix string) int {
 	rs.mu.Lock()
 	defer rs.mu.Unlock()
 
-	// Если ключа нет, проверяем, не пора ли кого-то выселить
-	if _, exists := rs.data[key]; !exists {
-		if rs.maxSize > 0 && len(rs.data) >= rs.maxSize {
-			if victim, ok := rs.policy.Evict(); ok {
-				delete(rs.data, victim)
-			}
+	var keys []K
+	for key := range rs.data {
+		if str, ok := any(key).(string); ok && strings.HasPrefix(str, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	for _, key := range keys {
+		rs.remove(key)
+	}
+
+	return len(keys)
+}
+
+// TagIndexed все значения хранилища учтены в индексе тегов
+func (rs *RawStorage[K]) TagIndexed() bool {
+	return true
+}
+
+// set запись значения, retag - заменить теги ключа на tags, иначе теги сохраняются
+func (rs *RawStorage[K]) set(key K, b []byte, dieAt int64, tags []string, retag bool) {
+	entry, exists := rs.data[key]
+	// значение сверх ограничения не сохраняется, прежнее значение ключа удаляется, чтобы не отдавать устаревшее
+	if limit := rs.itemLimit(); limit > 0 && int64(len(b)) > limit {
+		if exists {
+			rs.remove(key)
 		}
+
+		return
+	}
+	if exists {
+		rs.bytes -= int64(len(entry.b))
 	}
 
-	rs.data[key] = b
+	// Выселяем, пока не освободится место по количеству и объему
+	for rs.overflow(exists, int64(len(b))) {
+		victim, ok := rs.policy.Evict()
+		if !ok {
+			break
+		}
+		if victim == key {
+			// перезаписываемый ключ вернется в политику через OnSet
+			continue
+		}
+		rs.evict(victim)
+	}
+
+	if !exists {
+		entry = &rawEntry[K]{expiry: expiryItem[K]{key: key, index: -1}}
+		rs.data[key] = entry
+	}
+	entry.b = b
+	rs.setExpiry(entry, dieAt)
+	if retag {
+		entry.tags = rs.tags.set(key, entry.tags, tags)
+	}
+	rs.bytes += int64(len(b))
 	rs.policy.OnSet(key)
 }
 
@@ -52,8 +190,7 @@ func (rs *RawStorage[K]) Delete(key K) {
 	rs.mu.Lock()
 	defer rs.mu.Unlock()
 
-	delete(rs.data, key)
-	rs.policy.OnRemove(key)
+	rs.remove(key)
 }
 
 func (rs *RawStorage[K]) Range(fn func(key K, value []byte) bool) {
@@ -62,8 +199,20 @@ func (rs *RawStorage[K]) Range(fn func(key K, value []byte) bool) {
 	rs.mu.RLock()
 	defer rs.mu.RUnlock()
 
-	for k, v := range rs.data {
-		if !fn(k, v) {
+	for k, entry := range rs.data {
+		if !fn(k, entry.b) {
+			break
+		}
+	}
+}
+
+// RangeWithTags обход значений с их тегами, блокировки как у Range
+func (rs *RawStorage[K]) RangeWithTags(fn func(key K, value []byte, tags []string) bool) {
+	rs.mu.RLock()
+	defer rs.mu.RUnlock()
+
+	for k, entry := range rs.data {
+		if !fn(k, entry.b, entry.tags) {
 			break
 		}
 	}
@@ -87,6 +236,140 @@ func (rs *RawStorage[K]) Clear() {
 	rs.mu.Lock()
 	defer rs.mu.Unlock()
 
-	rs.data = make(map[K][]byte)
+	rs.data = make(map[K]*rawEntry[K])
+	rs.expiry.reset()
+	rs.tags.reset()
+	rs.bytes = 0
 	rs.policy.Reset()
 }
+
+// Bytes суммарный размер значений в байтах
+func (rs *RawStorage[K]) Bytes() int64 {
+	rs.mu.RLock()
+	defer rs.mu.RUnlock()
+
+	return rs.bytes
+}
+
+// SetEvictionListener установка обработчика вытеснений
+func (rs *RawStorage[K]) SetEvictionListener(listener EvictionListener[K]) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	rs.onEvict = listener
+}
+
+// MaxBytes бюджет суммарного размера значений, 0 - без ограничения
+func (rs *RawStorage[K]) MaxBytes() int64 {
+	rs.mu.RLock()
+	defer rs.mu.RUnlock()
+
+	return rs.maxBytes
+}
+
+// MaxItemBytes действующее ограничение размера одного значения (не более бюджета), 0 - без ограничения
+func (rs *RawStorage[K]) MaxItemBytes() int64 {
+	rs.mu.RLock()
+	defer rs.mu.RUnlock()
+
+	return rs.itemLimit()
+}
+
+// SetByteBudget установка бюджета объема, при уменьшении лишние значения вытесняются сразу
+func (rs *RawStorage[K]) SetByteBudget(maxBytes int64, maxItemBytes int64) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	rs.maxBytes = max(maxBytes, 0)
+	rs.maxItemBytes = max(maxItemBytes, 0)
+	for rs.maxBytes > 0 && rs.bytes > rs.maxBytes {
+		victim, ok := rs.policy.Evict()
+		if !ok {
+			break
+		}
+		rs.evict(victim)
+	}
+}
+
+// itemLimit ограничение размера значения: значение больше всего бюджета сохранить невозможно
+func (rs *RawStorage[K]) itemLimit() int64 {
+	switch {
+	case rs.maxItemBytes > 0 && rs.maxBytes > 0:
+		return min(rs.maxItemBytes, rs.maxBytes)
+	case rs.maxItemBytes > 0:
+		return rs.maxItemBytes
+	default:
+		return rs.maxBytes
+	}
+}
+
+// overflow требуется выселение для записи значения размера size (bytes уже без прежнего значения ключа)
+func (rs *RawStorage[K]) overflow(exists bool, size int64) bool {
+	if !exists && rs.maxSize > 0 && len(rs.data) >= rs.maxSize {
+		return true
+	}
+
+	return rs.maxBytes > 0 && rs.bytes+size > rs.maxBytes
+}
+
+// RemoveExpired удаление значений, истекших к моменту now (unix nano), не более limit (0 - без ограничения).
+// Просроченные ключи извлекаются из индекса, O(expired * log n), значения не распаковываются.
+func (rs *RawStorage[K]) RemoveExpired(now int64, limit int) int {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	var removed int
+	for limit <= 0 || removed < limit {
+		item, ok := rs.expiry.peek()
+		if !ok || item.dieAt >= now {
+			break
+		}
+		rs.remove(item.key)
+		removed++
+	}
+
+	return removed
+}
+
+// ExpiryIndexed все значения хранилища учтены в индексе просрочки
+func (rs *RawStorage[K]) ExpiryIndexed() bool {
+	return true
+}
+
+func (rs *RawStorage[K]) setExpiry(entry *rawEntry[K], dieAt int64) {
+	switch {
+	case entry.expiry.index >= 0 && entry.expiry.dieAt == dieAt:
+		return
+	case entry.expiry.index >= 0:
+		rs.expiry.remove(&entry.expiry)
+	}
+	entry.expiry.dieAt = dieAt
+	if dieAt > 0 {
+		rs.expiry.push(&entry.expiry)
+	}
+}
+
+func (rs *RawStorage[K]) remove(key K) {
+	rs.drop(key)
+	rs.policy.OnRemove(key)
+}
+
+// evict удаление выбранного политикой ключа (ключ уже исключен из политики)
+func (rs *RawStorage[K]) evict(victim K) {
+	rs.drop(victim)
+	if rs.onEvict != nil {
+		rs.onEvict(victim)
+	}
+}
+
+// drop удаление значения и его метаданных без уведомления политики
+func (rs *RawStorage[K]) drop(key K) {
+	entry, ok := rs.data[key]
+	if !ok {
+		return
+	}
+	rs.bytes -= int64(len(entry.b))
+	rs.expiry.remove(&entry.expiry)
+	rs.tags.remove(key, entry.tags)
+	delete(rs.data, key)
+}
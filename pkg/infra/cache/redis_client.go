@@ -0,0 +1,32 @@
+package cache
+
+import (
+	"github.com/ElfAstAhe/go-service-template/pkg/config"
+	"github.com/ElfAstAhe/go-service-template/pkg/errs"
+	"github.com/redis/go-redis/v9"
+)
+
+// NewRedisClient клиент redis по настройкам конфигурации (WithRedisStorage, NewRedisInvalidationBus),
+// закрытие клиента (Close) - ответственность вызывающего
+func NewRedisClient(cfg *config.RedisConfig) (redis.UniversalClient, error) {
+	if cfg == nil {
+		return nil, errs.NewInvalidArgumentError("cfg", nil)
+	}
+	if !cfg.Enabled {
+		return nil, errs.NewConfigValidateError("redis", "enabled", "redis is disabled", nil)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return redis.NewUniversalClient(&redis.UniversalOptions{
+		Addrs:        []string{cfg.Addr()},
+		Username:     cfg.Username,
+		Password:     cfg.Password,
+		DB:           cfg.DB,
+		PoolSize:     cfg.PoolSize,
+		DialTimeout:  cfg.DialTimeout,
+		ReadTimeout:  cfg.ReadTimeout,
+		WriteTimeout: cfg.WriteTimeout,
+	}), nil
+}
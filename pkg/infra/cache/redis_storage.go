@@ -0,0 +1,576 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/infra/metrics"
+	"github.com/ElfAstAhe/go-service-template/pkg/logger"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// DefaultRedisTimeout таймаут одной операции с redis
+	DefaultRedisTimeout time.Duration = 3 * time.Second
+	// DefaultRedisScanCount размер пачки SCAN
+	DefaultRedisScanCount int64 = 500
+)
+
+// ExpiryExtractor - извлечение момента истечения (unix nano, 0 - бессрочно) из упакованного значения
+type ExpiryExtractor func(b []byte) int64
+
+// CodecExpiry извлечение момента истечения из конверта кодека
+func CodecExpiry[V any](codec Codec[V]) ExpiryExtractor {
+	return func(b []byte) int64 {
+		dieAt, err := decodeExpiry(codec, b)
+		if err != nil {
+			return 0
+		}
+
+		return dieAt
+	}
+}
+
+// RedisErrorHandler - обработчик ошибок redis, интерфейс Storage ошибок не возвращает,
+// при ошибке операция трактуется как промах (Get/Has) или не выполняется (Set/Delete).
+// Ошибки записи через Manager возвращаются и вызывающему (см. CheckedWriter).
+type RedisErrorHandler func(op string, err error)
+
+// NewRedisErrorReporter обработчик ошибок redis с журналированием и метрикой cache_errors_total,
+// устанавливается CacheFactory по умолчанию
+func NewRedisErrorReporter(cache string, log logger.Logger) RedisErrorHandler {
+	log = log.GetLogger(cache)
+
+	return func(op string, err error) {
+		metrics.ObserveCacheError(cache, op)
+		log.Errorf("cache %s redis %s failed: %v", cache, op, err)
+	}
+}
+
+// RedisStorage - хранилище кэша в redis.
+// Все ключи хранятся в пространстве имен prefix ("<prefix>:<key>"), Range/Len/Clear затрагивают только его,
+// поэтому prefix обязан быть уникальным для каждого кэша. TTL выставляется на стороне сервера по конверту кодека,
+// вытеснение выполняет сам redis (maxmemory-policy), политика вытеснения кэша не используется.
+type RedisStorage[K comparable] struct {
+	client        redis.UniversalClient
+	prefix        string
+	tagPrefix     string
+	keyTagsPrefix string
+	keyCodec      KeyCodec[K]
+	expiry        ExpiryExtractor
+	timeout       time.Duration
+	scanCount     int64
+	onError       RedisErrorHandler
+}
+
+var _ ExpiringStorage[string] = (*RedisStorage[string])(nil)
+var _ TaggedStorage[string] = (*RedisStorage[string])(nil)
+var _ CheckedWriter[string] = (*RedisStorage[string])(nil)
+var _ KeyRanger[string] = (*RedisStorage[string])(nil)
+
+// redisTagScript добавление ключа в множество тега, время жизни множества - наибольшее среди его ключей:
+// KEYS[1] множество тега, ARGV[1] ключ, ARGV[2] время жизни ключа в мс (0 - бессрочно)
+var redisTagScript = redis.NewScript(`
+local existed = redis.call('EXISTS', KEYS[1])
+redis.call('SADD', KEYS[1], ARGV[1])
+local ttl = tonumber(ARGV[2])
+if ttl == 0 then
+	redis.call('PERSIST', KEYS[1])
+	return 1
+end
+local current = redis.call('PTTL', KEYS[1])
+if existed == 0 or (current >= 0 and current < ttl) then
+	redis.call('PEXPIRE', KEYS[1], ttl)
+end
+return 1
+`)
+
+type RedisStorageOption[K comparable] func(*RedisStorage[K])
+
+func NewRedisStorage[K comparable](client redis.UniversalClient, prefix string, opts ...RedisStorageOption[K]) *RedisStorage[K] {
+	res := &RedisStorage[K]{
+		client:        client,
+		prefix:        prefix + ":",
+		tagPrefix:     prefix + "#tag:",
+		keyTagsPrefix: prefix + "#keytags:",
+		keyCodec:      DefaultKeyCodec[K](),
+		timeout:       DefaultRedisTimeout,
+		scanCount:     DefaultRedisScanCount,
+		onError:       func(string, error) {},
+	}
+	for _, opt := range opts {
+		opt(res)
+	}
+
+	return res
+}
+
+func WithRedisKeyCodec[K comparable](keyCodec KeyCodec[K]) RedisStorageOption[K] {
+	return func(rs *RedisStorage[K]) {
+		rs.keyCodec = keyCodec
+	}
+}
+
+func WithRedisExpiry[K comparable](expiry ExpiryExtractor) RedisStorageOption[K] {
+	return func(rs *RedisStorage[K]) {
+		rs.expiry = expiry
+	}
+}
+
+func WithRedisTimeout[K comparable](timeout time.Duration) RedisStorageOption[K] {
+	return func(rs *RedisStorage[K]) {
+		rs.timeout = timeout
+	}
+}
+
+func WithRedisScanCount[K comparable](scanCount int64) RedisStorageOption[K] {
+	return func(rs *RedisStorage[K]) {
+		rs.scanCount = scanCount
+	}
+}
+
+func WithRedisErrorHandler[K comparable](handler RedisErrorHandler) RedisStorageOption[K] {
+	return func(rs *RedisStorage[K]) {
+		rs.onError = handler
+	}
+}
+
+func (rs *RedisStorage[K]) Get(key K) ([]byte, bool) {
+	redisKey, ok := rs.encodeKey("get", key)
+	if !ok {
+		return nil, false
+	}
+	ctx, cancel := rs.context()
+	defer cancel()
+
+	b, err := rs.client.Get(ctx, redisKey).Bytes()
+	if err != nil {
+		if !errors.Is(err, redis.Nil) {
+			rs.onError("get", err)
+		}
+
+		return nil, false
+	}
+
+	return b, true
+}
+
+// GetWithExpiry чтение значения, момент истечения не запрашивается (0): просроченные ключи удаляет сам redis
+func (rs *RedisStorage[K]) GetWithExpiry(key K) ([]byte, int64, bool) {
+	b, ok := rs.Get(key)
+
+	return b, 0, ok
+}
+
+func (rs *RedisStorage[K]) Set(key K, b []byte) {
+	var dieAt int64
+	if rs.expiry != nil {
+		dieAt = rs.expiry(b)
+	}
+	rs.SetWithExpiry(key, b, dieAt)
+}
+
+// SetWithExpiry запись значения с TTL на стороне сервера по моменту истечения (извлечение из значения не требуется),
+// ошибка передается обработчику ошибок (см. SetWithExpiryChecked)
+func (rs *RedisStorage[K]) SetWithExpiry(key K, b []byte, dieAt int64) {
+	if err := rs.SetWithExpiryChecked(key, b, dieAt); err != nil {
+		rs.onError("set", err)
+	}
+}
+
+// SetWithExpiryChecked запись значения с TTL на стороне сервера по моменту истечения с ошибкой записи
+func (rs *RedisStorage[K]) SetWithExpiryChecked(key K, b []byte, dieAt int64) error {
+	encoded, err := rs.keyCodec.EncodeKey(key)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := rs.context()
+	defer cancel()
+
+	return rs.setValue(ctx, rs.prefix+encoded, b, dieAt)
+}
+
+// RemoveExpired просроченные ключи удаляет сам redis, очистка не требуется
+func (rs *RedisStorage[K]) RemoveExpired(int64, int) int {
+	return 0
+}
+
+// ExpiryIndexed истечение ключей контролирует redis
+func (rs *RedisStorage[K]) ExpiryIndexed() bool {
+	return true
+}
+
+// SetWithTags запись значения и замена тегов ключа, ошибка передается обработчику ошибок (см. SetWithTagsChecked)
+func (rs *RedisStorage[K]) SetWithTags(key K, b []byte, dieAt int64, tags []string) {
+	if err := rs.SetWithTagsChecked(key, b, dieAt, tags); err != nil {
+		rs.onError("set_tags", err)
+	}
+}
+
+// SetWithTagsChecked запись значения и замена тегов ключа: ключ добавляется в множества тегов ("<prefix>#tag:<tag>",
+// вне пространства имен значений) до записи значения, поэтому значение без тегов не появляется даже при сбое между
+// командами, и удаляется из множеств прежних тегов после нее. Прежние теги ведутся множеством ключа
+// ("<prefix>#keytags:<key>") с временем жизни ключа. Множество тега живет, пока жив его самый долгоживущий ключ,
+// ключи, удаленные самим redis (TTL, maxmemory), Delete или RemoveTag, остаются в множестве до его истечения
+// или следующей записи ключа с тегами. Ошибка снятия прежних тегов не возвращается: лишняя инвалидация безопасна.
+func (rs *RedisStorage[K]) SetWithTagsChecked(key K, b []byte, dieAt int64, tags []string) error {
+	encoded, err := rs.keyCodec.EncodeKey(key)
+	if err != nil {
+		return err
+	}
+	redisKey := rs.prefix + encoded
+	ctx, cancel := rs.context()
+	defer cancel()
+
+	var ttl int64
+	if dieAt > 0 {
+		ttl = time.Until(time.Unix(0, dieAt)).Milliseconds()
+		if ttl <= 0 {
+			// значение уже просрочено, теги не нужны
+			return rs.setValue(ctx, redisKey, b, dieAt)
+		}
+	}
+	for _, tag := range tags {
+		if err := redisTagScript.Run(ctx, rs.client, []string{rs.tagPrefix + tag}, redisKey, ttl).Err(); err != nil {
+			return err
+		}
+	}
+	if err := rs.setValue(ctx, redisKey, b, dieAt); err != nil {
+		return err
+	}
+
+	keyTagsKey := rs.keyTagsPrefix + encoded
+	prevTags, err := rs.client.SMembers(ctx, keyTagsKey).Result()
+	if err != nil {
+		rs.onError("set_tags", err)
+	}
+	// ключ снимается с тегов, которых больше нет, по одному: в кластере множества тегов в разных слотах
+	if stale := staleTags(prevTags, tags); len(stale) > 0 {
+		_, err = rs.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+			for _, tag := range stale {
+				pipe.SRem(ctx, rs.tagPrefix+tag, redisKey)
+			}
+
+			return nil
+		})
+		if err != nil {
+			rs.onError("set_tags", err)
+		}
+	}
+	_, err = rs.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Unlink(ctx, keyTagsKey)
+		if len(tags) == 0 {
+			return nil
+		}
+		members := make([]any, 0, len(tags))
+		for _, tag := range tags {
+			members = append(members, tag)
+		}
+		pipe.SAdd(ctx, keyTagsKey, members...)
+		if ttl > 0 {
+			pipe.PExpire(ctx, keyTagsKey, time.Duration(ttl)*time.Millisecond)
+		}
+
+		return nil
+	})
+	if err != nil {
+		rs.onError("set_tags", err)
+	}
+
+	return nil
+}
+
+// RemoveTag удаление ключей тега. Множество тега не удаляется: запись с тегами, идущая одновременно с инвалидацией,
+// добавляет ключ в множество до записи значения, и ее членство не должно пропасть (см. SetWithTagsChecked)
+func (rs *RedisStorage[K]) RemoveTag(tag string) int {
+	ctx, cancel := rs.context()
+	defer cancel()
+
+	redisKeys, err := rs.client.SMembers(ctx, rs.tagPrefix+tag).Result()
+	if err != nil {
+		rs.onError("remove_tag", err)
+
+		return 0
+	}
+	// ключи удаляются по одному, в кластере ключи тега могут лежать в разных слотах
+	cmds := make([]*redis.IntCmd, 0, len(redisKeys))
+	_, err = rs.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, redisKey := range redisKeys {
+			cmds = append(cmds, pipe.Unlink(ctx, redisKey))
+		}
+
+		return nil
+	})
+	if err != nil {
+		rs.onError("remove_tag", err)
+	}
+	var removed int
+	for _, cmd := range cmds {
+		removed += int(cmd.Val())
+	}
+
+	return removed
+}
+
+// RemovePrefix удаление ключей пространства имен, строковый ключ которых начинается с prefix (через SCAN)
+func (rs *RedisStorage[K]) RemovePrefix(prefix string) int {
+	key, ok := any(prefix).(K)
+	if !ok {
+		return 0
+	}
+	redisPrefix, ok := rs.encodeKey("remove_prefix", key)
+	if !ok {
+		return 0
+	}
+	var removed int
+	rs.scanMatch("remove_prefix", escapeRedisPattern(redisPrefix)+"*", func(ctx context.Context, redisKeys []string) bool {
+		count, err := rs.client.Unlink(ctx, redisKeys...).Result()
+		if err != nil {
+			rs.onError("remove_prefix", err)
+
+			return false
+		}
+		removed += int(count)
+
+		return true
+	})
+
+	return removed
+}
+
+// TagIndexed теги ведутся множествами на стороне сервера
+func (rs *RedisStorage[K]) TagIndexed() bool {
+	return true
+}
+
+func (rs *RedisStorage[K]) Delete(key K) {
+	redisKey, ok := rs.encodeKey("delete", key)
+	if !ok {
+		return
+	}
+	ctx, cancel := rs.context()
+	defer cancel()
+
+	if err := rs.client.Unlink(ctx, redisKey).Err(); err != nil {
+		rs.onError("delete", err)
+	}
+}
+
+// Range обход ключей пространства имен через SCAN, ключи, добавленные или удаленные во время обхода,
+// могут быть как пропущены, так и возвращены (гарантии SCAN)
+func (rs *RedisStorage[K]) Range(fn func(key K, value []byte) bool) {
+	rs.scan("range", func(ctx context.Context, redisKeys []string) bool {
+		values, err := rs.client.MGet(ctx, redisKeys...).Result()
+		if err != nil {
+			rs.onError("range", err)
+
+			return false
+		}
+		for i, value := range values {
+			// ключ мог истечь между SCAN и MGET
+			str, ok := value.(string)
+			if !ok {
+				continue
+			}
+			key, err := rs.keyCodec.DecodeKey(strings.TrimPrefix(redisKeys[i], rs.prefix))
+			if err != nil {
+				rs.onError("range", err)
+
+				continue
+			}
+			if !fn(key, []byte(str)) {
+				return false
+			}
+		}
+
+		return true
+	})
+}
+
+// RangeKeys обход ключей пространства имен через SCAN без чтения значений
+func (rs *RedisStorage[K]) RangeKeys(fn func(key K) bool) {
+	rs.scan("range_keys", func(_ context.Context, redisKeys []string) bool {
+		for _, redisKey := range redisKeys {
+			key, err := rs.keyCodec.DecodeKey(strings.TrimPrefix(redisKey, rs.prefix))
+			if err != nil {
+				rs.onError("range_keys", err)
+
+				continue
+			}
+			if !fn(key) {
+				return false
+			}
+		}
+
+		return true
+	})
+}
+
+func (rs *RedisStorage[K]) Has(key K) bool {
+	redisKey, ok := rs.encodeKey("has", key)
+	if !ok {
+		return false
+	}
+	ctx, cancel := rs.context()
+	defer cancel()
+
+	count, err := rs.client.Exists(ctx, redisKey).Result()
+	if err != nil {
+		rs.onError("has", err)
+
+		return false
+	}
+
+	return count > 0
+}
+
+// Len кол-во ключей пространства имен, O(N) по кол-ву ключей БД redis
+func (rs *RedisStorage[K]) Len() int {
+	var res int
+	rs.scan("len", func(_ context.Context, redisKeys []string) bool {
+		res += len(redisKeys)
+
+		return true
+	})
+
+	return res
+}
+
+// Clear удаление всех ключей пространства имен, множеств тегов и тегов ключей
+func (rs *RedisStorage[K]) Clear() {
+	unlink := func(ctx context.Context, redisKeys []string) bool {
+		if err := rs.client.Unlink(ctx, redisKeys...).Err(); err != nil {
+			rs.onError("clear", err)
+
+			return false
+		}
+
+		return true
+	}
+	rs.scan("clear", unlink)
+	rs.scanMatch("clear", escapeRedisPattern(rs.tagPrefix)+"*", unlink)
+	rs.scanMatch("clear", escapeRedisPattern(rs.keyTagsPrefix)+"*", unlink)
+}
+
+func (rs *RedisStorage[K]) GetPrefix() string {
+	return strings.TrimSuffix(rs.prefix, ":")
+}
+
+// scan обход ключей пространства имен пачками, для кластера обходятся все master узлы
+func (rs *RedisStorage[K]) scan(op string, fn func(ctx context.Context, redisKeys []string) bool) {
+	rs.scanMatch(op, escapeRedisPattern(rs.prefix)+"*", fn)
+}
+
+// scanMatch обход ключей по шаблону MATCH пачками
+func (rs *RedisStorage[K]) scanMatch(op string, match string, fn func(ctx context.Context, redisKeys []string) bool) {
+	scanNode := func(ctx context.Context, client redis.Cmdable) error {
+		var cursor uint64
+		for {
+			opCtx, cancel := context.WithTimeout(ctx, rs.timeout)
+			keys, next, err := client.Scan(opCtx, cursor, match, rs.scanCount).Result()
+			if err != nil {
+				cancel()
+
+				return err
+			}
+			if len(keys) > 0 && !fn(opCtx, keys) {
+				cancel()
+
+				return errStopScan
+			}
+			cancel()
+			if next == 0 {
+				return nil
+			}
+			cursor = next
+		}
+	}
+
+	var err error
+	if cluster, ok := rs.client.(*redis.ClusterClient); ok {
+		// узлы обходятся параллельно, обработчик пачек вызывается последовательно
+		var mu sync.Mutex
+		var stopped bool
+		nodeFn := fn
+		fn = func(ctx context.Context, redisKeys []string) bool {
+			mu.Lock()
+			defer mu.Unlock()
+
+			if stopped {
+				return false
+			}
+			stopped = !nodeFn(ctx, redisKeys)
+
+			return !stopped
+		}
+		err = cluster.ForEachMaster(context.Background(), func(ctx context.Context, client *redis.Client) error {
+			return scanNode(ctx, client)
+		})
+	} else {
+		err = scanNode(context.Background(), rs.client)
+	}
+	if err != nil && !errors.Is(err, errStopScan) {
+		rs.onError(op, err)
+	}
+}
+
+func (rs *RedisStorage[K]) encodeKey(op string, key K) (string, bool) {
+	encoded, err := rs.keyCodec.EncodeKey(key)
+	if err != nil {
+		rs.onError(op, err)
+
+		return "", false
+	}
+
+	return rs.prefix + encoded, true
+}
+
+// setValue запись значения с TTL по моменту истечения, уже просроченное значение удаляется
+func (rs *RedisStorage[K]) setValue(ctx context.Context, redisKey string, b []byte, dieAt int64) error {
+	var ttl time.Duration
+	if dieAt > 0 {
+		ttl = time.Until(time.Unix(0, dieAt))
+		if ttl <= 0 {
+			// значение уже просрочено, старое значение тоже не должно остаться
+			return rs.client.Unlink(ctx, redisKey).Err()
+		}
+	}
+
+	return rs.client.Set(ctx, redisKey, b, ttl).Err()
+}
+
+func (rs *RedisStorage[K]) context() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), rs.timeout)
+}
+
+var errStopScan = errors.New("stop scan")
+
+// escapeRedisPattern экранирование спецсимволов glob шаблона MATCH
+func escapeRedisPattern(s string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		switch r {
+		case '*', '?', '[', ']', '\\':
+			sb.WriteRune('\\')
+		}
+		sb.WriteRune(r)
+	}
+
+	return sb.String()
+}
+
+// staleTags прежние теги, отсутствующие среди новых
+func staleTags(prev []string, tags []string) []string {
+	var res []string
+	for _, tag := range prev {
+		if !slices.Contains(tags, tag) {
+			res = append(res, tag)
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,161 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/errs"
+	"github.com/ElfAstAhe/go-service-template/pkg/logger"
+	"github.com/ElfAstAhe/go-service-template/pkg/transport/worker"
+)
+
+// Refresher - фоновое обновление записей кэша через загрузчик на ограниченном пуле обработчиков:
+//   - refresh-ahead: обращение к записи в пределах окна до истечения ставит ее перезагрузку в очередь;
+//   - stale-while-revalidate: истекшая запись в пределах льготного периода отдается, пока выполняется перезагрузка.
+//
+// Для каждого ключа одновременно выполняется не больше одной перезагрузки, при заполненной очереди
+// обновление пропускается (истекшая запись в этом случае - промах). Подключается к Manager через
+// WithManagerRefresher, как container.Runner запускается и останавливается контейнером.
+type Refresher[K comparable, V any] struct {
+	*worker.BasePool[K]
+	loader Loader[K, V]
+	ttl    time.Duration
+	// window окно до истечения, в котором обращение запускает перезагрузку, 0 - refresh-ahead отключен
+	window time.Duration
+	// grace льготный период после истечения, 0 - stale-while-revalidate отключен
+	grace   time.Duration
+	cache   *Manager[K, V]
+	mu      sync.Mutex
+	pending map[K]struct{}
+}
+
+type RefresherOption[K comparable, V any] func(*Refresher[K, V])
+
+// WithRefreshAhead перезагрузка записей, к которым обращаются в пределах window до истечения,
+// window должно быть меньше ttl перезагруженных значений, иначе каждое обращение запускает перезагрузку
+func WithRefreshAhead[K comparable, V any](window time.Duration) RefresherOption[K, V] {
+	return func(r *Refresher[K, V]) {
+		r.window = window
+	}
+}
+
+// WithStaleWhileRevalidate выдача истекших записей в пределах grace на время их перезагрузки
+func WithStaleWhileRevalidate[K comparable, V any](grace time.Duration) RefresherOption[K, V] {
+	return func(r *Refresher[K, V]) {
+		r.grace = grace
+	}
+}
+
+// NewRefresher ttl - время жизни перезагруженных значений, config - параметры пула (кол-во обработчиков, емкость очереди)
+func NewRefresher[K comparable, V any](
+	name string,
+	config *worker.BasePoolConfig,
+	loader Loader[K, V],
+	ttl time.Duration,
+	log logger.Logger,
+	opts ...RefresherOption[K, V],
+) *Refresher[K, V] {
+	res := &Refresher[K, V]{
+		loader:  loader,
+		ttl:     ttl,
+		pending: make(map[K]struct{}),
+	}
+	for _, opt := range opts {
+		opt(res)
+	}
+	res.BasePool = worker.NewBasePool[K](name, config, res.refresh, log)
+
+	return res
+}
+
+// GetTTL время жизни перезагруженных значений
+func (r *Refresher[K, V]) GetTTL() time.Duration {
+	return r.ttl
+}
+
+// GetWindow окно refresh-ahead
+func (r *Refresher[K, V]) GetWindow() time.Duration {
+	return r.window
+}
+
+// GetGrace льготный период stale-while-revalidate
+func (r *Refresher[K, V]) GetGrace() time.Duration {
+	return r.grace
+}
+
+// Pending кол-во запланированных и выполняющихся перезагрузок
+func (r *Refresher[K, V]) Pending() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return len(r.pending)
+}
+
+// ahead перезагрузка записи, если до истечения осталось не больше окна
+func (r *Refresher[K, V]) ahead(key K, dieAt int64, now int64) {
+	if r.window <= 0 || dieAt <= 0 || dieAt-now > r.window.Nanoseconds() {
+		return
+	}
+	r.schedule(key)
+}
+
+// stale true - истекшую запись можно отдать: она в пределах льготного периода и ее перезагрузка запланирована
+func (r *Refresher[K, V]) stale(key K, dieAt int64, now int64) bool {
+	if r.grace <= 0 || now > dieAt+r.grace.Nanoseconds() {
+		return false
+	}
+
+	return r.schedule(key)
+}
+
+// schedule постановка перезагрузки в очередь, true - перезагрузка запланирована (сейчас или ранее)
+func (r *Refresher[K, V]) schedule(key K) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.pending[key]; ok {
+		return true
+	}
+	// ключ отмечается до постановки: обработчик может снять отметку раньше, чем вернется TryPush
+	r.pending[key] = struct{}{}
+	if !r.TryPush(key) {
+		delete(r.pending, key)
+
+		return false
+	}
+
+	return true
+}
+
+func (r *Refresher[K, V]) done(key K) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.pending, key)
+}
+
+// refresh обработчик пула: загрузка и запись значения, ошибки загрузчика протоколируются пулом
+func (r *Refresher[K, V]) refresh(ctx context.Context, _ int, key K) error {
+	defer r.done(key)
+
+	if r.cache == nil {
+		return errs.NewCommonError(fmt.Sprintf("cache refresher %s not attached to cache", r.GetName()), nil)
+	}
+	value, found, err := r.loader(ctx, key)
+	if err != nil {
+		return errs.NewDalCacheError("Refresher.refresh", fmt.Sprintf("load key [%v]", key), err)
+	}
+	if !found {
+		// значения в источнике больше нет
+		if r.cache.negativeTTL > 0 {
+			return r.cache.setNegative("Refresher.refresh", key, r.cache.negativeTTL)
+		}
+		r.cache.Delete(key)
+
+		return nil
+	}
+
+	return r.cache.Set(key, value, r.ttl)
+}
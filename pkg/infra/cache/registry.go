@@ -0,0 +1,278 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/errs"
+	"github.com/ElfAstAhe/go-service-template/pkg/utils"
+)
+
+// DefaultAdminMaxKeys ограничение кол-ва ключей, собираемых AdminCache.Keys за один вызов
+const DefaultAdminMaxKeys int = 10000
+
+// KeyRanger - кэш или хранилище, позволяющие обойти свои ключи (включая истекшие, но еще не удаленные)
+type KeyRanger[K comparable] interface {
+	RangeKeys(fn func(key K) bool)
+}
+
+// CacheInfo - сводка кэша реестра, Stats - счетчики событий, если кэш зарегистрирован с ними (WithAdminCacheStats)
+type CacheInfo struct {
+	Name  string      `json:"name"`
+	Type  string      `json:"type"`
+	Size  int         `json:"size"`
+	Stats *CacheStats `json:"stats,omitempty"`
+}
+
+// KeyPage - страница ключей кэша, Truncated - обход остановлен по ограничению кол-ва ключей,
+// Total и страницы считаются только по собранным ключам
+type KeyPage struct {
+	Keys      []string `json:"keys"`
+	Total     int      `json:"total"`
+	Offset    int      `json:"offset"`
+	Limit     int      `json:"limit"`
+	Truncated bool     `json:"truncated,omitempty"`
+}
+
+// AdminCache - типонезависимый административный доступ к кэшу, ключи передаются в строковом виде (KeyCodec)
+type AdminCache interface {
+	Info() CacheInfo
+	// Keys страница ключей с префиксом prefix, отсортированных по строковому виду, limit <= 0 - все ключи.
+	// Собирается не более maxKeys ключей (WithAdminCacheMaxKeys), обход дальше не идет
+	Keys(prefix string, offset int, limit int) (*KeyPage, error)
+	// GetJSON значение ключа в json, чтение учитывается политикой вытеснения и статистикой как обычное
+	GetJSON(key string) (json.RawMessage, bool, error)
+	Delete(key string) error
+	Clear()
+	Janitor(ctx context.Context) error
+}
+
+// Registry - реестр именованных кэшей для администрирования
+type Registry struct {
+	mu     sync.RWMutex
+	caches map[string]AdminCache
+}
+
+// defaultRegistry реестр кэшей, собранных через CacheFactory
+var defaultRegistry = NewRegistry()
+
+func NewRegistry() *Registry {
+	return &Registry{
+		caches: make(map[string]AdminCache),
+	}
+}
+
+// DefaultRegistry реестр кэшей, собранных через CacheFactory с именем (WithName, WithMetrics)
+func DefaultRegistry() *Registry {
+	return defaultRegistry
+}
+
+// Register регистрация кэша под именем, прежний кэш с тем же именем заменяется
+func (r *Registry) Register(name string, cache AdminCache) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.caches[name] = cache
+}
+
+func (r *Registry) Unregister(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.caches, name)
+}
+
+// Get кэш по имени, *errs.DalNotFoundError - кэш не зарегистрирован
+func (r *Registry) Get(name string) (AdminCache, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	res, ok := r.caches[name]
+	if !ok {
+		return nil, errs.NewDalNotFoundError("cache", name, nil)
+	}
+
+	return res, nil
+}
+
+// List сводки всех кэшей, упорядоченные по имени
+func (r *Registry) List() []CacheInfo {
+	r.mu.RLock()
+	caches := make([]AdminCache, 0, len(r.caches))
+	for _, cache := range r.caches {
+		caches = append(caches, cache)
+	}
+	r.mu.RUnlock()
+
+	res := make([]CacheInfo, 0, len(caches))
+	for _, cache := range caches {
+		res = append(res, cache.Info())
+	}
+	slices.SortFunc(res, func(a, b CacheInfo) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return res
+}
+
+// RegisterCache регистрация типизированного кэша в реестре, ключи преобразуются DefaultKeyCodec
+func RegisterCache[K comparable, V any](registry *Registry, name string, cache Cache[K, V], opts ...AdminCacheOption) {
+	registry.Register(name, NewAdminCache[K, V](name, cache, DefaultKeyCodec[K](), opts...))
+}
+
+// adminCache - адаптер Cache[K, V] к AdminCache
+type adminCache[K comparable, V any] struct {
+	name     string
+	cache    Cache[K, V]
+	keyCodec KeyCodec[K]
+	stats    StatsSnapshotter
+	maxKeys  int
+}
+
+var _ AdminCache = (*adminCache[string, any])(nil)
+
+// adminCacheConfig необязательные параметры адаптера
+type adminCacheConfig struct {
+	stats   StatsSnapshotter
+	maxKeys int
+}
+
+type AdminCacheOption func(*adminCacheConfig)
+
+// WithAdminCacheStats счетчики событий кэша для CacheInfo (StatsCounter, переданный кэшу как StatsRecorder)
+func WithAdminCacheStats(stats StatsSnapshotter) AdminCacheOption {
+	return func(config *adminCacheConfig) {
+		config.stats = stats
+	}
+}
+
+// WithAdminCacheMaxKeys ограничение кол-ва ключей, собираемых Keys за один вызов (по умолчанию DefaultAdminMaxKeys)
+func WithAdminCacheMaxKeys(maxKeys int) AdminCacheOption {
+	return func(config *adminCacheConfig) {
+		if maxKeys > 0 {
+			config.maxKeys = maxKeys
+		}
+	}
+}
+
+func NewAdminCache[K comparable, V any](name string, cache Cache[K, V], keyCodec KeyCodec[K], opts ...AdminCacheOption) AdminCache {
+	conf := &adminCacheConfig{maxKeys: DefaultAdminMaxKeys}
+	for _, opt := range opts {
+		opt(conf)
+	}
+
+	return &adminCache[K, V]{
+		name:     name,
+		cache:    cache,
+		keyCodec: keyCodec,
+		stats:    conf.stats,
+		maxKeys:  conf.maxKeys,
+	}
+}
+
+func (ac *adminCache[K, V]) Info() CacheInfo {
+	res := CacheInfo{
+		Name: ac.name,
+		Type: utils.GetTypeName(ac.cache),
+		Size: ac.cache.Size(),
+	}
+	if !utils.IsNil(ac.stats) {
+		stats := ac.stats.Stats()
+		res.Stats = &stats
+	}
+
+	return res
+}
+
+func (ac *adminCache[K, V]) Keys(prefix string, offset int, limit int) (*KeyPage, error) {
+	if offset < 0 {
+		return nil, errs.NewInvalidArgumentError("offset", offset)
+	}
+	ranger, ok := ac.cache.(KeyRanger[K])
+	if !ok {
+		return nil, errs.NewDalCacheError("AdminCache.Keys", fmt.Sprintf("cache [%s] does not support key listing", ac.name), nil)
+	}
+
+	var (
+		keys      []string
+		rangeErr  error
+		truncated bool
+	)
+	ranger.RangeKeys(func(key K) bool {
+		if len(keys) >= ac.maxKeys {
+			truncated = true
+
+			return false
+		}
+		str, err := ac.keyCodec.EncodeKey(key)
+		if err != nil {
+			rangeErr = err
+
+			return false
+		}
+		if strings.HasPrefix(str, prefix) {
+			keys = append(keys, str)
+		}
+
+		return true
+	})
+	if rangeErr != nil {
+		return nil, errs.NewDalCacheError("AdminCache.Keys", "encode key", rangeErr)
+	}
+	slices.Sort(keys)
+
+	res := &KeyPage{
+		Total:     len(keys),
+		Offset:    offset,
+		Limit:     limit,
+		Truncated: truncated,
+	}
+	start := min(offset, len(keys))
+	end := len(keys)
+	if limit > 0 {
+		end = min(start+limit, len(keys))
+	}
+	res.Keys = append(make([]string, 0, end-start), keys[start:end]...)
+
+	return res, nil
+}
+
+func (ac *adminCache[K, V]) GetJSON(key string) (json.RawMessage, bool, error) {
+	k, err := ac.keyCodec.DecodeKey(key)
+	if err != nil {
+		return nil, false, errs.NewInvalidArgumentErrorChain("key", key, err)
+	}
+	value, ok, err := ac.cache.Get(k)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	res, err := json.Marshal(value)
+	if err != nil {
+		return nil, false, errs.NewDalCacheError("AdminCache.GetJSON", "marshal value", err)
+	}
+
+	return res, true, nil
+}
+
+func (ac *adminCache[K, V]) Delete(key string) error {
+	k, err := ac.keyCodec.DecodeKey(key)
+	if err != nil {
+		return errs.NewInvalidArgumentErrorChain("key", key, err)
+	}
+	ac.cache.Delete(k)
+
+	return nil
+}
+
+func (ac *adminCache[K, V]) Clear() {
+	ac.cache.Clear()
+}
+
+func (ac *adminCache[K, V]) Janitor(ctx context.Context) error {
+	return ac.cache.CacheJanitor(ctx, time.Now())
+}
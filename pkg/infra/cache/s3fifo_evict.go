@@ -0,0 +1,182 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+)
+
+const (
+	// s3FIFOMaxFreq предел счетчика обращений (2 бита)
+	s3FIFOMaxFreq uint8 = 3
+)
+
+type s3FIFOItem[K comparable] struct {
+	key   K
+	freq  uint8
+	small bool
+}
+
+// S3FIFOEvict - политика S3-FIFO: новые ключи попадают в малую очередь (~10% емкости), из нее в основную
+// переходят только ключи, к которым обращались повторно, остальные вытесняются сразу и запоминаются в очереди-призраке.
+// Ключ из призрака при повторной записи попадает сразу в основную очередь. Основная очередь - FIFO с повторной
+// вставкой ключей, к которым были обращения. Быстро отсеивает однократные обращения (сканирования).
+type S3FIFOEvict[K comparable] struct {
+	mu       sync.Mutex
+	items    map[K]*list.Element
+	small    *list.List
+	main     *list.List
+	ghost    *list.List
+	ghosts   map[K]*list.Element
+	smallCap int
+	ghostCap int
+	emptyKey K
+}
+
+// NewS3FIFOEvict capacity - ожидаемая емкость хранилища (кол-во элементов)
+func NewS3FIFOEvict[K comparable](capacity int) *S3FIFOEvict[K] {
+	capacity = max(capacity, 1)
+	smallCap := max(capacity/10, 1)
+
+	return &S3FIFOEvict[K]{
+		items:    make(map[K]*list.Element),
+		small:    list.New(),
+		main:     list.New(),
+		ghost:    list.New(),
+		ghosts:   make(map[K]*list.Element),
+		smallCap: smallCap,
+		ghostCap: max(capacity-smallCap, 1),
+	}
+}
+
+func (sf *S3FIFOEvict[K]) OnGet(key K) {
+	sf.mu.Lock()
+	defer sf.mu.Unlock()
+
+	if el, ok := sf.items[key]; ok {
+		sf.touch(el)
+	}
+}
+
+func (sf *S3FIFOEvict[K]) OnSet(key K) {
+	sf.mu.Lock()
+	defer sf.mu.Unlock()
+
+	if el, ok := sf.items[key]; ok {
+		sf.touch(el)
+
+		return
+	}
+
+	if el, ok := sf.ghosts[key]; ok {
+		// ключ недавно вытеснен из малой очереди - сразу в основную
+		sf.ghost.Remove(el)
+		delete(sf.ghosts, key)
+		sf.items[key] = sf.main.PushFront(&s3FIFOItem[K]{key: key})
+
+		return
+	}
+	sf.items[key] = sf.small.PushFront(&s3FIFOItem[K]{key: key, small: true})
+}
+
+func (sf *S3FIFOEvict[K]) OnRemove(key K) {
+	sf.mu.Lock()
+	defer sf.mu.Unlock()
+
+	if el, ok := sf.items[key]; ok {
+		sf.queueOf(el).Remove(el)
+		delete(sf.items, key)
+	}
+}
+
+func (sf *S3FIFOEvict[K]) Reset() {
+	sf.mu.Lock()
+	defer sf.mu.Unlock()
+
+	sf.items = make(map[K]*list.Element)
+	sf.ghosts = make(map[K]*list.Element)
+	sf.small.Init()
+	sf.main.Init()
+	sf.ghost.Init()
+}
+
+func (sf *S3FIFOEvict[K]) Evict() (K, bool) {
+	sf.mu.Lock()
+	defer sf.mu.Unlock()
+
+	for sf.small.Len() > 0 || sf.main.Len() > 0 {
+		if sf.small.Len() >= sf.smallCap || sf.main.Len() == 0 {
+			if key, ok := sf.evictSmall(); ok {
+				return key, true
+			}
+
+			continue
+		}
+		if key, ok := sf.evictMain(); ok {
+			return key, true
+		}
+	}
+
+	return sf.emptyKey, false
+}
+
+// --- Внутренние неблокирующие методы ---
+
+func (sf *S3FIFOEvict[K]) touch(el *list.Element) {
+	item := el.Value.(*s3FIFOItem[K])
+	if item.freq < s3FIFOMaxFreq {
+		item.freq++
+	}
+}
+
+func (sf *S3FIFOEvict[K]) queueOf(el *list.Element) *list.List {
+	if el.Value.(*s3FIFOItem[K]).small {
+		return sf.small
+	}
+
+	return sf.main
+}
+
+// evictSmall вытеснение из малой очереди, ключ с повторными обращениями переводится в основную (ok=false)
+func (sf *S3FIFOEvict[K]) evictSmall() (K, bool) {
+	el := sf.small.Back()
+	item := el.Value.(*s3FIFOItem[K])
+	sf.small.Remove(el)
+	if item.freq > 0 {
+		item.freq = 0
+		item.small = false
+		sf.items[item.key] = sf.main.PushFront(item)
+
+		return sf.emptyKey, false
+	}
+
+	delete(sf.items, item.key)
+	sf.addGhost(item.key)
+
+	return item.key, true
+}
+
+// evictMain вытеснение из основной очереди, ключ с обращениями возвращается в голову очереди (ok=false)
+func (sf *S3FIFOEvict[K]) evictMain() (K, bool) {
+	el := sf.main.Back()
+	item := el.Value.(*s3FIFOItem[K])
+	if item.freq > 0 {
+		item.freq--
+		sf.main.MoveToFront(el)
+
+		return sf.emptyKey, false
+	}
+
+	sf.main.Remove(el)
+	delete(sf.items, item.key)
+
+	return item.key, true
+}
+
+func (sf *S3FIFOEvict[K]) addGhost(key K) {
+	sf.ghosts[key] = sf.ghost.PushFront(key)
+	for sf.ghost.Len() > sf.ghostCap {
+		el := sf.ghost.Back()
+		delete(sf.ghosts, el.Value.(K))
+		sf.ghost.Remove(el)
+	}
+}
@@ -18,12 +18,19 @@ type ShardStorage[K comparable] struct {
 	shardCount uint64
 }
 
+// NewShardStorage шардированное хранилище: емкость maxSize (кол-во элементов) задается на каждый шард,
+// каждый шард получает собственный экземпляр политики вытеснения от policyFactory (nil - LRU)
 func NewShardStorage[K comparable](
 	shardCount uint64,
 	shardFactory ShardFactory[K],
 	maxSize int,
-	policy EvictionPolicy[K],
+	policyFactory EvictionPolicyFactory[K],
 ) *ShardStorage[K] {
+	if policyFactory == nil {
+		policyFactory = func(int) EvictionPolicy[K] {
+			return NewLRUEvict[K]()
+		}
+	}
 	res := &ShardStorage[K]{
 		hashSeed: maphash.MakeSeed(),
 		hashPool: &sync.Pool{
@@ -37,7 +44,7 @@ func NewShardStorage[K comparable](
 	res.shardIndex = res.ShardIndexSelector(res.shardCount)
 
 	for i := uint64(0); i < res.shardCount; i++ {
-		res.shards = append(res.shards, shardFactory(maxSize, policy))
+		res.shards = append(res.shards, shardFactory(maxSize, policyFactory(policyCapacity(maxSize))))
 	}
 
 	return res
@@ -51,6 +58,108 @@ func (ss *ShardStorage[K]) Set(key K, b []byte) {
 	ss.GetShard(key).Set(key, b)
 }
 
+// GetWithExpiry чтение с моментом истечения, для шардов без индекса просрочки момент неизвестен (0)
+func (ss *ShardStorage[K]) GetWithExpiry(key K) ([]byte, int64, bool) {
+	shard := ss.GetShard(key)
+	if expiring, ok := shard.(ExpiringStorage[K]); ok {
+		return expiring.GetWithExpiry(key)
+	}
+	b, ok := shard.Get(key)
+
+	return b, 0, ok
+}
+
+// SetWithExpiry запись с моментом истечения, для шардов без индекса просрочки момент не сохраняется
+func (ss *ShardStorage[K]) SetWithExpiry(key K, b []byte, dieAt int64) {
+	shard := ss.GetShard(key)
+	if expiring, ok := shard.(ExpiringStorage[K]); ok {
+		expiring.SetWithExpiry(key, b, dieAt)
+
+		return
+	}
+	shard.Set(key, b)
+}
+
+// RemoveExpired удаление просрочки по индексам шардов, limit общий на все шарды
+func (ss *ShardStorage[K]) RemoveExpired(now int64, limit int) int {
+	var removed int
+	for _, shard := range ss.shards {
+		if limit > 0 && removed >= limit {
+			break
+		}
+		expiring, ok := shard.(ExpiringStorage[K])
+		if !ok {
+			continue
+		}
+		shardLimit := 0
+		if limit > 0 {
+			shardLimit = limit - removed
+		}
+		removed += expiring.RemoveExpired(now, shardLimit)
+	}
+
+	return removed
+}
+
+// ExpiryIndexed все шарды ведут индекс просрочки
+func (ss *ShardStorage[K]) ExpiryIndexed() bool {
+	for _, shard := range ss.shards {
+		expiring, ok := shard.(ExpiringStorage[K])
+		if !ok || !expiring.ExpiryIndexed() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// SetWithTags запись с тегами, для шардов без индекса тегов теги не сохраняются (см. TagIndexed)
+func (ss *ShardStorage[K]) SetWithTags(key K, b []byte, dieAt int64, tags []string) {
+	shard := ss.GetShard(key)
+	if tagged, ok := shard.(TaggedStorage[K]); ok {
+		tagged.SetWithTags(key, b, dieAt, tags)
+
+		return
+	}
+	ss.SetWithExpiry(key, b, dieAt)
+}
+
+// RemoveTag удаление значений с тегом во всех шардах
+func (ss *ShardStorage[K]) RemoveTag(tag string) int {
+	var removed int
+	for _, shard := range ss.shards {
+		if tagged, ok := shard.(TaggedStorage[K]); ok {
+			removed += tagged.RemoveTag(tag)
+		}
+	}
+
+	return removed
+}
+
+// RemovePrefix удаление значений по префиксу строкового ключа во всех шардах
+func (ss *ShardStorage[K]) RemovePrefix(prefix string) int {
+	var removed int
+	for _, shard := range ss.shards {
+		if tagged, ok := shard.(TaggedStorage[K]); ok {
+			removed += tagged.RemovePrefix(prefix)
+		}
+	}
+
+	return removed
+}
+
+// TagIndexed все шарды ведут индекс тегов
+func (ss *ShardStorage[K]) TagIndexed() bool {
+	for _, shard := range ss.shards {
+		tagged, ok := shard.(TaggedStorage[K])
+		if !ok || !tagged.TagIndexed() {
+			return false
+		}
+	}
+
+	return true
+}
+
 func (ss *ShardStorage[K]) Delete(key K) {
 	ss.GetShard(key).Delete(key)
 }
@@ -76,6 +185,54 @@ func (ss *ShardStorage[K]) Clear() {
 	}
 }
 
+// Bytes суммарный размер значений шардов, ведущих учет размера
+func (ss *ShardStorage[K]) Bytes() int64 {
+	var total int64
+	for _, shard := range ss.shards {
+		if sizer, ok := shard.(ByteSizer); ok {
+			total += sizer.Bytes()
+		}
+	}
+
+	return total
+}
+
+// SetByteBudget распределение бюджета объема поровну между шардами, ведущими учет размера.
+// Ограничение размера одного значения применяется к каждому шарду как есть.
+func (ss *ShardStorage[K]) SetByteBudget(maxBytes int64, maxItemBytes int64) {
+	shardBytes := ShardByteBudget(maxBytes, ss.shardCount)
+	for _, shard := range ss.shards {
+		if budgeter, ok := shard.(ByteBudgeter); ok {
+			budgeter.SetByteBudget(shardBytes, maxItemBytes)
+		}
+	}
+}
+
+// MaxItemBytes наименьшее действующее ограничение размера значения среди шардов, 0 - без ограничения
+func (ss *ShardStorage[K]) MaxItemBytes() int64 {
+	var res int64
+	for _, shard := range ss.shards {
+		budgeter, ok := shard.(ByteBudgeter)
+		if !ok {
+			continue
+		}
+		if limit := budgeter.MaxItemBytes(); limit > 0 && (res == 0 || limit < res) {
+			res = limit
+		}
+	}
+
+	return res
+}
+
+// SetEvictionListener установка обработчика вытеснений всем шардам, сообщающим о вытеснениях
+func (ss *ShardStorage[K]) SetEvictionListener(listener EvictionListener[K]) {
+	for _, shard := range ss.shards {
+		if reporter, ok := shard.(EvictionReporter[K]); ok {
+			reporter.SetEvictionListener(listener)
+		}
+	}
+}
+
 func (ss *ShardStorage[K]) Range(fn func(key K, value []byte) bool) {
 	// Последовательно итерируем каждый шард.
 	// Если пользовательская функция fn вернет false,
@@ -96,6 +253,42 @@ func (ss *ShardStorage[K]) Range(fn func(key K, value []byte) bool) {
 	}
 }
 
+// RangeWithTags обход значений шардов с тегами, для шардов без тегов теги пустые
+func (ss *ShardStorage[K]) RangeWithTags(fn func(key K, value []byte, tags []string) bool) {
+	for _, shard := range ss.shards {
+		stop := false
+		visit := func(key K, value []byte, tags []string) bool {
+			if !fn(key, value, tags) {
+				stop = true
+
+				return false
+			}
+
+			return true
+		}
+		if ranger, ok := shard.(TagRanger[K]); ok {
+			ranger.RangeWithTags(visit)
+		} else {
+			shard.Range(func(key K, value []byte) bool {
+				return visit(key, value, nil)
+			})
+		}
+		if stop {
+			break
+		}
+	}
+}
+
+// ShardByteBudget доля бюджета объема на один шард (с округлением вверх), 0 - без ограничения
+func ShardByteBudget(maxBytes int64, shardCount uint64) int64 {
+	if maxBytes <= 0 || shardCount == 0 {
+		return 0
+	}
+	count := int64(shardCount)
+
+	return (maxBytes + count - 1) / count
+}
+
 // ShardIndexSelector выбор стратегии расчёта индекса шарда
 func (ss *ShardStorage[K]) ShardIndexSelector(shardCount uint64) ShardIndex[K] {
 	if ss.isPowerOfTwo(shardCount) {
@@ -109,6 +302,11 @@ func (ss *ShardStorage[K]) isPowerOfTwo(n uint64) bool {
 	return n > 0 && (n&(n-1)) == 0
 }
 
+// GetShards шарды хранилища
+func (ss *ShardStorage[K]) GetShards() []Storage[K] {
+	return ss.shards
+}
+
 func (ss *ShardStorage[K]) GetShard(key K) Storage[K] {
 	return ss.shards[ss.shardIndex(key)]
 }
@@ -0,0 +1,475 @@
+package cache
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/container"
+	"github.com/ElfAstAhe/go-service-template/pkg/errs"
+	"github.com/ElfAstAhe/go-service-template/pkg/logger"
+	"github.com/ElfAstAhe/go-service-template/pkg/utils"
+)
+
+const (
+	// SnapshotVersion текущая версия формата файла снимка (2 - записи с тегами)
+	SnapshotVersion uint16 = 2
+	// snapshotVersionNoTags версия формата без тегов, поддерживается при загрузке
+	snapshotVersionNoTags uint16 = 1
+	// DefaultSnapshotMaxBytes ограничение размера снимка по умолчанию
+	DefaultSnapshotMaxBytes int64 = 256 * 1024 * 1024
+)
+
+// snapshotMagic сигнатура файла снимка
+var snapshotMagic = [8]byte{'G', 'S', 'T', 'C', 'A', 'C', 'H', 'E'}
+
+const (
+	// snapshotFlagEncrypted данные снимка зашифрованы
+	snapshotFlagEncrypted uint16 = 1 << iota
+)
+
+// snapshotCipherOverhead запас на служебные данные шифрования (nonce, tag) при проверке размера
+const snapshotCipherOverhead int64 = 1024
+
+// snapshotHeader заголовок файла: сигнатура, версия, флаги, размер и sha256 данных
+type snapshotHeader struct {
+	Magic    [8]byte
+	Version  uint16
+	Flags    uint16
+	Size     int64
+	Checksum [sha256.Size]byte
+}
+
+// Snapshot - сохранение содержимого хранилища кэша в локальный файл и загрузка при старте (прогрев).
+//
+// Формат файла: заголовок (snapshotHeader) и данные - кол-во записей и записи (ключ, момент истечения,
+// упакованное значение как есть, вместе с конвертом кодека, теги для хранилищ с индексом тегов). Данные защищены контрольной суммой sha256
+// и могут быть зашифрованы (WithSnapshotCipher). Файл записывается атомарно через временный файл.
+// Просроченные записи не сохраняются и не загружаются.
+//
+// Пример использования:
+//
+//	snapshot := cache.NewManagerSnapshot("users-cache", "/var/lib/app/users.cache", manager, log)
+//	_, _ = snapshot.Load(ctx)                            // при старте
+//	pc.RegisterInstance("users-cache-snapshot", snapshot) // Close(ctx) сохраняет снимок при остановке
+type Snapshot[K comparable] struct {
+	name       string
+	path       string
+	storage    Storage[K]
+	expiry     ExpiryExtractor
+	keyCodec   KeyCodec[K]
+	cipher     utils.Cipher
+	maxBytes   int64
+	maxEntries int
+	log        logger.Logger
+}
+
+var _ container.ContextCloser = (*Snapshot[string])(nil)
+
+type SnapshotOption[K comparable] func(*Snapshot[K])
+
+// WithSnapshotKeyCodec сериализация ключей (по умолчанию DefaultKeyCodec)
+func WithSnapshotKeyCodec[K comparable](keyCodec KeyCodec[K]) SnapshotOption[K] {
+	return func(s *Snapshot[K]) {
+		s.keyCodec = keyCodec
+	}
+}
+
+// WithSnapshotCipher шифрование данных снимка
+func WithSnapshotCipher[K comparable](cipher utils.Cipher) SnapshotOption[K] {
+	return func(s *Snapshot[K]) {
+		s.cipher = cipher
+	}
+}
+
+// WithSnapshotMaxBytes ограничение размера данных снимка: при сохранении лишние записи отбрасываются,
+// файл большего размера не загружается, 0 - без ограничения
+func WithSnapshotMaxBytes[K comparable](maxBytes int64) SnapshotOption[K] {
+	return func(s *Snapshot[K]) {
+		s.maxBytes = maxBytes
+	}
+}
+
+// WithSnapshotMaxEntries ограничение кол-ва записей снимка, 0 - без ограничения
+func WithSnapshotMaxEntries[K comparable](maxEntries int) SnapshotOption[K] {
+	return func(s *Snapshot[K]) {
+		s.maxEntries = maxEntries
+	}
+}
+
+// NewSnapshot снимок хранилища, expiry - извлечение момента истечения из упакованного значения (см. CodecExpiry)
+func NewSnapshot[K comparable](
+	name string,
+	path string,
+	storage Storage[K],
+	expiry ExpiryExtractor,
+	log logger.Logger,
+	opts ...SnapshotOption[K],
+) *Snapshot[K] {
+	res := &Snapshot[K]{
+		name:     name,
+		path:     path,
+		storage:  storage,
+		expiry:   expiry,
+		keyCodec: DefaultKeyCodec[K](),
+		maxBytes: DefaultSnapshotMaxBytes,
+		log:      log.GetLogger(name),
+	}
+	for _, opt := range opts {
+		opt(res)
+	}
+
+	return res
+}
+
+// NewManagerSnapshot снимок хранилища менеджера кэша
+func NewManagerSnapshot[K comparable, V any](
+	name string,
+	path string,
+	manager *Manager[K, V],
+	log logger.Logger,
+	opts ...SnapshotOption[K],
+) *Snapshot[K] {
+	return NewSnapshot[K](name, path, manager.storage, CodecExpiry[V](manager.codec), log, opts...)
+}
+
+func (s *Snapshot[K]) GetName() string {
+	return s.name
+}
+
+func (s *Snapshot[K]) GetPath() string {
+	return s.path
+}
+
+// Close сохранение снимка при остановке приложения (container.ContextCloser)
+func (s *Snapshot[K]) Close(ctx context.Context) error {
+	_, err := s.Save(ctx)
+
+	return err
+}
+
+// Save сохранение непросроченных записей хранилища в файл, возвращает кол-во сохраненных записей
+func (s *Snapshot[K]) Save(ctx context.Context) (int, error) {
+	body, count, err := s.encode(ctx)
+	if err != nil {
+		return 0, errs.NewDalCacheError("Snapshot.Save", fmt.Sprintf("snapshot [%s] encode", s.name), err)
+	}
+
+	header := snapshotHeader{
+		Magic:   snapshotMagic,
+		Version: SnapshotVersion,
+	}
+	if s.cipher != nil {
+		body, err = s.cipher.Encrypt(body)
+		if err != nil {
+			return 0, errs.NewDalCacheError("Snapshot.Save", fmt.Sprintf("snapshot [%s] encrypt", s.name), err)
+		}
+		header.Flags |= snapshotFlagEncrypted
+	}
+	header.Size = int64(len(body))
+	header.Checksum = sha256.Sum256(body)
+
+	if err := s.writeFile(&header, body); err != nil {
+		return 0, errs.NewDalCacheError("Snapshot.Save", fmt.Sprintf("snapshot [%s] write [%s]", s.name, s.path), err)
+	}
+	s.log.Infof("cache snapshot %s saved %d entries (%d bytes) to %s", s.name, count, len(body), s.path)
+
+	return count, nil
+}
+
+// Load загрузка непросроченных записей из файла в хранилище, отсутствие файла - не ошибка,
+// возвращает кол-во загруженных записей
+func (s *Snapshot[K]) Load(ctx context.Context) (int, error) {
+	body, version, err := s.readFile()
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			s.log.Debugf("cache snapshot %s not found at %s, cold start", s.name, s.path)
+
+			return 0, nil
+		}
+
+		return 0, errs.NewDalCacheError("Snapshot.Load", fmt.Sprintf("snapshot [%s] read [%s]", s.name, s.path), err)
+	}
+
+	count, err := s.decode(ctx, body, version)
+	if err != nil {
+		return count, errs.NewDalCacheError("Snapshot.Load", fmt.Sprintf("snapshot [%s] decode", s.name), err)
+	}
+	s.log.Infof("cache snapshot %s loaded %d entries from %s", s.name, count, s.path)
+
+	return count, nil
+}
+
+// encode сериализация записей: кол-во, затем (ключ, момент истечения, значение, кол-во тегов, теги)
+func (s *Snapshot[K]) encode(ctx context.Context) ([]byte, int, error) {
+	now := time.Now().UnixNano()
+	var records bytes.Buffer
+	var count int
+	var truncated bool
+	var rangeErr error
+	buf := make([]byte, binary.MaxVarintLen64)
+	s.rangeWithTags(func(key K, value []byte, tags []string) bool {
+		if rangeErr = ctx.Err(); rangeErr != nil {
+			return false
+		}
+		var dieAt int64
+		if s.expiry != nil {
+			dieAt = s.expiry(value)
+		}
+		if dieAt > 0 && now > dieAt {
+			return true
+		}
+		encodedKey, err := s.keyCodec.EncodeKey(key)
+		if err != nil {
+			s.log.Warnf("cache snapshot %s skip key [%v]: %v", s.name, key, err)
+
+			return true
+		}
+		size := (4+len(tags))*binary.MaxVarintLen64 + len(encodedKey) + len(value)
+		for _, tag := range tags {
+			size += len(tag)
+		}
+		if (s.maxEntries > 0 && count >= s.maxEntries) || (s.maxBytes > 0 && int64(records.Len()+size) > s.maxBytes) {
+			truncated = true
+
+			return false
+		}
+		records.Write(buf[:binary.PutUvarint(buf, uint64(len(encodedKey)))])
+		records.WriteString(encodedKey)
+		records.Write(buf[:binary.PutVarint(buf, dieAt)])
+		records.Write(buf[:binary.PutUvarint(buf, uint64(len(value)))])
+		records.Write(value)
+		records.Write(buf[:binary.PutUvarint(buf, uint64(len(tags)))])
+		for _, tag := range tags {
+			records.Write(buf[:binary.PutUvarint(buf, uint64(len(tag)))])
+			records.WriteString(tag)
+		}
+		count++
+
+		return true
+	})
+	if rangeErr != nil {
+		return nil, 0, rangeErr
+	}
+	if truncated {
+		s.log.Warnf("cache snapshot %s truncated to %d entries by size limits", s.name, count)
+	}
+
+	res := make([]byte, 0, records.Len()+binary.MaxVarintLen64)
+	res = binary.AppendUvarint(res, uint64(count))
+	res = append(res, records.Bytes()...)
+
+	return res, count, nil
+}
+
+func (s *Snapshot[K]) decode(ctx context.Context, body []byte, version uint16) (int, error) {
+	reader := bytes.NewReader(body)
+	total, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return 0, err
+	}
+	if s.maxEntries > 0 && total > uint64(s.maxEntries) {
+		return 0, fmt.Errorf("snapshot entries [%d] exceed limit [%d]", total, s.maxEntries)
+	}
+
+	expiring, _ := s.storage.(ExpiringStorage[K])
+	tagged, _ := s.storage.(TaggedStorage[K])
+	now := time.Now().UnixNano()
+	var count int
+	for i := uint64(0); i < total; i++ {
+		if err := ctx.Err(); err != nil {
+			return count, err
+		}
+		encodedKey, err := readSnapshotBytes(reader)
+		if err != nil {
+			return count, err
+		}
+		dieAt, err := binary.ReadVarint(reader)
+		if err != nil {
+			return count, err
+		}
+		value, err := readSnapshotBytes(reader)
+		if err != nil {
+			return count, err
+		}
+		var tags []string
+		if version > snapshotVersionNoTags {
+			if tags, err = readSnapshotTags(reader); err != nil {
+				return count, err
+			}
+		}
+		if dieAt > 0 && now > dieAt {
+			continue
+		}
+		key, err := s.keyCodec.DecodeKey(string(encodedKey))
+		if err != nil {
+			s.log.Warnf("cache snapshot %s skip key [%s]: %v", s.name, encodedKey, err)
+
+			continue
+		}
+		switch {
+		case len(tags) > 0 && tagged != nil:
+			tagged.SetWithTags(key, value, dieAt, tags)
+		case expiring != nil:
+			expiring.SetWithExpiry(key, value, dieAt)
+		default:
+			s.storage.Set(key, value)
+		}
+		count++
+	}
+
+	return count, nil
+}
+
+// rangeWithTags обход записей с тегами, если хранилище их отдает
+func (s *Snapshot[K]) rangeWithTags(fn func(key K, value []byte, tags []string) bool) {
+	if ranger, ok := s.storage.(TagRanger[K]); ok {
+		ranger.RangeWithTags(fn)
+
+		return
+	}
+	s.storage.Range(func(key K, value []byte) bool {
+		return fn(key, value, nil)
+	})
+}
+
+func readSnapshotTags(reader *bytes.Reader) ([]string, error) {
+	count, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return nil, err
+	}
+	// каждый тег занимает хотя бы байт длины
+	if count > uint64(reader.Len()) {
+		return nil, io.ErrUnexpectedEOF
+	}
+	var res []string
+	for i := uint64(0); i < count; i++ {
+		tag, err := readSnapshotBytes(reader)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, string(tag))
+	}
+
+	return res, nil
+}
+
+func readSnapshotBytes(reader *bytes.Reader) ([]byte, error) {
+	size, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return nil, err
+	}
+	if size > uint64(reader.Len()) {
+		return nil, io.ErrUnexpectedEOF
+	}
+	res := make([]byte, size)
+	if _, err := io.ReadFull(reader, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// writeFile атомарная запись: временный файл в том же каталоге, fsync, переименование
+func (s *Snapshot[K]) writeFile(header *snapshotHeader, body []byte) (err error) {
+	dir := filepath.Dir(s.path)
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, filepath.Base(s.path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tmp.Close()
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+
+	writer := bufio.NewWriter(tmp)
+	if err := binary.Write(writer, binary.BigEndian, header); err != nil {
+		return err
+	}
+	if _, err := writer.Write(body); err != nil {
+		return err
+	}
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), s.path)
+}
+
+// readFile чтение и проверка файла, возвращает данные и версию формата
+func (s *Snapshot[K]) readFile() ([]byte, uint16, error) {
+	file, err := os.Open(s.path)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() { _ = file.Close() }()
+
+	var header snapshotHeader
+	if err := binary.Read(file, binary.BigEndian, &header); err != nil {
+		return nil, 0, fmt.Errorf("read header: %w", err)
+	}
+	if header.Magic != snapshotMagic {
+		return nil, 0, errors.New("not a cache snapshot file")
+	}
+	if header.Version < snapshotVersionNoTags || header.Version > SnapshotVersion {
+		return nil, 0, fmt.Errorf("unsupported snapshot version [%d], expected [%d]", header.Version, SnapshotVersion)
+	}
+	encrypted := header.Flags&snapshotFlagEncrypted != 0
+	limit := s.maxBytes
+	if encrypted {
+		limit += snapshotCipherOverhead
+	}
+	if header.Size < 0 || (s.maxBytes > 0 && header.Size > limit) {
+		return nil, 0, fmt.Errorf("snapshot size [%d] exceeds limit [%d]", header.Size, s.maxBytes)
+	}
+	// размер из заголовка не доверенный: без ограничения объема он ограничен только размером файла
+	info, err := file.Stat()
+	if err != nil {
+		return nil, 0, fmt.Errorf("stat: %w", err)
+	}
+	if available := info.Size() - int64(binary.Size(header)); header.Size > available {
+		return nil, 0, fmt.Errorf("snapshot size [%d] exceeds file body [%d]", header.Size, available)
+	}
+	if encrypted && s.cipher == nil {
+		return nil, 0, errors.New("snapshot is encrypted, cipher not applied")
+	}
+	if !encrypted && s.cipher != nil {
+		return nil, 0, errors.New("snapshot is not encrypted, but cipher applied")
+	}
+
+	body := make([]byte, header.Size)
+	if _, err := io.ReadFull(file, body); err != nil {
+		return nil, 0, fmt.Errorf("read body: %w", err)
+	}
+	if sha256.Sum256(body) != header.Checksum {
+		return nil, 0, errors.New("snapshot checksum mismatch")
+	}
+	if encrypted {
+		body, err = s.cipher.Decrypt(body)
+		if err != nil {
+			return nil, 0, fmt.Errorf("decrypt: %w", err)
+		}
+	}
+
+	return body, header.Version, nil
+}
@@ -10,15 +10,33 @@ type Cache[K comparable, V any] interface {
 	// Get возвращает десериализованную копию объекта.
 	Get(key K) (V, bool, error)
 
+	// GetOrLoad возвращает объект из кэша, при промахе загружает его через loader и сохраняет на ttl.
+	// Одновременные загрузки одного ключа выполняются один раз, ошибки загрузчика не кэшируются.
+	GetOrLoad(ctx context.Context, key K, loader Loader[K, V], ttl time.Duration) (V, bool, error)
+
 	// Set сериализует объект и сохраняет его в кэш.
 	Set(key K, value V, ttl time.Duration) error
 
 	// Delete удаляет ключ из кэша.
 	Delete(key K)
 
+	// SetWithTags сохраняет объект с тегами для групповой инвалидации, заменяет прежние теги ключа.
+	SetWithTags(key K, value V, ttl time.Duration, tags ...string) error
+
+	// InvalidateTag удаляет все объекты с тегом, возвращает кол-во удаленных.
+	InvalidateTag(tag string) (int, error)
+
+	// InvalidatePrefix удаляет все объекты, ключ которых начинается с prefix (только строковые ключи).
+	InvalidatePrefix(prefix string) (int, error)
+
 	// Служебные методы
 	Size() int
 	Clear()
 
 	CacheJanitor(ctx context.Context, eventTime time.Time) error
 }
+
+// ExpiryReader - кэш, возвращающий вместе со значением момент его истечения (unix nano, 0 - бессрочно)
+type ExpiryReader[K comparable, V any] interface {
+	GetWithExpiry(key K) (V, int64, bool, error)
+}
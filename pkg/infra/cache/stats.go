@@ -0,0 +1,209 @@
+package cache
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/infra/metrics"
+	"github.com/ElfAstAhe/go-service-template/pkg/utils"
+)
+
+// StatsRecorder - приемник событий кэша (метрики, статистика)
+type StatsRecorder interface {
+	Hit()
+	Miss()
+	Set()
+	Delete()
+	// Expired значение просрочено и удалено при чтении
+	Expired()
+	// Evicted значение вытеснено политикой вытеснения
+	Evicted()
+	// Janitor выполнение очистки просрочки
+	Janitor(removed int, err error, startTime time.Time)
+}
+
+// EvictionListener - уведомление о вытеснении ключа политикой вытеснения
+type EvictionListener[K comparable] func(key K)
+
+// EvictionReporter - хранилище, сообщающее о вытеснениях (RawStorage, ShardStorage)
+type EvictionReporter[K comparable] interface {
+	SetEvictionListener(listener EvictionListener[K])
+}
+
+type nopStatsRecorder struct{}
+
+func (nopStatsRecorder) Hit()                          {}
+func (nopStatsRecorder) Miss()                         {}
+func (nopStatsRecorder) Set()                          {}
+func (nopStatsRecorder) Delete()                       {}
+func (nopStatsRecorder) Expired()                      {}
+func (nopStatsRecorder) Evicted()                      {}
+func (nopStatsRecorder) Janitor(int, error, time.Time) {}
+
+// CacheStats - счетчики событий кэша с момента создания
+type CacheStats struct {
+	Hits        uint64 `json:"hits"`
+	Misses      uint64 `json:"misses"`
+	Evictions   uint64 `json:"evictions"`
+	Expirations uint64 `json:"expirations"`
+}
+
+// StatsSnapshotter - источник текущих счетчиков кэша
+type StatsSnapshotter interface {
+	Stats() CacheStats
+}
+
+// StatsCounter - подсчет событий кэша (для CacheInfo реестра) с передачей их в next
+type StatsCounter struct {
+	next        StatsRecorder
+	hits        atomic.Uint64
+	misses      atomic.Uint64
+	evictions   atomic.Uint64
+	expirations atomic.Uint64
+}
+
+var _ StatsRecorder = (*StatsCounter)(nil)
+var _ StatsSnapshotter = (*StatsCounter)(nil)
+
+// NewStatsCounter счетчик событий, next - следующий приемник (nil - без передачи)
+func NewStatsCounter(next StatsRecorder) *StatsCounter {
+	if utils.IsNil(next) {
+		next = nopStatsRecorder{}
+	}
+
+	return &StatsCounter{
+		next: next,
+	}
+}
+
+func (sc *StatsCounter) Hit() {
+	sc.hits.Add(1)
+	sc.next.Hit()
+}
+
+func (sc *StatsCounter) Miss() {
+	sc.misses.Add(1)
+	sc.next.Miss()
+}
+
+func (sc *StatsCounter) Set() {
+	sc.next.Set()
+}
+
+func (sc *StatsCounter) Delete() {
+	sc.next.Delete()
+}
+
+func (sc *StatsCounter) Expired() {
+	sc.expirations.Add(1)
+	sc.next.Expired()
+}
+
+func (sc *StatsCounter) Evicted() {
+	sc.evictions.Add(1)
+	sc.next.Evicted()
+}
+
+// Janitor удаленные очисткой просроченные значения учитываются как истекшие
+func (sc *StatsCounter) Janitor(removed int, err error, startTime time.Time) {
+	if removed > 0 {
+		sc.expirations.Add(uint64(removed))
+	}
+	sc.next.Janitor(removed, err, startTime)
+}
+
+func (sc *StatsCounter) Stats() CacheStats {
+	return CacheStats{
+		Hits:        sc.hits.Load(),
+		Misses:      sc.misses.Load(),
+		Evictions:   sc.evictions.Load(),
+		Expirations: sc.expirations.Load(),
+	}
+}
+
+// MetricsRecorder - публикация событий кэша в prometheus (см. metrics.ObserveCache*)
+type MetricsRecorder struct {
+	cache  string
+	policy string
+}
+
+var _ StatsRecorder = (*MetricsRecorder)(nil)
+
+func NewMetricsRecorder(cache string, policy string) *MetricsRecorder {
+	return &MetricsRecorder{
+		cache:  cache,
+		policy: policy,
+	}
+}
+
+func (mr *MetricsRecorder) Hit() {
+	metrics.ObserveCacheHit(mr.cache)
+}
+
+func (mr *MetricsRecorder) Miss() {
+	metrics.ObserveCacheMiss(mr.cache)
+}
+
+func (mr *MetricsRecorder) Set() {
+	metrics.ObserveCacheSet(mr.cache)
+}
+
+func (mr *MetricsRecorder) Delete() {
+	metrics.ObserveCacheDelete(mr.cache)
+}
+
+func (mr *MetricsRecorder) Expired() {
+	metrics.ObserveCacheExpired(mr.cache)
+}
+
+func (mr *MetricsRecorder) Evicted() {
+	metrics.ObserveCacheEviction(mr.cache, mr.policy)
+}
+
+func (mr *MetricsRecorder) Janitor(removed int, err error, startTime time.Time) {
+	metrics.ObserveCacheJanitor(mr.cache, removed, err, startTime)
+}
+
+// PolicyName имя политики вытеснения для метрик
+func PolicyName[K comparable](policy EvictionPolicy[K]) string {
+	switch policy.(type) {
+	case nil:
+		return "none"
+	case *LRUEvict[K]:
+		return "lru"
+	case *LFUEvict[K]:
+		return "lfu"
+	case *FIFOEvict[K]:
+		return "fifo"
+	case *TinyLFUEvict[K]:
+		return "tinylfu"
+	case *ARCEvict[K]:
+		return "arc"
+	case *S3FIFOEvict[K]:
+		return "s3fifo"
+	}
+	if named, ok := policy.(interface{ GetName() string }); ok {
+		return named.GetName()
+	}
+
+	return utils.GetTypeName(policy)
+}
+
+// RegisterStorageMetrics подключение хранилища к метрикам: вытеснения передаются в recorder,
+// текущий размер (кол-во и байты, если хранилище ведет их учет) снимается при сборе метрик.
+// Размер RedisStorage не публикуется: его Len - полный SCAN пространства имен на каждый сбор метрик.
+func RegisterStorageMetrics[K comparable](name string, storage Storage[K], recorder StatsRecorder) {
+	if reporter, ok := storage.(EvictionReporter[K]); ok {
+		reporter.SetEvictionListener(func(K) {
+			recorder.Evicted()
+		})
+	}
+	if _, ok := storage.(*RedisStorage[K]); ok {
+		return
+	}
+	var bytes func() int64
+	if sizer, ok := storage.(ByteSizer); ok {
+		bytes = sizer.Bytes
+	}
+	metrics.RegisterCacheSize(name, storage.Len, bytes)
+}
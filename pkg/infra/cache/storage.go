@@ -10,3 +10,61 @@ type Storage[K comparable] interface {
 	Len() int
 	Clear()
 }
+
+// ByteSizer - хранилище, ведущее учет размера данных в байтах
+type ByteSizer interface {
+	Bytes() int64
+}
+
+// ByteBudgeter - хранилище с ограничением объема данных в байтах (RawStorage, ShardStorage)
+type ByteBudgeter interface {
+	ByteSizer
+	// SetByteBudget установка бюджета суммарного размера и ограничения размера одного значения, 0 - без ограничения
+	SetByteBudget(maxBytes int64, maxItemBytes int64)
+	// MaxItemBytes действующее ограничение размера одного значения, 0 - без ограничения
+	MaxItemBytes() int64
+}
+
+// ExpiringStorage - хранилище, хранящее момент истечения рядом со значением и ведущее индекс просрочки.
+// Позволяет проверять истечение при чтении и очищать просрочку без распаковки значений кодеком.
+type ExpiringStorage[K comparable] interface {
+	Storage[K]
+	// GetWithExpiry значение и момент истечения (unix nano, 0 - бессрочно или неизвестно)
+	GetWithExpiry(key K) ([]byte, int64, bool)
+	// SetWithExpiry сохранение значения с моментом истечения (unix nano, 0 - бессрочно)
+	SetWithExpiry(key K, b []byte, dieAt int64)
+	// RemoveExpired удаление значений, истекших к моменту now (unix nano), не более limit (0 - без ограничения),
+	// возвращает кол-во удаленных значений
+	RemoveExpired(now int64, limit int) int
+	// ExpiryIndexed все значения хранилища учтены в индексе просрочки (для составных хранилищ зависит от частей)
+	ExpiryIndexed() bool
+}
+
+// TaggedStorage - хранилище с индексом тегов (тег -> ключи). Индекс согласован с удалением, вытеснением
+// и очисткой просрочки. Перезапись через SetWithTags заменяет теги ключа, обычная запись (Set, SetWithExpiry)
+// их сохраняет: лишняя инвалидация безопасна, потерянная - нет (например, при фоновом обновлении значения).
+type TaggedStorage[K comparable] interface {
+	Storage[K]
+	// SetWithTags сохранение значения с моментом истечения (unix nano, 0 - бессрочно) и тегами
+	SetWithTags(key K, b []byte, dieAt int64, tags []string)
+	// RemoveTag удаление всех значений с тегом, возвращает кол-во удаленных значений
+	RemoveTag(tag string) int
+	// RemovePrefix удаление всех значений, строковый ключ которых начинается с prefix,
+	// для нестроковых ключей не удаляет ничего, возвращает кол-во удаленных значений
+	RemovePrefix(prefix string) int
+	// TagIndexed все значения хранилища учтены в индексе тегов (для составных хранилищ зависит от частей)
+	TagIndexed() bool
+}
+
+// CheckedWriter - хранилище, сообщающее об ошибке записи (удаленные хранилища), Manager возвращает ее вызывающему
+type CheckedWriter[K comparable] interface {
+	// SetWithTagsChecked SetWithTags с ошибкой записи: при ошибке значение без тегов в хранилище не остается
+	SetWithTagsChecked(key K, b []byte, dieAt int64, tags []string) error
+	// SetWithExpiryChecked SetWithExpiry с ошибкой записи
+	SetWithExpiryChecked(key K, b []byte, dieAt int64) error
+}
+
+// TagRanger - хранилище, отдающее теги значений при обходе (для переноса значений вместе с тегами, см. Snapshot)
+type TagRanger[K comparable] interface {
+	RangeWithTags(fn func(key K, value []byte, tags []string) bool)
+}
@@ -0,0 +1,57 @@
+package cache
+
+import (
+	"maps"
+	"slices"
+)
+
+// tagIndex - индекс тегов хранилища (тег -> ключи), не потокобезопасен (защищается блокировкой хранилища)
+type tagIndex[K comparable] struct {
+	tags map[string]map[K]struct{}
+}
+
+func newTagIndex[K comparable]() *tagIndex[K] {
+	return &tagIndex[K]{
+		tags: make(map[string]map[K]struct{}),
+	}
+}
+
+// set замена тегов ключа, возвращает новые теги ключа без повторов
+func (ti *tagIndex[K]) set(key K, old []string, tags []string) []string {
+	ti.remove(key, old)
+	var res []string
+	for _, tag := range tags {
+		keys, ok := ti.tags[tag]
+		if !ok {
+			keys = make(map[K]struct{})
+			ti.tags[tag] = keys
+		}
+		if _, ok := keys[key]; ok {
+			continue
+		}
+		keys[key] = struct{}{}
+		res = append(res, tag)
+	}
+
+	return res
+}
+
+// remove исключение ключа из индекса его тегов
+func (ti *tagIndex[K]) remove(key K, tags []string) {
+	for _, tag := range tags {
+		keys := ti.tags[tag]
+		delete(keys, key)
+		if len(keys) == 0 {
+			delete(ti.tags, tag)
+		}
+	}
+}
+
+// keys копия ключей тега (удаление ключей изменяет индекс)
+func (ti *tagIndex[K]) keys(tag string) []K {
+	return slices.Collect(maps.Keys(ti.tags[tag]))
+}
+
+func (ti *tagIndex[K]) reset() {
+	ti.tags = make(map[string]map[K]struct{})
+}
@@ -0,0 +1,76 @@
+package test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/infra/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestARCEvict(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		arc := cache.NewARCEvict[string](10)
+
+		_, ok := arc.Evict()
+		assert.False(t, ok)
+	})
+
+	t.Run("recent_evicted_before_frequent", func(t *testing.T) {
+		arc := cache.NewARCEvict[string](3)
+
+		arc.OnSet("a")
+		arc.OnSet("b")
+		arc.OnGet("a") // a -> T2
+		arc.OnSet("c")
+
+		key, ok := arc.Evict()
+		assert.True(t, ok)
+		assert.Equal(t, "b", key, "Вытесняется старейший ключ T1")
+	})
+
+	t.Run("ghost_hit_promotes_to_frequent", func(t *testing.T) {
+		arc := cache.NewARCEvict[string](2)
+
+		arc.OnSet("a")
+		arc.OnSet("b")
+		key, _ := arc.Evict()
+		assert.Equal(t, "a", key)
+
+		// "a" в призраке B1: повторная запись попадает в T2
+		arc.OnSet("a")
+		arc.OnSet("c")
+		key, _ = arc.Evict()
+		assert.Equal(t, "b", key)
+	})
+
+	t.Run("frequent_key_survives_scan", func(t *testing.T) {
+		const capacity = 100
+		storage := cache.NewRawStorage[string](capacity, cache.NewARCEvict[string](capacity))
+
+		storage.Set("hot", []byte("v"))
+		_, _ = storage.Get("hot")
+		for i := 0; i < 5*capacity; i++ {
+			storage.Set(fmt.Sprintf("scan-%d", i), []byte("v"))
+		}
+
+		assert.True(t, storage.Has("hot"))
+		assert.LessOrEqual(t, storage.Len(), capacity)
+	})
+
+	t.Run("remove_and_reset", func(t *testing.T) {
+		arc := cache.NewARCEvict[string](10)
+
+		arc.OnSet("a")
+		arc.OnSet("b")
+		arc.OnRemove("a")
+		key, ok := arc.Evict()
+		assert.True(t, ok)
+		assert.Equal(t, "b", key)
+
+		arc.OnSet("c")
+		arc.Reset()
+		_, ok = arc.Evict()
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,136 @@
+package test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/errs"
+	"github.com/ElfAstAhe/go-service-template/pkg/infra/cache"
+	"github.com/ElfAstAhe/go-service-template/pkg/infra/cache/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRawStorage_MaxBytes_EvictsUntilUnderBudget(t *testing.T) {
+	storage := cache.NewRawStorage[string](0, cache.NewFIFOEvict[string](), cache.WithRawMaxBytes[string](10))
+
+	var evicted []string
+	storage.SetEvictionListener(func(key string) {
+		evicted = append(evicted, key)
+	})
+
+	storage.Set("a", []byte("1234"))
+	storage.Set("b", []byte("1234"))
+	assert.Equal(t, int64(8), storage.Bytes())
+
+	// требуется освободить место под 8 байт: вытесняются оба значения
+	storage.Set("c", []byte("12345678"))
+	assert.Equal(t, []string{"a", "b"}, evicted)
+	assert.Equal(t, int64(8), storage.Bytes())
+	assert.Equal(t, 1, storage.Len())
+}
+
+func TestRawStorage_MaxBytes_OverwriteDoesNotEvictItself(t *testing.T) {
+	storage := cache.NewRawStorage[string](0, cache.NewFIFOEvict[string](), cache.WithRawMaxBytes[string](10))
+
+	storage.Set("a", []byte("123456"))
+	storage.Set("b", []byte("1234"))
+
+	// перезапись "a" укладывается в бюджет за счет вытеснения "b"
+	storage.Set("a", []byte("1234567"))
+	assert.True(t, storage.Has("a"))
+	assert.False(t, storage.Has("b"))
+	assert.Equal(t, int64(7), storage.Bytes())
+
+	b, ok := storage.Get("a")
+	require.True(t, ok)
+	assert.Equal(t, "1234567", string(b))
+}
+
+func TestRawStorage_MaxItemBytes_Rejects(t *testing.T) {
+	storage := cache.NewRawStorage[string](0, cache.NewLRUEvict[string](),
+		cache.WithRawMaxBytes[string](100),
+		cache.WithRawMaxItemBytes[string](5),
+	)
+	assert.Equal(t, int64(5), storage.MaxItemBytes())
+
+	storage.Set("a", []byte("123"))
+	storage.Set("a", []byte("123456"))
+	assert.False(t, storage.Has("a"), "Значение сверх ограничения не сохраняется, прежнее удаляется")
+	assert.Equal(t, int64(0), storage.Bytes())
+}
+
+func TestRawStorage_MaxItemBytes_LimitedByBudget(t *testing.T) {
+	storage := cache.NewRawStorage[string](0, cache.NewLRUEvict[string](), cache.WithRawMaxBytes[string](4))
+	assert.Equal(t, int64(4), storage.MaxItemBytes())
+
+	storage.Set("a", []byte("12345"))
+	assert.Equal(t, 0, storage.Len())
+}
+
+func TestRawStorage_SetByteBudget_Shrinks(t *testing.T) {
+	storage := cache.NewRawStorage[string](0, cache.NewFIFOEvict[string]())
+	storage.Set("a", []byte("1234"))
+	storage.Set("b", []byte("1234"))
+	storage.Set("c", []byte("1234"))
+
+	storage.SetByteBudget(8, 0)
+	assert.Equal(t, int64(8), storage.Bytes())
+	assert.False(t, storage.Has("a"))
+}
+
+func TestShardStorage_SetByteBudget(t *testing.T) {
+	factory := func(maxSize int, _ cache.EvictionPolicy[string]) cache.Storage[string] {
+		return cache.NewRawStorage[string](maxSize, cache.NewLRUEvict[string]())
+	}
+	storage := cache.NewShardStorage[string](4, factory, 0, nil)
+	storage.SetByteBudget(10, 0)
+
+	// бюджет шарда - 3 байта
+	assert.Equal(t, int64(3), storage.MaxItemBytes())
+	for _, key := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+		storage.Set(key, []byte("12"))
+	}
+	assert.LessOrEqual(t, storage.Bytes(), int64(4*3))
+}
+
+func TestShardByteBudget(t *testing.T) {
+	assert.Equal(t, int64(0), cache.ShardByteBudget(0, 4))
+	assert.Equal(t, int64(25), cache.ShardByteBudget(100, 4))
+	assert.Equal(t, int64(34), cache.ShardByteBudget(100, 3))
+}
+
+func TestCacheFactory_WithMaxBytes(t *testing.T) {
+	c, err := cache.CacheFactory[string, string](
+		cache.WithCodec[string, string](cache.NewJSONCodec[string](func() string { return "" })),
+		cache.WithMaxSize[string, string](0),
+		cache.WithMaxBytes[string, string](1024),
+		cache.WithMaxItemBytes[string, string](128),
+		cache.WithShardCount[string, string](4),
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, c.Set("small", "v", time.Minute))
+
+	err = c.Set("big", strings.Repeat("x", 256), time.Minute)
+	require.Error(t, err)
+	_, ok := errors.AsType[*errs.DalCacheError](err)
+	assert.True(t, ok)
+
+	_, found, err := c.Get("big")
+	require.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestCacheFactory_WithMaxBytes_UnsupportedStorage(t *testing.T) {
+	_, err := cache.CacheFactory[string, string](
+		cache.WithCodec[string, string](cache.NewJSONCodec[string](func() string { return "" })),
+		cache.WithMaxBytes[string, string](1024),
+		cache.WithShardFactory[string, string](func(int, cache.EvictionPolicy[string]) cache.Storage[string] {
+			return mocks.NewMockStorage[string](t)
+		}),
+	)
+	require.Error(t, err)
+}
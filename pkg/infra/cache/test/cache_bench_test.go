@@ -33,7 +33,7 @@ func BenchmarkStorage_Contention(b *testing.B) {
 			return cache.NewRawStorage[string](m, p)
 		}
 		// Используем 64 шарда (степень двойки для скорости)
-		s := cache.NewShardStorage[string](64, factory, 10000, cache.NewLRUEvict[string]())
+		s := cache.NewShardStorage[string](64, factory, 10000, nil)
 		b.ResetTimer()
 		b.RunParallel(func(pb *testing.PB) {
 			i := 0
@@ -81,3 +81,40 @@ func BenchmarkManager_FullCycle(b *testing.B) {
 		})
 	}
 }
+
+// Бенчмарк полного цикла кэша объектов (без сериализации) для сравнения с JSON/GOB
+func BenchmarkObjectManager_FullCycle(b *testing.B) {
+	val := &BenchData{ID: 1, Value: "benchmark-test-payload"}
+
+	modes := []struct {
+		name  string
+		clone cache.CloneFunc[*BenchData]
+	}{
+		{"Object", nil},
+		{"ObjectClone", func(v *BenchData) *BenchData {
+			res := *v
+
+			return &res
+		}},
+	}
+
+	for _, tc := range modes {
+		b.Run(tc.name, func(b *testing.B) {
+			c, _ := cache.CacheFactory(
+				cache.WithShardCount[string, *BenchData](64),
+				cache.WithObjectStorage[string, *BenchData](tc.clone),
+				cache.WithLRUEvictPolicy[string, *BenchData](),
+			)
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				i := 0
+				for pb.Next() {
+					key := fmt.Sprintf("key-%d", i)
+					_ = c.Set(key, val, time.Minute)
+					_, _, _ = c.Get(key)
+					i++
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/infra/cache"
+	"github.com/ElfAstAhe/go-service-template/pkg/infra/cache/mocks"
+	"github.com/ElfAstAhe/go-service-template/pkg/utils"
+	utlmocks "github.com/ElfAstAhe/go-service-template/pkg/utils/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newWrapperTestCodec() cache.Codec[TestUser] {
+	return cache.NewJSONCodec[TestUser](func() TestUser { return TestUser{} })
+}
+
+func newWrapperTestCipher() utils.Cipher {
+	return utils.MustNewAesGcmCipher([]byte("0123456789abcdef0123456789abcdef"))
+}
+
+func TestCompressCodec(t *testing.T) {
+	large := TestUser{ID: 1, Name: strings.Repeat("compressible ", 500)}
+	small := TestUser{ID: 2, Name: "small"}
+
+	for _, encoding := range []string{utils.EncodingGzip, utils.EncodingBrotli} {
+		t.Run(encoding, func(t *testing.T) {
+			inner := newWrapperTestCodec()
+			codec, err := cache.NewCompressCodec[TestUser](inner, encoding, cache.DefaultCompressThreshold)
+			require.NoError(t, err)
+
+			plain, err := inner.Marshal(large, time.Minute)
+			require.NoError(t, err)
+			buf, err := codec.Marshal(large, time.Minute)
+			require.NoError(t, err)
+			assert.Less(t, len(buf), len(plain)/10)
+
+			env, err := codec.Unmarshal(buf)
+			require.NoError(t, err)
+			assert.Equal(t, large, env.Value)
+			dieAt, err := codec.DecodeExpiry(buf)
+			require.NoError(t, err)
+			assert.InDelta(t, env.DieAt, dieAt, float64(time.Second))
+
+			// меньше порога - без сжатия
+			buf, err = codec.Marshal(small, time.Minute)
+			require.NoError(t, err)
+			assert.Contains(t, string(buf), "small")
+			env, err = codec.Unmarshal(buf)
+			require.NoError(t, err)
+			assert.Equal(t, small, env.Value)
+		})
+	}
+
+	_, err := cache.NewCompressCodec[TestUser](newWrapperTestCodec(), utils.EncodingCompress, 0)
+	assert.Error(t, err)
+}
+
+func TestCipherCodec(t *testing.T) {
+	val := TestUser{ID: 7, Name: "secret-name"}
+	codec, err := cache.NewCipherCodec[TestUser](newWrapperTestCodec(), newWrapperTestCipher())
+	require.NoError(t, err)
+
+	buf, err := codec.Marshal(val, time.Minute)
+	require.NoError(t, err)
+	assert.NotContains(t, string(buf), "secret-name")
+
+	env, err := codec.Unmarshal(buf)
+	require.NoError(t, err)
+	assert.Equal(t, val, env.Value)
+
+	// чужой ключ
+	other, err := cache.NewCipherCodec[TestUser](newWrapperTestCodec(),
+		utils.MustNewAesGcmCipher([]byte("fedcba9876543210fedcba9876543210")))
+	require.NoError(t, err)
+	_, err = other.Unmarshal(buf)
+	assert.Error(t, err)
+}
+
+func TestCipherCodec_ExpiryWithoutDecrypt(t *testing.T) {
+	// шифр без ожиданий Decrypt: вызов расшифровки провалит тест
+	cipher := utlmocks.NewMockCipher(t)
+	cipher.On("Encrypt", mock.Anything).Return([]byte("encrypted"), nil)
+	codec, err := cache.NewCipherCodec[TestUser](newWrapperTestCodec(), cipher)
+	require.NoError(t, err)
+
+	before := time.Now().Add(time.Hour).UnixNano()
+	buf, err := codec.Marshal(TestUser{ID: 1}, time.Hour)
+	require.NoError(t, err)
+
+	dieAt := cache.CodecExpiry[TestUser](codec)(buf)
+	assert.GreaterOrEqual(t, dieAt, before)
+	assert.LessOrEqual(t, dieAt, time.Now().Add(time.Hour).UnixNano())
+
+	// сборщик просроченных записей не расшифровывает значения
+	mStorage := mocks.NewMockStorage[string](t)
+	mStorage.On("Range", mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(0).(func(string, []byte) bool)("expired", buf)
+	}).Return()
+	mStorage.On("Delete", "expired").Once()
+	mgr := cache.New[string, TestUser](mStorage, codec, 100)
+	require.NoError(t, mgr.CacheJanitor(t.Context(), time.Now().Add(2*time.Hour)))
+}
+
+func TestCipherCodec_Compressed(t *testing.T) {
+	val := TestUser{ID: 3, Name: strings.Repeat("compressible ", 500)}
+	compressed, err := cache.NewCompressCodec[TestUser](newWrapperTestCodec(), utils.EncodingGzip, 0)
+	require.NoError(t, err)
+	codec, err := cache.NewCipherCodec[TestUser](compressed, newWrapperTestCipher())
+	require.NoError(t, err)
+
+	mgr := cache.New[string, TestUser](cache.NewRawStorage[string](0, cache.NewLRUEvict[string]()), codec, 100)
+	require.NoError(t, mgr.Set("user:3", val, time.Minute))
+	res, ok, err := mgr.Get("user:3")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, val, res)
+}
@@ -0,0 +1,132 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/infra/cache"
+	"github.com/ElfAstAhe/go-service-template/pkg/infra/cache/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRawStorage_RemoveExpired(t *testing.T) {
+	storage := cache.NewRawStorage[string](0, cache.NewLRUEvict[string]())
+
+	storage.SetWithExpiry("c", []byte("3"), 30)
+	storage.SetWithExpiry("a", []byte("1"), 10)
+	storage.SetWithExpiry("forever", []byte("0"), 0)
+	storage.SetWithExpiry("b", []byte("2"), 20)
+	storage.SetWithExpiry("d", []byte("4"), 40)
+
+	assert.Equal(t, 2, storage.RemoveExpired(25, 0))
+	assert.False(t, storage.Has("a"))
+	assert.False(t, storage.Has("b"))
+	assert.True(t, storage.Has("c"))
+
+	// ограничение кол-ва удаляемых за проход
+	assert.Equal(t, 1, storage.RemoveExpired(100, 1))
+	assert.False(t, storage.Has("c"))
+	assert.True(t, storage.Has("d"))
+
+	assert.Equal(t, 1, storage.RemoveExpired(100, 0))
+	assert.True(t, storage.Has("forever"), "Бессрочные значения не удаляются")
+	assert.Equal(t, int64(1), storage.Bytes())
+}
+
+func TestRawStorage_SetWithExpiry_UpdatesIndex(t *testing.T) {
+	storage := cache.NewRawStorage[string](0, cache.NewLRUEvict[string]())
+
+	storage.SetWithExpiry("a", []byte("1"), 10)
+	storage.SetWithExpiry("a", []byte("1"), 100)
+	assert.Equal(t, 0, storage.RemoveExpired(50, 0), "Перезапись переносит момент истечения")
+
+	_, dieAt, ok := storage.GetWithExpiry("a")
+	require.True(t, ok)
+	assert.Equal(t, int64(100), dieAt)
+
+	storage.SetWithExpiry("a", []byte("1"), 0)
+	assert.Equal(t, 0, storage.RemoveExpired(1000, 0), "Бессрочное значение исключается из индекса")
+
+	storage.SetWithExpiry("b", []byte("2"), 10)
+	storage.Delete("b")
+	assert.Equal(t, 0, storage.RemoveExpired(1000, 0), "Удаленный ключ исключается из индекса")
+}
+
+func TestRawStorage_RemoveExpired_Many(t *testing.T) {
+	storage := cache.NewRawStorage[int](0, cache.NewFIFOEvict[int]())
+	const total = 1000
+	for i := 0; i < total; i++ {
+		// моменты истечения вперемешку
+		storage.SetWithExpiry(i, []byte{1}, int64((i*7919)%total+1))
+	}
+	for i := 0; i < total; i += 3 {
+		storage.Delete(i)
+	}
+
+	removed := storage.RemoveExpired(total/2+1, 0)
+	var keys []int
+	storage.Range(func(key int, _ []byte) bool {
+		keys = append(keys, key)
+
+		return true
+	})
+	for _, key := range keys {
+		_, dieAt, _ := storage.GetWithExpiry(key)
+		assert.GreaterOrEqual(t, dieAt, int64(total/2+1))
+	}
+	assert.Equal(t, total-(total/3+1)-removed, storage.Len())
+}
+
+func TestShardStorage_RemoveExpired(t *testing.T) {
+	factory := func(maxSize int, _ cache.EvictionPolicy[string]) cache.Storage[string] {
+		return cache.NewRawStorage[string](maxSize, cache.NewLRUEvict[string]())
+	}
+	storage := cache.NewShardStorage[string](4, factory, 0, nil)
+	assert.True(t, storage.ExpiryIndexed())
+
+	for i := 0; i < 20; i++ {
+		storage.SetWithExpiry(fmt.Sprintf("key-%d", i), []byte("v"), int64(i+1))
+	}
+
+	assert.Equal(t, 10, storage.RemoveExpired(11, 0))
+	assert.Equal(t, 10, storage.Len())
+
+	// ограничение общее на все шарды
+	assert.Equal(t, 5, storage.RemoveExpired(100, 5))
+	assert.Equal(t, 5, storage.Len())
+}
+
+func TestShardStorage_ExpiryIndexed_MixedShards(t *testing.T) {
+	factory := func(maxSize int, _ cache.EvictionPolicy[string]) cache.Storage[string] {
+		return mocks.NewMockStorage[string](t)
+	}
+	storage := cache.NewShardStorage[string](2, factory, 0, nil)
+	assert.False(t, storage.ExpiryIndexed())
+}
+
+func TestManager_ExpiryIndex_SkipsCodec(t *testing.T) {
+	storage := cache.NewRawStorage[string](0, cache.NewLRUEvict[string]())
+	mCodec := mocks.NewMockCodec[string](t)
+	mgr := cache.New[string, string](storage, mCodec, 0)
+
+	mCodec.On("Marshal", mock.Anything, mock.Anything).Return([]byte("raw"), nil)
+	require.NoError(t, mgr.Set("short", "v", time.Millisecond))
+	require.NoError(t, mgr.Set("long", "v", time.Hour))
+	time.Sleep(5 * time.Millisecond)
+
+	// просрочка отсекается по индексу хранилища без распаковки
+	_, ok, err := mgr.Get("short")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, mgr.Set("short", "v", time.Millisecond))
+	time.Sleep(5 * time.Millisecond)
+	require.NoError(t, mgr.CacheJanitor(context.Background(), time.Now()))
+	assert.False(t, storage.Has("short"))
+	assert.True(t, storage.Has("long"))
+	mCodec.AssertNotCalled(t, "Unmarshal", mock.Anything)
+}
@@ -25,6 +25,26 @@ func TestCacheFactory(t *testing.T) {
 		assert.False(t, isL2)
 	})
 
+	t.Run("success_scan_resistant_policies", func(t *testing.T) {
+		for _, opt := range []cache.Option[string, string]{
+			cache.WithTinyLFUEvictPolicy[string, string](),
+			cache.WithARCEvictPolicy[string, string](),
+			cache.WithS3FIFOEvictPolicy[string, string](),
+		} {
+			c, err := cache.CacheFactory(
+				cache.WithCodec[string, string](jsonCodec),
+				cache.WithMaxSize[string, string](2),
+				opt,
+			)
+			assert.NoError(t, err)
+
+			for _, key := range []string{"a", "b", "c"} {
+				assert.NoError(t, c.Set(key, key, 0))
+			}
+			assert.Equal(t, 2, c.Size())
+		}
+	})
+
 	t.Run("success_l2_sharded_cache", func(t *testing.T) {
 		c, err := cache.CacheFactory(
 			cache.WithCodec[string, string](jsonCodec),
@@ -0,0 +1,319 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/infra/cache"
+	"github.com/ElfAstAhe/go-service-template/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newLoadTestCache(t *testing.T, opts ...cache.Option[string, *TestData]) cache.Cache[string, *TestData] {
+	opts = append([]cache.Option[string, *TestData]{
+		cache.WithCodec[string, *TestData](cache.NewJSONCodec[*TestData](func() *TestData { return &TestData{} })),
+	}, opts...)
+	res, err := cache.CacheFactory[string, *TestData](opts...)
+	require.NoError(t, err)
+
+	return res
+}
+
+func TestManager_GetOrLoad_Deduplicates(t *testing.T) {
+	c := newLoadTestCache(t)
+
+	var loads atomic.Int32
+	release := make(chan struct{})
+	loader := func(ctx context.Context, key string) (*TestData, bool, error) {
+		loads.Add(1)
+		<-release
+
+		return &TestData{ID: 1}, true, nil
+	}
+
+	const goroutines = 50
+	var wg sync.WaitGroup
+	var started sync.WaitGroup
+	started.Add(goroutines)
+	results := make([]*TestData, goroutines)
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			started.Done()
+			res, found, err := c.GetOrLoad(context.Background(), "k", loader, time.Minute)
+			assert.NoError(t, err)
+			assert.True(t, found)
+			results[i] = res
+		}(i)
+	}
+	started.Wait()
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), loads.Load(), "Загрузка одного ключа должна выполняться один раз")
+	for _, res := range results {
+		require.NotNil(t, res)
+		assert.Equal(t, 1, res.ID)
+	}
+
+	// следующий вызов обслуживается из кэша
+	_, found, err := c.GetOrLoad(context.Background(), "k", loader, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, int32(1), loads.Load())
+}
+
+func TestManager_GetOrLoad_ErrorNotCached(t *testing.T) {
+	c := newLoadTestCache(t)
+
+	loadErr := errors.New("db unavailable")
+	var loads int
+	loader := func(ctx context.Context, key string) (*TestData, bool, error) {
+		loads++
+		if loads == 1 {
+			return nil, false, loadErr
+		}
+
+		return &TestData{ID: 2}, true, nil
+	}
+
+	_, _, err := c.GetOrLoad(context.Background(), "k", loader, time.Minute)
+	assert.ErrorIs(t, err, loadErr)
+	assert.Equal(t, 0, c.Size(), "Ошибка загрузчика не должна кэшироваться")
+
+	res, found, err := c.GetOrLoad(context.Background(), "k", loader, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, 2, res.ID)
+}
+
+func TestManager_GetOrLoad_NegativeCaching(t *testing.T) {
+	notFound := func(loads *int) cache.Loader[string, *TestData] {
+		return func(ctx context.Context, key string) (*TestData, bool, error) {
+			*loads++
+
+			return nil, false, nil
+		}
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		c := newLoadTestCache(t)
+		var loads int
+		for i := 0; i < 2; i++ {
+			_, found, err := c.GetOrLoad(context.Background(), "k", notFound(&loads), time.Minute)
+			require.NoError(t, err)
+			assert.False(t, found)
+		}
+		assert.Equal(t, 2, loads)
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		c := newLoadTestCache(t, cache.WithNegativeTTL[string, *TestData](30*time.Millisecond))
+		var loads int
+		for i := 0; i < 2; i++ {
+			res, found, err := c.GetOrLoad(context.Background(), "k", notFound(&loads), time.Minute)
+			require.NoError(t, err)
+			assert.False(t, found)
+			assert.Nil(t, res)
+		}
+		assert.Equal(t, 1, loads, "Негативный результат должен кэшироваться")
+
+		// негативный результат живет negativeTTL
+		time.Sleep(40 * time.Millisecond)
+		_, _, err := c.GetOrLoad(context.Background(), "k", notFound(&loads), time.Minute)
+		require.NoError(t, err)
+		assert.Equal(t, 2, loads)
+	})
+
+	t.Run("l2 default", func(t *testing.T) {
+		c := newLoadTestCache(t, cache.WithL2Cache[string, *TestData]())
+		var loads int
+		for i := 0; i < 2; i++ {
+			_, found, err := c.GetOrLoad(context.Background(), "k", notFound(&loads), time.Minute)
+			require.NoError(t, err)
+			assert.False(t, found)
+		}
+		assert.Equal(t, 1, loads)
+	})
+}
+
+func TestManager_GetOrLoad_WaiterContextCancelled(t *testing.T) {
+	c := newLoadTestCache(t)
+
+	release := make(chan struct{})
+	loading := make(chan struct{})
+	go func() {
+		_, _, _ = c.GetOrLoad(context.Background(), "k", func(ctx context.Context, key string) (*TestData, bool, error) {
+			close(loading)
+			<-release
+
+			return &TestData{ID: 1}, true, nil
+		}, time.Minute)
+	}()
+	<-loading
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, _, err := c.GetOrLoad(ctx, "k", func(ctx context.Context, key string) (*TestData, bool, error) {
+		t.Error("Загрузчик ожидающего вызова не должен выполняться")
+
+		return nil, false, nil
+	}, time.Minute)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	close(release)
+}
+
+func TestCodec_NilValueKeepsTTL(t *testing.T) {
+	codecs := map[string]cache.Codec[*TestData]{
+		"json": cache.NewJSONCodec[*TestData](func() *TestData { return &TestData{} }),
+		"gob":  cache.NewGobCodec[*TestData](func() *TestData { return &TestData{} }),
+	}
+	for name, codec := range codecs {
+		t.Run(name, func(t *testing.T) {
+			b, err := codec.Marshal(nil, time.Minute)
+			require.NoError(t, err)
+			env, err := codec.Unmarshal(b)
+			require.NoError(t, err)
+			assert.Nil(t, env.Value)
+			assert.True(t, env.Nil)
+			assert.Greater(t, env.DieAt, time.Now().UnixNano())
+		})
+	}
+}
+
+func TestGetOrLoad_NegativeCachingStructValue(t *testing.T) {
+	notFound := func(loads *int) cache.Loader[string, TestUser] {
+		return func(ctx context.Context, key string) (TestUser, bool, error) {
+			*loads++
+
+			return TestUser{}, false, nil
+		}
+	}
+	zeroFound := func(ctx context.Context, key string) (TestUser, bool, error) {
+		return TestUser{}, true, nil
+	}
+	newCache := func(t *testing.T, opts ...cache.Option[string, TestUser]) cache.Cache[string, TestUser] {
+		opts = append([]cache.Option[string, TestUser]{
+			cache.WithCodec[string, TestUser](newWrapperTestCodec()),
+			cache.WithNegativeTTL[string, TestUser](time.Minute),
+		}, opts...)
+		res, err := cache.CacheFactory[string, TestUser](opts...)
+		require.NoError(t, err)
+
+		return res
+	}
+
+	testCases := map[string][]cache.Option[string, TestUser]{
+		"manager": nil,
+		"l2":      {cache.WithL2Cache[string, TestUser]()},
+		"object":  {cache.WithObjectStorage[string, TestUser](nil)},
+	}
+	for name, opts := range testCases {
+		t.Run(name, func(t *testing.T) {
+			c := newCache(t, opts...)
+
+			var loads int
+			for i := 0; i < 2; i++ {
+				_, found, err := c.GetOrLoad(context.Background(), "missing", notFound(&loads), time.Minute)
+				require.NoError(t, err)
+				assert.False(t, found, "Негативный результат не должен превращаться в найденное нулевое значение")
+			}
+			assert.Equal(t, 1, loads, "Негативный результат должен кэшироваться")
+
+			// найденное нулевое значение не является негативным результатом
+			for i := 0; i < 2; i++ {
+				res, found, err := c.GetOrLoad(context.Background(), "zero", zeroFound, time.Minute)
+				require.NoError(t, err)
+				assert.True(t, found)
+				assert.Equal(t, TestUser{}, res)
+			}
+		})
+	}
+}
+
+func TestGet_AfterNegativeResult(t *testing.T) {
+	notFound := func(ctx context.Context, key string) (int, bool, error) {
+		return 0, false, nil
+	}
+	newCache := func(t *testing.T, opts ...cache.Option[string, int]) cache.Cache[string, int] {
+		opts = append([]cache.Option[string, int]{
+			cache.WithCodec[string, int](cache.NewJSONCodec[int](func() int { return 0 })),
+			cache.WithNegativeTTL[string, int](time.Minute),
+		}, opts...)
+		res, err := cache.CacheFactory[string, int](opts...)
+		require.NoError(t, err)
+
+		return res
+	}
+	near := func(t *testing.T) cache.Cache[string, int] {
+		res := cache.NewNearCache[string, int]("near", newCache(t), newCache(t), time.Minute, nil, newNearTestLogger())
+		t.Cleanup(func() { _ = res.Close() })
+
+		return res
+	}
+
+	testCases := map[string]func(t *testing.T) cache.Cache[string, int]{
+		"manager": func(t *testing.T) cache.Cache[string, int] { return newCache(t) },
+		"l2":      func(t *testing.T) cache.Cache[string, int] { return newCache(t, cache.WithL2Cache[string, int]()) },
+		"near":    near,
+	}
+	for name, factory := range testCases {
+		t.Run(name, func(t *testing.T) {
+			c := factory(t)
+
+			_, found, err := c.GetOrLoad(context.Background(), "k", notFound, time.Minute)
+			require.NoError(t, err)
+			require.False(t, found)
+
+			for i := 0; i < 2; i++ {
+				_, ok, err := c.Get("k")
+				require.NoError(t, err)
+				assert.False(t, ok, "Негативный результат не должен читаться как найденное значение")
+				_, found, err = c.GetOrLoad(context.Background(), "k", notFound, time.Minute)
+				require.NoError(t, err)
+				assert.False(t, found)
+			}
+		})
+	}
+}
+
+func TestCodec_MarshalNil(t *testing.T) {
+	compressed, err := cache.NewCompressCodec[TestUser](newWrapperTestCodec(), utils.EncodingGzip, 0)
+	require.NoError(t, err)
+	encrypted, err := cache.NewCipherCodec[TestUser](newWrapperTestCodec(), newWrapperTestCipher())
+	require.NoError(t, err)
+
+	codecs := map[string]cache.Codec[TestUser]{
+		"json":     newWrapperTestCodec(),
+		"gob":      cache.NewGobCodec[TestUser](func() TestUser { return TestUser{} }),
+		"compress": compressed,
+		"cipher":   encrypted,
+	}
+	for name, codec := range codecs {
+		t.Run(name, func(t *testing.T) {
+			marshaler, ok := codec.(cache.NilMarshaler)
+			require.True(t, ok)
+			b, err := marshaler.MarshalNil(time.Minute)
+			require.NoError(t, err)
+			env, err := codec.Unmarshal(b)
+			require.NoError(t, err)
+			assert.True(t, env.Nil)
+			assert.Equal(t, TestUser{}, env.Value)
+			assert.Greater(t, env.DieAt, time.Now().UnixNano())
+
+			// нулевое значение без признака Nil остается найденным значением
+			b, err = codec.Marshal(TestUser{}, time.Minute)
+			require.NoError(t, err)
+			env, err = codec.Unmarshal(b)
+			require.NoError(t, err)
+			assert.False(t, env.Nil)
+		})
+	}
+}
@@ -34,11 +34,10 @@ func TestL2Manager_NegativeCaching(t *testing.T) {
 	// Проверим, что в сторадже появились байты по этому ключу
 	assert.True(t, storage.Has(key), "L2Manager should put negative value into storage")
 
-	// 4. Проверяем, что данные реально лежат в кэше (через базовый Manager)
-	// Если вызвать напрямую Manager.Get, он должен вернуть ok=true для "пустоты"
+	// 4. Негативный результат - промах и для базового Manager, найденным значением он не становится
 	val2, ok2, err2 := l2.Manager.Get(key)
 	assert.NoError(t, err2)
-	assert.True(t, ok2, "Base Manager should see the negative cached value as ok=true")
+	assert.False(t, ok2, "Base Manager should treat the negative cached value as a miss")
 	assert.Empty(t, val2)
 }
 
@@ -0,0 +1,149 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/infra/cache"
+	"github.com/ElfAstAhe/go-service-template/pkg/infra/cache/mocks"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManager_Stats(t *testing.T) {
+	stats := mocks.NewMockStatsRecorder(t)
+	storage := cache.NewRawStorage[string](10, cache.NewLRUEvict[string]())
+	codec := cache.NewJSONCodec[string](func() string { return "" })
+	mgr := cache.New[string, string](storage, codec, 0, cache.WithManagerStats[string, string](stats))
+
+	stats.On("Set").Return().Once()
+	stats.On("Hit").Return().Once()
+	stats.On("Miss").Return().Twice()
+	stats.On("Expired").Return().Once()
+	stats.On("Delete").Return().Once()
+
+	require.NoError(t, mgr.Set("k", "v", 20*time.Millisecond))
+	_, ok, err := mgr.Get("k")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	_, ok, err = mgr.Get("missing")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	time.Sleep(30 * time.Millisecond)
+	_, ok, err = mgr.Get("k")
+	require.NoError(t, err)
+	assert.False(t, ok, "Просроченное значение считается промахом")
+
+	mgr.Delete("k")
+}
+
+func TestManager_Stats_Janitor(t *testing.T) {
+	stats := mocks.NewMockStatsRecorder(t)
+	storage := cache.NewRawStorage[string](10, cache.NewLRUEvict[string]())
+	codec := cache.NewJSONCodec[string](func() string { return "" })
+	mgr := cache.New[string, string](storage, codec, 100, cache.WithManagerStats[string, string](stats))
+
+	stats.On("Set").Return().Times(3)
+	require.NoError(t, mgr.Set("a", "1", time.Millisecond))
+	require.NoError(t, mgr.Set("b", "2", time.Millisecond))
+	require.NoError(t, mgr.Set("c", "3", time.Hour))
+	time.Sleep(5 * time.Millisecond)
+
+	stats.On("Janitor", 2, nil, mock.AnythingOfType("time.Time")).Return().Once()
+	require.NoError(t, mgr.CacheJanitor(context.Background(), time.Now()))
+	assert.Equal(t, 1, storage.Len())
+}
+
+func TestRawStorage_EvictionListenerAndBytes(t *testing.T) {
+	storage := cache.NewRawStorage[string](2, cache.NewFIFOEvict[string]())
+
+	var evicted []string
+	storage.SetEvictionListener(func(key string) {
+		evicted = append(evicted, key)
+	})
+
+	storage.Set("a", []byte("12345"))
+	storage.Set("b", []byte("123"))
+	assert.Equal(t, int64(8), storage.Bytes())
+
+	storage.Set("c", []byte("1"))
+	assert.Equal(t, []string{"a"}, evicted, "Вытеснение должно сообщаться слушателю")
+	assert.Equal(t, int64(4), storage.Bytes())
+
+	storage.Set("b", []byte("12"))
+	assert.Equal(t, int64(3), storage.Bytes(), "Перезапись учитывает разницу размеров")
+
+	storage.Delete("b")
+	assert.Equal(t, int64(1), storage.Bytes())
+
+	storage.Clear()
+	assert.Equal(t, int64(0), storage.Bytes())
+}
+
+func TestShardStorage_EvictionListenerAndBytes(t *testing.T) {
+	factory := func(maxSize int, _ cache.EvictionPolicy[string]) cache.Storage[string] {
+		return cache.NewRawStorage[string](maxSize, cache.NewLRUEvict[string]())
+	}
+	storage := cache.NewShardStorage[string](2, factory, 1, nil)
+
+	var evicted int
+	storage.SetEvictionListener(func(string) {
+		evicted++
+	})
+
+	storage.Set("a", []byte("12"))
+	storage.Set("b", []byte("34"))
+	storage.Set("c", []byte("56"))
+	assert.Equal(t, int64(2*storage.Len()), storage.Bytes())
+	assert.Equal(t, 3-storage.Len(), evicted)
+}
+
+func TestPolicyName(t *testing.T) {
+	assert.Equal(t, "lru", cache.PolicyName[string](cache.NewLRUEvict[string]()))
+	assert.Equal(t, "lfu", cache.PolicyName[string](cache.NewLFUEvict[string]()))
+	assert.Equal(t, "fifo", cache.PolicyName[string](cache.NewFIFOEvict[string]()))
+	assert.Equal(t, "none", cache.PolicyName[string](nil))
+}
+
+func TestCacheFactory_WithMetrics(t *testing.T) {
+	c, err := cache.CacheFactory[string, string](
+		cache.WithCodec[string, string](cache.NewJSONCodec[string](func() string { return "" })),
+		cache.WithMetrics[string, string]("test_factory_metrics"),
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, c.Set("k", "v", time.Minute))
+	val, ok, err := c.Get("k")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "v", val)
+}
+
+func TestRegisterStorageMetrics_RedisSizeSkipped(t *testing.T) {
+	srv, client := newTestRedis(t)
+	storage := cache.NewRedisStorage[string](client, "metrics")
+	storage.Set("k", []byte("v"))
+
+	recorder := mocks.NewMockStatsRecorder(t)
+	cache.RegisterStorageMetrics[string]("test_redis_size_metrics", storage, recorder)
+
+	commands := srv.CommandCount()
+	families, err := prometheus.DefaultGatherer.Gather()
+	require.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() != "cache_size_items" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				assert.NotEqual(t, "test_redis_size_metrics", label.GetValue(), "Размер redis хранилища не должен публиковаться")
+			}
+		}
+	}
+	assert.Equal(t, commands, srv.CommandCount(), "Сбор метрик не должен обращаться к redis")
+}
@@ -0,0 +1,232 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/infra/cache"
+	"github.com/ElfAstAhe/go-service-template/pkg/infra/pubsub"
+	pubsubmocks "github.com/ElfAstAhe/go-service-template/pkg/infra/pubsub/mocks"
+	logmocks "github.com/ElfAstAhe/go-service-template/pkg/logger/mocks"
+	"github.com/ElfAstAhe/go-service-template/pkg/transport/amqp"
+	amqpmocks "github.com/ElfAstAhe/go-service-template/pkg/transport/amqp/mocks"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newNearTestLogger() *logmocks.MockLogger {
+	log := &logmocks.MockLogger{}
+	log.On("GetLogger", mock.Anything).Return(log)
+	log.On("Debugf", mock.Anything, mock.Anything).Maybe()
+	log.On("Debugf", mock.Anything, mock.Anything, mock.Anything).Maybe()
+	log.On("Warnf", mock.Anything, mock.Anything, mock.Anything).Maybe()
+	log.On("Errorf", mock.Anything, mock.Anything, mock.Anything).Maybe()
+
+	return log
+}
+
+func newTestCodec() cache.Codec[*TestData] {
+	return cache.NewJSONCodec[*TestData](func() *TestData { return &TestData{} })
+}
+
+// newTestReplica реплика: собственный L1 в памяти и общий L2 в redis
+func newTestReplica(t *testing.T, client *redis.Client, publisher cache.InvalidationPublisher[string]) *cache.NearCache[string, *TestData] {
+	local := cache.New[string, *TestData](cache.NewRawStorage[string](100, cache.NewLRUEvict[string]()), newTestCodec(), 100)
+	shared, err := cache.CacheFactory[string, *TestData](
+		cache.WithCodec[string, *TestData](newTestCodec()),
+		cache.WithRedisStorage[string, *TestData](client, "near"),
+	)
+	require.NoError(t, err)
+
+	res := cache.NewNearCache[string, *TestData]("near", local, shared, time.Minute, publisher, newNearTestLogger())
+	t.Cleanup(func() { _ = res.Close() })
+
+	return res
+}
+
+func TestNearCache_ReadThroughPopulatesLocal(t *testing.T) {
+	_, client := newTestRedis(t)
+	replica := newTestReplica(t, client, nil)
+
+	require.NoError(t, replica.GetShared().Set("k", &TestData{ID: 1}, time.Hour))
+	_, ok, _ := replica.GetLocal().Get("k")
+	assert.False(t, ok)
+
+	val, ok, err := replica.Get("k")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 1, val.ID)
+
+	val, ok, _ = replica.GetLocal().Get("k")
+	assert.True(t, ok, "Значение из L2 должно попасть в L1")
+	assert.Equal(t, 1, val.ID)
+}
+
+func TestNearCache_InvalidatesOtherReplicas(t *testing.T) {
+	_, client := newTestRedis(t)
+	dispatcher := pubsub.NewEventDispatcher[*cache.Invalidation[string]]("invalidation", time.Second, newNearTestLogger())
+	first := newTestReplica(t, client, dispatcher)
+	second := newTestReplica(t, client, dispatcher)
+
+	require.NoError(t, first.Set("k", &TestData{ID: 1}, time.Hour))
+	val, ok, err := second.Get("k")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, 1, val.ID)
+
+	// запись первой репликой должна сбросить L1 второй
+	require.NoError(t, first.Set("k", &TestData{ID: 2}, time.Hour))
+	assert.Eventually(t, func() bool {
+		_, ok, _ := second.GetLocal().Get("k")
+		return !ok
+	}, time.Second, 5*time.Millisecond)
+	val, ok, err = second.Get("k")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, 2, val.ID)
+
+	// собственная инвалидация не сбрасывает L1 источника
+	_, ok, _ = first.GetLocal().Get("k")
+	assert.True(t, ok)
+
+	first.Delete("k")
+	assert.Eventually(t, func() bool {
+		_, ok, _ := second.GetLocal().Get("k")
+		return !ok
+	}, time.Second, 5*time.Millisecond)
+	_, ok, _ = second.Get("k")
+	assert.False(t, ok)
+}
+
+func TestNearCache_LocalTTLBounded(t *testing.T) {
+	_, client := newTestRedis(t)
+	local := cache.New[string, *TestData](cache.NewRawStorage[string](100, cache.NewLRUEvict[string]()), newTestCodec(), 100)
+	shared, err := cache.CacheFactory[string, *TestData](
+		cache.WithCodec[string, *TestData](newTestCodec()),
+		cache.WithRedisStorage[string, *TestData](client, "near"),
+	)
+	require.NoError(t, err)
+	replica := cache.NewNearCache[string, *TestData]("near", local, shared, 20*time.Millisecond, nil, newNearTestLogger())
+
+	require.NoError(t, replica.Set("k", &TestData{ID: 1}, time.Hour))
+	time.Sleep(30 * time.Millisecond)
+	_, ok, _ := local.Get("k")
+	assert.False(t, ok, "L1 не должен хранить значение дольше localTTL")
+	_, ok, _ = shared.Get("k")
+	assert.True(t, ok)
+}
+
+func TestNearCache_LocalTTLBoundedBySharedExpiry(t *testing.T) {
+	_, client := newTestRedis(t)
+	local := cache.New[string, *TestData](cache.NewRawStorage[string](100, cache.NewLRUEvict[string]()), newTestCodec(), 100)
+	shared, err := cache.CacheFactory[string, *TestData](
+		cache.WithCodec[string, *TestData](newTestCodec()),
+		cache.WithRedisStorage[string, *TestData](client, "near"),
+	)
+	require.NoError(t, err)
+	replica := cache.NewNearCache[string, *TestData]("near", local, shared, time.Minute, nil, newNearTestLogger())
+
+	require.NoError(t, shared.Set("get", &TestData{ID: 1}, 50*time.Millisecond))
+	require.NoError(t, shared.Set("load", &TestData{ID: 2}, 50*time.Millisecond))
+	_, sharedDieAt, ok, err := shared.(cache.ExpiryReader[string, *TestData]).GetWithExpiry("get")
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	_, ok, err = replica.Get("get")
+	require.NoError(t, err)
+	require.True(t, ok)
+	_, ok, err = replica.GetOrLoad(context.Background(), "load", func(context.Context, string) (*TestData, bool, error) {
+		t.Error("Значение есть в L2, загрузка не нужна")
+
+		return nil, false, nil
+	}, time.Hour)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	_, localDieAt, ok, err := local.GetWithExpiry("get")
+	require.NoError(t, err)
+	require.True(t, ok)
+	// L1 отсчитывает истечение от момента своей записи, допускается расхождение в пределах миллисекунды
+	assert.LessOrEqual(t, localDieAt, sharedDieAt+int64(time.Millisecond), "L1 не должен пережить значение в L2")
+
+	time.Sleep(70 * time.Millisecond)
+	_, ok, _ = local.Get("get")
+	assert.False(t, ok, "L1 должен истечь вместе с L2")
+	_, ok, _ = local.Get("load")
+	assert.False(t, ok, "L1 должен истечь вместе с L2")
+}
+
+func TestRedisInvalidationBus_CrossReplica(t *testing.T) {
+	_, client := newTestRedis(t)
+
+	firstBus := cache.NewRedisInvalidationBus[string](client, "cache-invalidation", newNearTestLogger())
+	secondBus := cache.NewRedisInvalidationBus[string](client, "cache-invalidation", newNearTestLogger())
+	require.NoError(t, firstBus.Start(context.Background()))
+	require.NoError(t, secondBus.Start(context.Background()))
+	defer func() {
+		assert.NoError(t, firstBus.Stop(context.Background()))
+		assert.NoError(t, secondBus.Stop(context.Background()))
+	}()
+
+	first := newTestReplica(t, client, firstBus)
+	second := newTestReplica(t, client, secondBus)
+
+	require.NoError(t, first.Set("k", &TestData{ID: 1}, time.Hour))
+	_, ok, _ := second.Get("k")
+	require.True(t, ok)
+
+	first.Clear()
+	assert.Eventually(t, func() bool {
+		_, ok, _ := second.GetLocal().Get("k")
+		return !ok
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestAMQPInvalidationBus_PublishAndReceive(t *testing.T) {
+	var published []byte
+	sender := amqpmocks.NewMockSender[any](t)
+	sender.On("Publish", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { published = args.Get(1).(amqp.Message).GetPayload() }).
+		Return(nil).Once()
+
+	incoming := amqpmocks.NewMockMessage(t)
+	incoming.On("GetPayload").Return([]byte(`{"cache":"near","source":"other","keys":["k"]}`))
+	receiver := amqpmocks.NewMockReceiver[any](t)
+	receiver.On("Receive", mock.Anything, mock.Anything).Return(incoming, nil).Once()
+	receiver.On("Receive", mock.Anything, mock.Anything).
+		Return(func(ctx context.Context, _ any) (amqp.Message, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		})
+	receiver.On("Accept", mock.Anything, incoming).Return(nil).Once()
+
+	newMessage := func(payload []byte) amqp.Message {
+		msg := amqpmocks.NewMockMessage(t)
+		msg.On("GetPayload").Return(payload)
+		return msg
+	}
+	bus := cache.NewAMQPInvalidationBus[string, any, any]("invalidation", sender, nil, receiver, nil, newMessage, newNearTestLogger())
+
+	received := make(chan *cache.Invalidation[string], 1)
+	observer := pubsubmocks.NewMockObserver[*cache.Invalidation[string]](t)
+	observer.On("GetName").Return("observer")
+	observer.On("OnNotify", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { received <- args.Get(1).(*cache.Invalidation[string]) }).
+		Return(nil).Once()
+	bus.Register(observer)
+
+	bus.Notify(context.Background(), &cache.Invalidation[string]{Cache: "near", Source: "self", All: true})
+	assert.JSONEq(t, `{"cache":"near","source":"self","all":true}`, string(published))
+
+	require.NoError(t, bus.Start(context.Background()))
+	select {
+	case msg := <-received:
+		assert.Equal(t, []string{"k"}, msg.Keys)
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for invalidation")
+	}
+	require.NoError(t, bus.Stop(context.Background()))
+}
@@ -0,0 +1,237 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/infra/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func cloneTestData(v *TestData) *TestData {
+	res := *v
+
+	return &res
+}
+
+func newTestObjectCache(t *testing.T, opts ...cache.Option[string, *TestData]) cache.Cache[string, *TestData] {
+	t.Helper()
+	opts = append([]cache.Option[string, *TestData]{cache.WithObjectStorage[string, *TestData](cloneTestData)}, opts...)
+	c, err := cache.CacheFactory(opts...)
+	require.NoError(t, err)
+
+	return c
+}
+
+func TestObjectManager_SetGet(t *testing.T) {
+	c := newTestObjectCache(t, cache.WithShardCount[string, *TestData](4))
+
+	require.NoError(t, c.Set("k1", &TestData{ID: 1, Active: true}, time.Hour))
+	res, ok, err := c.Get("k1")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, &TestData{ID: 1, Active: true}, res)
+
+	_, ok, err = c.Get("missing")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	c.Delete("k1")
+	assertCached(t, c, false, "k1")
+	assert.Zero(t, c.Size())
+}
+
+func TestObjectManager_Clone(t *testing.T) {
+	t.Run("copy-on-read", func(t *testing.T) {
+		c := newTestObjectCache(t)
+		value := &TestData{ID: 1}
+		require.NoError(t, c.Set("k1", value, time.Hour))
+
+		value.ID = 100
+		res, _, _ := c.Get("k1")
+		assert.Equal(t, 1, res.ID, "Изменение исходного объекта не влияет на кэш")
+
+		res.ID = 200
+		res, _, _ = c.Get("k1")
+		assert.Equal(t, 1, res.ID, "Изменение прочитанного объекта не влияет на кэш")
+	})
+
+	t.Run("shared", func(t *testing.T) {
+		c := cache.NewObjectManager[string, *TestData](1, 0, nil, 100)
+		value := &TestData{ID: 1}
+		require.NoError(t, c.Set("k1", value, time.Hour))
+
+		res, _, _ := c.Get("k1")
+		assert.Same(t, value, res, "Без clone кэш отдает хранимый объект")
+	})
+}
+
+func TestObjectManager_Expiry(t *testing.T) {
+	c := cache.NewObjectManager[string, *TestData](4, 0, nil, 100)
+	require.NoError(t, c.Set("short", &TestData{ID: 1}, 20*time.Millisecond))
+	require.NoError(t, c.Set("long", &TestData{ID: 2}, time.Hour))
+	require.NoError(t, c.Set("forever", &TestData{ID: 3}, 0))
+
+	time.Sleep(40 * time.Millisecond)
+	assertCached(t, c, false, "short")
+
+	require.NoError(t, c.Set("short", &TestData{ID: 1}, 20*time.Millisecond))
+	require.NoError(t, c.CacheJanitor(context.Background(), time.Now().Add(time.Minute)))
+	assert.Equal(t, 2, c.Size(), "Janitor удаляет только истекшие значения")
+	assertCached(t, c, true, "long", "forever")
+}
+
+func TestObjectManager_JanitorLimit(t *testing.T) {
+	c := cache.NewObjectManager[string, *TestData](4, 0, nil, 3)
+	for _, key := range []string{"a", "b", "c", "d", "e"} {
+		require.NoError(t, c.Set(key, &TestData{}, time.Millisecond))
+	}
+
+	require.NoError(t, c.CacheJanitor(context.Background(), time.Now().Add(time.Minute)))
+	assert.Equal(t, 2, c.Size())
+}
+
+func TestObjectManager_Eviction(t *testing.T) {
+	policies := map[string]cache.Option[string, *TestData]{
+		"lru":     cache.WithLRUEvictPolicy[string, *TestData](),
+		"lfu":     cache.WithLFUEvictPolicy[string, *TestData](),
+		"fifo":    cache.WithFIFOEvictPolicy[string, *TestData](),
+		"tinylfu": cache.WithTinyLFUEvictPolicy[string, *TestData](),
+		"arc":     cache.WithARCEvictPolicy[string, *TestData](),
+		"s3fifo":  cache.WithS3FIFOEvictPolicy[string, *TestData](),
+	}
+	for name, policy := range policies {
+		t.Run(name, func(t *testing.T) {
+			c := newTestObjectCache(t, cache.WithMaxSize[string, *TestData](10), policy)
+			for i := 0; i < 100; i++ {
+				require.NoError(t, c.Set(fmt.Sprintf("key-%d", i), &TestData{ID: i}, time.Hour))
+			}
+			assert.LessOrEqual(t, c.Size(), 10)
+		})
+	}
+
+	t.Run("lru order", func(t *testing.T) {
+		var evicted []string
+		c := cache.NewObjectManager[string, *TestData](1, 2, nil, 100)
+		c.SetEvictionListener(func(key string) {
+			evicted = append(evicted, key)
+		})
+		require.NoError(t, c.Set("a", &TestData{ID: 1}, time.Hour))
+		require.NoError(t, c.Set("b", &TestData{ID: 2}, time.Hour))
+		_, _, _ = c.Get("a")
+		require.NoError(t, c.Set("c", &TestData{ID: 3}, time.Hour))
+
+		assert.Equal(t, []string{"b"}, evicted)
+		assertCached(t, c, true, "a", "c")
+	})
+}
+
+func TestObjectManager_Tags(t *testing.T) {
+	c := newTestObjectCache(t, cache.WithShardCount[string, *TestData](4))
+	require.NoError(t, c.SetWithTags("order:1", &TestData{ID: 1}, time.Hour, "user:1", "orders"))
+	require.NoError(t, c.SetWithTags("order:2", &TestData{ID: 2}, time.Hour, "user:1"))
+	require.NoError(t, c.SetWithTags("order:3", &TestData{ID: 3}, time.Hour, "user:2", "orders"))
+	require.NoError(t, c.Set("user:1:profile", &TestData{ID: 4}, time.Hour))
+
+	removed, err := c.InvalidateTag("user:1")
+	require.NoError(t, err)
+	assert.Equal(t, 2, removed)
+	assertCached(t, c, false, "order:1", "order:2")
+	assertCached(t, c, true, "order:3")
+
+	removed, err = c.InvalidatePrefix("user:")
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+	assertCached(t, c, false, "user:1:profile")
+
+	intCache := cache.NewObjectManager[int, *TestData](1, 0, nil, 100)
+	_, err = intCache.InvalidatePrefix("1")
+	assert.Error(t, err)
+}
+
+func TestObjectManager_GetOrLoad(t *testing.T) {
+	c := newTestObjectCache(t, cache.WithNegativeTTL[string, *TestData](time.Hour))
+
+	var calls atomic.Int32
+	loader := func(_ context.Context, key string) (*TestData, bool, error) {
+		calls.Add(1)
+		time.Sleep(10 * time.Millisecond)
+		if key == "missing" {
+			return nil, false, nil
+		}
+
+		return &TestData{ID: 7}, true, nil
+	}
+
+	var wg sync.WaitGroup
+	results := make([]*TestData, 10)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			res, ok, err := c.GetOrLoad(context.Background(), "k1", loader, time.Hour)
+			assert.NoError(t, err)
+			assert.True(t, ok)
+			results[i] = res
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), calls.Load(), "Одновременные загрузки выполняются один раз")
+	for i := 1; i < len(results); i++ {
+		assert.Equal(t, 7, results[i].ID)
+		assert.NotSame(t, results[0], results[i], "Каждый вызывающий получает свою копию")
+	}
+
+	for i := 0; i < 2; i++ {
+		_, ok, err := c.GetOrLoad(context.Background(), "missing", loader, time.Hour)
+		require.NoError(t, err)
+		assert.False(t, ok)
+	}
+	assert.Equal(t, int32(2), calls.Load(), "Негативный результат кэшируется")
+}
+
+func TestObjectManager_GetAfterNegativeResult(t *testing.T) {
+	c, err := cache.CacheFactory[string, int](
+		cache.WithObjectStorage[string, int](nil),
+		cache.WithNegativeTTL[string, int](time.Hour),
+	)
+	require.NoError(t, err)
+	notFound := func(context.Context, string) (int, bool, error) {
+		return 0, false, nil
+	}
+
+	_, found, err := c.GetOrLoad(context.Background(), "k", notFound, time.Hour)
+	require.NoError(t, err)
+	require.False(t, found)
+	assert.Equal(t, 1, c.Size(), "Негативный результат хранится в кэше")
+
+	res, ok, err := c.Get("k")
+	require.NoError(t, err)
+	assert.False(t, ok, "Негативный результат не должен читаться как найденное значение")
+	assert.Zero(t, res)
+
+	require.NoError(t, c.Set("zero", 0, time.Hour))
+	_, ok, err = c.Get("zero")
+	require.NoError(t, err)
+	assert.True(t, ok, "Сохраненное нулевое значение - не негативный результат")
+}
+
+func TestCacheFactory_ObjectStorageInvalidConfig(t *testing.T) {
+	_, client := newTestRedis(t)
+	cases := map[string]cache.Option[string, *TestData]{
+		"redis":     cache.WithRedisStorage[string, *TestData](client, "test"),
+		"l2":        cache.WithL2Cache[string, *TestData](),
+		"max bytes": cache.WithMaxBytes[string, *TestData](1024),
+	}
+	for name, opt := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := cache.CacheFactory(cache.WithObjectStorage[string, *TestData](nil), opt)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/infra/cache"
+)
+
+const (
+	policyBenchCapacity = 1000
+	policyBenchKeySpace = 100000
+	policyBenchTraceLen = 100000
+)
+
+type policyBenchCase struct {
+	name   string
+	policy func(capacity int) cache.EvictionPolicy[uint64]
+}
+
+var policyBenchCases = []policyBenchCase{
+	{name: "LRU", policy: func(int) cache.EvictionPolicy[uint64] { return cache.NewLRUEvict[uint64]() }},
+	{name: "LFU", policy: func(int) cache.EvictionPolicy[uint64] { return cache.NewLFUEvict[uint64]() }},
+	{name: "FIFO", policy: func(int) cache.EvictionPolicy[uint64] { return cache.NewFIFOEvict[uint64]() }},
+	{name: "TinyLFU", policy: func(c int) cache.EvictionPolicy[uint64] { return cache.NewTinyLFUEvict[uint64](c) }},
+	{name: "ARC", policy: func(c int) cache.EvictionPolicy[uint64] { return cache.NewARCEvict[uint64](c) }},
+	{name: "S3FIFO", policy: func(c int) cache.EvictionPolicy[uint64] { return cache.NewS3FIFOEvict[uint64](c) }},
+}
+
+// zipfTrace обращения с распределением Ципфа (малая доля ключей получает большую часть обращений)
+func zipfTrace(seed int64, length int) []uint64 {
+	zipf := rand.NewZipf(rand.New(rand.NewSource(seed)), 1.07, 1, policyBenchKeySpace-1)
+	res := make([]uint64, length)
+	for i := range res {
+		res[i] = zipf.Uint64()
+	}
+
+	return res
+}
+
+// scanTrace обращения Ципфа, перемежающиеся однократными последовательными сканированиями
+// уникальных ключей объемом в несколько емкостей кэша
+func scanTrace(seed int64, length int) []uint64 {
+	zipf := zipfTrace(seed, length)
+	res := make([]uint64, 0, length)
+	scanKey := uint64(policyBenchKeySpace)
+	for i, key := range zipf {
+		if len(res) >= length {
+			break
+		}
+		res = append(res, key)
+		if i > 0 && i%(10*policyBenchCapacity) == 0 {
+			for j := 0; j < 3*policyBenchCapacity && len(res) < length; j++ {
+				res = append(res, scanKey)
+				scanKey++
+			}
+		}
+	}
+
+	return res
+}
+
+// replayTrace прогон трассы через хранилище: промах - запись, возвращается доля попаданий
+func replayTrace(policy cache.EvictionPolicy[uint64], trace []uint64) float64 {
+	storage := cache.NewRawStorage[uint64](policyBenchCapacity, policy)
+	payload := []byte{1}
+	var hits int
+	for _, key := range trace {
+		if _, ok := storage.Get(key); ok {
+			hits++
+
+			continue
+		}
+		storage.Set(key, payload)
+	}
+
+	return float64(hits) / float64(len(trace))
+}
+
+func benchmarkPolicyHitRatio(b *testing.B, trace []uint64) {
+	for _, bc := range policyBenchCases {
+		b.Run(bc.name, func(b *testing.B) {
+			var ratio float64
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				ratio = replayTrace(bc.policy(policyBenchCapacity), trace)
+			}
+			b.ReportMetric(ratio*100, "hit%")
+		})
+	}
+}
+
+// BenchmarkPolicy_HitRatio_Zipf доля попаданий политик вытеснения на трассе Ципфа
+func BenchmarkPolicy_HitRatio_Zipf(b *testing.B) {
+	benchmarkPolicyHitRatio(b, zipfTrace(42, policyBenchTraceLen))
+}
+
+// BenchmarkPolicy_HitRatio_Scan доля попаданий политик вытеснения на трассе Ципфа со сканированиями
+func BenchmarkPolicy_HitRatio_Scan(b *testing.B) {
+	benchmarkPolicyHitRatio(b, scanTrace(42, policyBenchTraceLen))
+}
@@ -0,0 +1,102 @@
+package test
+
+import (
+	"encoding/binary"
+	"errors"
+	"testing"
+	"time"
+
+	pb "github.com/ElfAstAhe/go-service-template/pkg/api/grpc/example/v1"
+	"github.com/ElfAstAhe/go-service-template/pkg/infra/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// binaryPoint значение с собственной бинарной сериализацией
+type binaryPoint struct {
+	X int32
+	Y int32
+}
+
+func (bp *binaryPoint) MarshalBinary() ([]byte, error) {
+	res := make([]byte, 8)
+	binary.BigEndian.PutUint32(res, uint32(bp.X))
+	binary.BigEndian.PutUint32(res[4:], uint32(bp.Y))
+
+	return res, nil
+}
+
+func (bp *binaryPoint) UnmarshalBinary(data []byte) error {
+	if len(data) != 8 {
+		return errors.New("invalid point length")
+	}
+	bp.X = int32(binary.BigEndian.Uint32(data))
+	bp.Y = int32(binary.BigEndian.Uint32(data[4:]))
+
+	return nil
+}
+
+func newProtoTestCodec() *cache.ProtoCodec[*pb.Test] {
+	return cache.NewProtoCodec[*pb.Test](func() *pb.Test { return &pb.Test{} })
+}
+
+func TestProtoCodec_Manager(t *testing.T) {
+	mgr := cache.New[string, *pb.Test](cache.NewRawStorage[string](0, cache.NewLRUEvict[string]()), newProtoTestCodec(), 100)
+	val := pb.Test_builder{Id: "1", Code: "code", Name: "name"}.Build()
+
+	require.NoError(t, mgr.Set("test:1", val, time.Minute))
+	res, ok, err := mgr.Get("test:1")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.True(t, proto.Equal(val, res))
+
+	// пустое значение - негативный результат (Envelope.Nil), чтение - промах
+	require.NoError(t, mgr.Set("test:nil", nil, time.Minute))
+	assert.Equal(t, 2, mgr.Size())
+	res, ok, err = mgr.Get("test:nil")
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Nil(t, res)
+}
+
+func TestProtoCodec_DecodeExpiry(t *testing.T) {
+	codec := newProtoTestCodec()
+
+	buf, err := codec.Marshal(pb.Test_builder{Id: "1"}.Build(), time.Hour)
+	require.NoError(t, err)
+	env, err := codec.Unmarshal(buf)
+	require.NoError(t, err)
+	dieAt, err := codec.DecodeExpiry(buf)
+	require.NoError(t, err)
+	assert.Equal(t, env.DieAt, dieAt)
+	assert.Equal(t, env.DieAt, cache.CodecExpiry[*pb.Test](codec)(buf))
+
+	buf, err = codec.Marshal(pb.Test_builder{Id: "2"}.Build(), 0)
+	require.NoError(t, err)
+	dieAt, err = codec.DecodeExpiry(buf)
+	require.NoError(t, err)
+	assert.Zero(t, dieAt)
+
+	_, err = codec.Unmarshal([]byte{0xff, 0, 0})
+	assert.Error(t, err)
+}
+
+func TestBinaryCodec_RoundTrip(t *testing.T) {
+	codec := cache.NewBinaryCodec[*binaryPoint](func() *binaryPoint { return &binaryPoint{} })
+	val := &binaryPoint{X: -5, Y: 42}
+
+	buf, err := codec.Marshal(val, time.Minute)
+	require.NoError(t, err)
+	env, err := codec.Unmarshal(buf)
+	require.NoError(t, err)
+	assert.Equal(t, val, env.Value)
+	assert.Positive(t, env.DieAt)
+
+	buf, err = codec.Marshal(nil, time.Minute)
+	require.NoError(t, err)
+	env, err = codec.Unmarshal(buf)
+	require.NoError(t, err)
+	assert.True(t, env.Nil)
+	assert.Nil(t, env.Value)
+}
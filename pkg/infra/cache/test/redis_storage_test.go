@@ -0,0 +1,204 @@
+package test
+
+import (
+	"errors"
+	"net"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/config"
+	"github.com/ElfAstAhe/go-service-template/pkg/errs"
+	"github.com/ElfAstAhe/go-service-template/pkg/infra/cache"
+	logmocks "github.com/ElfAstAhe/go-service-template/pkg/logger/mocks"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRedis(t *testing.T) (*miniredis.Miniredis, *redis.Client) {
+	srv := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: srv.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	return srv, client
+}
+
+func TestRedisStorage_CRUD(t *testing.T) {
+	srv, client := newTestRedis(t)
+	storage := cache.NewRedisStorage[string](client, "users")
+
+	storage.Set("k1", []byte("v1"))
+	assert.True(t, srv.Exists("users:k1"), "Ключ должен храниться с префиксом")
+
+	b, ok := storage.Get("k1")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("v1"), b)
+	assert.True(t, storage.Has("k1"))
+
+	storage.Delete("k1")
+	_, ok = storage.Get("k1")
+	assert.False(t, ok)
+	assert.False(t, storage.Has("k1"))
+}
+
+func TestRedisStorage_PrefixScope(t *testing.T) {
+	srv, client := newTestRedis(t)
+	users := cache.NewRedisStorage[string](client, "users")
+	// спецсимволы glob в префиксе не должны расширять область
+	other := cache.NewRedisStorage[string](client, "use*")
+
+	for _, key := range []string{"a", "b", "c"} {
+		users.Set(key, []byte(key))
+	}
+	other.Set("x", []byte("x"))
+	require.NoError(t, srv.Set("foreign", "value"))
+
+	assert.Equal(t, 3, users.Len())
+	assert.Equal(t, 1, other.Len())
+
+	var keys []string
+	users.Range(func(key string, value []byte) bool {
+		assert.Equal(t, key, string(value))
+		keys = append(keys, key)
+
+		return true
+	})
+	sort.Strings(keys)
+	assert.Equal(t, []string{"a", "b", "c"}, keys)
+
+	users.Clear()
+	assert.Equal(t, 0, users.Len())
+	assert.Equal(t, 1, other.Len())
+	assert.True(t, srv.Exists("foreign"), "Clear не должен затрагивать чужие ключи")
+}
+
+func TestRedisStorage_NonStringKey(t *testing.T) {
+	srv, client := newTestRedis(t)
+	storage := cache.NewRedisStorage[int](client, "ids")
+
+	storage.Set(42, []byte("answer"))
+	assert.True(t, srv.Exists("ids:42"))
+
+	var keys []int
+	storage.Range(func(key int, value []byte) bool {
+		keys = append(keys, key)
+
+		return true
+	})
+	assert.Equal(t, []int{42}, keys)
+}
+
+func TestRedisStorage_ServerSideTTL(t *testing.T) {
+	srv, client := newTestRedis(t)
+	codec := cache.NewJSONCodec[*TestData](func() *TestData { return &TestData{} })
+	storage := cache.NewRedisStorage[string](client, "ttl", cache.WithRedisExpiry[string](cache.CodecExpiry[*TestData](codec)))
+
+	b, err := codec.Marshal(&TestData{ID: 1}, time.Minute)
+	require.NoError(t, err)
+	storage.Set("short", b)
+	b, err = codec.Marshal(&TestData{ID: 2}, 0)
+	require.NoError(t, err)
+	storage.Set("forever", b)
+
+	ttl := srv.TTL("ttl:short")
+	assert.True(t, ttl > 0 && ttl <= time.Minute, "TTL должен быть выставлен по конверту: %v", ttl)
+	assert.Equal(t, time.Duration(0), srv.TTL("ttl:forever"))
+
+	srv.FastForward(2 * time.Minute)
+	assert.False(t, storage.Has("short"))
+	assert.True(t, storage.Has("forever"))
+}
+
+func TestRedisStorage_ErrorHandler(t *testing.T) {
+	srv, client := newTestRedis(t)
+	var ops []string
+	storage := cache.NewRedisStorage[string](client, "err", cache.WithRedisErrorHandler[string](func(op string, err error) {
+		ops = append(ops, op)
+	}))
+	srv.Close()
+
+	_, ok := storage.Get("k")
+	assert.False(t, ok)
+	storage.Set("k", []byte("v"))
+	assert.Equal(t, []string{"get", "set"}, ops)
+}
+
+func TestCacheFactory_Redis(t *testing.T) {
+	srv, client := newTestRedis(t)
+
+	c, err := cache.CacheFactory[string, *TestData](
+		cache.WithCodec[string, *TestData](cache.NewJSONCodec[*TestData](func() *TestData { return &TestData{} })),
+		cache.WithRedisStorage[string, *TestData](client, "factory"),
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, c.Set("k", &TestData{ID: 7, Active: true}, time.Minute))
+	assert.True(t, srv.TTL("factory:k") > 0)
+
+	val, ok, err := c.Get("k")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 7, val.ID)
+	assert.Equal(t, 1, c.Size())
+
+	_, err = cache.CacheFactory[string, *TestData](
+		cache.WithCodec[string, *TestData](cache.NewJSONCodec[*TestData](func() *TestData { return &TestData{} })),
+		cache.WithRedisStorage[string, *TestData](client, ""),
+	)
+	assert.Error(t, err, "Пустой префикс недопустим")
+}
+
+func TestCacheFactory_RedisErrors(t *testing.T) {
+	srv, client := newTestRedis(t)
+	log := logmocks.NewMockLogger(t)
+	log.On("GetLogger", "factory_errors").Return(log).Once()
+	log.On("Errorf", mock.Anything, mock.MatchedBy(func(args []any) bool {
+		return len(args) == 3 && args[0] == "factory_errors" && args[1] == "get"
+	})).Once()
+
+	c, err := cache.CacheFactory[string, *TestData](
+		cache.WithCodec[string, *TestData](cache.NewJSONCodec[*TestData](func() *TestData { return &TestData{} })),
+		cache.WithRedisStorage[string, *TestData](client, "factory"),
+		cache.WithName[string, *TestData]("factory_errors"),
+		cache.WithLogger[string, *TestData](log),
+	)
+	require.NoError(t, err)
+	srv.SetError("ERR unavailable")
+
+	// ошибка записи возвращается вызывающему, ошибка чтения - промах с записью в журнал
+	err = c.Set("k", &TestData{ID: 1}, time.Minute)
+	require.Error(t, err)
+	_, ok := errors.AsType[*errs.DalCacheError](err)
+	assert.True(t, ok)
+
+	_, ok, err = c.Get("k")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestNewRedisClient(t *testing.T) {
+	srv := miniredis.RunT(t)
+	host, port, err := net.SplitHostPort(srv.Addr())
+	require.NoError(t, err)
+
+	cfg := config.NewDefaultRedisConfig()
+	_, err = cache.NewRedisClient(cfg)
+	assert.Error(t, err, "Выключенный redis не должен создавать клиента")
+
+	cfg.Enabled = true
+	cfg.Host = ""
+	_, err = cache.NewRedisClient(cfg)
+	assert.Error(t, err)
+
+	cfg.Host, cfg.Port = host, port
+	client, err := cache.NewRedisClient(cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+
+	storage := cache.NewRedisStorage[string](client, "users")
+	storage.Set("k1", []byte("v1"))
+	assert.True(t, srv.Exists("users:k1"))
+}
@@ -0,0 +1,156 @@
+package test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/infra/cache"
+	"github.com/ElfAstAhe/go-service-template/pkg/transport/worker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type refreshTestLoader struct {
+	loads   atomic.Int32
+	value   atomic.Int32
+	found   atomic.Bool
+	release chan struct{}
+}
+
+func newRefreshTestLoader(value int32) *refreshTestLoader {
+	res := &refreshTestLoader{}
+	res.value.Store(value)
+	res.found.Store(true)
+
+	return res
+}
+
+func (l *refreshTestLoader) load(ctx context.Context, _ string) (*TestData, bool, error) {
+	l.loads.Add(1)
+	if l.release != nil {
+		select {
+		case <-l.release:
+		case <-ctx.Done():
+			return nil, false, ctx.Err()
+		}
+	}
+
+	return &TestData{ID: int(l.value.Load())}, l.found.Load(), nil
+}
+
+func newRefreshTestCache(t *testing.T, loader *refreshTestLoader, opts ...cache.RefresherOption[string, *TestData]) (cache.Cache[string, *TestData], *cache.Refresher[string, *TestData]) {
+	log := newNearTestLogger()
+	log.On("Errorf", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Maybe()
+	refresher := cache.NewRefresher[string, *TestData]("cache-refresher", worker.NewBasePoolConfig(2, 16, false, time.Second),
+		loader.load, time.Hour, log, opts...)
+	require.NoError(t, refresher.Start(context.Background()))
+	t.Cleanup(func() { _ = refresher.Stop(context.Background()) })
+
+	return newLoadTestCache(t, cache.WithRefresher[string, *TestData](refresher)), refresher
+}
+
+func TestRefresher_RefreshAhead(t *testing.T) {
+	loader := newRefreshTestLoader(2)
+	c, _ := newRefreshTestCache(t, loader, cache.WithRefreshAhead[string, *TestData](30*time.Minute))
+
+	// вне окна перезагрузки нет
+	require.NoError(t, c.Set("far", &TestData{ID: 1}, 2*time.Hour))
+	_, ok, err := c.Get("far")
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	require.NoError(t, c.Set("near", &TestData{ID: 1}, time.Minute))
+	res, ok, err := c.Get("near")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, 1, res.ID, "Текущее значение отдается сразу")
+
+	assert.Eventually(t, func() bool {
+		res, ok, err := c.Get("near")
+
+		return err == nil && ok && res.ID == 2
+	}, time.Second, 5*time.Millisecond)
+	assert.Equal(t, int32(1), loader.loads.Load())
+}
+
+func TestRefresher_StaleWhileRevalidate(t *testing.T) {
+	loader := newRefreshTestLoader(2)
+	loader.release = make(chan struct{})
+	c, refresher := newRefreshTestCache(t, loader, cache.WithStaleWhileRevalidate[string, *TestData](time.Hour))
+
+	require.NoError(t, c.Set("key", &TestData{ID: 1}, time.Millisecond))
+	time.Sleep(5 * time.Millisecond)
+
+	// истекшая запись отдается всем, пока выполняется одна перезагрузка
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res, ok, err := c.Get("key")
+			assert.NoError(t, err)
+			assert.True(t, ok)
+			assert.Equal(t, 1, res.ID)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 1, refresher.Pending())
+
+	close(loader.release)
+	assert.Eventually(t, func() bool {
+		res, ok, err := c.Get("key")
+
+		return err == nil && ok && res.ID == 2
+	}, time.Second, 5*time.Millisecond)
+	assert.Equal(t, int32(1), loader.loads.Load())
+	assert.Equal(t, 0, refresher.Pending())
+}
+
+func TestRefresher_StaleOutOfGrace(t *testing.T) {
+	loader := newRefreshTestLoader(2)
+	c, _ := newRefreshTestCache(t, loader, cache.WithStaleWhileRevalidate[string, *TestData](time.Millisecond))
+
+	require.NoError(t, c.Set("key", &TestData{ID: 1}, time.Millisecond))
+	time.Sleep(10 * time.Millisecond)
+
+	_, ok, err := c.Get("key")
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, int32(0), loader.loads.Load())
+}
+
+func TestRefresher_NotRunning(t *testing.T) {
+	loader := newRefreshTestLoader(2)
+	c, refresher := newRefreshTestCache(t, loader, cache.WithStaleWhileRevalidate[string, *TestData](time.Hour))
+	require.NoError(t, refresher.Stop(context.Background()))
+
+	require.NoError(t, c.Set("key", &TestData{ID: 1}, time.Millisecond))
+	time.Sleep(5 * time.Millisecond)
+
+	// перезагрузку запланировать нельзя - истекшая запись не отдается
+	_, ok, err := c.Get("key")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestRefresher_NotFound(t *testing.T) {
+	loader := newRefreshTestLoader(2)
+	loader.found.Store(false)
+	c, _ := newRefreshTestCache(t, loader, cache.WithRefreshAhead[string, *TestData](time.Hour))
+
+	require.NoError(t, c.Set("key", &TestData{ID: 1}, time.Minute))
+	_, ok, err := c.Get("key")
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// значения в источнике больше нет - запись удаляется
+	assert.Eventually(t, func() bool {
+		_, ok, err := c.Get("key")
+
+		return err == nil && !ok
+	}, time.Second, 5*time.Millisecond)
+}
@@ -0,0 +1,163 @@
+package test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/infra/cache"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheFactory_Registry(t *testing.T) {
+	c := newLoadTestCache(t, cache.WithName[string, *TestData]("test_registry_factory"))
+	t.Cleanup(func() {
+		cache.DefaultRegistry().Unregister("test_registry_factory")
+	})
+	require.NoError(t, c.Set("k1", &TestData{ID: 1}, time.Hour))
+
+	adminCache, err := cache.DefaultRegistry().Get("test_registry_factory")
+	require.NoError(t, err)
+	info := adminCache.Info()
+	assert.Equal(t, "test_registry_factory", info.Name)
+	assert.Equal(t, 1, info.Size)
+
+	value, ok, err := adminCache.GetJSON("k1")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.JSONEq(t, `{"id":1,"active":false}`, string(value))
+
+	require.NoError(t, adminCache.Delete("k1"))
+	assert.Zero(t, c.Size())
+
+	_, err = cache.DefaultRegistry().Get("unknown")
+	assert.Error(t, err)
+}
+
+func TestCacheFactory_RegistryStats(t *testing.T) {
+	caches := map[string]cache.Cache[string, *TestData]{
+		"test_registry_stats_manager": newLoadTestCache(t,
+			cache.WithName[string, *TestData]("test_registry_stats_manager"),
+			cache.WithMaxSize[string, *TestData](2),
+		),
+		"test_registry_stats_object": newLoadTestCache(t,
+			cache.WithName[string, *TestData]("test_registry_stats_object"),
+			cache.WithMaxSize[string, *TestData](2),
+			cache.WithObjectStorage[string, *TestData](nil),
+		),
+	}
+	for name, c := range caches {
+		t.Run(name, func(t *testing.T) {
+			t.Cleanup(func() {
+				cache.DefaultRegistry().Unregister(name)
+			})
+			require.NoError(t, c.Set("a", &TestData{ID: 1}, time.Hour))
+			require.NoError(t, c.Set("b", &TestData{ID: 2}, time.Millisecond))
+			time.Sleep(5 * time.Millisecond)
+			assertCached(t, c, false, "b")
+			assertCached(t, c, true, "a")
+			require.NoError(t, c.Set("c", &TestData{ID: 3}, time.Hour))
+			require.NoError(t, c.Set("d", &TestData{ID: 4}, time.Hour))
+
+			adminCache, err := cache.DefaultRegistry().Get(name)
+			require.NoError(t, err)
+			info := adminCache.Info()
+			require.NotNil(t, info.Stats)
+			assert.Equal(t, cache.CacheStats{Hits: 1, Misses: 1, Evictions: 1, Expirations: 1}, *info.Stats)
+		})
+	}
+
+	info := cache.NewAdminCache[string, *TestData]("plain", newTagTestManager(0), cache.StringKeyCodec{}).Info()
+	assert.Nil(t, info.Stats, "Без счетчиков статистика не публикуется")
+}
+
+func TestAdminCache_Keys(t *testing.T) {
+	c := newTagTestManager(0)
+	for _, key := range []string{"b:2", "a:1", "b:1", "c:1"} {
+		require.NoError(t, c.Set(key, &TestData{}, time.Hour))
+	}
+	adminCache := cache.NewAdminCache[string, *TestData]("keys", c, cache.StringKeyCodec{})
+
+	page, err := adminCache.Keys("b:", 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b:1", "b:2"}, page.Keys)
+
+	page, err = adminCache.Keys("", 1, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b:1", "b:2"}, page.Keys)
+	assert.Equal(t, 4, page.Total)
+
+	require.NoError(t, adminCache.Janitor(context.Background()))
+	adminCache.Clear()
+	assert.Zero(t, c.Size())
+}
+
+// commandRecorder запоминает имена команд redis
+type commandRecorder struct {
+	mu    sync.Mutex
+	names []string
+}
+
+func (cr *commandRecorder) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (cr *commandRecorder) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		cr.mu.Lock()
+		cr.names = append(cr.names, cmd.Name())
+		cr.mu.Unlock()
+
+		return next(ctx, cmd)
+	}
+}
+
+func (cr *commandRecorder) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return next
+}
+
+func TestAdminCache_KeysRedis(t *testing.T) {
+	_, client := newTestRedis(t)
+	c := cache.New[string, *TestData](cache.NewRedisStorage[string](client, "admin"), newTestCodec(), 100)
+	for _, key := range []string{"b:2", "a:1", "b:1", "c:1"} {
+		require.NoError(t, c.Set(key, &TestData{}, time.Hour))
+	}
+	recorder := &commandRecorder{}
+	client.AddHook(recorder)
+
+	adminCache := cache.NewAdminCache[string, *TestData]("keys", c, cache.StringKeyCodec{})
+	page, err := adminCache.Keys("b:", 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b:1", "b:2"}, page.Keys)
+	assert.False(t, page.Truncated)
+	assert.Contains(t, recorder.names, "scan")
+	assert.NotContains(t, recorder.names, "mget", "Значения при обходе ключей не читаются")
+
+	// обход ограничен кол-вом ключей
+	adminCache = cache.NewAdminCache[string, *TestData]("keys", c, cache.StringKeyCodec{}, cache.WithAdminCacheMaxKeys(2))
+	page, err = adminCache.Keys("", 0, 0)
+	require.NoError(t, err)
+	assert.Len(t, page.Keys, 2)
+	assert.Equal(t, 2, page.Total)
+	assert.True(t, page.Truncated)
+}
+
+func TestAdminCache_IntKeys(t *testing.T) {
+	c := cache.NewObjectManager[int, *TestData](2, 0, nil, 100)
+	require.NoError(t, c.Set(42, &TestData{ID: 42}, time.Hour))
+	adminCache := cache.NewAdminCache[int, *TestData]("int", c, cache.DefaultKeyCodec[int]())
+
+	page, err := adminCache.Keys("", 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"42"}, page.Keys)
+
+	_, ok, err := adminCache.GetJSON("42")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	_, _, err = adminCache.GetJSON("not-a-number")
+	assert.Error(t, err)
+}
@@ -0,0 +1,81 @@
+package test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/infra/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestS3FIFOEvict(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		sf := cache.NewS3FIFOEvict[string](10)
+
+		_, ok := sf.Evict()
+		assert.False(t, ok)
+	})
+
+	t.Run("one_hit_wonders_evicted_first", func(t *testing.T) {
+		sf := cache.NewS3FIFOEvict[string](10)
+
+		sf.OnSet("a")
+		sf.OnSet("b")
+		sf.OnGet("a")
+
+		// "a" читался повторно и переходит в основную очередь
+		key, ok := sf.Evict()
+		assert.True(t, ok)
+		assert.Equal(t, "b", key)
+		key, ok = sf.Evict()
+		assert.True(t, ok)
+		assert.Equal(t, "a", key)
+	})
+
+	t.Run("ghost_goes_to_main", func(t *testing.T) {
+		sf := cache.NewS3FIFOEvict[string](10)
+
+		sf.OnSet("a")
+		key, _ := sf.Evict()
+		assert.Equal(t, "a", key)
+
+		// "a" в призраке: запись сразу в основную очередь, малая очередь вытесняется первой
+		sf.OnSet("a")
+		sf.OnSet("b")
+		key, _ = sf.Evict()
+		assert.Equal(t, "b", key)
+	})
+
+	t.Run("frequent_key_survives_scan", func(t *testing.T) {
+		const capacity = 100
+		storage := cache.NewRawStorage[string](capacity, cache.NewS3FIFOEvict[string](capacity))
+
+		storage.Set("hot", []byte("v"))
+		_, _ = storage.Get("hot")
+		for i := 0; i < 5*capacity; i++ {
+			storage.Set(fmt.Sprintf("scan-%d", i), []byte("v"))
+			if i%10 == 0 {
+				_, _ = storage.Get("hot")
+			}
+		}
+
+		assert.True(t, storage.Has("hot"))
+		assert.LessOrEqual(t, storage.Len(), capacity)
+	})
+
+	t.Run("remove_and_reset", func(t *testing.T) {
+		sf := cache.NewS3FIFOEvict[string](10)
+
+		sf.OnSet("a")
+		sf.OnSet("b")
+		sf.OnRemove("a")
+		key, ok := sf.Evict()
+		assert.True(t, ok)
+		assert.Equal(t, "b", key)
+
+		sf.OnSet("c")
+		sf.Reset()
+		_, ok = sf.Evict()
+		assert.False(t, ok)
+	})
+}
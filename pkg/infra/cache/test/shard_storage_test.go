@@ -117,10 +117,10 @@ func TestShardStorage_Concurrency_Real(t *testing.T) {
 		return cache.NewRawStorage[int](maxSize, policy)
 	}
 
-	//    policy := cache.NewFIFOEvict[int]()
-	// policy := cache.NewLFUEvict[int]()
-	policy := cache.NewLRUEvict[int]()
-	ss := cache.NewShardStorage[int](shardCount, factory, 1000, policy)
+	policyFactory := func(int) cache.EvictionPolicy[int] {
+		return cache.NewLRUEvict[int]()
+	}
+	ss := cache.NewShardStorage[int](shardCount, factory, 1000, policyFactory)
 
 	wg := sync.WaitGroup{}
 	iterations := 1000
@@ -148,3 +148,81 @@ func TestShardStorage_Concurrency_Real(t *testing.T) {
 	wg.Wait()
 	assert.True(t, ss.Len() > 0)
 }
+
+func TestShardStorage_PolicyPerShard(t *testing.T) {
+	const shardCount = 8
+	var policies []cache.EvictionPolicy[int]
+	var capacities []int
+	policyFactory := func(capacity int) cache.EvictionPolicy[int] {
+		policy := cache.NewLRUEvict[int]()
+		policies = append(policies, policy)
+		capacities = append(capacities, capacity)
+
+		return policy
+	}
+	var received []cache.EvictionPolicy[int]
+	factory := func(maxSize int, policy cache.EvictionPolicy[int]) cache.Storage[int] {
+		received = append(received, policy)
+
+		return cache.NewRawStorage[int](maxSize, policy)
+	}
+
+	cache.NewShardStorage[int](shardCount, factory, 100, policyFactory)
+
+	assert.Len(t, policies, shardCount, "Каждый шард должен получить свой экземпляр политики")
+	assert.Equal(t, policies, received)
+	for i := range policies {
+		assert.Equal(t, 100, capacities[i], "Емкость задается на каждый шард")
+		for j := i + 1; j < len(policies); j++ {
+			assert.NotSame(t, policies[i], policies[j])
+		}
+	}
+}
+
+func TestShardStorage_CapacityPerShard_Concurrent(t *testing.T) {
+	const (
+		shardCount = 16
+		maxSize    = 100
+		workers    = 16
+		iterations = 5000
+	)
+	policyFactories := map[string]cache.EvictionPolicyFactory[int]{
+		"lru":     func(int) cache.EvictionPolicy[int] { return cache.NewLRUEvict[int]() },
+		"lfu":     func(int) cache.EvictionPolicy[int] { return cache.NewLFUEvict[int]() },
+		"fifo":    func(int) cache.EvictionPolicy[int] { return cache.NewFIFOEvict[int]() },
+		"tinylfu": func(c int) cache.EvictionPolicy[int] { return cache.NewTinyLFUEvict[int](c) },
+		"arc":     func(c int) cache.EvictionPolicy[int] { return cache.NewARCEvict[int](c) },
+		"s3fifo":  func(c int) cache.EvictionPolicy[int] { return cache.NewS3FIFOEvict[int](c) },
+	}
+	for name, policyFactory := range policyFactories {
+		t.Run(name, func(t *testing.T) {
+			factory := func(maxSize int, policy cache.EvictionPolicy[int]) cache.Storage[int] {
+				return cache.NewRawStorage[int](maxSize, policy)
+			}
+			ss := cache.NewShardStorage[int](shardCount, factory, maxSize, policyFactory)
+
+			var wg sync.WaitGroup
+			for w := 0; w < workers; w++ {
+				wg.Add(1)
+				go func(workerID int) {
+					defer wg.Done()
+					for j := 0; j < iterations; j++ {
+						key := workerID*iterations + j
+						ss.Set(key, []byte("v"))
+						_, _ = ss.Get(key - 1)
+						if j%7 == 0 {
+							ss.Delete(key - 3)
+						}
+					}
+				}(w)
+			}
+			wg.Wait()
+
+			for i, shard := range ss.GetShards() {
+				assert.LessOrEqual(t, shard.Len(), maxSize, "Шард %d превысил свою емкость", i)
+			}
+			assert.LessOrEqual(t, ss.Len(), maxSize*shardCount)
+			assert.Greater(t, ss.Len(), 0)
+		})
+	}
+}
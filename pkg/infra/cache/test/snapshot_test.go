@@ -0,0 +1,174 @@
+package test
+
+import (
+	"context"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/infra/cache"
+	logmocks "github.com/ElfAstAhe/go-service-template/pkg/logger/mocks"
+	"github.com/ElfAstAhe/go-service-template/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newSnapshotTestLogger() *logmocks.MockLogger {
+	log := newNearTestLogger()
+	log.On("Infof", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Maybe()
+	log.On("Debugf", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Maybe()
+
+	return log
+}
+
+func newSnapshotTestManager() *cache.Manager[string, *TestData] {
+	storage := cache.NewRawStorage[string](0, cache.NewLRUEvict[string]())
+
+	return cache.New[string, *TestData](storage, newTestCodec(), 100)
+}
+
+func TestSnapshot_SaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache", "users.snapshot")
+	log := newSnapshotTestLogger()
+
+	src := newSnapshotTestManager()
+	require.NoError(t, src.Set("alive", &TestData{ID: 1, Active: true}, time.Hour))
+	require.NoError(t, src.Set("forever", &TestData{ID: 2}, 0))
+	require.NoError(t, src.Set("expired", &TestData{ID: 3}, time.Millisecond))
+	time.Sleep(5 * time.Millisecond)
+
+	saved, err := cache.NewManagerSnapshot("users", path, src, log).Save(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, saved, "Просроченные записи не сохраняются")
+
+	dst := newSnapshotTestManager()
+	loaded, err := cache.NewManagerSnapshot("users", path, dst, log).Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, loaded)
+
+	val, ok, err := dst.Get("alive")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, 1, val.ID)
+	assert.True(t, val.Active)
+
+	_, ok, err = dst.Get("forever")
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestSnapshot_LoadSkipsExpired(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "users.snapshot")
+	log := newSnapshotTestLogger()
+
+	src := newSnapshotTestManager()
+	require.NoError(t, src.Set("short", &TestData{ID: 1}, 30*time.Millisecond))
+	require.NoError(t, src.Set("long", &TestData{ID: 2}, time.Hour))
+	_, err := cache.NewManagerSnapshot("users", path, src, log).Save(context.Background())
+	require.NoError(t, err)
+
+	time.Sleep(40 * time.Millisecond)
+	dst := newSnapshotTestManager()
+	loaded, err := cache.NewManagerSnapshot("users", path, dst, log).Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, loaded)
+	assert.Equal(t, 1, dst.Size())
+
+	// момент истечения восстановлен в индексе хранилища
+	require.NoError(t, dst.CacheJanitor(context.Background(), time.Now().Add(2*time.Hour)))
+	assert.Equal(t, 0, dst.Size())
+}
+
+func TestSnapshot_Encrypted(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "users.snapshot")
+	log := newSnapshotTestLogger()
+	cipher := utils.MustNewAesGcmCipher([]byte("0123456789abcdef0123456789abcdef"))
+
+	src := newSnapshotTestManager()
+	require.NoError(t, src.Set("top-secret-key", &TestData{ID: 1}, time.Hour))
+	_, err := cache.NewManagerSnapshot("users", path, src, log, cache.WithSnapshotCipher[string](cipher)).
+		Save(context.Background())
+	require.NoError(t, err)
+
+	raw, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(raw), "top-secret-key")
+
+	// без шифра зашифрованный снимок не загружается
+	_, err = cache.NewManagerSnapshot("users", path, newSnapshotTestManager(), log).Load(context.Background())
+	assert.Error(t, err)
+
+	dst := newSnapshotTestManager()
+	loaded, err := cache.NewManagerSnapshot("users", path, dst, log, cache.WithSnapshotCipher[string](cipher)).
+		Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, loaded)
+}
+
+func TestSnapshot_Corrupted(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "users.snapshot")
+	log := newSnapshotTestLogger()
+
+	src := newSnapshotTestManager()
+	require.NoError(t, src.Set("a", &TestData{ID: 1}, time.Hour))
+	_, err := cache.NewManagerSnapshot("users", path, src, log).Save(context.Background())
+	require.NoError(t, err)
+
+	raw, err := os.ReadFile(path)
+	require.NoError(t, err)
+	raw[len(raw)-1] ^= 0xff
+	require.NoError(t, os.WriteFile(path, raw, 0o600))
+
+	dst := newSnapshotTestManager()
+	_, err = cache.NewManagerSnapshot("users", path, dst, log).Load(context.Background())
+	assert.ErrorContains(t, err, "checksum")
+	assert.Equal(t, 0, dst.Size())
+
+	// неизвестная версия формата
+	raw[len(raw)-1] ^= 0xff
+	raw[9] = 0xff
+	require.NoError(t, os.WriteFile(path, raw, 0o600))
+	_, err = cache.NewManagerSnapshot("users", path, dst, log).Load(context.Background())
+	assert.ErrorContains(t, err, "version")
+
+	// размер данных в заголовке больше файла, без ограничения объема
+	raw[9] = byte(cache.SnapshotVersion)
+	binary.BigEndian.PutUint64(raw[12:20], 1<<62)
+	require.NoError(t, os.WriteFile(path, raw, 0o600))
+	_, err = cache.NewManagerSnapshot("users", path, dst, log, cache.WithSnapshotMaxBytes[string](0)).Load(context.Background())
+	assert.ErrorContains(t, err, "exceeds file body")
+}
+
+func TestSnapshot_Limits(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "users.snapshot")
+	log := newSnapshotTestLogger()
+
+	src := newSnapshotTestManager()
+	for _, key := range []string{"a", "b", "c", "d"} {
+		require.NoError(t, src.Set(key, &TestData{ID: len(key)}, time.Hour))
+	}
+
+	saved, err := cache.NewManagerSnapshot("users", path, src, log, cache.WithSnapshotMaxEntries[string](2)).
+		Save(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, saved)
+
+	_, err = cache.NewManagerSnapshot("users", path, src, log).Save(context.Background())
+	require.NoError(t, err)
+	// файл больше ограничения не загружается
+	_, err = cache.NewManagerSnapshot("users", path, newSnapshotTestManager(), log, cache.WithSnapshotMaxBytes[string](16)).
+		Load(context.Background())
+	assert.ErrorContains(t, err, "exceeds limit")
+}
+
+func TestSnapshot_LoadMissingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing.snapshot")
+
+	loaded, err := cache.NewManagerSnapshot("users", path, newSnapshotTestManager(), newSnapshotTestLogger()).
+		Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, loaded)
+}
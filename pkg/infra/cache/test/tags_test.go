@@ -0,0 +1,280 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/errs"
+	"github.com/ElfAstAhe/go-service-template/pkg/infra/cache"
+	"github.com/ElfAstAhe/go-service-template/pkg/infra/pubsub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTagTestManager(maxSize int) *cache.Manager[string, *TestData] {
+	return cache.New[string, *TestData](cache.NewRawStorage[string](maxSize, cache.NewLRUEvict[string]()), newTestCodec(), 100)
+}
+
+func assertCached(t *testing.T, c cache.Cache[string, *TestData], expected bool, keys ...string) {
+	t.Helper()
+	for _, key := range keys {
+		_, ok, err := c.Get(key)
+		require.NoError(t, err)
+		assert.Equal(t, expected, ok, "key [%s]", key)
+	}
+}
+
+func TestManager_InvalidateTag(t *testing.T) {
+	caches := map[string]cache.Cache[string, *TestData]{
+		"raw": newTagTestManager(0),
+		"sharded": newLoadTestCache(t,
+			cache.WithShardCount[string, *TestData](4),
+			cache.WithMaxSize[string, *TestData](0),
+		),
+	}
+	for name, c := range caches {
+		t.Run(name, func(t *testing.T) {
+			require.NoError(t, c.SetWithTags("order:1", &TestData{ID: 1}, time.Hour, "user:1", "orders"))
+			require.NoError(t, c.SetWithTags("order:2", &TestData{ID: 2}, time.Hour, "user:1"))
+			require.NoError(t, c.SetWithTags("order:3", &TestData{ID: 3}, time.Hour, "user:2", "orders"))
+			require.NoError(t, c.Set("plain", &TestData{ID: 4}, time.Hour))
+
+			removed, err := c.InvalidateTag("user:1")
+			require.NoError(t, err)
+			assert.Equal(t, 2, removed)
+			assertCached(t, c, false, "order:1", "order:2")
+			assertCached(t, c, true, "order:3", "plain")
+
+			removed, err = c.InvalidateTag("orders")
+			require.NoError(t, err)
+			assert.Equal(t, 1, removed, "Удаленные ключи исключаются из индекса всех своих тегов")
+
+			removed, err = c.InvalidateTag("unknown")
+			require.NoError(t, err)
+			assert.Zero(t, removed)
+		})
+	}
+}
+
+func TestManager_TagsRewrite(t *testing.T) {
+	c := newTagTestManager(0)
+
+	require.NoError(t, c.SetWithTags("k", &TestData{ID: 1}, time.Hour, "old"))
+	// обычная перезапись сохраняет теги
+	require.NoError(t, c.Set("k", &TestData{ID: 2}, time.Hour))
+	removed, err := c.InvalidateTag("old")
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+
+	// перезапись с тегами заменяет их
+	require.NoError(t, c.SetWithTags("k", &TestData{ID: 1}, time.Hour, "old"))
+	require.NoError(t, c.SetWithTags("k", &TestData{ID: 2}, time.Hour, "new"))
+	removed, err = c.InvalidateTag("old")
+	require.NoError(t, err)
+	assert.Zero(t, removed)
+	assertCached(t, c, true, "k")
+	removed, err = c.InvalidateTag("new")
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+}
+
+func TestManager_TagsConsistentWithEvictionAndExpiry(t *testing.T) {
+	c := newTagTestManager(2)
+
+	require.NoError(t, c.SetWithTags("a", &TestData{ID: 1}, time.Hour, "tag"))
+	require.NoError(t, c.SetWithTags("b", &TestData{ID: 2}, time.Millisecond, "tag"))
+	require.NoError(t, c.SetWithTags("c", &TestData{ID: 3}, time.Hour, "tag"))
+	// "a" вытеснен по емкости, "b" удаляется как просроченный
+	require.NoError(t, c.CacheJanitor(context.Background(), time.Now().Add(time.Minute)))
+	assert.Equal(t, 1, c.Size())
+
+	removed, err := c.InvalidateTag("tag")
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+	assert.Zero(t, c.Size())
+}
+
+func TestManager_InvalidatePrefix(t *testing.T) {
+	c := newTagTestManager(0)
+	for _, key := range []string{"user:1/a", "user:1/b", "user:12/a", "order:1"} {
+		require.NoError(t, c.Set(key, &TestData{}, time.Hour))
+	}
+
+	removed, err := c.InvalidatePrefix("user:1/")
+	require.NoError(t, err)
+	assert.Equal(t, 2, removed)
+	assertCached(t, c, false, "user:1/a", "user:1/b")
+	assertCached(t, c, true, "user:12/a", "order:1")
+
+	// префикс только для строковых ключей
+	intCache := cache.New[int, *TestData](cache.NewRawStorage[int](0, cache.NewLRUEvict[int]()), newTestCodec(), 100)
+	_, err = intCache.InvalidatePrefix("1")
+	assert.Error(t, err)
+}
+
+func TestManager_TagsUnsupportedStorage(t *testing.T) {
+	c := cache.New[string, *TestData](newUntaggedStorage(), newTestCodec(), 100)
+
+	assert.Error(t, c.SetWithTags("k", &TestData{}, time.Hour, "tag"))
+	_, err := c.InvalidateTag("tag")
+	assert.Error(t, err)
+}
+
+func TestRedisStorage_Tags(t *testing.T) {
+	srv, client := newTestRedis(t)
+	c, err := cache.CacheFactory[string, *TestData](
+		cache.WithCodec[string, *TestData](newTestCodec()),
+		cache.WithRedisStorage[string, *TestData](client, "orders"),
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, c.SetWithTags("order:1", &TestData{ID: 1}, time.Minute, "user:1"))
+	require.NoError(t, c.SetWithTags("order:2", &TestData{ID: 2}, time.Hour, "user:1"))
+	require.NoError(t, c.SetWithTags("order:3", &TestData{ID: 3}, time.Hour, "user:2"))
+	assert.Equal(t, 3, c.Size(), "Множества тегов вне пространства имен значений")
+	// множество тега живет не меньше своего самого долгого ключа
+	assert.InDelta(t, time.Hour.Seconds(), srv.TTL("orders#tag:user:1").Seconds(), 1)
+
+	removed, err := c.InvalidateTag("user:1")
+	require.NoError(t, err)
+	assert.Equal(t, 2, removed)
+	removed, err = c.InvalidateTag("user:1")
+	require.NoError(t, err)
+	assert.Zero(t, removed, "Повторная инвалидация ничего не удаляет")
+	assertCached(t, c, false, "order:1", "order:2")
+	assertCached(t, c, true, "order:3")
+
+	require.NoError(t, c.Set("order:10", &TestData{}, time.Hour))
+	removed, err = c.InvalidatePrefix("order:1")
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+	assertCached(t, c, true, "order:3")
+
+	c.Clear()
+	assert.False(t, srv.Exists("orders#tag:user:2"))
+}
+
+func TestRedisStorage_TagsRewrite(t *testing.T) {
+	srv, client := newTestRedis(t)
+	c, err := cache.CacheFactory[string, *TestData](
+		cache.WithCodec[string, *TestData](newTestCodec()),
+		cache.WithRedisStorage[string, *TestData](client, "orders"),
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, c.SetWithTags("k", &TestData{ID: 1}, time.Hour, "old", "kept"))
+	// обычная перезапись сохраняет теги
+	require.NoError(t, c.Set("k", &TestData{ID: 2}, time.Hour))
+	require.NoError(t, c.SetWithTags("other", &TestData{ID: 3}, time.Hour, "old"))
+
+	// перезапись с тегами заменяет их
+	require.NoError(t, c.SetWithTags("k", &TestData{ID: 4}, time.Hour, "kept", "new"))
+	members, err := srv.SMembers("orders#tag:old")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"orders:other"}, members, "Ключ должен быть снят с прежнего тега")
+	assert.InDelta(t, time.Hour.Seconds(), srv.TTL("orders#keytags:k").Seconds(), 1)
+
+	removed, err := c.InvalidateTag("old")
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+	assertCached(t, c, true, "k")
+	assertCached(t, c, false, "other")
+
+	removed, err = c.InvalidateTag("new")
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+	assertCached(t, c, false, "k")
+
+	require.NoError(t, c.SetWithTags("k", &TestData{ID: 5}, time.Hour, "kept"))
+	require.NoError(t, c.SetWithTags("k", &TestData{ID: 6}, time.Hour))
+	removed, err = c.InvalidateTag("kept")
+	require.NoError(t, err)
+	assert.Zero(t, removed, "Перезапись без тегов снимает все прежние теги")
+	assertCached(t, c, true, "k")
+	assert.False(t, srv.Exists("orders#keytags:k"))
+
+	require.NoError(t, c.SetWithTags("k", &TestData{ID: 7}, time.Hour, "kept"))
+	assert.True(t, srv.Exists("orders#keytags:k"))
+	c.Clear()
+	assert.False(t, srv.Exists("orders#keytags:k"), "Clear удаляет теги ключей")
+}
+
+func TestRedisStorage_TagsFailure(t *testing.T) {
+	srv, client := newTestRedis(t)
+	c, err := cache.CacheFactory[string, *TestData](
+		cache.WithCodec[string, *TestData](newTestCodec()),
+		cache.WithRedisStorage[string, *TestData](client, "orders"),
+	)
+	require.NoError(t, err)
+
+	// множество тега занято значением другого типа, добавление ключа в тег завершится ошибкой
+	require.NoError(t, srv.Set("orders#tag:user:1", "broken"))
+	err = c.SetWithTags("order:1", &TestData{ID: 1}, time.Hour, "user:1")
+	require.Error(t, err)
+	_, ok := errors.AsType[*errs.DalCacheError](err)
+	assert.True(t, ok)
+	assert.False(t, srv.Exists("orders:order:1"), "Значение без тегов не должно быть записано")
+}
+
+func TestSnapshot_PreservesTags(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tags.snapshot")
+	log := newSnapshotTestLogger()
+
+	src := newTagTestManager(0)
+	require.NoError(t, src.SetWithTags("a", &TestData{ID: 1}, time.Hour, "tag"))
+	require.NoError(t, src.Set("b", &TestData{ID: 2}, time.Hour))
+	_, err := cache.NewManagerSnapshot("tags", path, src, log).Save(context.Background())
+	require.NoError(t, err)
+
+	dst := newTagTestManager(0)
+	loaded, err := cache.NewManagerSnapshot("tags", path, dst, log).Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, loaded)
+
+	removed, err := dst.InvalidateTag("tag")
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+	assertCached(t, dst, true, "b")
+}
+
+func TestNearCache_InvalidateTag(t *testing.T) {
+	_, client := newTestRedis(t)
+	dispatcher := pubsub.NewEventDispatcher[*cache.Invalidation[string]]("invalidation", time.Second, newNearTestLogger())
+	first := newTestReplica(t, client, dispatcher)
+	second := newTestReplica(t, client, dispatcher)
+
+	require.NoError(t, first.SetWithTags("user:1/a", &TestData{ID: 1}, time.Hour, "user:1"))
+	require.NoError(t, first.SetWithTags("user:1/b", &TestData{ID: 2}, time.Hour, "user:1"))
+	// L1 второй реплики заполнен из L2 без тегов
+	assertCached(t, second, true, "user:1/a", "user:1/b")
+
+	removed, err := first.InvalidateTag("user:1")
+	require.NoError(t, err)
+	assert.Equal(t, 2, removed)
+	assert.Zero(t, first.GetLocal().Size())
+	assert.Eventually(t, func() bool {
+		return second.GetLocal().Size() == 0
+	}, time.Second, 5*time.Millisecond)
+	assertCached(t, second, false, "user:1/a", "user:1/b")
+
+	require.NoError(t, first.Set("user:2/a", &TestData{ID: 3}, time.Hour))
+	assertCached(t, second, true, "user:2/a")
+	_, err = first.InvalidatePrefix("user:2/")
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		_, ok, _ := second.GetLocal().Get("user:2/a")
+		return !ok
+	}, time.Second, 5*time.Millisecond)
+}
+
+// untaggedStorage хранилище без индекса тегов
+type untaggedStorage struct {
+	cache.Storage[string]
+}
+
+func newUntaggedStorage() *untaggedStorage {
+	return &untaggedStorage{Storage: cache.NewRawStorage[string](0, cache.NewLRUEvict[string]())}
+}
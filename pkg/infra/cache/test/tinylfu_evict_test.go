@@ -0,0 +1,69 @@
+package test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/infra/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTinyLFUEvict(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		tl := cache.NewTinyLFUEvict[string](10)
+
+		_, ok := tl.Evict()
+		assert.False(t, ok)
+	})
+
+	t.Run("frequent_key_survives_scan", func(t *testing.T) {
+		const capacity = 100
+		tl := cache.NewTinyLFUEvict[string](capacity)
+		storage := cache.NewRawStorage[string](capacity, tl)
+
+		// популярный ключ с историей обращений
+		storage.Set("hot", []byte("v"))
+		for i := 0; i < 10; i++ {
+			_, _ = storage.Get("hot")
+		}
+
+		// однократное сканирование в несколько раз больше емкости
+		for i := 0; i < 5*capacity; i++ {
+			storage.Set(fmt.Sprintf("scan-%d", i), []byte("v"))
+		}
+
+		assert.True(t, storage.Has("hot"), "Популярный ключ не должен вытесняться сканированием")
+		assert.LessOrEqual(t, storage.Len(), capacity)
+	})
+
+	t.Run("rare_candidate_rejected", func(t *testing.T) {
+		tl := cache.NewTinyLFUEvict[string](4)
+
+		for _, key := range []string{"a", "b", "c"} {
+			tl.OnSet(key)
+			tl.OnGet(key)
+			tl.OnGet(key)
+		}
+		// окно заполнено ключом "new" без истории, он проигрывает жертве основной области
+		tl.OnSet("new")
+		key, ok := tl.Evict()
+		assert.True(t, ok)
+		assert.Equal(t, "new", key)
+	})
+
+	t.Run("remove_and_reset", func(t *testing.T) {
+		tl := cache.NewTinyLFUEvict[string](10)
+
+		tl.OnSet("a")
+		tl.OnSet("b")
+		tl.OnRemove("a")
+		key, ok := tl.Evict()
+		assert.True(t, ok)
+		assert.Equal(t, "b", key)
+
+		tl.OnSet("c")
+		tl.Reset()
+		_, ok = tl.Evict()
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,192 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+)
+
+// сегменты W-TinyLFU
+const (
+	tinyLFUWindow uint8 = iota
+	tinyLFUProbation
+	tinyLFUProtected
+)
+
+type tinyLFUItem[K comparable] struct {
+	key     K
+	segment uint8
+}
+
+// TinyLFUEvict - политика W-TinyLFU: новые ключи попадают в окно (LRU, ~1% емкости), вышедший из окна кандидат
+// допускается в основную область (SLRU: probation + protected 80%) только если по оценке count-min sketch
+// он популярнее жертвы основной области. Устойчива к однократным сканированиям.
+type TinyLFUEvict[K comparable] struct {
+	mu           sync.Mutex
+	sketch       *countMinSketch[K]
+	items        map[K]*list.Element
+	window       *list.List
+	probation    *list.List
+	protected    *list.List
+	windowCap    int
+	mainCap      int
+	protectedCap int
+	emptyKey     K
+}
+
+// NewTinyLFUEvict capacity - ожидаемая емкость хранилища (кол-во элементов)
+func NewTinyLFUEvict[K comparable](capacity int) *TinyLFUEvict[K] {
+	capacity = max(capacity, 2)
+	windowCap := max(capacity/100, 1)
+	mainCap := capacity - windowCap
+
+	return &TinyLFUEvict[K]{
+		sketch:       newCountMinSketch[K](capacity),
+		items:        make(map[K]*list.Element),
+		window:       list.New(),
+		probation:    list.New(),
+		protected:    list.New(),
+		windowCap:    windowCap,
+		mainCap:      mainCap,
+		protectedCap: max(mainCap*80/100, 1),
+	}
+}
+
+func (tl *TinyLFUEvict[K]) OnGet(key K) {
+	tl.mu.Lock()
+	defer tl.mu.Unlock()
+
+	tl.sketch.increment(key)
+	if el, ok := tl.items[key]; ok {
+		tl.touch(el)
+	}
+}
+
+func (tl *TinyLFUEvict[K]) OnSet(key K) {
+	tl.mu.Lock()
+	defer tl.mu.Unlock()
+
+	tl.sketch.increment(key)
+	if el, ok := tl.items[key]; ok {
+		tl.touch(el)
+
+		return
+	}
+
+	tl.items[key] = tl.window.PushFront(&tinyLFUItem[K]{key: key, segment: tinyLFUWindow})
+	// пока основная область не заполнена, кандидаты из окна переходят в нее без отбора
+	for tl.window.Len() > tl.windowCap && tl.mainLen() < tl.mainCap {
+		tl.moveTo(tl.window.Back(), tl.probation, tinyLFUProbation)
+	}
+}
+
+func (tl *TinyLFUEvict[K]) OnRemove(key K) {
+	tl.mu.Lock()
+	defer tl.mu.Unlock()
+
+	tl.remove(key)
+}
+
+func (tl *TinyLFUEvict[K]) Reset() {
+	tl.mu.Lock()
+	defer tl.mu.Unlock()
+
+	tl.items = make(map[K]*list.Element)
+	tl.window.Init()
+	tl.probation.Init()
+	tl.protected.Init()
+	tl.sketch.reset()
+}
+
+func (tl *TinyLFUEvict[K]) Evict() (K, bool) {
+	tl.mu.Lock()
+	defer tl.mu.Unlock()
+
+	victim := tl.mainVictim()
+	// окно заполнено (вытеснение выполняется перед записью нового ключа в окно):
+	// кандидат из окна соревнуется с жертвой основной области
+	if tl.window.Len() >= tl.windowCap {
+		candidate := tl.window.Back()
+		if victim == nil {
+			return tl.evict(candidate), true
+		}
+		candidateKey := candidate.Value.(*tinyLFUItem[K]).key
+		victimKey := victim.Value.(*tinyLFUItem[K]).key
+		if tl.sketch.estimate(candidateKey) > tl.sketch.estimate(victimKey) {
+			tl.moveTo(candidate, tl.probation, tinyLFUProbation)
+
+			return tl.evict(victim), true
+		}
+
+		return tl.evict(candidate), true
+	}
+	if victim == nil {
+		victim = tl.window.Back()
+	}
+	if victim == nil {
+		return tl.emptyKey, false
+	}
+
+	return tl.evict(victim), true
+}
+
+// --- Внутренние неблокирующие методы ---
+
+func (tl *TinyLFUEvict[K]) touch(el *list.Element) {
+	item := el.Value.(*tinyLFUItem[K])
+	switch item.segment {
+	case tinyLFUWindow:
+		tl.window.MoveToFront(el)
+	case tinyLFUProbation:
+		// повторное обращение - ключ переходит в защищенную область
+		tl.moveTo(el, tl.protected, tinyLFUProtected)
+		for tl.protected.Len() > tl.protectedCap {
+			tl.moveTo(tl.protected.Back(), tl.probation, tinyLFUProbation)
+		}
+	case tinyLFUProtected:
+		tl.protected.MoveToFront(el)
+	}
+}
+
+func (tl *TinyLFUEvict[K]) mainVictim() *list.Element {
+	if el := tl.probation.Back(); el != nil {
+		return el
+	}
+
+	return tl.protected.Back()
+}
+
+func (tl *TinyLFUEvict[K]) mainLen() int {
+	return tl.probation.Len() + tl.protected.Len()
+}
+
+func (tl *TinyLFUEvict[K]) segmentList(segment uint8) *list.List {
+	switch segment {
+	case tinyLFUProbation:
+		return tl.probation
+	case tinyLFUProtected:
+		return tl.protected
+	default:
+		return tl.window
+	}
+}
+
+func (tl *TinyLFUEvict[K]) moveTo(el *list.Element, dst *list.List, segment uint8) {
+	item := el.Value.(*tinyLFUItem[K])
+	tl.segmentList(item.segment).Remove(el)
+	item.segment = segment
+	tl.items[item.key] = dst.PushFront(item)
+}
+
+func (tl *TinyLFUEvict[K]) evict(el *list.Element) K {
+	item := el.Value.(*tinyLFUItem[K])
+	tl.segmentList(item.segment).Remove(el)
+	delete(tl.items, item.key)
+
+	return item.key
+}
+
+func (tl *TinyLFUEvict[K]) remove(key K) {
+	if el, ok := tl.items[key]; ok {
+		tl.evict(el)
+	}
+}
@@ -0,0 +1,177 @@
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Cache metrics
+var (
+	cacheHits = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "cache_hits_total",
+		Help: "Total number of cache hits",
+	}, []string{"cache"})
+
+	cacheMisses = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "cache_misses_total",
+		Help: "Total number of cache misses",
+	}, []string{"cache"})
+
+	cacheSets = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "cache_sets_total",
+		Help: "Total number of cache writes",
+	}, []string{"cache"})
+
+	cacheDeletes = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "cache_deletes_total",
+		Help: "Total number of cache deletes",
+	}, []string{"cache"})
+
+	cacheEvictions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "cache_evictions_total",
+		Help: "Total number of cache evictions by eviction policy",
+	}, []string{"cache", "policy"})
+
+	cacheExpired = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "cache_expired_on_read_total",
+		Help: "Total number of expired items removed on read",
+	}, []string{"cache"})
+
+	cacheErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "cache_errors_total",
+		Help: "Total number of cache storage errors",
+	}, []string{"cache", "op"})
+
+	cacheJanitorRuns = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "cache_janitor_runs_total",
+		Help: "Total number of cache janitor runs",
+	}, []string{"cache", "status"})
+
+	cacheJanitorRemoved = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "cache_janitor_removed_total",
+		Help: "Total number of expired items removed by cache janitor",
+	}, []string{"cache"})
+
+	cacheJanitorDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "cache_janitor_duration_seconds",
+		Help:    "Duration of cache janitor runs",
+		Buckets: []float64{0.0005, 0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5},
+	}, []string{"cache"})
+)
+
+// Gauge metrics - размер кэшей снимается в момент сбора метрик
+var (
+	cacheSizeItemsDesc = prometheus.NewDesc("cache_size_items", "Current number of items in cache", []string{"cache"}, nil)
+	cacheSizeBytesDesc = prometheus.NewDesc("cache_size_bytes", "Current size of cached data in bytes", []string{"cache"}, nil)
+
+	cacheSizes = newCacheSizeCollector()
+)
+
+func init() {
+	prometheus.MustRegister(cacheSizes)
+}
+
+func ObserveCacheHit(cache string) {
+	cacheHits.WithLabelValues(cache).Inc()
+}
+
+func ObserveCacheMiss(cache string) {
+	cacheMisses.WithLabelValues(cache).Inc()
+}
+
+func ObserveCacheSet(cache string) {
+	cacheSets.WithLabelValues(cache).Inc()
+}
+
+func ObserveCacheDelete(cache string) {
+	cacheDeletes.WithLabelValues(cache).Inc()
+}
+
+func ObserveCacheEviction(cache, policy string) {
+	cacheEvictions.WithLabelValues(cache, policy).Inc()
+}
+
+func ObserveCacheExpired(cache string) {
+	cacheExpired.WithLabelValues(cache).Inc()
+}
+
+func ObserveCacheError(cache, op string) {
+	cacheErrors.WithLabelValues(cache, op).Inc()
+}
+
+func ObserveCacheJanitor(cache string, removed int, err error, startTime time.Time) {
+	status := StatusSuccess
+	if err != nil {
+		status = StatusFail
+	}
+
+	cacheJanitorRuns.WithLabelValues(cache, status).Inc()
+	cacheJanitorRemoved.WithLabelValues(cache).Add(float64(removed))
+	cacheJanitorDuration.WithLabelValues(cache).Observe(time.Since(startTime).Seconds())
+}
+
+// RegisterCacheSize регистрация источника размера кэша, bytes может быть nil (размер в байтах неизвестен),
+// повторная регистрация с тем же именем заменяет источник
+func RegisterCacheSize(cache string, items func() int, bytes func() int64) {
+	cacheSizes.register(cache, items, bytes)
+}
+
+// UnregisterCacheSize удаление источника размера кэша
+func UnregisterCacheSize(cache string) {
+	cacheSizes.unregister(cache)
+}
+
+type cacheSizeSource struct {
+	items func() int
+	bytes func() int64
+}
+
+// cacheSizeCollector - сбор текущего размера зарегистрированных кэшей
+type cacheSizeCollector struct {
+	mu      sync.RWMutex
+	sources map[string]*cacheSizeSource
+}
+
+var _ prometheus.Collector = (*cacheSizeCollector)(nil)
+
+func newCacheSizeCollector() *cacheSizeCollector {
+	return &cacheSizeCollector{
+		sources: make(map[string]*cacheSizeSource),
+	}
+}
+
+func (csc *cacheSizeCollector) register(cache string, items func() int, bytes func() int64) {
+	csc.mu.Lock()
+	defer csc.mu.Unlock()
+
+	csc.sources[cache] = &cacheSizeSource{items: items, bytes: bytes}
+}
+
+func (csc *cacheSizeCollector) unregister(cache string) {
+	csc.mu.Lock()
+	defer csc.mu.Unlock()
+
+	delete(csc.sources, cache)
+}
+
+func (csc *cacheSizeCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- cacheSizeItemsDesc
+	ch <- cacheSizeBytesDesc
+}
+
+func (csc *cacheSizeCollector) Collect(ch chan<- prometheus.Metric) {
+	csc.mu.RLock()
+	defer csc.mu.RUnlock()
+
+	for cache, source := range csc.sources {
+		if source.items != nil {
+			ch <- prometheus.MustNewConstMetric(cacheSizeItemsDesc, prometheus.GaugeValue, float64(source.items()), cache)
+		}
+		if source.bytes != nil {
+			ch <- prometheus.MustNewConstMetric(cacheSizeBytesDesc, prometheus.GaugeValue, float64(source.bytes()), cache)
+		}
+	}
+}
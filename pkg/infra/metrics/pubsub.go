@@ -0,0 +1,46 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// PubSub metrics
+var (
+	pubsubQueueDepth = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "pubsub_queue_depth",
+		Help: "Current number of events waiting in dispatcher ordered queues",
+	}, []string{"dispatcher"})
+
+	pubsubRetries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "pubsub_delivery_retries_total",
+		Help: "Total number of event delivery retries after observer error",
+	}, []string{"dispatcher", "observer"})
+
+	pubsubDeadLetters = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "pubsub_dead_letters_total",
+		Help: "Total number of events not delivered after all attempts",
+	}, []string{"dispatcher", "observer"})
+
+	pubsubDropped = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "pubsub_dropped_total",
+		Help: "Total number of events dropped by dispatcher queue overflow policy",
+	}, []string{"dispatcher", "policy"})
+)
+
+// ObservePubSubQueueDepth изменение глубины очереди диспетчера на delta
+func ObservePubSubQueueDepth(dispatcher string, delta int) {
+	pubsubQueueDepth.WithLabelValues(dispatcher).Add(float64(delta))
+}
+
+func ObservePubSubRetry(dispatcher, observer string) {
+	pubsubRetries.WithLabelValues(dispatcher, observer).Inc()
+}
+
+func ObservePubSubDeadLetter(dispatcher, observer string) {
+	pubsubDeadLetters.WithLabelValues(dispatcher, observer).Inc()
+}
+
+func ObservePubSubDropped(dispatcher, policy string) {
+	pubsubDropped.WithLabelValues(dispatcher, policy).Inc()
+}
@@ -0,0 +1,39 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Scheduler metrics
+var (
+	schedulerNextRun = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "scheduler_next_run_timestamp_seconds",
+		Help: "Unix time of the next scheduled run, 0 - no run scheduled",
+	}, []string{"scheduler"})
+
+	schedulerRuns = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "scheduler_runs_total",
+		Help: "Total number of scheduler runs",
+	}, []string{"scheduler", "status"})
+)
+
+// ObserveSchedulerNextRun нулевое время - запуск не запланирован
+func ObserveSchedulerNextRun(scheduler string, next time.Time) {
+	if next.IsZero() {
+		schedulerNextRun.WithLabelValues(scheduler).Set(0)
+
+		return
+	}
+	schedulerNextRun.WithLabelValues(scheduler).Set(float64(next.UnixNano()) / float64(time.Second))
+}
+
+func ObserveSchedulerRun(scheduler string, err error) {
+	status := StatusSuccess
+	if err != nil {
+		status = StatusFail
+	}
+	schedulerRuns.WithLabelValues(scheduler, status).Inc()
+}
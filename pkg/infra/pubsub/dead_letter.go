@@ -0,0 +1,85 @@
+package pubsub
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultDeadLetterCapacity емкость MemoryDeadLetterStore по умолчанию
+const DefaultDeadLetterCapacity = 1000
+
+// DeadLetter - событие, не доставленное наблюдателю после всех попыток
+type DeadLetter[T any] struct {
+	Event T
+	// Observer имя наблюдателя, "" - событие не принято диспетчером (остановлен, отмена контекста)
+	Observer string
+	// Key ключ партиции упорядоченной доставки
+	Key      string
+	Attempts int
+	Err      error
+	FailedAt time.Time
+}
+
+// DeadLetterObserver - приемник недоставленных событий
+type DeadLetterObserver[T any] interface {
+	OnDeadLetter(ctx context.Context, letter *DeadLetter[T]) error
+}
+
+// MemoryDeadLetterStore - хранилище недоставленных событий в памяти, при переполнении вытесняются самые старые
+type MemoryDeadLetterStore[T any] struct {
+	mu       sync.Mutex
+	letters  []*DeadLetter[T]
+	capacity int
+}
+
+var _ DeadLetterObserver[string] = (*MemoryDeadLetterStore[string])(nil)
+
+func NewMemoryDeadLetterStore[T any](capacity int) *MemoryDeadLetterStore[T] {
+	if capacity <= 0 {
+		capacity = DefaultDeadLetterCapacity
+	}
+
+	return &MemoryDeadLetterStore[T]{
+		capacity: capacity,
+	}
+}
+
+func (ms *MemoryDeadLetterStore[T]) OnDeadLetter(_ context.Context, letter *DeadLetter[T]) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	if len(ms.letters) >= ms.capacity {
+		ms.letters[0] = nil
+		ms.letters = ms.letters[1:]
+	}
+	ms.letters = append(ms.letters, letter)
+
+	return nil
+}
+
+// List копия списка недоставленных событий
+func (ms *MemoryDeadLetterStore[T]) List() []*DeadLetter[T] {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	return append([]*DeadLetter[T](nil), ms.letters...)
+}
+
+// Drain извлечение всех недоставленных событий (например, для повторной отправки через Notify)
+func (ms *MemoryDeadLetterStore[T]) Drain() []*DeadLetter[T] {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	res := ms.letters
+	ms.letters = nil
+
+	return res
+}
+
+func (ms *MemoryDeadLetterStore[T]) Len() int {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	return len(ms.letters)
+}
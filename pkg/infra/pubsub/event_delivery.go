@@ -0,0 +1,457 @@
+package pubsub
+
+import (
+	"context"
+	"errors"
+	"hash/maphash"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/errs"
+	"github.com/ElfAstAhe/go-service-template/pkg/infra/metrics"
+	"github.com/ElfAstAhe/go-service-template/pkg/utils"
+)
+
+// OverflowPolicy поведение при заполненной очереди диспетчера
+type OverflowPolicy int
+
+const (
+	// OverflowBlock ожидание места в очереди до отмены контекста публикации
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDropOldest вытеснение самого старого события очереди в dead-letter
+	OverflowDropOldest
+	// OverflowDropNewest новое событие уходит в dead-letter, публикация не получает ошибку
+	OverflowDropNewest
+	// OverflowError новое событие уходит в dead-letter, публикация получает errs.QueueFullError
+	OverflowError
+)
+
+func (op OverflowPolicy) String() string {
+	switch op {
+	case OverflowBlock:
+		return "block"
+	case OverflowDropOldest:
+		return "drop-oldest"
+	case OverflowDropNewest:
+		return "drop-newest"
+	case OverflowError:
+		return "error"
+	default:
+		return "unknown"
+	}
+}
+
+// retryPolicy параметры повторной доставки
+type retryPolicy struct {
+	maxAttempts int
+	baseDelay   time.Duration
+	maxDelay    time.Duration
+}
+
+func (rp retryPolicy) backoff(attempt int) time.Duration {
+	// защита от переполнения сдвига
+	shift := min(attempt-1, 30)
+	delay := rp.baseDelay * time.Duration(1<<shift)
+	if delay <= 0 || delay > rp.maxDelay {
+		return rp.maxDelay
+	}
+
+	return delay
+}
+
+// syncResult ожидание и сбор ошибок доставки для NotifySync
+type syncResult struct {
+	wg   sync.WaitGroup
+	mu   sync.Mutex
+	errs []error
+}
+
+func (sr *syncResult) add() {
+	if sr != nil {
+		sr.wg.Add(1)
+	}
+}
+
+func (sr *syncResult) done(err error) {
+	if sr == nil {
+		return
+	}
+	if err != nil {
+		sr.mu.Lock()
+		sr.errs = append(sr.errs, err)
+		sr.mu.Unlock()
+	}
+	sr.wg.Done()
+}
+
+func (sr *syncResult) err() error {
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+
+	return errors.Join(sr.errs...)
+}
+
+// deliveryJob единица работы очереди, observer == nil - доставка всем наблюдателям по порядку имен
+type deliveryJob[T any] struct {
+	data     T
+	key      string
+	observer Observer[T]
+	result   *syncResult
+}
+
+// deliveryQueue ограниченные очереди доставки: упорядоченные партиции либо общий пул обработчиков
+type deliveryQueue[T any] struct {
+	mu         sync.RWMutex
+	key        PartitionKeyFunc[T]
+	seed       maphash.Seed
+	partitions int
+	workers    int
+	queueSize  int
+	policy     OverflowPolicy
+	queues     []chan deliveryJob[T]
+	running    atomic.Bool
+	stopping   chan struct{}
+	runCtx     context.Context
+	runCancel  context.CancelFunc
+	wg         sync.WaitGroup
+}
+
+// newOrderedQueue партиции с одним обработчиком на каждую, порядок событий одного ключа сохраняется
+func newOrderedQueue[T any](key PartitionKeyFunc[T], partitions int, queueSize int, policy OverflowPolicy) *deliveryQueue[T] {
+	return &deliveryQueue[T]{
+		key:        key,
+		seed:       maphash.MakeSeed(),
+		partitions: partitions,
+		workers:    1,
+		queueSize:  queueSize,
+		policy:     policy,
+	}
+}
+
+// newPoolQueue одна очередь с workers обработчиками, каждое задание - доставка одному наблюдателю
+func newPoolQueue[T any](workers int, queueSize int, policy OverflowPolicy) *deliveryQueue[T] {
+	return &deliveryQueue[T]{
+		partitions: 1,
+		workers:    workers,
+		queueSize:  queueSize,
+		policy:     policy,
+	}
+}
+
+func (dq *deliveryQueue[T]) isOrdered() bool {
+	return dq.key != nil
+}
+
+func (dq *deliveryQueue[T]) partition(key string) int {
+	if dq.partitions == 1 {
+		return 0
+	}
+
+	return int(maphash.String(dq.seed, key) % uint64(dq.partitions))
+}
+
+// Start запуск обработчиков очередей, в режиме рассылки ничего не делает
+func (ed *EventDispatcher[T]) Start(ctx context.Context) error {
+	dq := ed.queue
+	if dq == nil {
+		return nil
+	}
+
+	dq.mu.Lock()
+	defer dq.mu.Unlock()
+	if dq.running.Load() {
+		return errs.NewCommonError("pub/sub event dispatcher "+ed.GetName()+" already running", nil)
+	}
+
+	dq.runCtx, dq.runCancel = context.WithCancel(context.WithoutCancel(ctx))
+	dq.stopping = make(chan struct{})
+	dq.queues = make([]chan deliveryJob[T], dq.partitions)
+	for i := range dq.queues {
+		dq.queues[i] = make(chan deliveryJob[T], dq.queueSize)
+		for range dq.workers {
+			dq.wg.Add(1)
+			go ed.processQueue(dq.runCtx, dq.queues[i])
+		}
+	}
+	dq.running.Store(true)
+
+	return nil
+}
+
+// Stop остановка приема событий и дообработка очередей, при отмене stopCtx оставшиеся события уходят в dead-letter
+func (ed *EventDispatcher[T]) Stop(stopCtx context.Context) error {
+	dq := ed.queue
+	if dq == nil || !dq.running.Swap(false) {
+		return nil
+	}
+
+	// разблокировать ожидающих Notify до захвата блокировки
+	close(dq.stopping)
+	dq.mu.Lock()
+	for _, queue := range dq.queues {
+		close(queue)
+	}
+	dq.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		dq.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		dq.runCancel()
+		return nil
+	case <-stopCtx.Done():
+		// попытки доставки прерываются, остаток очередей уходит в dead-letter
+		dq.runCancel()
+		<-done
+
+		return errs.NewCommonError("pub/sub event dispatcher "+ed.GetName()+" stop timeout", stopCtx.Err())
+	}
+}
+
+// Close корректное завершение: прием событий прекращается, накопленные очереди дообрабатываются до отмены ctx
+func (ed *EventDispatcher[T]) Close(ctx context.Context) error {
+	return ed.Stop(ctx)
+}
+
+// IsRunning в режиме рассылки диспетчер всегда готов к работе
+func (ed *EventDispatcher[T]) IsRunning() bool {
+	if ed.queue == nil {
+		return true
+	}
+
+	return ed.queue.running.Load()
+}
+
+// submit постановка события в очереди: одно задание в упорядоченном режиме, по заданию на наблюдателя в режиме пула
+func (ed *EventDispatcher[T]) submit(ctx context.Context, data T, result *syncResult) error {
+	dq := ed.queue
+	if dq.isOrdered() {
+		return ed.enqueue(ctx, deliveryJob[T]{data: data, key: dq.key(data), result: result})
+	}
+
+	var submitErrs []error
+	for _, observer := range ed.snapshotObservers(false) {
+		if err := ed.enqueue(ctx, deliveryJob[T]{data: data, observer: observer, result: result}); err != nil {
+			submitErrs = append(submitErrs, err)
+		}
+	}
+
+	return errors.Join(submitErrs...)
+}
+
+// enqueue постановка задания в очередь с учетом политики переполнения, отклоненное задание уходит в dead-letter
+func (ed *EventDispatcher[T]) enqueue(ctx context.Context, job deliveryJob[T]) error {
+	dq := ed.queue
+	job.result.add()
+
+	dq.mu.RLock()
+	err := ed.push(ctx, job)
+	dq.mu.RUnlock()
+	if err == nil {
+		return nil
+	}
+
+	ed.reject(ctx, job, err)
+	var queueFull *errs.QueueFullError
+	if dq.policy == OverflowDropNewest && errors.As(err, &queueFull) {
+		return nil
+	}
+
+	return err
+}
+
+// push вызывается под блокировкой чтения очереди
+func (ed *EventDispatcher[T]) push(ctx context.Context, job deliveryJob[T]) error {
+	dq := ed.queue
+	if !dq.running.Load() {
+		return errs.NewCommonError("pub/sub event dispatcher "+ed.GetName()+" is not running", nil)
+	}
+
+	queue := dq.queues[dq.partition(job.key)]
+	switch dq.policy {
+	case OverflowDropOldest:
+		for {
+			select {
+			case queue <- job:
+				metrics.ObservePubSubQueueDepth(ed.GetName(), 1)
+				return nil
+			default:
+			}
+			select {
+			case oldest := <-queue:
+				metrics.ObservePubSubQueueDepth(ed.GetName(), -1)
+				metrics.ObservePubSubDropped(ed.GetName(), dq.policy.String())
+				ed.reject(ctx, oldest, errs.NewQueueFullError(ed.GetName(), cap(queue), nil))
+			default:
+			}
+		}
+	case OverflowDropNewest, OverflowError:
+		select {
+		case queue <- job:
+			metrics.ObservePubSubQueueDepth(ed.GetName(), 1)
+			return nil
+		default:
+			metrics.ObservePubSubDropped(ed.GetName(), dq.policy.String())
+			return errs.NewQueueFullError(ed.GetName(), cap(queue), nil)
+		}
+	default:
+		select {
+		case queue <- job:
+			metrics.ObservePubSubQueueDepth(ed.GetName(), 1)
+			return nil
+		case <-ctx.Done():
+			return errs.NewCommonError("pub/sub event dispatcher "+ed.GetName()+" enqueue canceled", ctx.Err())
+		case <-dq.stopping:
+			return errs.NewCommonError("pub/sub event dispatcher "+ed.GetName()+" is stopping", nil)
+		}
+	}
+}
+
+// reject задание не попало в очередь либо вытеснено из нее
+func (ed *EventDispatcher[T]) reject(ctx context.Context, job deliveryJob[T], cause error) {
+	observer := ""
+	if job.observer != nil {
+		observer = job.observer.GetName()
+	}
+	ed.sendDeadLetter(ctx, job.data, observer, job.key, 0, cause)
+	job.result.done(cause)
+}
+
+func (ed *EventDispatcher[T]) processQueue(ctx context.Context, queue <-chan deliveryJob[T]) {
+	defer ed.queue.wg.Done()
+
+	for job := range queue {
+		metrics.ObservePubSubQueueDepth(ed.GetName(), -1)
+		if job.observer != nil {
+			job.result.done(ed.deliver(ctx, job.observer, job.data, job.key))
+			continue
+		}
+
+		var jobErrs []error
+		for _, observer := range ed.snapshotObservers(true) {
+			if err := ed.deliver(ctx, observer, job.data, job.key); err != nil {
+				jobErrs = append(jobErrs, err)
+			}
+		}
+		job.result.done(errors.Join(jobErrs...))
+	}
+}
+
+// snapshotObservers копия списка наблюдателей, sorted - в порядке имен для детерминированной доставки
+func (ed *EventDispatcher[T]) snapshotObservers(sorted bool) []Observer[T] {
+	ed.mu.RLock()
+	observers := make([]Observer[T], 0, len(ed.observers))
+	for _, observer := range ed.observers {
+		observers = append(observers, observer)
+	}
+	ed.mu.RUnlock()
+
+	if sorted {
+		slices.SortFunc(observers, func(a, b Observer[T]) int {
+			return strings.Compare(a.GetName(), b.GetName())
+		})
+	}
+
+	return observers
+}
+
+// deliver доставка события наблюдателю с повторами, по исчерпании попыток событие уходит в dead-letter.
+// Постоянная ошибка (errs.TlPermanentError) не повторяется, событие сразу уходит в dead-letter.
+func (ed *EventDispatcher[T]) deliver(ctx context.Context, observer Observer[T], data T, key string) error {
+	var (
+		err       error
+		panicked  bool
+		attempt   int
+		permanent *errs.TlPermanentError
+	)
+	for attempt = 1; ; attempt++ {
+		panicked, err = ed.notifyObserver(ctx, observer, data)
+		if err == nil {
+			return nil
+		}
+		if attempt >= ed.retry.maxAttempts || ctx.Err() != nil || errors.As(err, &permanent) {
+			break
+		}
+
+		metrics.ObservePubSubRetry(ed.GetName(), observer.GetName())
+		delay := ed.retry.backoff(attempt)
+		ed.logger.Warnf("pub/sub event dispatcher %s observer %s attempt %d failed, retry in %v: %v", ed.GetName(), observer.GetName(), attempt, delay, err)
+		if !sleepCtx(ctx, delay) {
+			break
+		}
+	}
+	// паника уже залогирована в notifyObserver
+	if !panicked {
+		ed.logger.Errorf("pub/sub event dispatcher %s observer %s on notify got error %v", ed.GetName(), observer.GetName(), err)
+	}
+
+	ed.sendDeadLetter(ctx, data, observer.GetName(), key, attempt, err)
+
+	return err
+}
+
+// notifyObserver одна попытка доставки с таймаутом notifyTimeout
+func (ed *EventDispatcher[T]) notifyObserver(ctx context.Context, observer Observer[T], data T) (panicked bool, err error) {
+	ed.logger.Debugf("pub/sub event dispatcher %s observer %s start", ed.GetName(), observer.GetName())
+	defer ed.logger.Debugf("pub/sub event dispatcher %s observer %s finish", ed.GetName(), observer.GetName())
+
+	attemptCtx, cancel := context.WithTimeout(ctx, ed.notifyTimeout)
+	defer cancel()
+
+	defer func() {
+		if r := recover(); r != nil {
+			panicked, err = true, panicError(r)
+			ed.logger.Errorf("pub/sub event dispatcher %s observer %s panic recovery %v", ed.GetName(), observer.GetName(), err)
+		}
+	}()
+
+	return false, observer.OnNotify(attemptCtx, data)
+}
+
+// sendDeadLetter передача недоставленного события приемнику, контекст отвязан от отмены
+func (ed *EventDispatcher[T]) sendDeadLetter(ctx context.Context, data T, observer string, key string, attempts int, cause error) {
+	metrics.ObservePubSubDeadLetter(ed.GetName(), observer)
+	if utils.IsNil(ed.deadLetter) {
+		return
+	}
+
+	dlCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), ed.notifyTimeout)
+	defer cancel()
+
+	defer func() {
+		if r := recover(); r != nil {
+			ed.logger.Errorf("pub/sub event dispatcher %s dead letter panic recovery %v", ed.GetName(), panicError(r))
+		}
+	}()
+
+	letter := &DeadLetter[T]{
+		Event:    data,
+		Observer: observer,
+		Key:      key,
+		Attempts: attempts,
+		Err:      cause,
+		FailedAt: time.Now(),
+	}
+	if err := ed.deadLetter.OnDeadLetter(dlCtx, letter); err != nil {
+		ed.logger.Errorf("pub/sub event dispatcher %s dead letter got error %v", ed.GetName(), err)
+	}
+}
+
+func sleepCtx(ctx context.Context, delay time.Duration) bool {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/ElfAstAhe/go-service-template/pkg/container"
 	"github.com/ElfAstAhe/go-service-template/pkg/errs"
 	"github.com/ElfAstAhe/go-service-template/pkg/logger"
 	"github.com/ElfAstAhe/go-service-template/pkg/utils"
@@ -21,21 +22,33 @@ type EventDispatcher[T any] struct {
 	observers     map[string]Observer[T]
 	notifyTimeout time.Duration
 	logger        logger.Logger
+	retry         retryPolicy
+	deadLetter    DeadLetterObserver[T]
+	overflow      OverflowPolicy
+	queue         *deliveryQueue[T]
 }
 
-var _ Publisher[string] = (*EventDispatcher[string])(nil)
+var (
+	_ Publisher[string] = (*EventDispatcher[string])(nil)
+	_ container.Runner  = (*EventDispatcher[string])(nil)
+)
 
-func NewEventDispatcher[T any](name string, notifyTimeout time.Duration, log logger.Logger) *EventDispatcher[T] {
+// NewEventDispatcher диспетчер событий, по умолчанию рассылает событие всем наблюдателям параллельно без повторов
+func NewEventDispatcher[T any](name string, notifyTimeout time.Duration, log logger.Logger, opts ...EventDispatcherOption[T]) *EventDispatcher[T] {
 	res := &EventDispatcher[T]{
 		name:          name,
 		notifyTimeout: notifyTimeout,
 		logger:        log.GetLogger(name),
 		observers:     make(map[string]Observer[T]),
+		retry:         retryPolicy{maxAttempts: 1, baseDelay: DefaultRetryBaseDelay, maxDelay: DefaultRetryMaxDelay},
 	}
 	// check for correct timeout
 	if res.notifyTimeout <= 0 {
 		res.notifyTimeout = DefaultNotifyTimeout
 	}
+	for _, opt := range opts {
+		opt(res)
+	}
 
 	return res
 }
@@ -64,17 +77,18 @@ func (ed *EventDispatcher[T]) Notify(ctx context.Context, data T) {
 	ed.logger.Debugf("pub/sub event dispatcher %s Notify start", ed.GetName())
 	defer ed.logger.Debugf("pub/sub event dispatcher %s Notify finish", ed.GetName())
 
-	ed.mu.RLock()
-	if len(ed.observers) == 0 {
-		ed.mu.RUnlock()
+	if ed.queue != nil {
+		if err := ed.submit(ctx, data, nil); err != nil {
+			ed.logger.Warnf("pub/sub event dispatcher %s Notify rejected: %v", ed.GetName(), err)
+		}
+
 		return
 	}
 
-	observers := make([]Observer[T], 0, len(ed.observers))
-	for _, observer := range ed.observers {
-		observers = append(observers, observer)
+	observers := ed.snapshotObservers(false)
+	if len(observers) == 0 {
+		return
 	}
-	ed.mu.RUnlock()
 
 	go ed.internalNotify(context.WithoutCancel(ctx), data, observers)
 }
@@ -84,39 +98,70 @@ func (ed *EventDispatcher[T]) internalNotify(ctx context.Context, data T, observ
 	defer ed.logger.Debugf("pub/sub event dispatcher %s internalNotify finish", ed.GetName())
 
 	var wg sync.WaitGroup
-	asyncCtx, asyncCancel := context.WithTimeout(ctx, ed.notifyTimeout)
-	defer asyncCancel()
-
 	for _, observer := range observers {
-		obs := observer
 		wg.Add(1)
 		go func(observe Observer[T]) {
-			ed.logger.Debugf("pub/sub event dispatcher %s observer %s start", ed.GetName(), observe.GetName())
-			defer ed.logger.Debugf("pub/sub event dispatcher %s observer %s finish", ed.GetName(), observe.GetName())
-
 			defer wg.Done()
 
-			defer func() {
-				if r := recover(); r != nil {
-					// Превращаем панику в читаемую ошибку для логов
-					var recoveryErr error
-					if e, ok := r.(error); ok {
-						recoveryErr = errs.NewCommonError("panic recovery", e)
-					} else {
-						recoveryErr = errs.NewCommonError(fmt.Sprintf("panic recovery [%v]", r), nil)
-					}
-
-					ed.logger.Errorf("pub/sub event dispatcher %s observer %s panic recovery %v", ed.GetName(), observe.GetName(), recoveryErr)
-				}
-			}()
-			if err := observe.OnNotify(asyncCtx, data); err != nil {
-				ed.logger.Errorf("pub/sub event dispatcher %s observer %s on notify got error %v", ed.GetName(), observe.GetName(), err)
-			}
-		}(obs)
+			ed.deliver(ctx, observe, data, "")
+		}(observer)
 	}
 	wg.Wait()
 }
 
+// TryNotify асинхронная публикация с ошибкой постановки в очередь (переполнение при OverflowError, остановка, отмена ctx),
+// в режиме рассылки аналог Notify
+func (ed *EventDispatcher[T]) TryNotify(ctx context.Context, data T) error {
+	if ed.queue == nil {
+		ed.Notify(ctx, data)
+
+		return nil
+	}
+
+	return ed.submit(ctx, data, nil)
+}
+
+// NotifySync публикация с ожиданием доставки всем наблюдателям, возвращает errors.Join ошибок наблюдателей и постановки в очередь
+func (ed *EventDispatcher[T]) NotifySync(ctx context.Context, data T) error {
+	ed.logger.Debugf("pub/sub event dispatcher %s NotifySync start", ed.GetName())
+	defer ed.logger.Debugf("pub/sub event dispatcher %s NotifySync finish", ed.GetName())
+
+	result := &syncResult{}
+	if ed.queue != nil {
+		// ошибки постановки уже учтены в result
+		_ = ed.submit(ctx, data, result)
+	} else {
+		for _, observer := range ed.snapshotObservers(false) {
+			result.add()
+			go func(observe Observer[T]) {
+				result.done(ed.deliver(ctx, observe, data, ""))
+			}(observer)
+		}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		result.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return result.err()
+	case <-ctx.Done():
+		return errs.NewCommonError("pub/sub event dispatcher "+ed.GetName()+" NotifySync canceled", ctx.Err())
+	}
+}
+
 func (ed *EventDispatcher[T]) GetName() string {
 	return ed.name
 }
+
+// panicError превращение паники наблюдателя в читаемую ошибку для логов
+func panicError(r any) error {
+	if e, ok := r.(error); ok {
+		return errs.NewCommonError("panic recovery", e)
+	}
+
+	return errs.NewCommonError(fmt.Sprintf("panic recovery [%v]", r), nil)
+}
@@ -0,0 +1,89 @@
+package pubsub
+
+import (
+	"time"
+)
+
+const (
+	DefaultOrderedPartitions = 8
+	DefaultOrderedQueueSize  = 256
+	DefaultPoolWorkers       = 8
+	DefaultPoolQueueSize     = 1024
+	DefaultRetryBaseDelay    = 100 * time.Millisecond
+	DefaultRetryMaxDelay     = 5 * time.Second
+)
+
+// PartitionKeyFunc извлечение ключа партиции из события, события с одним ключом доставляются строго по порядку
+type PartitionKeyFunc[T any] func(T) string
+
+type EventDispatcherOption[T any] func(*EventDispatcher[T])
+
+// WithOrderedDelivery упорядоченная доставка: события распределяются по ключу между partitions очередями емкостью queueSize,
+// каждая очередь обслуживается одной горутиной, наблюдатели вызываются последовательно.
+// Требует запуска диспетчера через Start
+func WithOrderedDelivery[T any](key PartitionKeyFunc[T], partitions int, queueSize int) EventDispatcherOption[T] {
+	return func(ed *EventDispatcher[T]) {
+		if key == nil {
+			return
+		}
+		if partitions <= 0 {
+			partitions = DefaultOrderedPartitions
+		}
+		if queueSize <= 0 {
+			queueSize = DefaultOrderedQueueSize
+		}
+		ed.queue = newOrderedQueue[T](key, partitions, queueSize, ed.overflow)
+	}
+}
+
+// WithWorkerPool доставка пулом из workers обработчиков через общую очередь емкостью queueSize,
+// число горутин не зависит от потока событий. Требует запуска диспетчера через Start
+func WithWorkerPool[T any](workers int, queueSize int) EventDispatcherOption[T] {
+	return func(ed *EventDispatcher[T]) {
+		if workers <= 0 {
+			workers = DefaultPoolWorkers
+		}
+		if queueSize <= 0 {
+			queueSize = DefaultPoolQueueSize
+		}
+		ed.queue = newPoolQueue[T](workers, queueSize, ed.overflow)
+	}
+}
+
+// WithOverflowPolicy поведение при заполненной очереди (по умолчанию OverflowBlock), порядок относительно режима доставки не важен
+func WithOverflowPolicy[T any](policy OverflowPolicy) EventDispatcherOption[T] {
+	return func(ed *EventDispatcher[T]) {
+		ed.overflow = policy
+		if ed.queue != nil {
+			ed.queue.policy = policy
+		}
+	}
+}
+
+// WithRetry повторная доставка при ошибке наблюдателя (кроме errs.TlPermanentError),
+// задержка между попытками растет экспоненциально от baseDelay до maxDelay
+func WithRetry[T any](maxAttempts int, baseDelay time.Duration, maxDelay time.Duration) EventDispatcherOption[T] {
+	return func(ed *EventDispatcher[T]) {
+		if maxAttempts < 1 {
+			maxAttempts = 1
+		}
+		if baseDelay <= 0 {
+			baseDelay = DefaultRetryBaseDelay
+		}
+		if maxDelay < baseDelay {
+			maxDelay = max(baseDelay, DefaultRetryMaxDelay)
+		}
+		ed.retry = retryPolicy{
+			maxAttempts: maxAttempts,
+			baseDelay:   baseDelay,
+			maxDelay:    maxDelay,
+		}
+	}
+}
+
+// WithDeadLetter приемник событий, не доставленных после всех попыток
+func WithDeadLetter[T any](deadLetter DeadLetterObserver[T]) EventDispatcherOption[T] {
+	return func(ed *EventDispatcher[T]) {
+		ed.deadLetter = deadLetter
+	}
+}
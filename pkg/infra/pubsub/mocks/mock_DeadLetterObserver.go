@@ -0,0 +1,96 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/infra/pubsub"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockDeadLetterObserver creates a new instance of MockDeadLetterObserver. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDeadLetterObserver[T any](t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDeadLetterObserver[T] {
+	mock := &MockDeadLetterObserver[T]{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockDeadLetterObserver is an autogenerated mock type for the DeadLetterObserver type
+type MockDeadLetterObserver[T any] struct {
+	mock.Mock
+}
+
+type MockDeadLetterObserver_Expecter[T any] struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDeadLetterObserver[T]) EXPECT() *MockDeadLetterObserver_Expecter[T] {
+	return &MockDeadLetterObserver_Expecter[T]{mock: &_m.Mock}
+}
+
+// OnDeadLetter provides a mock function for the type MockDeadLetterObserver
+func (_mock *MockDeadLetterObserver[T]) OnDeadLetter(ctx context.Context, letter *pubsub.DeadLetter[T]) error {
+	ret := _mock.Called(ctx, letter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OnDeadLetter")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *pubsub.DeadLetter[T]) error); ok {
+		r0 = returnFunc(ctx, letter)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDeadLetterObserver_OnDeadLetter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OnDeadLetter'
+type MockDeadLetterObserver_OnDeadLetter_Call[T any] struct {
+	*mock.Call
+}
+
+// OnDeadLetter is a helper method to define mock.On call
+//   - ctx context.Context
+//   - letter *pubsub.DeadLetter[T]
+func (_e *MockDeadLetterObserver_Expecter[T]) OnDeadLetter(ctx any, letter any) *MockDeadLetterObserver_OnDeadLetter_Call[T] {
+	return &MockDeadLetterObserver_OnDeadLetter_Call[T]{Call: _e.mock.On("OnDeadLetter", ctx, letter)}
+}
+
+func (_c *MockDeadLetterObserver_OnDeadLetter_Call[T]) Run(run func(ctx context.Context, letter *pubsub.DeadLetter[T])) *MockDeadLetterObserver_OnDeadLetter_Call[T] {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *pubsub.DeadLetter[T]
+		if args[1] != nil {
+			arg1 = args[1].(*pubsub.DeadLetter[T])
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeadLetterObserver_OnDeadLetter_Call[T]) Return(err error) *MockDeadLetterObserver_OnDeadLetter_Call[T] {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDeadLetterObserver_OnDeadLetter_Call[T]) RunAndReturn(run func(ctx context.Context, letter *pubsub.DeadLetter[T]) error) *MockDeadLetterObserver_OnDeadLetter_Call[T] {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,95 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockSyncPublisher creates a new instance of MockSyncPublisher. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSyncPublisher[T any](t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSyncPublisher[T] {
+	mock := &MockSyncPublisher[T]{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockSyncPublisher is an autogenerated mock type for the SyncPublisher type
+type MockSyncPublisher[T any] struct {
+	mock.Mock
+}
+
+type MockSyncPublisher_Expecter[T any] struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSyncPublisher[T]) EXPECT() *MockSyncPublisher_Expecter[T] {
+	return &MockSyncPublisher_Expecter[T]{mock: &_m.Mock}
+}
+
+// NotifySync provides a mock function for the type MockSyncPublisher
+func (_mock *MockSyncPublisher[T]) NotifySync(context1 context.Context, v T) error {
+	ret := _mock.Called(context1, v)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NotifySync")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, T) error); ok {
+		r0 = returnFunc(context1, v)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSyncPublisher_NotifySync_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NotifySync'
+type MockSyncPublisher_NotifySync_Call[T any] struct {
+	*mock.Call
+}
+
+// NotifySync is a helper method to define mock.On call
+//   - context1 context.Context
+//   - v T
+func (_e *MockSyncPublisher_Expecter[T]) NotifySync(context1 any, v any) *MockSyncPublisher_NotifySync_Call[T] {
+	return &MockSyncPublisher_NotifySync_Call[T]{Call: _e.mock.On("NotifySync", context1, v)}
+}
+
+func (_c *MockSyncPublisher_NotifySync_Call[T]) Run(run func(context1 context.Context, v T)) *MockSyncPublisher_NotifySync_Call[T] {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 T
+		if args[1] != nil {
+			arg1 = args[1].(T)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSyncPublisher_NotifySync_Call[T]) Return(err error) *MockSyncPublisher_NotifySync_Call[T] {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSyncPublisher_NotifySync_Call[T]) RunAndReturn(run func(context1 context.Context, v T) error) *MockSyncPublisher_NotifySync_Call[T] {
+	_c.Call.Return(run)
+	return _c
+}
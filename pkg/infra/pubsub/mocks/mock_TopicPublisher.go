@@ -0,0 +1,101 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockTopicPublisher creates a new instance of MockTopicPublisher. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTopicPublisher(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTopicPublisher {
+	mock := &MockTopicPublisher{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockTopicPublisher is an autogenerated mock type for the TopicPublisher type
+type MockTopicPublisher struct {
+	mock.Mock
+}
+
+type MockTopicPublisher_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTopicPublisher) EXPECT() *MockTopicPublisher_Expecter {
+	return &MockTopicPublisher_Expecter{mock: &_m.Mock}
+}
+
+// Publish provides a mock function for the type MockTopicPublisher
+func (_mock *MockTopicPublisher) Publish(ctx context.Context, topic string, payload any) error {
+	ret := _mock.Called(ctx, topic, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Publish")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, any) error); ok {
+		r0 = returnFunc(ctx, topic, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTopicPublisher_Publish_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Publish'
+type MockTopicPublisher_Publish_Call struct {
+	*mock.Call
+}
+
+// Publish is a helper method to define mock.On call
+//   - ctx context.Context
+//   - topic string
+//   - payload any
+func (_e *MockTopicPublisher_Expecter) Publish(ctx any, topic any, payload any) *MockTopicPublisher_Publish_Call {
+	return &MockTopicPublisher_Publish_Call{Call: _e.mock.On("Publish", ctx, topic, payload)}
+}
+
+func (_c *MockTopicPublisher_Publish_Call) Run(run func(ctx context.Context, topic string, payload any)) *MockTopicPublisher_Publish_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 any
+		if args[2] != nil {
+			arg2 = args[2].(any)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTopicPublisher_Publish_Call) Return(err error) *MockTopicPublisher_Publish_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTopicPublisher_Publish_Call) RunAndReturn(run func(ctx context.Context, topic string, payload any) error) *MockTopicPublisher_Publish_Call {
+	_c.Call.Return(run)
+	return _c
+}
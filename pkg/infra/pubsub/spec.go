@@ -15,3 +15,13 @@ type Observer[T any] interface {
 	GetName() string
 	OnNotify(context.Context, T) error
 }
+
+// TopicPublisher публикация событий в именованные топики
+type TopicPublisher interface {
+	Publish(ctx context.Context, topic string, payload any) error
+}
+
+// SyncPublisher публикация с ожиданием доставки всем наблюдателям
+type SyncPublisher[T any] interface {
+	NotifySync(context.Context, T) error
+}
@@ -0,0 +1,233 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/errs"
+	"github.com/ElfAstAhe/go-service-template/pkg/infra/pubsub"
+	pubsubmocks "github.com/ElfAstAhe/go-service-template/pkg/infra/pubsub/mocks"
+	loggermocks "github.com/ElfAstAhe/go-service-template/pkg/logger/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type orderEvent struct {
+	Key string
+	Seq int
+}
+
+// recordingObserver запоминает порядок событий, до failTimes раз возвращает ошибку
+type recordingObserver struct {
+	name      string
+	failTimes int32
+	calls     atomic.Int32
+	mu        sync.Mutex
+	received  map[string][]int
+	delivered chan struct{}
+}
+
+func newRecordingObserver(name string, failTimes int32) *recordingObserver {
+	return &recordingObserver{
+		name:      name,
+		failTimes: failTimes,
+		received:  make(map[string][]int),
+		delivered: make(chan struct{}, 1024),
+	}
+}
+
+func (ro *recordingObserver) GetName() string {
+	return ro.name
+}
+
+func (ro *recordingObserver) OnNotify(_ context.Context, event orderEvent) error {
+	if ro.calls.Add(1) <= ro.failTimes {
+		return errors.New("temporary failure")
+	}
+	ro.mu.Lock()
+	ro.received[event.Key] = append(ro.received[event.Key], event.Seq)
+	ro.mu.Unlock()
+	ro.delivered <- struct{}{}
+
+	return nil
+}
+
+func (ro *recordingObserver) sequence(key string) []int {
+	ro.mu.Lock()
+	defer ro.mu.Unlock()
+
+	return append([]int(nil), ro.received[key]...)
+}
+
+func newDeliveryLogger() *loggermocks.MockLogger {
+	mockLog := &loggermocks.MockLogger{}
+	mockLog.On("GetLogger", mock.Anything).Return(mockLog)
+	mockLog.On("Debugf", mock.Anything, mock.Anything, mock.Anything).Maybe()
+	mockLog.On("Warnf", mock.Anything, mock.Anything).Maybe()
+	mockLog.On("Errorf", mock.Anything, mock.Anything).Maybe()
+
+	return mockLog
+}
+
+func orderKey(event orderEvent) string {
+	return event.Key
+}
+
+func waitDelivered(t *testing.T, ch <-chan struct{}, count int) {
+	t.Helper()
+	for range count {
+		select {
+		case <-ch:
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for delivery")
+		}
+	}
+}
+
+func TestEventDispatcher_OrderedDeliveryPerKey(t *testing.T) {
+	dispatcher := pubsub.NewEventDispatcher[orderEvent]("ordered", time.Second, newDeliveryLogger(),
+		pubsub.WithOrderedDelivery(orderKey, 4, 16),
+	)
+	obs := newRecordingObserver("recorder", 0)
+	dispatcher.Register(obs)
+	require.NoError(t, dispatcher.Start(context.Background()))
+	assert.True(t, dispatcher.IsRunning())
+
+	keys := []string{"a", "b", "c"}
+	const perKey = 50
+	for seq := range perKey {
+		for _, key := range keys {
+			dispatcher.Notify(context.Background(), orderEvent{Key: key, Seq: seq})
+		}
+	}
+	waitDelivered(t, obs.delivered, perKey*len(keys))
+	require.NoError(t, dispatcher.Stop(context.Background()))
+	assert.False(t, dispatcher.IsRunning())
+
+	for _, key := range keys {
+		seq := obs.sequence(key)
+		require.Len(t, seq, perKey, key)
+		for i, v := range seq {
+			assert.Equal(t, i, v, "key %s out of order", key)
+		}
+	}
+}
+
+func TestEventDispatcher_RetryThenSuccess(t *testing.T) {
+	store := pubsub.NewMemoryDeadLetterStore[orderEvent](10)
+	dispatcher := pubsub.NewEventDispatcher[orderEvent]("retry", time.Second, newDeliveryLogger(),
+		pubsub.WithRetry[orderEvent](3, time.Millisecond, 5*time.Millisecond),
+		pubsub.WithDeadLetter[orderEvent](store),
+	)
+	obs := newRecordingObserver("flaky", 2)
+	dispatcher.Register(obs)
+
+	dispatcher.Notify(context.Background(), orderEvent{Key: "a", Seq: 1})
+	waitDelivered(t, obs.delivered, 1)
+
+	assert.Equal(t, int32(3), obs.calls.Load())
+	assert.Equal(t, 0, store.Len())
+}
+
+func TestEventDispatcher_DeadLetterAfterRetries(t *testing.T) {
+	store := pubsub.NewMemoryDeadLetterStore[orderEvent](10)
+	dispatcher := pubsub.NewEventDispatcher[orderEvent]("dead-letter", time.Second, newDeliveryLogger(),
+		pubsub.WithOrderedDelivery(orderKey, 1, 4),
+		pubsub.WithRetry[orderEvent](3, time.Millisecond, 2*time.Millisecond),
+		pubsub.WithDeadLetter[orderEvent](store),
+	)
+	obs := newRecordingObserver("broken", 100)
+	dispatcher.Register(obs)
+	require.NoError(t, dispatcher.Start(context.Background()))
+
+	dispatcher.Notify(context.Background(), orderEvent{Key: "order-1", Seq: 7})
+	require.NoError(t, dispatcher.Stop(context.Background()))
+
+	letters := store.List()
+	require.Len(t, letters, 1)
+	assert.Equal(t, "broken", letters[0].Observer)
+	assert.Equal(t, "order-1", letters[0].Key)
+	assert.Equal(t, 3, letters[0].Attempts)
+	assert.Equal(t, 7, letters[0].Event.Seq)
+	assert.Error(t, letters[0].Err)
+	assert.Equal(t, int32(3), obs.calls.Load())
+
+	assert.Len(t, store.Drain(), 1)
+	assert.Equal(t, 0, store.Len())
+}
+
+func TestEventDispatcher_PermanentErrorNotRetried(t *testing.T) {
+	store := pubsub.NewMemoryDeadLetterStore[orderEvent](10)
+	dispatcher := pubsub.NewEventDispatcher[orderEvent]("permanent", time.Second, newDeliveryLogger(),
+		pubsub.WithRetry[orderEvent](3, time.Millisecond, 2*time.Millisecond),
+		pubsub.WithDeadLetter[orderEvent](store),
+	)
+	obs := pubsubmocks.NewMockObserver[orderEvent](t)
+	obs.On("GetName").Return("invalid").Maybe()
+	obs.On("OnNotify", mock.Anything, mock.Anything).Return(errs.NewTlPermanentError("validate", errors.New("invalid order")))
+	dispatcher.Register(obs)
+
+	err := dispatcher.NotifySync(context.Background(), orderEvent{Key: "order-1", Seq: 1})
+	require.Error(t, err)
+	obs.AssertNumberOfCalls(t, "OnNotify", 1)
+
+	letters := store.List()
+	require.Len(t, letters, 1)
+	assert.Equal(t, 1, letters[0].Attempts)
+}
+
+func TestEventDispatcher_NotifyNotRunningGoesToDeadLetter(t *testing.T) {
+	store := pubsub.NewMemoryDeadLetterStore[orderEvent](10)
+	dispatcher := pubsub.NewEventDispatcher[orderEvent]("not-running", time.Second, newDeliveryLogger(),
+		pubsub.WithOrderedDelivery(orderKey, 2, 4),
+		pubsub.WithDeadLetter[orderEvent](store),
+	)
+	dispatcher.Register(newRecordingObserver("recorder", 0))
+
+	dispatcher.Notify(context.Background(), orderEvent{Key: "a", Seq: 1})
+
+	letters := store.List()
+	require.Len(t, letters, 1)
+	assert.Empty(t, letters[0].Observer)
+	assert.Equal(t, 0, letters[0].Attempts)
+}
+
+func TestEventDispatcher_StopTimeoutDeadLettersRest(t *testing.T) {
+	store := pubsub.NewMemoryDeadLetterStore[orderEvent](100)
+	dispatcher := pubsub.NewEventDispatcher[orderEvent]("stop-timeout", time.Second, newDeliveryLogger(),
+		pubsub.WithOrderedDelivery(orderKey, 1, 16),
+		pubsub.WithRetry[orderEvent](5, 50*time.Millisecond, 50*time.Millisecond),
+		pubsub.WithDeadLetter[orderEvent](store),
+	)
+	dispatcher.Register(newRecordingObserver("broken", 1000))
+	require.NoError(t, dispatcher.Start(context.Background()))
+
+	for seq := range 5 {
+		dispatcher.Notify(context.Background(), orderEvent{Key: "a", Seq: seq})
+	}
+
+	stopCtx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.Error(t, dispatcher.Stop(stopCtx))
+
+	// ни одно событие не потеряно молча
+	assert.Equal(t, 5, store.Len())
+}
+
+func TestMemoryDeadLetterStore_Capacity(t *testing.T) {
+	store := pubsub.NewMemoryDeadLetterStore[int](2)
+	for i := range 3 {
+		require.NoError(t, store.OnDeadLetter(context.Background(), &pubsub.DeadLetter[int]{Event: i, Err: fmt.Errorf("err %d", i)}))
+	}
+
+	letters := store.List()
+	require.Len(t, letters, 2)
+	assert.Equal(t, 1, letters[0].Event)
+	assert.Equal(t, 2, letters[1].Event)
+}
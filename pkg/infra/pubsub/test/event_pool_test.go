@@ -0,0 +1,257 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/errs"
+	"github.com/ElfAstAhe/go-service-template/pkg/infra/pubsub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// gateObserver блокирует доставку до закрытия gate, считает максимум одновременных вызовов
+type gateObserver struct {
+	name    string
+	gate    chan struct{}
+	started chan int
+	active  atomic.Int32
+	peak    atomic.Int32
+	calls   atomic.Int32
+}
+
+func newGateObserver(name string) *gateObserver {
+	return &gateObserver{
+		name:    name,
+		gate:    make(chan struct{}),
+		started: make(chan int, 1024),
+	}
+}
+
+func (g *gateObserver) GetName() string {
+	return g.name
+}
+
+func (g *gateObserver) OnNotify(ctx context.Context, value int) error {
+	current := g.active.Add(1)
+	defer g.active.Add(-1)
+	for {
+		peak := g.peak.Load()
+		if current <= peak || g.peak.CompareAndSwap(peak, current) {
+			break
+		}
+	}
+	g.calls.Add(1)
+	g.started <- value
+
+	select {
+	case <-g.gate:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+type failingObserver struct {
+	name string
+}
+
+func (f *failingObserver) GetName() string {
+	return f.name
+}
+
+func (f *failingObserver) OnNotify(context.Context, int) error {
+	return errors.New(f.name + " failed")
+}
+
+func waitStarted(t *testing.T, g *gateObserver, count int) []int {
+	t.Helper()
+	res := make([]int, 0, count)
+	for range count {
+		select {
+		case v := <-g.started:
+			res = append(res, v)
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for observer start")
+		}
+	}
+
+	return res
+}
+
+func TestEventDispatcher_WorkerPoolBoundsConcurrency(t *testing.T) {
+	dispatcher := pubsub.NewEventDispatcher[int]("pool", time.Second, newDeliveryLogger(),
+		pubsub.WithWorkerPool[int](2, 100),
+	)
+	obs := newGateObserver("gate")
+	dispatcher.Register(obs)
+	require.NoError(t, dispatcher.Start(context.Background()))
+
+	for i := range 20 {
+		require.NoError(t, dispatcher.TryNotify(context.Background(), i))
+	}
+	waitStarted(t, obs, 2)
+	// остальные события ждут в очереди, а не в горутинах
+	assert.Never(t, func() bool { return obs.calls.Load() > 2 }, 30*time.Millisecond, 5*time.Millisecond)
+
+	close(obs.gate)
+	require.NoError(t, dispatcher.Close(context.Background()))
+
+	assert.Equal(t, int32(20), obs.calls.Load())
+	assert.Equal(t, int32(2), obs.peak.Load())
+}
+
+func TestEventDispatcher_OverflowError(t *testing.T) {
+	store := pubsub.NewMemoryDeadLetterStore[int](10)
+	dispatcher := pubsub.NewEventDispatcher[int]("overflow-error", time.Second, newDeliveryLogger(),
+		pubsub.WithOverflowPolicy[int](pubsub.OverflowError),
+		pubsub.WithWorkerPool[int](1, 1),
+		pubsub.WithDeadLetter[int](store),
+	)
+	obs := newGateObserver("gate")
+	dispatcher.Register(obs)
+	require.NoError(t, dispatcher.Start(context.Background()))
+
+	require.NoError(t, dispatcher.TryNotify(context.Background(), 1))
+	waitStarted(t, obs, 1)
+	require.NoError(t, dispatcher.TryNotify(context.Background(), 2))
+
+	err := dispatcher.TryNotify(context.Background(), 3)
+	var queueFull *errs.QueueFullError
+	require.ErrorAs(t, err, &queueFull)
+	assert.Equal(t, "overflow-error", queueFull.Name)
+	assert.Equal(t, 1, queueFull.Capacity)
+
+	close(obs.gate)
+	require.NoError(t, dispatcher.Close(context.Background()))
+
+	letters := store.List()
+	require.Len(t, letters, 1)
+	assert.Equal(t, 3, letters[0].Event)
+	assert.Equal(t, "gate", letters[0].Observer)
+	assert.Equal(t, int32(2), obs.calls.Load())
+}
+
+func TestEventDispatcher_OverflowDropNewest(t *testing.T) {
+	store := pubsub.NewMemoryDeadLetterStore[int](10)
+	dispatcher := pubsub.NewEventDispatcher[int]("overflow-drop-newest", time.Second, newDeliveryLogger(),
+		pubsub.WithWorkerPool[int](1, 1),
+		pubsub.WithOverflowPolicy[int](pubsub.OverflowDropNewest),
+		pubsub.WithDeadLetter[int](store),
+	)
+	obs := newGateObserver("gate")
+	dispatcher.Register(obs)
+	require.NoError(t, dispatcher.Start(context.Background()))
+
+	require.NoError(t, dispatcher.TryNotify(context.Background(), 1))
+	waitStarted(t, obs, 1)
+	require.NoError(t, dispatcher.TryNotify(context.Background(), 2))
+	require.NoError(t, dispatcher.TryNotify(context.Background(), 3))
+
+	close(obs.gate)
+	require.NoError(t, dispatcher.Close(context.Background()))
+
+	assert.Equal(t, []int{2}, waitStarted(t, obs, 1))
+	require.Equal(t, 1, store.Len())
+	assert.Equal(t, 3, store.List()[0].Event)
+}
+
+func TestEventDispatcher_OverflowDropOldest(t *testing.T) {
+	store := pubsub.NewMemoryDeadLetterStore[int](10)
+	dispatcher := pubsub.NewEventDispatcher[int]("overflow-drop-oldest", time.Second, newDeliveryLogger(),
+		pubsub.WithWorkerPool[int](1, 2),
+		pubsub.WithOverflowPolicy[int](pubsub.OverflowDropOldest),
+		pubsub.WithDeadLetter[int](store),
+	)
+	obs := newGateObserver("gate")
+	dispatcher.Register(obs)
+	require.NoError(t, dispatcher.Start(context.Background()))
+
+	require.NoError(t, dispatcher.TryNotify(context.Background(), 1))
+	waitStarted(t, obs, 1)
+	for i := 2; i <= 5; i++ {
+		require.NoError(t, dispatcher.TryNotify(context.Background(), i))
+	}
+
+	close(obs.gate)
+	require.NoError(t, dispatcher.Close(context.Background()))
+
+	assert.ElementsMatch(t, []int{4, 5}, waitStarted(t, obs, 2))
+	dropped := make([]int, 0, 2)
+	for _, letter := range store.List() {
+		dropped = append(dropped, letter.Event)
+	}
+	assert.Equal(t, []int{2, 3}, dropped)
+}
+
+func TestEventDispatcher_OverflowBlockHonorsContext(t *testing.T) {
+	dispatcher := pubsub.NewEventDispatcher[int]("overflow-block", time.Second, newDeliveryLogger(),
+		pubsub.WithWorkerPool[int](1, 1),
+	)
+	obs := newGateObserver("gate")
+	dispatcher.Register(obs)
+	require.NoError(t, dispatcher.Start(context.Background()))
+
+	require.NoError(t, dispatcher.TryNotify(context.Background(), 1))
+	waitStarted(t, obs, 1)
+	require.NoError(t, dispatcher.TryNotify(context.Background(), 2))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.Error(t, dispatcher.TryNotify(ctx, 3))
+
+	close(obs.gate)
+	require.NoError(t, dispatcher.Close(context.Background()))
+}
+
+func TestEventDispatcher_NotifySyncAggregatesErrors(t *testing.T) {
+	testCases := []struct {
+		name string
+		opts []pubsub.EventDispatcherOption[int]
+	}{
+		{name: "broadcast"},
+		{name: "pool", opts: []pubsub.EventDispatcherOption[int]{pubsub.WithWorkerPool[int](2, 10)}},
+		{name: "ordered", opts: []pubsub.EventDispatcherOption[int]{
+			pubsub.WithOrderedDelivery(func(int) string { return "k" }, 2, 10),
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dispatcher := pubsub.NewEventDispatcher[int]("sync-"+tc.name, time.Second, newDeliveryLogger(), tc.opts...)
+			ok := newGateObserver("ok")
+			close(ok.gate)
+			dispatcher.Register(ok)
+			dispatcher.Register(&failingObserver{name: "first"})
+			dispatcher.Register(&failingObserver{name: "second"})
+			require.NoError(t, dispatcher.Start(context.Background()))
+			defer func() {
+				require.NoError(t, dispatcher.Close(context.Background()))
+			}()
+
+			err := dispatcher.NotifySync(context.Background(), 42)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "first failed")
+			assert.Contains(t, err.Error(), "second failed")
+			// наблюдатель уже отработал к моменту возврата
+			assert.Equal(t, int32(1), ok.calls.Load())
+		})
+	}
+}
+
+func TestEventDispatcher_NotifySyncSuccess(t *testing.T) {
+	dispatcher := pubsub.NewEventDispatcher[int]("sync-success", time.Second, newDeliveryLogger(),
+		pubsub.WithWorkerPool[int](1, 10),
+	)
+	obs := newGateObserver("ok")
+	close(obs.gate)
+	dispatcher.Register(obs)
+	require.NoError(t, dispatcher.Start(context.Background()))
+
+	assert.NoError(t, dispatcher.NotifySync(context.Background(), 1))
+	require.NoError(t, dispatcher.Close(context.Background()))
+	assert.Error(t, dispatcher.NotifySync(context.Background(), 2))
+}
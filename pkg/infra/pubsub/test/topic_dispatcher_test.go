@@ -0,0 +1,231 @@
+package test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/infra/pubsub"
+	loggermocks "github.com/ElfAstAhe/go-service-template/pkg/logger/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type orderCreated struct {
+	ID int
+}
+
+// topicRecorder - наблюдатель, запоминающий топики полученных событий
+type topicRecorder struct {
+	name   string
+	mu     sync.Mutex
+	topics []string
+	events chan *pubsub.Event
+}
+
+func newTopicRecorder(name string) *topicRecorder {
+	return &topicRecorder{name: name, events: make(chan *pubsub.Event, 16)}
+}
+
+func (tr *topicRecorder) GetName() string {
+	return tr.name
+}
+
+func (tr *topicRecorder) OnNotify(_ context.Context, event *pubsub.Event) error {
+	tr.mu.Lock()
+	tr.topics = append(tr.topics, event.Topic)
+	tr.mu.Unlock()
+	tr.events <- event
+
+	return nil
+}
+
+func (tr *topicRecorder) wait(t *testing.T, count int) []string {
+	t.Helper()
+	for i := 0; i < count; i++ {
+		select {
+		case <-tr.events:
+		case <-time.After(time.Second):
+			t.Fatalf("observer %s: timeout waiting for event %d", tr.name, i+1)
+		}
+	}
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	return append([]string(nil), tr.topics...)
+}
+
+func (tr *topicRecorder) assertNoEvents(t *testing.T) {
+	t.Helper()
+	select {
+	case event := <-tr.events:
+		t.Fatalf("observer %s: unexpected event %s", tr.name, event.Topic)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func newTopicTestDispatcher() *pubsub.TopicDispatcher {
+	mockLog := &loggermocks.MockLogger{}
+	mockLog.On("GetLogger", mock.Anything).Return(mockLog)
+	mockLog.On("Errorf", mock.Anything, mock.Anything).Return().Maybe()
+
+	return pubsub.NewTopicDispatcher("topic-dispatcher", 100*time.Millisecond, mockLog)
+}
+
+func TestMatchTopic(t *testing.T) {
+	cases := []struct {
+		pattern string
+		topic   string
+		match   bool
+	}{
+		{"orders.created", "orders.created", true},
+		{"orders.created", "orders.deleted", false},
+		{"orders.*", "orders.created", true},
+		{"orders.*", "orders", false},
+		{"orders.*", "orders.created.eu", false},
+		{"orders.#", "orders", true},
+		{"orders.#", "orders.created.eu", true},
+		{"#", "any.topic", true},
+		{"*.created", "users.created", true},
+		{"orders.#.eu", "orders.created.eu", true},
+		{"orders.#.eu", "orders.eu", true},
+		{"orders.#.eu", "orders.created.us", false},
+		{"orders.cr*", "orders.created", false},
+	}
+	for _, tc := range cases {
+		assert.Equal(t, tc.match, pubsub.MatchTopic(tc.pattern, tc.topic), "pattern [%s] topic [%s]", tc.pattern, tc.topic)
+	}
+}
+
+func TestTopicDispatcher_Routing(t *testing.T) {
+	dispatcher := newTopicTestDispatcher()
+	exact := newTopicRecorder("exact")
+	one := newTopicRecorder("one")
+	multi := newTopicRecorder("multi")
+	_, err := dispatcher.Subscribe("orders.created", exact)
+	require.NoError(t, err)
+	_, err = dispatcher.Subscribe("orders.*", one)
+	require.NoError(t, err)
+	_, err = dispatcher.Subscribe("orders.#", multi)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, dispatcher.Publish(ctx, "orders.created", 1))
+	assert.Equal(t, []string{"orders.created"}, exact.wait(t, 1))
+	assert.Equal(t, []string{"orders.created"}, one.wait(t, 1))
+	assert.Equal(t, []string{"orders.created"}, multi.wait(t, 1))
+
+	require.NoError(t, dispatcher.Publish(ctx, "orders.created.eu", 2))
+	assert.Equal(t, []string{"orders.created", "orders.created.eu"}, multi.wait(t, 1))
+	exact.assertNoEvents(t)
+	one.assertNoEvents(t)
+
+	require.NoError(t, dispatcher.Publish(ctx, "users.created", 3))
+	multi.assertNoEvents(t)
+	assert.False(t, dispatcher.HasSubscribers("users.created"))
+	assert.True(t, dispatcher.HasSubscribers("orders.deleted"))
+}
+
+func TestTopicDispatcher_InvalidTopics(t *testing.T) {
+	dispatcher := newTopicTestDispatcher()
+	for _, pattern := range []string{"", "orders..created", "orders.cr*"} {
+		_, err := dispatcher.Subscribe(pattern, newTopicRecorder("invalid"))
+		assert.Error(t, err, "pattern [%s]", pattern)
+	}
+	for _, topic := range []string{"", "orders.*", "orders.#", ".orders"} {
+		assert.Error(t, dispatcher.Publish(context.Background(), topic, nil), "topic [%s]", topic)
+	}
+	_, err := dispatcher.Subscribe("orders", nil)
+	assert.Error(t, err)
+}
+
+func TestTopicDispatcher_TypedSubscribe(t *testing.T) {
+	dispatcher := newTopicTestDispatcher()
+	received := make(chan orderCreated, 4)
+	sub, err := pubsub.Subscribe(dispatcher, "orders.#", "typed", func(_ context.Context, topic string, payload orderCreated) error {
+		received <- payload
+
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "orders.#", sub.GetPattern())
+
+	ctx := context.Background()
+	require.NoError(t, dispatcher.Publish(ctx, "orders.created", "not an order"))
+	require.NoError(t, dispatcher.Publish(ctx, "orders.created", orderCreated{ID: 7}))
+
+	select {
+	case payload := <-received:
+		assert.Equal(t, 7, payload.ID)
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for typed event")
+	}
+	select {
+	case payload := <-received:
+		t.Fatalf("unexpected payload %v", payload)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	_, err = pubsub.Subscribe[orderCreated](dispatcher, "orders", "nil-handler", nil)
+	assert.Error(t, err)
+}
+
+func TestTopicDispatcher_Unsubscribe(t *testing.T) {
+	dispatcher := newTopicTestDispatcher()
+	exact := newTopicRecorder("exact")
+	wildcard := newTopicRecorder("wildcard")
+	exactSub, err := dispatcher.Subscribe("orders.created", exact)
+	require.NoError(t, err)
+	wildcardSub, err := dispatcher.Subscribe("orders.*", wildcard)
+	require.NoError(t, err)
+	_, err = pubsub.Subscribe(dispatcher, "users.#", "typed", func(context.Context, string, orderCreated) error {
+		return nil
+	})
+	require.NoError(t, err)
+
+	subs := dispatcher.Subscriptions()
+	require.Len(t, subs, 3)
+	assert.Equal(t, "orders.created", subs[0].Pattern)
+	assert.Equal(t, "exact", subs[0].Observer)
+	assert.Equal(t, "orders.*", subs[1].Pattern)
+	assert.Equal(t, "typed", subs[2].Observer)
+	assert.NotEmpty(t, subs[2].PayloadType)
+
+	exactSub.Unsubscribe()
+	wildcardSub.Unsubscribe()
+	wildcardSub.Unsubscribe()
+	assert.Len(t, dispatcher.Subscriptions(), 1)
+	assert.False(t, dispatcher.HasSubscribers("orders.created"))
+
+	require.NoError(t, dispatcher.Publish(context.Background(), "orders.created", 1))
+	exact.assertNoEvents(t)
+	wildcard.assertNoEvents(t)
+}
+
+func TestTopicDispatcher_ObserverFailure(t *testing.T) {
+	mockLog := &loggermocks.MockLogger{}
+	mockLog.On("GetLogger", mock.Anything).Return(mockLog)
+	logged := make(chan struct{}, 2)
+	mockLog.On("Errorf", mock.Anything, mock.Anything).Run(func(mock.Arguments) {
+		logged <- struct{}{}
+	}).Return()
+	dispatcher := pubsub.NewTopicDispatcher("failing", 100*time.Millisecond, mockLog)
+
+	_, err := pubsub.Subscribe(dispatcher, "orders.*", "panic", func(context.Context, string, int) error {
+		panic("observer failure")
+	})
+	require.NoError(t, err)
+	healthy := newTopicRecorder("healthy")
+	_, err = dispatcher.Subscribe("orders.created", healthy)
+	require.NoError(t, err)
+
+	require.NoError(t, dispatcher.Publish(context.Background(), "orders.created", 1))
+	healthy.wait(t, 1)
+	select {
+	case <-logged:
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for panic log")
+	}
+}
@@ -0,0 +1,112 @@
+package pubsub
+
+import (
+	"strings"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/errs"
+)
+
+// Разделитель и шаблоны сегментов топика (как в AMQP topic exchange)
+const (
+	TopicSeparator = "."
+	// TopicWildcardOne ровно один сегмент
+	TopicWildcardOne = "*"
+	// TopicWildcardAny ноль и более сегментов
+	TopicWildcardAny = "#"
+)
+
+// topicPattern - разобранный шаблон подписки
+type topicPattern struct {
+	raw      string
+	segments []string
+	wildcard bool
+}
+
+func parseTopicPattern(pattern string) (*topicPattern, error) {
+	segments, err := splitTopic("pattern", pattern)
+	if err != nil {
+		return nil, err
+	}
+	res := &topicPattern{
+		raw:      pattern,
+		segments: segments,
+	}
+	for _, segment := range segments {
+		switch {
+		case segment == TopicWildcardOne || segment == TopicWildcardAny:
+			res.wildcard = true
+		case strings.ContainsAny(segment, TopicWildcardOne+TopicWildcardAny):
+			return nil, errs.NewInvalidArgumentError("pattern", pattern)
+		}
+	}
+
+	return res, nil
+}
+
+// ValidateTopic проверка имени топика публикации: непустые сегменты без шаблонов
+func ValidateTopic(topic string) error {
+	if _, err := splitTopic("topic", topic); err != nil {
+		return err
+	}
+	if strings.ContainsAny(topic, TopicWildcardOne+TopicWildcardAny) {
+		return errs.NewInvalidArgumentError("topic", topic)
+	}
+
+	return nil
+}
+
+// MatchTopic соответствие топика шаблону подписки
+func MatchTopic(pattern string, topic string) bool {
+	parsed, err := parseTopicPattern(pattern)
+	if err != nil || ValidateTopic(topic) != nil {
+		return false
+	}
+
+	return parsed.match(strings.Split(topic, TopicSeparator))
+}
+
+func (tp *topicPattern) match(topic []string) bool {
+	return matchSegments(tp.segments, topic)
+}
+
+func matchSegments(pattern []string, topic []string) bool {
+	for len(pattern) > 0 {
+		switch pattern[0] {
+		case TopicWildcardAny:
+			// # поглощает от нуля до всех оставшихся сегментов
+			for i := 0; i <= len(topic); i++ {
+				if matchSegments(pattern[1:], topic[i:]) {
+					return true
+				}
+			}
+
+			return false
+		case TopicWildcardOne:
+			if len(topic) == 0 {
+				return false
+			}
+		default:
+			if len(topic) == 0 || pattern[0] != topic[0] {
+				return false
+			}
+		}
+		pattern = pattern[1:]
+		topic = topic[1:]
+	}
+
+	return len(topic) == 0
+}
+
+func splitTopic(param string, value string) ([]string, error) {
+	if value == "" {
+		return nil, errs.NewInvalidArgumentError(param, value)
+	}
+	segments := strings.Split(value, TopicSeparator)
+	for _, segment := range segments {
+		if segment == "" {
+			return nil, errs.NewInvalidArgumentError(param, value)
+		}
+	}
+
+	return segments, nil
+}
@@ -0,0 +1,289 @@
+package pubsub
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/errs"
+	"github.com/ElfAstAhe/go-service-template/pkg/logger"
+	"github.com/ElfAstAhe/go-service-template/pkg/utils"
+)
+
+// Event - событие топика
+type Event struct {
+	Topic   string
+	Payload any
+}
+
+// TopicHandler обработчик типизированной подписки
+type TopicHandler[T any] func(ctx context.Context, topic string, payload T) error
+
+// SubscriptionInfo - сведения о подписке
+type SubscriptionInfo struct {
+	ID       uint64 `json:"id"`
+	Pattern  string `json:"pattern"`
+	Observer string `json:"observer"`
+	// PayloadType тип payload типизированной подписки, пусто - все события
+	PayloadType string `json:"payload_type,omitempty"`
+}
+
+// Subscription - подписка на топики, Unsubscribe идемпотентен
+type Subscription struct {
+	id          uint64
+	pattern     *topicPattern
+	observer    Observer[*Event]
+	accepts     func(any) bool
+	payloadType string
+	dispatcher  *TopicDispatcher
+}
+
+func (s *Subscription) GetID() uint64 {
+	return s.id
+}
+
+func (s *Subscription) GetPattern() string {
+	return s.pattern.raw
+}
+
+func (s *Subscription) Unsubscribe() {
+	s.dispatcher.unsubscribe(s)
+}
+
+func (s *Subscription) info() SubscriptionInfo {
+	return SubscriptionInfo{
+		ID:          s.id,
+		Pattern:     s.pattern.raw,
+		Observer:    s.observer.GetName(),
+		PayloadType: s.payloadType,
+	}
+}
+
+// TopicDispatcher - диспетчер событий по топикам: подписка по точному имени или шаблону
+// (orders.* - один сегмент, orders.# - ноль и более сегментов), доставка асинхронная, как у EventDispatcher
+type TopicDispatcher struct {
+	mu   sync.RWMutex
+	name string
+	// exact подписки без шаблонов по топику
+	exact map[string][]*Subscription
+	// wildcard подписки с шаблонами
+	wildcard      []*Subscription
+	nextID        atomic.Uint64
+	notifyTimeout time.Duration
+	logger        logger.Logger
+}
+
+var _ TopicPublisher = (*TopicDispatcher)(nil)
+
+func NewTopicDispatcher(name string, notifyTimeout time.Duration, log logger.Logger) *TopicDispatcher {
+	res := &TopicDispatcher{
+		name:          name,
+		exact:         make(map[string][]*Subscription),
+		notifyTimeout: notifyTimeout,
+		logger:        log.GetLogger(name),
+	}
+	// check for correct timeout
+	if res.notifyTimeout <= 0 {
+		res.notifyTimeout = DefaultNotifyTimeout
+	}
+
+	return res
+}
+
+// Subscribe подписка observer на топики шаблона pattern
+func (td *TopicDispatcher) Subscribe(pattern string, observer Observer[*Event]) (*Subscription, error) {
+	if utils.IsNil(observer) {
+		return nil, errs.NewInvalidArgumentError("observer", nil)
+	}
+
+	return td.subscribe(pattern, observer, nil, "")
+}
+
+// Subscribe типизированная подписка: handler получает только события шаблона pattern с payload типа T
+func Subscribe[T any](td *TopicDispatcher, pattern string, name string, handler TopicHandler[T]) (*Subscription, error) {
+	if handler == nil {
+		return nil, errs.NewInvalidArgumentError("handler", nil)
+	}
+	accepts := func(payload any) bool {
+		_, ok := payload.(T)
+
+		return ok
+	}
+
+	return td.subscribe(pattern, &typedObserver[T]{name: name, handler: handler}, accepts, utils.GetTypeName(new(T)))
+}
+
+// Publish асинхронная доставка payload подписчикам топика
+func (td *TopicDispatcher) Publish(ctx context.Context, topic string, payload any) error {
+	if err := ValidateTopic(topic); err != nil {
+		return err
+	}
+	subs := td.match(topic, payload)
+	if len(subs) == 0 {
+		return nil
+	}
+
+	go td.internalPublish(context.WithoutCancel(ctx), &Event{Topic: topic, Payload: payload}, subs)
+
+	return nil
+}
+
+// HasSubscribers есть подписчики топика
+func (td *TopicDispatcher) HasSubscribers(topic string) bool {
+	if ValidateTopic(topic) != nil {
+		return false
+	}
+	segments := strings.Split(topic, TopicSeparator)
+
+	td.mu.RLock()
+	defer td.mu.RUnlock()
+
+	if len(td.exact[topic]) > 0 {
+		return true
+	}
+
+	return slices.ContainsFunc(td.wildcard, func(sub *Subscription) bool {
+		return sub.pattern.match(segments)
+	})
+}
+
+// Subscriptions текущие подписки, упорядоченные по ID
+func (td *TopicDispatcher) Subscriptions() []SubscriptionInfo {
+	td.mu.RLock()
+	res := make([]SubscriptionInfo, 0, len(td.wildcard))
+	for _, subs := range td.exact {
+		for _, sub := range subs {
+			res = append(res, sub.info())
+		}
+	}
+	for _, sub := range td.wildcard {
+		res = append(res, sub.info())
+	}
+	td.mu.RUnlock()
+
+	slices.SortFunc(res, func(a, b SubscriptionInfo) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return res
+}
+
+func (td *TopicDispatcher) GetName() string {
+	return td.name
+}
+
+func (td *TopicDispatcher) subscribe(pattern string, observer Observer[*Event], accepts func(any) bool, payloadType string) (*Subscription, error) {
+	parsed, err := parseTopicPattern(pattern)
+	if err != nil {
+		return nil, err
+	}
+	sub := &Subscription{
+		id:          td.nextID.Add(1),
+		pattern:     parsed,
+		observer:    observer,
+		accepts:     accepts,
+		payloadType: payloadType,
+		dispatcher:  td,
+	}
+
+	td.mu.Lock()
+	defer td.mu.Unlock()
+
+	if parsed.wildcard {
+		td.wildcard = append(td.wildcard, sub)
+	} else {
+		td.exact[pattern] = append(td.exact[pattern], sub)
+	}
+
+	return sub, nil
+}
+
+func (td *TopicDispatcher) unsubscribe(sub *Subscription) {
+	td.mu.Lock()
+	defer td.mu.Unlock()
+
+	isSub := func(item *Subscription) bool {
+		return item == sub
+	}
+	if sub.pattern.wildcard {
+		td.wildcard = slices.DeleteFunc(td.wildcard, isSub)
+
+		return
+	}
+	subs := slices.DeleteFunc(td.exact[sub.pattern.raw], isSub)
+	if len(subs) == 0 {
+		delete(td.exact, sub.pattern.raw)
+
+		return
+	}
+	td.exact[sub.pattern.raw] = subs
+}
+
+// match подписки топика, принимающие payload
+func (td *TopicDispatcher) match(topic string, payload any) []*Subscription {
+	segments := strings.Split(topic, TopicSeparator)
+
+	td.mu.RLock()
+	defer td.mu.RUnlock()
+
+	var res []*Subscription
+	for _, sub := range td.exact[topic] {
+		if sub.accepts == nil || sub.accepts(payload) {
+			res = append(res, sub)
+		}
+	}
+	for _, sub := range td.wildcard {
+		if (sub.accepts == nil || sub.accepts(payload)) && sub.pattern.match(segments) {
+			res = append(res, sub)
+		}
+	}
+
+	return res
+}
+
+func (td *TopicDispatcher) internalPublish(ctx context.Context, event *Event, subs []*Subscription) {
+	var wg sync.WaitGroup
+	asyncCtx, asyncCancel := context.WithTimeout(ctx, td.notifyTimeout)
+	defer asyncCancel()
+
+	for _, sub := range subs {
+		wg.Add(1)
+		go func(observer Observer[*Event]) {
+			defer wg.Done()
+			defer func() {
+				if r := recover(); r != nil {
+					td.logger.Errorf("pub/sub topic dispatcher %s observer %s topic %s panic recovery %v",
+						td.GetName(), observer.GetName(), event.Topic, panicError(r))
+				}
+			}()
+			if err := observer.OnNotify(asyncCtx, event); err != nil {
+				td.logger.Errorf("pub/sub topic dispatcher %s observer %s topic %s on notify got error %v",
+					td.GetName(), observer.GetName(), event.Topic, err)
+			}
+		}(sub.observer)
+	}
+	wg.Wait()
+}
+
+// typedObserver - наблюдатель типизированной подписки
+type typedObserver[T any] struct {
+	name    string
+	handler TopicHandler[T]
+}
+
+func (to *typedObserver[T]) GetName() string {
+	return to.name
+}
+
+func (to *typedObserver[T]) OnNotify(ctx context.Context, event *Event) error {
+	payload, ok := event.Payload.(T)
+	if !ok {
+		return nil
+	}
+
+	return to.handler(ctx, event.Topic, payload)
+}
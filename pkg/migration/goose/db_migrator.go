@@ -2,7 +2,9 @@ package goose
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io/fs"
 
 	"github.com/ElfAstAhe/go-service-template/pkg/db"
 	"github.com/ElfAstAhe/go-service-template/pkg/errs"
@@ -11,65 +13,223 @@ import (
 	"github.com/pressly/goose/v3"
 )
 
-// DBMigrator is implementation of DBMigrator interface
+// DBMigrator is implementation of DBMigrator interface, based on goose Provider API (without goose global state)
 type DBMigrator struct {
-	db  db.DB
-	log logger.Logger
+	db           db.DB
+	log          logger.Logger
+	fsys         fs.FS
+	goMigrations []*goose.Migration
+	tableName    string
+	allowMissing bool
+	provider     *goose.Provider
 }
 
 var _ migration.Migrator = (*DBMigrator)(nil)
 
-func NewDBMigrator(db db.DB, logger logger.Logger) (*DBMigrator, error) {
-	return &DBMigrator{
-		db:  db,
-		log: logger.GetLogger("DB migrator"),
-	}, nil
+func NewDBMigrator(db db.DB, logger logger.Logger, opts ...Option) (*DBMigrator, error) {
+	res := &DBMigrator{
+		db:           db,
+		log:          logger.GetLogger("DB migrator"),
+		tableName:    DefaultTableName,
+		allowMissing: true,
+	}
+	for _, opt := range opts {
+		opt(res)
+	}
+
+	return res, nil
 }
 
 func (g *DBMigrator) Initialize() error {
-	if err := goose.SetDialect(g.db.GetDriver()); err != nil {
+	dialect, err := mapDriverDialect(g.db.GetDriver())
+	if err != nil {
 		return errs.NewDBMigrationError("error select dialect", err)
 	}
-	goose.SetTableName("goose_version_history")
-	goose.SetLogger(logger.NewGooseLogger(g.log))
+
+	provider, err := goose.NewProvider(
+		dialect,
+		g.db.GetDB(),
+		g.fsys,
+		goose.WithTableName(g.tableName),
+		goose.WithGoMigrations(g.goMigrations...),
+		goose.WithDisableGlobalRegistry(true),
+		goose.WithAllowOutofOrder(g.allowMissing),
+		goose.WithLogger(logger.NewGooseLogger(g.log)),
+	)
+	if err != nil {
+		return errs.NewDBMigrationError("error create migration provider", err)
+	}
+	g.provider = provider
 
 	return nil
 }
 
 func (g *DBMigrator) Up(ctx context.Context) (err error) {
-	defer func() {
-		if r := recover(); r != nil {
-			// Проверяем, является ли r ошибкой
-			recoveryErr, ok := r.(error)
-			if !ok {
-				// Если это строка или что-то другое, приводим к виду error вручную
-				recoveryErr = errs.NewConfigError(fmt.Sprintf("panic [%v] recovery", r), nil)
-			}
-			err = errs.NewDBMigrationError("migrate up panic", recoveryErr)
-		}
-	}()
-	if err := goose.UpContext(ctx, g.db.GetDB(), ".", goose.WithAllowMissing()); err != nil {
+	defer recoverPanic("migrate up panic", &err)
+
+	res, err := g.provider.Up(ctx)
+	g.logResults(res)
+	if err != nil {
 		return errs.NewDBMigrationError("error migrate up", err)
 	}
 
 	return nil
 }
 
+func (g *DBMigrator) UpTo(ctx context.Context, version int64) (err error) {
+	defer recoverPanic("migrate up to panic", &err)
+
+	res, err := g.provider.UpTo(ctx, version)
+	g.logResults(res)
+	if err != nil {
+		return errs.NewDBMigrationError(fmt.Sprintf("error migrate up to version [%d]", version), err)
+	}
+
+	return nil
+}
+
 func (g *DBMigrator) Down(ctx context.Context) (err error) {
-	defer func() {
-		if r := recover(); r != nil {
-			// Проверяем, является ли r ошибкой
-			recoveryErr, ok := r.(error)
-			if !ok {
-				// Если это строка или что-то другое, приводим к виду error вручную
-				recoveryErr = errs.NewConfigError(fmt.Sprintf("panic [%v] recovery", r), nil)
-			}
-			err = errs.NewDBMigrationError("migrate up panic", recoveryErr)
-		}
-	}()
-	if err := goose.DownContext(ctx, g.db.GetDB(), ".", goose.WithAllowMissing()); err != nil {
+	defer recoverPanic("migrate down panic", &err)
+
+	res, err := g.provider.Down(ctx)
+	if res != nil {
+		g.logResults([]*goose.MigrationResult{res})
+	}
+	if err != nil {
 		return errs.NewDBMigrationError("error migrate down", err)
 	}
 
 	return nil
 }
+
+func (g *DBMigrator) DownTo(ctx context.Context, version int64) (err error) {
+	defer recoverPanic("migrate down to panic", &err)
+
+	res, err := g.provider.DownTo(ctx, version)
+	g.logResults(res)
+	if err != nil {
+		return errs.NewDBMigrationError(fmt.Sprintf("error migrate down to version [%d]", version), err)
+	}
+
+	return nil
+}
+
+func (g *DBMigrator) Redo(ctx context.Context) (err error) {
+	defer recoverPanic("migrate redo panic", &err)
+
+	down, err := g.provider.Down(ctx)
+	if err != nil {
+		return errs.NewDBMigrationError("error redo: migrate down", err)
+	}
+	g.logResults([]*goose.MigrationResult{down})
+
+	up, err := g.provider.ApplyVersion(ctx, down.Source.Version, true)
+	if err != nil {
+		return errs.NewDBMigrationError(fmt.Sprintf("error redo: migrate up version [%d]", down.Source.Version), err)
+	}
+	g.logResults([]*goose.MigrationResult{up})
+
+	return nil
+}
+
+func (g *DBMigrator) Reset(ctx context.Context) error {
+	if err := g.DownTo(ctx, 0); err != nil {
+		return errs.NewDBMigrationError("error reset", err)
+	}
+
+	return nil
+}
+
+func (g *DBMigrator) Status(ctx context.Context) ([]*migration.Status, error) {
+	statuses, err := g.provider.Status(ctx)
+	if err != nil {
+		return nil, errs.NewDBMigrationError("error migration status", err)
+	}
+
+	res := make([]*migration.Status, 0, len(statuses))
+	for _, status := range statuses {
+		res = append(res, mapStatus(status))
+	}
+
+	return res, nil
+}
+
+func (g *DBMigrator) Version(ctx context.Context) (int64, error) {
+	version, err := g.provider.GetDBVersion(ctx)
+	if err != nil {
+		return 0, errs.NewDBMigrationError("error get db version", err)
+	}
+
+	return version, nil
+}
+
+func (g *DBMigrator) Pending(ctx context.Context) ([]*migration.Status, error) {
+	statuses, err := g.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*migration.Status, 0, len(statuses))
+	for _, status := range statuses {
+		if status.State == migration.StatePending {
+			res = append(res, status)
+		}
+	}
+
+	return res, nil
+}
+
+func (g *DBMigrator) logResults(results []*goose.MigrationResult) {
+	for _, res := range results {
+		g.log.Infof("migration %s", res.String())
+	}
+}
+
+func mapDriverDialect(driver string) (goose.Dialect, error) {
+	switch driver {
+	case "postgres", "pgx":
+		return goose.DialectPostgres, nil
+	case "mysql":
+		return goose.DialectMySQL, nil
+	case "sqlite3", "sqlite":
+		return goose.DialectSQLite3, nil
+	case "mssql", "azuresql", "sqlserver":
+		return goose.DialectMSSQL, nil
+	case "clickhouse":
+		return goose.DialectClickHouse, nil
+	default:
+		return "", errs.NewInvalidArgumentError("driver", driver)
+	}
+}
+
+func mapStatus(status *goose.MigrationStatus) *migration.Status {
+	res := &migration.Status{
+		Version:   status.Source.Version,
+		Type:      string(status.Source.Type),
+		Source:    status.Source.Path,
+		State:     migration.StatePending,
+		AppliedAt: status.AppliedAt,
+	}
+	if status.State == goose.StateApplied {
+		res.State = migration.StateApplied
+	}
+
+	return res
+}
+
+func recoverPanic(msg string, err *error) {
+	if r := recover(); r != nil {
+		// Проверяем, является ли r ошибкой
+		recoveryErr, ok := r.(error)
+		if !ok {
+			// Если это строка или что-то другое, приводим к виду error вручную
+			recoveryErr = errs.NewConfigError(fmt.Sprintf("panic [%v] recovery", r), nil)
+		}
+		*err = errs.NewDBMigrationError(msg, recoveryErr)
+	}
+}
+
+// IsNoChanges нет миграций для применения/отката
+func IsNoChanges(err error) bool {
+	return errors.Is(err, goose.ErrNoNextVersion)
+}
@@ -0,0 +1,40 @@
+package goose
+
+import (
+	"io/fs"
+
+	"github.com/pressly/goose/v3"
+)
+
+// DefaultTableName таблица истории версий миграций
+const DefaultTableName string = "goose_version_history"
+
+type Option func(*DBMigrator)
+
+// WithFS файловая система с SQL миграциями (например embed.FS), файлы вида NNNN_name.sql
+func WithFS(fsys fs.FS) Option {
+	return func(m *DBMigrator) {
+		m.fsys = fsys
+	}
+}
+
+// WithGoMigrations явно зарегистрированные Go миграции (см. goose.NewGoMigration)
+func WithGoMigrations(migrations ...*goose.Migration) Option {
+	return func(m *DBMigrator) {
+		m.goMigrations = append(m.goMigrations, migrations...)
+	}
+}
+
+// WithTableName таблица истории версий миграций
+func WithTableName(tableName string) Option {
+	return func(m *DBMigrator) {
+		m.tableName = tableName
+	}
+}
+
+// WithAllowMissing применение пропущенных (out-of-order) миграций
+func WithAllowMissing(allowMissing bool) Option {
+	return func(m *DBMigrator) {
+		m.allowMissing = allowMissing
+	}
+}
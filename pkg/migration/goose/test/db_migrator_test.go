@@ -0,0 +1,225 @@
+package test
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	dbmocks "github.com/ElfAstAhe/go-service-template/pkg/db/mocks"
+	logmocks "github.com/ElfAstAhe/go-service-template/pkg/logger/mocks"
+	"github.com/ElfAstAhe/go-service-template/pkg/migration"
+	migrator "github.com/ElfAstAhe/go-service-template/pkg/migration/goose"
+	"github.com/pressly/goose/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	_ "modernc.org/sqlite"
+)
+
+const (
+	sql0001 = `-- +goose Up
+create table test (id integer primary key, code text not null);
+
+-- +goose Down
+drop table test;
+`
+	sql0002 = `-- +goose Up
+create index idx_test_code on test (code);
+
+-- +goose Down
+drop index idx_test_code;
+`
+)
+
+func newTestLogger(t *testing.T) *logmocks.MockLogger {
+	log := logmocks.NewMockLogger(t)
+	log.On("GetLogger", mock.Anything).Return(log).Maybe()
+	log.On("Infof", mock.Anything, mock.Anything).Maybe()
+	log.On("Printf", mock.Anything, mock.Anything).Maybe()
+	log.On("Fatalf", mock.Anything, mock.Anything).Maybe()
+
+	return log
+}
+
+func newTestSQLite(t *testing.T) *sql.DB {
+	conn, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "migrations.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return conn
+}
+
+func newTestMigrator(t *testing.T, conn *sql.DB) *migrator.DBMigrator {
+	mockDB := dbmocks.NewMockDB(t)
+	mockDB.On("GetDriver").Return("sqlite").Maybe()
+	mockDB.On("GetDB").Return(conn).Maybe()
+
+	// 0001, 0002 - SQL миграции, 0003 - Go миграция
+	fsys := fstest.MapFS{
+		"0001_create_test.sql": {Data: []byte(sql0001)},
+		"0002_index_test.sql":  {Data: []byte(sql0002)},
+		"login.sql":            {Data: []byte("create user test;")},
+	}
+	goMigration := goose.NewGoMigration(3,
+		&goose.GoFunc{RunDB: func(ctx context.Context, db *sql.DB) error {
+			_, err := db.ExecContext(ctx, `insert into test (id, code) values (1, 'seed')`)
+
+			return err
+		}},
+		&goose.GoFunc{RunDB: func(ctx context.Context, db *sql.DB) error {
+			_, err := db.ExecContext(ctx, `delete from test where id = 1`)
+
+			return err
+		}},
+	)
+
+	res, err := migrator.NewDBMigrator(mockDB, newTestLogger(t),
+		migrator.WithFS(fsys),
+		migrator.WithGoMigrations(goMigration),
+		migrator.WithTableName("migration_history"),
+	)
+	require.NoError(t, err)
+	require.NoError(t, res.Initialize())
+
+	return res
+}
+
+func versions(statuses []*migration.Status) []int64 {
+	res := make([]int64, 0, len(statuses))
+	for _, status := range statuses {
+		res = append(res, status.Version)
+	}
+
+	return res
+}
+
+func tableExists(t *testing.T, conn *sql.DB, name string) bool {
+	var count int
+	err := conn.QueryRow(`select count(*) from sqlite_master where name = ?`, name).Scan(&count)
+	require.NoError(t, err)
+
+	return count > 0
+}
+
+func TestDBMigrator_Initialize_UnknownDriver(t *testing.T) {
+	mockDB := dbmocks.NewMockDB(t)
+	mockDB.On("GetDriver").Return("oracle").Once()
+
+	m, err := migrator.NewDBMigrator(mockDB, newTestLogger(t))
+	require.NoError(t, err)
+	assert.Error(t, m.Initialize())
+}
+
+func TestDBMigrator_UpStatusVersion(t *testing.T) {
+	ctx := context.Background()
+	conn := newTestSQLite(t)
+	m := newTestMigrator(t, conn)
+
+	version, err := m.Version(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), version)
+
+	pending, err := m.Pending(ctx)
+	require.NoError(t, err)
+	// файлы без номера версии (ручные скрипты) не являются миграциями
+	assert.Equal(t, []int64{1, 2, 3}, versions(pending))
+
+	require.NoError(t, m.Up(ctx))
+
+	version, err = m.Version(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), version)
+	assert.True(t, tableExists(t, conn, "test"))
+	assert.True(t, tableExists(t, conn, "idx_test_code"))
+
+	statuses, err := m.Status(ctx)
+	require.NoError(t, err)
+	require.Len(t, statuses, 3)
+	for _, status := range statuses {
+		assert.Equal(t, migration.StateApplied, status.State)
+		assert.False(t, status.AppliedAt.IsZero())
+	}
+	assert.Equal(t, "sql", statuses[0].Type)
+	assert.Equal(t, "0001_create_test.sql", statuses[0].Source)
+	assert.Equal(t, "go", statuses[2].Type)
+
+	pending, err = m.Pending(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+}
+
+func TestDBMigrator_UpToDownTo(t *testing.T) {
+	ctx := context.Background()
+	conn := newTestSQLite(t)
+	m := newTestMigrator(t, conn)
+
+	require.NoError(t, m.UpTo(ctx, 1))
+	version, err := m.Version(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), version)
+
+	pending, err := m.Pending(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{2, 3}, versions(pending))
+
+	require.NoError(t, m.Up(ctx))
+	require.NoError(t, m.DownTo(ctx, 1))
+
+	version, err = m.Version(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), version)
+	assert.True(t, tableExists(t, conn, "test"))
+	assert.False(t, tableExists(t, conn, "idx_test_code"))
+}
+
+func TestDBMigrator_DownRedoReset(t *testing.T) {
+	ctx := context.Background()
+	conn := newTestSQLite(t)
+	m := newTestMigrator(t, conn)
+
+	require.NoError(t, m.Up(ctx))
+
+	require.NoError(t, m.Down(ctx))
+	version, err := m.Version(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), version)
+
+	require.NoError(t, m.Redo(ctx))
+	version, err = m.Version(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), version)
+	assert.True(t, tableExists(t, conn, "idx_test_code"))
+
+	require.NoError(t, m.Reset(ctx))
+	version, err = m.Version(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), version)
+	assert.False(t, tableExists(t, conn, "test"))
+
+	err = m.Down(ctx)
+	require.Error(t, err)
+	assert.True(t, migrator.IsNoChanges(err))
+}
+
+func TestDBMigrator_FailedMigration(t *testing.T) {
+	ctx := context.Background()
+	conn := newTestSQLite(t)
+
+	mockDB := dbmocks.NewMockDB(t)
+	mockDB.On("GetDriver").Return("sqlite").Maybe()
+	mockDB.On("GetDB").Return(conn).Maybe()
+
+	m, err := migrator.NewDBMigrator(mockDB, newTestLogger(t), migrator.WithFS(fstest.MapFS{
+		"0001_create_test.sql": {Data: []byte(sql0001)},
+		"0002_broken.sql":      {Data: []byte("-- +goose Up\ncreate tabel broken;\n")},
+	}))
+	require.NoError(t, err)
+	require.NoError(t, m.Initialize())
+
+	require.Error(t, m.Up(ctx))
+	version, err := m.Version(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), version)
+}
@@ -2,11 +2,47 @@ package migration
 
 import (
 	"context"
+	"time"
 )
 
+// State - состояние миграции
+type State string
+
+const (
+	// StatePending миграция известна, но ещё не применена
+	StatePending State = "pending"
+	// StateApplied миграция применена
+	StateApplied State = "applied"
+)
+
+// Status - состояние отдельной миграции
+type Status struct {
+	Version   int64
+	Type      string
+	Source    string
+	State     State
+	AppliedAt time.Time
+}
+
 // Migrator is simple database migrator interface
 type Migrator interface {
 	Initialize() error
+	// Up применение всех ожидающих миграций
 	Up(ctx context.Context) error
+	// UpTo применение ожидающих миграций до версии включительно
+	UpTo(ctx context.Context, version int64) error
+	// Down откат последней применённой миграции
 	Down(ctx context.Context) error
+	// DownTo откат миграций до версии, не включая её
+	DownTo(ctx context.Context, version int64) error
+	// Redo откат и повторное применение последней миграции
+	Redo(ctx context.Context) error
+	// Reset откат всех миграций
+	Reset(ctx context.Context) error
+	// Status состояние всех известных миграций по возрастанию версий
+	Status(ctx context.Context) ([]*Status, error)
+	// Version текущая версия схемы БД
+	Version(ctx context.Context) (int64, error)
+	// Pending список ожидающих применения миграций (dry-run), БД не изменяется
+	Pending(ctx context.Context) ([]*Status, error)
 }
@@ -0,0 +1,585 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/migration"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockMigrator creates a new instance of MockMigrator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockMigrator(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockMigrator {
+	mock := &MockMigrator{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockMigrator is an autogenerated mock type for the Migrator type
+type MockMigrator struct {
+	mock.Mock
+}
+
+type MockMigrator_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockMigrator) EXPECT() *MockMigrator_Expecter {
+	return &MockMigrator_Expecter{mock: &_m.Mock}
+}
+
+// Down provides a mock function for the type MockMigrator
+func (_mock *MockMigrator) Down(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Down")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockMigrator_Down_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Down'
+type MockMigrator_Down_Call struct {
+	*mock.Call
+}
+
+// Down is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockMigrator_Expecter) Down(ctx any) *MockMigrator_Down_Call {
+	return &MockMigrator_Down_Call{Call: _e.mock.On("Down", ctx)}
+}
+
+func (_c *MockMigrator_Down_Call) Run(run func(ctx context.Context)) *MockMigrator_Down_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMigrator_Down_Call) Return(err error) *MockMigrator_Down_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockMigrator_Down_Call) RunAndReturn(run func(ctx context.Context) error) *MockMigrator_Down_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DownTo provides a mock function for the type MockMigrator
+func (_mock *MockMigrator) DownTo(ctx context.Context, version int64) error {
+	ret := _mock.Called(ctx, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DownTo")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = returnFunc(ctx, version)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockMigrator_DownTo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DownTo'
+type MockMigrator_DownTo_Call struct {
+	*mock.Call
+}
+
+// DownTo is a helper method to define mock.On call
+//   - ctx context.Context
+//   - version int64
+func (_e *MockMigrator_Expecter) DownTo(ctx any, version any) *MockMigrator_DownTo_Call {
+	return &MockMigrator_DownTo_Call{Call: _e.mock.On("DownTo", ctx, version)}
+}
+
+func (_c *MockMigrator_DownTo_Call) Run(run func(ctx context.Context, version int64)) *MockMigrator_DownTo_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMigrator_DownTo_Call) Return(err error) *MockMigrator_DownTo_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockMigrator_DownTo_Call) RunAndReturn(run func(ctx context.Context, version int64) error) *MockMigrator_DownTo_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Initialize provides a mock function for the type MockMigrator
+func (_mock *MockMigrator) Initialize() error {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Initialize")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func() error); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockMigrator_Initialize_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Initialize'
+type MockMigrator_Initialize_Call struct {
+	*mock.Call
+}
+
+// Initialize is a helper method to define mock.On call
+func (_e *MockMigrator_Expecter) Initialize() *MockMigrator_Initialize_Call {
+	return &MockMigrator_Initialize_Call{Call: _e.mock.On("Initialize")}
+}
+
+func (_c *MockMigrator_Initialize_Call) Run(run func()) *MockMigrator_Initialize_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockMigrator_Initialize_Call) Return(err error) *MockMigrator_Initialize_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockMigrator_Initialize_Call) RunAndReturn(run func() error) *MockMigrator_Initialize_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Pending provides a mock function for the type MockMigrator
+func (_mock *MockMigrator) Pending(ctx context.Context) ([]*migration.Status, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Pending")
+	}
+
+	var r0 []*migration.Status
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]*migration.Status, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []*migration.Status); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*migration.Status)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMigrator_Pending_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Pending'
+type MockMigrator_Pending_Call struct {
+	*mock.Call
+}
+
+// Pending is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockMigrator_Expecter) Pending(ctx any) *MockMigrator_Pending_Call {
+	return &MockMigrator_Pending_Call{Call: _e.mock.On("Pending", ctx)}
+}
+
+func (_c *MockMigrator_Pending_Call) Run(run func(ctx context.Context)) *MockMigrator_Pending_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMigrator_Pending_Call) Return(statuss []*migration.Status, err error) *MockMigrator_Pending_Call {
+	_c.Call.Return(statuss, err)
+	return _c
+}
+
+func (_c *MockMigrator_Pending_Call) RunAndReturn(run func(ctx context.Context) ([]*migration.Status, error)) *MockMigrator_Pending_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Redo provides a mock function for the type MockMigrator
+func (_mock *MockMigrator) Redo(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Redo")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockMigrator_Redo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Redo'
+type MockMigrator_Redo_Call struct {
+	*mock.Call
+}
+
+// Redo is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockMigrator_Expecter) Redo(ctx any) *MockMigrator_Redo_Call {
+	return &MockMigrator_Redo_Call{Call: _e.mock.On("Redo", ctx)}
+}
+
+func (_c *MockMigrator_Redo_Call) Run(run func(ctx context.Context)) *MockMigrator_Redo_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMigrator_Redo_Call) Return(err error) *MockMigrator_Redo_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockMigrator_Redo_Call) RunAndReturn(run func(ctx context.Context) error) *MockMigrator_Redo_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Reset provides a mock function for the type MockMigrator
+func (_mock *MockMigrator) Reset(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reset")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockMigrator_Reset_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reset'
+type MockMigrator_Reset_Call struct {
+	*mock.Call
+}
+
+// Reset is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockMigrator_Expecter) Reset(ctx any) *MockMigrator_Reset_Call {
+	return &MockMigrator_Reset_Call{Call: _e.mock.On("Reset", ctx)}
+}
+
+func (_c *MockMigrator_Reset_Call) Run(run func(ctx context.Context)) *MockMigrator_Reset_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMigrator_Reset_Call) Return(err error) *MockMigrator_Reset_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockMigrator_Reset_Call) RunAndReturn(run func(ctx context.Context) error) *MockMigrator_Reset_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Status provides a mock function for the type MockMigrator
+func (_mock *MockMigrator) Status(ctx context.Context) ([]*migration.Status, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Status")
+	}
+
+	var r0 []*migration.Status
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]*migration.Status, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []*migration.Status); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*migration.Status)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMigrator_Status_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Status'
+type MockMigrator_Status_Call struct {
+	*mock.Call
+}
+
+// Status is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockMigrator_Expecter) Status(ctx any) *MockMigrator_Status_Call {
+	return &MockMigrator_Status_Call{Call: _e.mock.On("Status", ctx)}
+}
+
+func (_c *MockMigrator_Status_Call) Run(run func(ctx context.Context)) *MockMigrator_Status_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMigrator_Status_Call) Return(statuss []*migration.Status, err error) *MockMigrator_Status_Call {
+	_c.Call.Return(statuss, err)
+	return _c
+}
+
+func (_c *MockMigrator_Status_Call) RunAndReturn(run func(ctx context.Context) ([]*migration.Status, error)) *MockMigrator_Status_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Up provides a mock function for the type MockMigrator
+func (_mock *MockMigrator) Up(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Up")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockMigrator_Up_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Up'
+type MockMigrator_Up_Call struct {
+	*mock.Call
+}
+
+// Up is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockMigrator_Expecter) Up(ctx any) *MockMigrator_Up_Call {
+	return &MockMigrator_Up_Call{Call: _e.mock.On("Up", ctx)}
+}
+
+func (_c *MockMigrator_Up_Call) Run(run func(ctx context.Context)) *MockMigrator_Up_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMigrator_Up_Call) Return(err error) *MockMigrator_Up_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockMigrator_Up_Call) RunAndReturn(run func(ctx context.Context) error) *MockMigrator_Up_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpTo provides a mock function for the type MockMigrator
+func (_mock *MockMigrator) UpTo(ctx context.Context, version int64) error {
+	ret := _mock.Called(ctx, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpTo")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = returnFunc(ctx, version)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockMigrator_UpTo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpTo'
+type MockMigrator_UpTo_Call struct {
+	*mock.Call
+}
+
+// UpTo is a helper method to define mock.On call
+//   - ctx context.Context
+//   - version int64
+func (_e *MockMigrator_Expecter) UpTo(ctx any, version any) *MockMigrator_UpTo_Call {
+	return &MockMigrator_UpTo_Call{Call: _e.mock.On("UpTo", ctx, version)}
+}
+
+func (_c *MockMigrator_UpTo_Call) Run(run func(ctx context.Context, version int64)) *MockMigrator_UpTo_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMigrator_UpTo_Call) Return(err error) *MockMigrator_UpTo_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockMigrator_UpTo_Call) RunAndReturn(run func(ctx context.Context, version int64) error) *MockMigrator_UpTo_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Version provides a mock function for the type MockMigrator
+func (_mock *MockMigrator) Version(ctx context.Context) (int64, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Version")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMigrator_Version_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Version'
+type MockMigrator_Version_Call struct {
+	*mock.Call
+}
+
+// Version is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockMigrator_Expecter) Version(ctx any) *MockMigrator_Version_Call {
+	return &MockMigrator_Version_Call{Call: _e.mock.On("Version", ctx)}
+}
+
+func (_c *MockMigrator_Version_Call) Run(run func(ctx context.Context)) *MockMigrator_Version_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMigrator_Version_Call) Return(n int64, err error) *MockMigrator_Version_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockMigrator_Version_Call) RunAndReturn(run func(ctx context.Context) (int64, error)) *MockMigrator_Version_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,212 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/config"
+	"github.com/ElfAstAhe/go-service-template/pkg/container"
+	"github.com/ElfAstAhe/go-service-template/pkg/db/lock"
+	"github.com/ElfAstAhe/go-service-template/pkg/errs"
+	"github.com/ElfAstAhe/go-service-template/pkg/logger"
+)
+
+// Startup - миграция схемы БД при старте реплики.
+// В режиме migrate миграции применяются под сессионной advisory блокировкой, реплики применяют их по очереди,
+// в режиме wait реплика не мигрирует и ожидает, пока схема не будет приведена к актуальной версии другой репликой.
+// Признак готовности (IsReady) используется как дополнительное условие /readyz.
+type Startup struct {
+	name     string
+	migrator Migrator
+	locker   lock.Locker
+	config   *config.MigrationConfig
+	log      logger.Logger
+	ready    *atomic.Bool
+}
+
+func NewStartup(
+	name string,
+	migrator Migrator,
+	locker lock.Locker,
+	config *config.MigrationConfig,
+	log logger.Logger,
+) (*Startup, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errs.NewCommonError(fmt.Sprintf("migration startup %s invalid config", name), err)
+	}
+
+	return &Startup{
+		name:     name,
+		migrator: migrator,
+		locker:   locker,
+		config:   config,
+		log:      log.GetLogger(name),
+		ready:    new(atomic.Bool),
+	}, nil
+}
+
+// Run миграция (ожидание миграции) согласно режиму
+func (s *Startup) Run(ctx context.Context) error {
+	var err error
+	switch s.config.Mode {
+	case config.MigrationModeMigrate:
+		err = s.migrate(ctx)
+	case config.MigrationModeWait:
+		err = s.wait(ctx)
+	case config.MigrationModeSkip:
+		s.log.Infof("migration startup %s skipped", s.GetName())
+	default:
+		err = errs.NewInvalidArgumentError("Mode", s.config.Mode)
+	}
+	if err != nil {
+		return err
+	}
+
+	s.ready.Store(true)
+
+	return nil
+}
+
+// IsReady схема БД приведена к актуальной версии
+func (s *Startup) IsReady() bool {
+	return s.ready.Load()
+}
+
+func (s *Startup) GetName() string {
+	return s.name
+}
+
+func (s *Startup) GetConfig() *config.MigrationConfig {
+	return s.config
+}
+
+func (s *Startup) migrate(ctx context.Context) error {
+	s.log.Debugf("migration startup %s acquiring lock [%s]", s.GetName(), s.config.LockKey)
+
+	lockCtx, cancel := context.WithTimeout(ctx, s.config.LockTimeout)
+	defer cancel()
+
+	mLock, err := s.locker.Lock(lockCtx, s.config.LockKey)
+	if err != nil {
+		return errs.NewDBMigrationError(fmt.Sprintf("migration lock [%s] not acquired within %v", s.config.LockKey, s.config.LockTimeout), err)
+	}
+	defer func() {
+		if err := mLock.Unlock(context.WithoutCancel(ctx)); err != nil {
+			s.log.Warnf("migration startup %s release lock failed: %v", s.GetName(), err)
+		}
+	}()
+
+	s.log.Infof("migration startup %s lock acquired, migrate up", s.GetName())
+	if err := s.migrator.Up(ctx); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *Startup) wait(ctx context.Context) error {
+	waitCtx, cancel := context.WithTimeout(ctx, s.config.WaitTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(s.config.WaitInterval)
+	defer ticker.Stop()
+
+	for {
+		pending, err := s.migrator.Pending(waitCtx)
+		if err != nil {
+			s.log.Warnf("migration startup %s check pending failed: %v", s.GetName(), err)
+		} else if len(pending) == 0 {
+			s.log.Infof("migration startup %s schema is up to date", s.GetName())
+
+			return nil
+		} else {
+			s.log.Debugf("migration startup %s waiting, pending migrations [%d]", s.GetName(), len(pending))
+		}
+
+		select {
+		case <-waitCtx.Done():
+			return errs.NewDBMigrationError(fmt.Sprintf("schema not migrated within %v", s.config.WaitTimeout), waitCtx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// StartupRunner - фоновая миграция при старте (MigrationConfig.Async),
+// серверы стартуют сразу, готовность выставляется по завершении миграции
+type StartupRunner struct {
+	startup *Startup
+	// context
+	ctx    context.Context
+	cancel context.CancelFunc
+	// sync
+	wg sync.WaitGroup
+	//
+	running *atomic.Bool
+}
+
+var _ container.Runner = (*StartupRunner)(nil)
+
+func NewStartupRunner(startup *Startup) *StartupRunner {
+	return &StartupRunner{
+		startup: startup,
+		running: new(atomic.Bool),
+	}
+}
+
+func (sr *StartupRunner) Start(ctx context.Context) error {
+	if !sr.running.CompareAndSwap(false, true) {
+		return errs.NewCommonError(fmt.Sprintf("migration runner %s already started", sr.GetName()), nil)
+	}
+
+	sr.ctx, sr.cancel = context.WithCancel(ctx)
+
+	sr.wg.Add(1)
+	go func() {
+		defer sr.wg.Done()
+
+		if err := sr.startup.Run(sr.ctx); err != nil {
+			sr.startup.log.Errorf("migration runner %s failed: %v", sr.GetName(), err)
+		}
+	}()
+
+	return nil
+}
+
+func (sr *StartupRunner) Stop(stopCtx context.Context) error {
+	if !sr.running.CompareAndSwap(true, false) {
+		return errs.NewCommonError(fmt.Sprintf("migration runner %s is not running", sr.GetName()), nil)
+	}
+
+	if sr.cancel != nil {
+		sr.cancel()
+	}
+
+	stopChan := make(chan struct{})
+	go func() {
+		sr.wg.Wait()
+		close(stopChan)
+	}()
+	select {
+	case <-stopChan:
+	case <-stopCtx.Done():
+		return errs.NewCommonError(fmt.Sprintf("migration runner %s stop timed out", sr.GetName()), stopCtx.Err())
+	}
+
+	return nil
+}
+
+func (sr *StartupRunner) GetName() string {
+	return sr.startup.GetName()
+}
+
+func (sr *StartupRunner) IsRunning() bool {
+	return sr.running.Load()
+}
+
+// IsReady схема БД приведена к актуальной версии
+func (sr *StartupRunner) IsReady() bool {
+	return sr.startup.IsReady()
+}
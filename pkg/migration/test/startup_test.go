@@ -0,0 +1,147 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/config"
+	lockmocks "github.com/ElfAstAhe/go-service-template/pkg/db/lock/mocks"
+	logmocks "github.com/ElfAstAhe/go-service-template/pkg/logger/mocks"
+	"github.com/ElfAstAhe/go-service-template/pkg/migration"
+	"github.com/ElfAstAhe/go-service-template/pkg/migration/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLogger(t *testing.T) *logmocks.MockLogger {
+	log := logmocks.NewMockLogger(t)
+	log.On("GetLogger", mock.Anything).Return(log).Maybe()
+	log.On("Debugf", mock.Anything, mock.Anything).Maybe()
+	log.On("Debugf", mock.Anything, mock.Anything, mock.Anything).Maybe()
+	log.On("Infof", mock.Anything, mock.Anything).Maybe()
+	log.On("Warnf", mock.Anything, mock.Anything, mock.Anything).Maybe()
+	log.On("Errorf", mock.Anything, mock.Anything, mock.Anything).Maybe()
+
+	return log
+}
+
+func newTestMigrationConfig(mode config.MigrationMode, async bool) *config.MigrationConfig {
+	return config.NewMigrationConfig(mode, async, "db-migration", 50*time.Millisecond, 100*time.Millisecond, 5*time.Millisecond)
+}
+
+func TestStartup_Config_Validate(t *testing.T) {
+	_, err := migration.NewStartup("startup", mocks.NewMockMigrator(t), lockmocks.NewMockLocker(t), newTestMigrationConfig("unknown", false), newTestLogger(t))
+	assert.Error(t, err)
+}
+
+func TestStartup_Migrate_UnderLock(t *testing.T) {
+	var calls []string
+
+	mLock := lockmocks.NewMockLock(t)
+	mLock.On("Unlock", mock.Anything).Run(func(args mock.Arguments) { calls = append(calls, "unlock") }).Return(nil).Once()
+
+	mLocker := lockmocks.NewMockLocker(t)
+	mLocker.On("Lock", mock.Anything, "db-migration").Run(func(args mock.Arguments) { calls = append(calls, "lock") }).Return(mLock, nil).Once()
+
+	mMigrator := mocks.NewMockMigrator(t)
+	mMigrator.On("Up", mock.Anything).Run(func(args mock.Arguments) { calls = append(calls, "up") }).Return(nil).Once()
+
+	startup, err := migration.NewStartup("startup", mMigrator, mLocker, newTestMigrationConfig(config.MigrationModeMigrate, false), newTestLogger(t))
+	require.NoError(t, err)
+	assert.False(t, startup.IsReady())
+
+	require.NoError(t, startup.Run(context.Background()))
+	assert.True(t, startup.IsReady())
+	assert.Equal(t, []string{"lock", "up", "unlock"}, calls)
+}
+
+func TestStartup_Migrate_LockTimeout(t *testing.T) {
+	mLocker := lockmocks.NewMockLocker(t)
+	mLocker.On("Lock", mock.Anything, "db-migration").Return(nil, context.DeadlineExceeded).Once()
+
+	startup, err := migration.NewStartup("startup", mocks.NewMockMigrator(t), mLocker, newTestMigrationConfig(config.MigrationModeMigrate, false), newTestLogger(t))
+	require.NoError(t, err)
+
+	assert.Error(t, startup.Run(context.Background()))
+	assert.False(t, startup.IsReady())
+}
+
+func TestStartup_Migrate_UpFailed_ReleasesLock(t *testing.T) {
+	mLock := lockmocks.NewMockLock(t)
+	mLock.On("Unlock", mock.Anything).Return(nil).Once()
+
+	mLocker := lockmocks.NewMockLocker(t)
+	mLocker.On("Lock", mock.Anything, "db-migration").Return(mLock, nil).Once()
+
+	mMigrator := mocks.NewMockMigrator(t)
+	mMigrator.On("Up", mock.Anything).Return(errors.New("up failed")).Once()
+
+	startup, err := migration.NewStartup("startup", mMigrator, mLocker, newTestMigrationConfig(config.MigrationModeMigrate, false), newTestLogger(t))
+	require.NoError(t, err)
+
+	assert.Error(t, startup.Run(context.Background()))
+	assert.False(t, startup.IsReady())
+}
+
+func TestStartup_Wait_UntilApplied(t *testing.T) {
+	mMigrator := mocks.NewMockMigrator(t)
+	mMigrator.On("Pending", mock.Anything).Return([]*migration.Status{{Version: 1, State: migration.StatePending}}, nil).Twice()
+	mMigrator.On("Pending", mock.Anything).Return([]*migration.Status{}, nil).Once()
+
+	startup, err := migration.NewStartup("startup", mMigrator, lockmocks.NewMockLocker(t), newTestMigrationConfig(config.MigrationModeWait, false), newTestLogger(t))
+	require.NoError(t, err)
+
+	require.NoError(t, startup.Run(context.Background()))
+	assert.True(t, startup.IsReady())
+}
+
+func TestStartup_Wait_Timeout(t *testing.T) {
+	mMigrator := mocks.NewMockMigrator(t)
+	mMigrator.On("Pending", mock.Anything).Return([]*migration.Status{{Version: 1, State: migration.StatePending}}, nil)
+
+	startup, err := migration.NewStartup("startup", mMigrator, lockmocks.NewMockLocker(t), newTestMigrationConfig(config.MigrationModeWait, false), newTestLogger(t))
+	require.NoError(t, err)
+
+	assert.Error(t, startup.Run(context.Background()))
+	assert.False(t, startup.IsReady())
+}
+
+func TestStartup_Skip(t *testing.T) {
+	startup, err := migration.NewStartup("startup", mocks.NewMockMigrator(t), lockmocks.NewMockLocker(t), newTestMigrationConfig(config.MigrationModeSkip, false), newTestLogger(t))
+	require.NoError(t, err)
+
+	require.NoError(t, startup.Run(context.Background()))
+	assert.True(t, startup.IsReady())
+}
+
+func TestStartupRunner_Async(t *testing.T) {
+	release := make(chan struct{})
+
+	mMigrator := mocks.NewMockMigrator(t)
+	mMigrator.On("Pending", mock.Anything).Return(func(ctx context.Context) ([]*migration.Status, error) {
+		select {
+		case <-release:
+			return nil, nil
+		default:
+			return []*migration.Status{{Version: 1, State: migration.StatePending}}, nil
+		}
+	})
+
+	conf := config.NewMigrationConfig(config.MigrationModeWait, true, "db-migration", time.Second, time.Second, 5*time.Millisecond)
+	startup, err := migration.NewStartup("startup", mMigrator, lockmocks.NewMockLocker(t), conf, newTestLogger(t))
+	require.NoError(t, err)
+	runner := migration.NewStartupRunner(startup)
+
+	require.NoError(t, runner.Start(context.Background()))
+	assert.True(t, runner.IsRunning())
+	assert.False(t, runner.IsReady(), "До применения миграций реплика не должна быть готова")
+
+	close(release)
+	assert.Eventually(t, runner.IsReady, time.Second, 5*time.Millisecond)
+
+	require.NoError(t, runner.Stop(context.Background()))
+	assert.False(t, runner.IsRunning())
+}
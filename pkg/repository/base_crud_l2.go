@@ -39,32 +39,33 @@ func NewBaseCRUDL2Repository[E domain.Entity[ID], ID comparable](
 }
 
 func (bcl *BaseCRUDL2Repository[E, ID]) Find(ctx context.Context, id ID) (E, error) {
-	// from cache
-	res, ok, err := bcl.crudCache.Get(id)
+	res, found, err := bcl.crudCache.GetOrLoad(ctx, id, bcl.load, bcl.defaultTTL)
 	if err != nil {
-		return bcl.nilEntity, errs.NewDalCacheError("BaseCRUDL2Repository.Find", fmt.Sprintf("get from cache entity id [%v]", id), err)
-	}
-	if ok {
-		if utils.IsNil(res) {
-			return res, errs.NewDalNotFoundError(bcl.GetInfo().Entity, "not found", nil)
+		if _, ok := errors.AsType[*errs.DalCacheError](err); !ok {
+			return bcl.nilEntity, err
 		}
-
-		return res, nil
+		// результат загружен, но не сохранен в кэш (негативный результат - ниже как not found)
+		bcl.log.Errorf("set into cache entity id [%v]: %v", id, err)
 	}
-	// orig op
-	res, err = bcl.next.Find(ctx, id)
+	if !found || utils.IsNil(res) {
+		return bcl.nilEntity, errs.NewDalNotFoundError(bcl.GetInfo().Entity, "not found", nil)
+	}
+
+	return res, nil
+}
+
+// load загрузчик кэша, отсутствие сущности - негативный результат
+func (bcl *BaseCRUDL2Repository[E, ID]) load(ctx context.Context, id ID) (E, bool, error) {
+	res, err := bcl.next.Find(ctx, id)
 	if err != nil {
-		if _, ok := errors.AsType[*errs.DalNotFoundError](err); !ok {
-			return res, err
+		if _, ok := errors.AsType[*errs.DalNotFoundError](err); ok {
+			return bcl.nilEntity, false, nil
 		}
-	}
-	// put into cache
-	cacheErr := bcl.crudCache.Set(id, res, bcl.defaultTTL)
-	if cacheErr != nil {
-		bcl.log.Errorf(fmt.Sprintf("set into cache entity id [%v]", id), cacheErr)
+
+		return bcl.nilEntity, false, err
 	}
 
-	return res, err
+	return res, true, nil
 }
 
 func (bcl *BaseCRUDL2Repository[E, ID]) List(ctx context.Context, limit, offset int) ([]E, error) {
@@ -0,0 +1,182 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/domain"
+	"github.com/ElfAstAhe/go-service-template/pkg/errs"
+	"github.com/ElfAstAhe/go-service-template/pkg/infra/cache"
+	"github.com/ElfAstAhe/go-service-template/pkg/logger"
+)
+
+// BaseOwnedL2Repository - кэширующая обертка OwnedRepository: экземпляры кэшируются по ключу владельца и идентификатора
+// с тегом владельца, поэтому изменение всего набора владельца (Save, DeleteAll) инвалидирует его записи разом.
+// Списки не кэшируются. Хранилище кэша должно поддерживать теги (cache.TaggedStorage).
+type BaseOwnedL2Repository[T domain.Entity[ID], ID comparable, OwnerID comparable] struct {
+	next       domain.OwnedRepository[T, ID, OwnerID]
+	entityInfo *EntityInfo
+	ownedCache cache.Cache[string, T]
+	nilEntity  T
+	defaultTTL time.Duration
+	log        logger.Logger
+}
+
+var _ domain.OwnedRepository[domain.Entity[string], string, string] = (*BaseOwnedL2Repository[domain.Entity[string], string, string])(nil)
+
+func NewBaseOwnedL2Repository[T domain.Entity[ID], ID comparable, OwnerID comparable](
+	next domain.OwnedRepository[T, ID, OwnerID],
+	entityInfo *EntityInfo,
+	ownedCache cache.Cache[string, T],
+	defaultTTL time.Duration,
+	log logger.Logger,
+) *BaseOwnedL2Repository[T, ID, OwnerID] {
+	return &BaseOwnedL2Repository[T, ID, OwnerID]{
+		next:       next,
+		entityInfo: entityInfo,
+		ownedCache: ownedCache,
+		defaultTTL: defaultTTL,
+		log:        log.GetLogger("BaseOwnedL2Repository"),
+	}
+}
+
+func (bol *BaseOwnedL2Repository[T, ID, OwnerID]) Find(ctx context.Context, ownerID OwnerID, id ID) (T, error) {
+	key := bol.ownedKey(ownerID, id)
+	res, found, err := bol.ownedCache.Get(key)
+	if err != nil {
+		bol.log.Errorf("get from cache entity key [%s]: %v", key, err)
+	}
+	if found {
+		return res, nil
+	}
+
+	// orig op
+	res, err = bol.next.Find(ctx, ownerID, id)
+	if err != nil {
+		return bol.nilEntity, err
+	}
+	bol.put(ownerID, res)
+
+	return res, nil
+}
+
+func (bol *BaseOwnedL2Repository[T, ID, OwnerID]) List(ctx context.Context, ownerID OwnerID, limit, offset int) ([]T, error) {
+	// orig op
+	return bol.next.List(ctx, ownerID, limit, offset)
+}
+
+func (bol *BaseOwnedL2Repository[T, ID, OwnerID]) ListAll(ctx context.Context, ownerID OwnerID) ([]T, error) {
+	// orig op
+	return bol.next.ListAll(ctx, ownerID)
+}
+
+func (bol *BaseOwnedL2Repository[T, ID, OwnerID]) ListAllByOwners(ctx context.Context, ownerIDs ...OwnerID) (map[OwnerID][]T, error) {
+	// orig op
+	return bol.next.ListAllByOwners(ctx, ownerIDs...)
+}
+
+// Save замена набора владельца: прежние записи владельца инвалидируются, сохраненные помещаются в кэш
+func (bol *BaseOwnedL2Repository[T, ID, OwnerID]) Save(ctx context.Context, ownerID OwnerID, owned []T) ([]T, error) {
+	// orig op
+	res, err := bol.next.Save(ctx, ownerID, owned)
+	// набор мог измениться частично и при ошибке
+	bol.invalidateOwner(ownerID)
+	if err != nil {
+		return res, err
+	}
+	for _, entity := range res {
+		bol.put(ownerID, entity)
+	}
+
+	return res, nil
+}
+
+func (bol *BaseOwnedL2Repository[T, ID, OwnerID]) Create(ctx context.Context, ownerID OwnerID, entity T) (T, error) {
+	// orig op
+	res, err := bol.next.Create(ctx, ownerID, entity)
+	if err != nil {
+		return bol.nilEntity, err
+	}
+	bol.put(ownerID, res)
+
+	return res, nil
+}
+
+func (bol *BaseOwnedL2Repository[T, ID, OwnerID]) Change(ctx context.Context, ownerID OwnerID, entity T) (T, error) {
+	// orig op
+	res, err := bol.next.Change(ctx, ownerID, entity)
+	if err != nil {
+		if _, ok := errors.AsType[*errs.DalNotFoundError](err); ok {
+			bol.ownedCache.Delete(bol.ownedKey(ownerID, entity.GetID()))
+		}
+
+		return res, err
+	}
+	bol.put(ownerID, res)
+
+	return res, nil
+}
+
+// DeleteAll удаление всех экземпляров владельца с инвалидацией его записей по тегу
+func (bol *BaseOwnedL2Repository[T, ID, OwnerID]) DeleteAll(ctx context.Context, ownerID OwnerID) error {
+	err := bol.next.DeleteAll(ctx, ownerID)
+	if err != nil {
+		return err
+	}
+	bol.invalidateOwner(ownerID)
+
+	return nil
+}
+
+func (bol *BaseOwnedL2Repository[T, ID, OwnerID]) Delete(ctx context.Context, ownerID OwnerID, id ID) error {
+	err := bol.next.Delete(ctx, ownerID, id)
+	if err != nil {
+		return err
+	}
+	// delete from owned cache
+	bol.ownedCache.Delete(bol.ownedKey(ownerID, id))
+
+	return nil
+}
+
+func (bol *BaseOwnedL2Repository[T, ID, OwnerID]) GetInfo() *EntityInfo {
+	return bol.entityInfo
+}
+
+func (bol *BaseOwnedL2Repository[T, ID, OwnerID]) GetCache() cache.Cache[string, T] {
+	return bol.ownedCache
+}
+
+func (bol *BaseOwnedL2Repository[T, ID, OwnerID]) GetDefaultTTL() time.Duration {
+	return bol.defaultTTL
+}
+
+func (bol *BaseOwnedL2Repository[T, ID, OwnerID]) GetLogger() logger.Logger {
+	return bol.log
+}
+
+// OwnerTag тег записей кэша владельца
+func (bol *BaseOwnedL2Repository[T, ID, OwnerID]) OwnerTag(ownerID OwnerID) string {
+	return fmt.Sprintf("%s:owner:%v", bol.GetInfo().Entity, ownerID)
+}
+
+func (bol *BaseOwnedL2Repository[T, ID, OwnerID]) ownedKey(ownerID OwnerID, id ID) string {
+	return fmt.Sprintf("%v/%v", ownerID, id)
+}
+
+func (bol *BaseOwnedL2Repository[T, ID, OwnerID]) put(ownerID OwnerID, entity T) {
+	key := bol.ownedKey(ownerID, entity.GetID())
+	if err := bol.ownedCache.SetWithTags(key, entity, bol.defaultTTL, bol.OwnerTag(ownerID)); err != nil {
+		// запись без тега не будет инвалидирована DeleteAll
+		bol.ownedCache.Delete(key)
+		bol.log.Errorf("set into cache entity key [%s]: %v", key, err)
+	}
+}
+
+func (bol *BaseOwnedL2Repository[T, ID, OwnerID]) invalidateOwner(ownerID OwnerID) {
+	if _, err := bol.ownedCache.InvalidateTag(bol.OwnerTag(ownerID)); err != nil {
+		bol.log.Errorf("invalidate cache owner [%v]: %v", ownerID, err)
+	}
+}
@@ -0,0 +1,154 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/domain/mocks"
+	"github.com/ElfAstAhe/go-service-template/pkg/errs"
+	"github.com/ElfAstAhe/go-service-template/pkg/infra/cache"
+	logmocks "github.com/ElfAstAhe/go-service-template/pkg/logger/mocks"
+	"github.com/ElfAstAhe/go-service-template/pkg/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	ownerA = "owner-a"
+	ownerB = "owner-b"
+)
+
+type testOwned struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func (to *testOwned) GetID() string         { return to.ID }
+func (to *testOwned) SetID(id string)       { to.ID = id }
+func (to *testOwned) IsExists() bool        { return to.ID != "" }
+func (to *testOwned) BeforeCreate() error   { return nil }
+func (to *testOwned) BeforeChange() error   { return nil }
+func (to *testOwned) ValidateCreate() error { return nil }
+func (to *testOwned) ValidateChange() error { return nil }
+
+func newTestOwnedL2Repository(t *testing.T) (*repository.BaseOwnedL2Repository[*testOwned, string, string], *mocks.MockOwnedRepository[*testOwned, string, string]) {
+	log := logmocks.NewMockLogger(t)
+	log.On("GetLogger", mock.Anything).Return(log).Maybe()
+	log.On("Errorf", mock.Anything, mock.Anything, mock.Anything).Maybe()
+
+	next := mocks.NewMockOwnedRepository[*testOwned, string, string](t)
+	ownedCache := cache.New[string, *testOwned](
+		cache.NewRawStorage[string](0, cache.NewLRUEvict[string]()),
+		cache.NewJSONCodec[*testOwned](func() *testOwned { return new(testOwned) }),
+		100,
+	)
+
+	return repository.NewBaseOwnedL2Repository[*testOwned, string, string](
+		next,
+		repository.NewEntityInfo("test_owned", "testOwned"),
+		ownedCache,
+		time.Hour,
+		log,
+	), next
+}
+
+func TestBaseOwnedL2Repository_FindThenDeleteAll(t *testing.T) {
+	ctx := context.Background()
+	repo, next := newTestOwnedL2Repository(t)
+
+	next.On("Find", mock.Anything, ownerA, "1").Return(&testOwned{ID: "1", Name: "first"}, nil).Once()
+	next.On("Find", mock.Anything, ownerB, "1").Return(&testOwned{ID: "1", Name: "other"}, nil).Once()
+	for range 2 {
+		res, err := repo.Find(ctx, ownerA, "1")
+		require.NoError(t, err)
+		assert.Equal(t, "first", res.Name)
+		res, err = repo.Find(ctx, ownerB, "1")
+		require.NoError(t, err)
+		assert.Equal(t, "other", res.Name)
+	}
+	next.AssertNumberOfCalls(t, "Find", 2)
+
+	next.On("DeleteAll", mock.Anything, ownerA).Return(nil).Once()
+	require.NoError(t, repo.DeleteAll(ctx, ownerA))
+
+	notFound := errs.NewDalNotFoundError("testOwned", "1", nil)
+	next.On("Find", mock.Anything, ownerA, "1").Return(nil, notFound).Once()
+	_, err := repo.Find(ctx, ownerA, "1")
+	assert.ErrorIs(t, err, notFound, "Записи владельца должны быть инвалидированы DeleteAll")
+
+	res, err := repo.Find(ctx, ownerB, "1")
+	require.NoError(t, err)
+	assert.Equal(t, "other", res.Name, "Записи другого владельца остаются в кэше")
+	next.AssertNumberOfCalls(t, "Find", 3)
+}
+
+func TestBaseOwnedL2Repository_DeleteAllError(t *testing.T) {
+	ctx := context.Background()
+	repo, next := newTestOwnedL2Repository(t)
+
+	next.On("Find", mock.Anything, ownerA, "1").Return(&testOwned{ID: "1", Name: "first"}, nil).Once()
+	_, err := repo.Find(ctx, ownerA, "1")
+	require.NoError(t, err)
+
+	failure := errors.New("delete failed")
+	next.On("DeleteAll", mock.Anything, ownerA).Return(failure).Once()
+	assert.ErrorIs(t, repo.DeleteAll(ctx, ownerA), failure)
+
+	res, err := repo.Find(ctx, ownerA, "1")
+	require.NoError(t, err)
+	assert.Equal(t, "first", res.Name)
+	next.AssertNumberOfCalls(t, "Find", 1)
+}
+
+func TestBaseOwnedL2Repository_SaveReplacesOwnerSet(t *testing.T) {
+	ctx := context.Background()
+	repo, next := newTestOwnedL2Repository(t)
+
+	next.On("Find", mock.Anything, ownerA, "1").Return(&testOwned{ID: "1", Name: "first"}, nil).Once()
+	next.On("Find", mock.Anything, ownerA, "2").Return(&testOwned{ID: "2", Name: "second"}, nil).Once()
+	for _, id := range []string{"1", "2"} {
+		_, err := repo.Find(ctx, ownerA, id)
+		require.NoError(t, err)
+	}
+
+	saved := []*testOwned{{ID: "2", Name: "second v2"}, {ID: "3", Name: "third"}}
+	next.On("Save", mock.Anything, ownerA, saved).Return(saved, nil).Once()
+	res, err := repo.Save(ctx, ownerA, saved)
+	require.NoError(t, err)
+	assert.Equal(t, saved, res)
+
+	notFound := errs.NewDalNotFoundError("testOwned", "1", nil)
+	next.On("Find", mock.Anything, ownerA, "1").Return(nil, notFound).Once()
+	_, err = repo.Find(ctx, ownerA, "1")
+	assert.ErrorIs(t, err, notFound, "Запись, отсутствующая в новом наборе, должна быть инвалидирована")
+
+	found, err := repo.Find(ctx, ownerA, "2")
+	require.NoError(t, err)
+	assert.Equal(t, "second v2", found.Name)
+	found, err = repo.Find(ctx, ownerA, "3")
+	require.NoError(t, err)
+	assert.Equal(t, "third", found.Name)
+	next.AssertNumberOfCalls(t, "Find", 3)
+}
+
+func TestBaseOwnedL2Repository_SaveErrorInvalidates(t *testing.T) {
+	ctx := context.Background()
+	repo, next := newTestOwnedL2Repository(t)
+
+	next.On("Find", mock.Anything, ownerA, "1").Return(&testOwned{ID: "1", Name: "first"}, nil).Twice()
+	_, err := repo.Find(ctx, ownerA, "1")
+	require.NoError(t, err)
+
+	failure := errors.New("save failed")
+	owned := []*testOwned{{ID: "1", Name: "first v2"}}
+	next.On("Save", mock.Anything, ownerA, owned).Return(nil, failure).Once()
+	_, err = repo.Save(ctx, ownerA, owned)
+	assert.ErrorIs(t, err, failure)
+
+	_, err = repo.Find(ctx, ownerA, "1")
+	require.NoError(t, err)
+	next.AssertNumberOfCalls(t, "Find", 2)
+}
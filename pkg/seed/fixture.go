@@ -0,0 +1,153 @@
+package seed
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/errs"
+	"go.yaml.in/yaml/v3"
+)
+
+// RefPrefix - префикс ссылки на запись другой фикстуры, формат "$ref:<entity>/<ref>",
+// при загрузке ссылка заменяется идентификатором созданной записи
+const RefPrefix string = "$ref:"
+
+// Fixture - набор записей одной сущности
+type Fixture struct {
+	// Entity имя сущности, по нему выбирается EntityLoader
+	Entity string `json:"entity" yaml:"entity"`
+	// DependsOn явные зависимости от других сущностей (ссылки $ref учитываются автоматически)
+	DependsOn []string `json:"depends_on,omitempty" yaml:"depends_on,omitempty"`
+	// Records записи
+	Records []*Record `json:"records" yaml:"records"`
+	// Source исходный файл фикстуры
+	Source string `json:"-" yaml:"-"`
+}
+
+// Record - запись фикстуры
+type Record struct {
+	// Ref локальный ключ записи для ссылок из других записей
+	Ref string `json:"ref,omitempty" yaml:"ref,omitempty"`
+	// Fields значения полей сущности
+	Fields map[string]any `json:"fields" yaml:"fields"`
+}
+
+func (f *Fixture) Validate() error {
+	if strings.TrimSpace(f.Entity) == "" {
+		return errs.NewInvalidArgumentError("Entity", f.Entity)
+	}
+	refs := make(map[string]struct{}, len(f.Records))
+	for i, record := range f.Records {
+		if record == nil {
+			return errs.NewInvalidArgumentError(fmt.Sprintf("Records[%d]", i), nil)
+		}
+		if record.Ref == "" {
+			continue
+		}
+		if _, ok := refs[record.Ref]; ok {
+			return errs.NewCommonError(fmt.Sprintf("fixture [%s] duplicate ref [%s]", f.Entity, record.Ref), nil)
+		}
+		refs[record.Ref] = struct{}{}
+	}
+
+	return nil
+}
+
+// ParseFixture разбор фикстуры, формат определяется по расширению (.yaml, .yml, .json)
+func ParseFixture(name string, data []byte) (*Fixture, error) {
+	res := &Fixture{Source: name}
+
+	var err error
+	switch strings.ToLower(path.Ext(name)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, res)
+	case ".json":
+		err = json.Unmarshal(data, res)
+	default:
+		return nil, errs.NewCommonError(fmt.Sprintf("fixture [%s] unsupported format", name), nil)
+	}
+	if err != nil {
+		return nil, errs.NewCommonError(fmt.Sprintf("fixture [%s] parse failed", name), err)
+	}
+	if err := res.Validate(); err != nil {
+		return nil, errs.NewCommonError(fmt.Sprintf("fixture [%s] invalid", name), err)
+	}
+
+	return res, nil
+}
+
+// LoadFS чтение всех фикстур каталога (без рекурсии) в порядке имен файлов
+func LoadFS(fsys fs.FS, dir string) ([]*Fixture, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, errs.NewCommonError(fmt.Sprintf("read fixtures dir [%s] failed", dir), err)
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !isFixtureFile(entry.Name()) {
+			continue
+		}
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+
+	res := make([]*Fixture, 0, len(names))
+	for _, name := range names {
+		fileName := path.Join(dir, name)
+		data, err := fs.ReadFile(fsys, fileName)
+		if err != nil {
+			return nil, errs.NewCommonError(fmt.Sprintf("read fixture [%s] failed", fileName), err)
+		}
+		fixture, err := ParseFixture(fileName, data)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, fixture)
+	}
+
+	return res, nil
+}
+
+func isFixtureFile(name string) bool {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".yaml", ".yml", ".json":
+		return true
+	default:
+		return false
+	}
+}
+
+// parseRef разбор ссылки "$ref:<entity>/<ref>"
+func parseRef(value string) (entity string, ref string, ok bool) {
+	if !strings.HasPrefix(value, RefPrefix) {
+		return "", "", false
+	}
+	entity, ref, ok = strings.Cut(strings.TrimPrefix(value, RefPrefix), "/")
+	if !ok || entity == "" || ref == "" {
+		return "", "", false
+	}
+
+	return entity, ref, true
+}
+
+// collectRefEntities сущности, на которые ссылаются записи фикстуры
+func collectRefEntities(value any, dest map[string]struct{}) {
+	switch v := value.(type) {
+	case string:
+		if entity, _, ok := parseRef(v); ok {
+			dest[entity] = struct{}{}
+		}
+	case map[string]any:
+		for _, item := range v {
+			collectRefEntities(item, dest)
+		}
+	case []any:
+		for _, item := range v {
+			collectRefEntities(item, dest)
+		}
+	}
+}
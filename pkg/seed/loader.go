@@ -0,0 +1,130 @@
+package seed
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/db"
+	"github.com/ElfAstAhe/go-service-template/pkg/domain"
+	"github.com/ElfAstAhe/go-service-template/pkg/errs"
+	"github.com/go-viper/mapstructure/v2"
+)
+
+// EntityLoader - загрузчик записей фикстур одной сущности
+type EntityLoader interface {
+	// Entity имя сущности
+	Entity() string
+	// Load создание записи, возвращает идентификатор для разрешения ссылок
+	Load(ctx context.Context, fields map[string]any) (any, error)
+	// Truncate удаление всех записей сущности
+	Truncate(ctx context.Context) error
+}
+
+// Decoder - преобразование полей фикстуры в экземпляр сущности
+type Decoder[T any] func(fields map[string]any, dest T) error
+
+// Truncator - удаление всех записей сущности
+type Truncator func(ctx context.Context) error
+
+// CRUDLoader - загрузчик через domain.CRUDRepository (Create), проходят штатные BeforeCreate/ValidateCreate
+type CRUDLoader[T domain.Entity[ID], ID comparable] struct {
+	entity    string
+	repo      domain.CRUDRepository[T, ID]
+	factory   func() T
+	decoder   Decoder[T]
+	truncator Truncator
+}
+
+var _ EntityLoader = (*CRUDLoader[domain.Entity[string], string])(nil)
+
+func NewCRUDLoader[T domain.Entity[ID], ID comparable](
+	entity string,
+	repo domain.CRUDRepository[T, ID],
+	factory func() T,
+	truncator Truncator,
+) *CRUDLoader[T, ID] {
+	return NewCRUDLoaderWithDecoder[T, ID](entity, repo, factory, DecodeFields[T], truncator)
+}
+
+func NewCRUDLoaderWithDecoder[T domain.Entity[ID], ID comparable](
+	entity string,
+	repo domain.CRUDRepository[T, ID],
+	factory func() T,
+	decoder Decoder[T],
+	truncator Truncator,
+) *CRUDLoader[T, ID] {
+	return &CRUDLoader[T, ID]{
+		entity:    entity,
+		repo:      repo,
+		factory:   factory,
+		decoder:   decoder,
+		truncator: truncator,
+	}
+}
+
+func (cl *CRUDLoader[T, ID]) Entity() string {
+	return cl.entity
+}
+
+func (cl *CRUDLoader[T, ID]) Load(ctx context.Context, fields map[string]any) (any, error) {
+	entity := cl.factory()
+	if err := cl.decoder(fields, entity); err != nil {
+		return nil, errs.NewCommonError(fmt.Sprintf("seed [%s] decode record failed", cl.entity), err)
+	}
+	created, err := cl.repo.Create(ctx, entity)
+	if err != nil {
+		return nil, err
+	}
+
+	return created.GetID(), nil
+}
+
+func (cl *CRUDLoader[T, ID]) Truncate(ctx context.Context) error {
+	if cl.truncator == nil {
+		return errs.NewNotImplementedError(errs.NewCommonError(fmt.Sprintf("seed [%s] truncator not applied", cl.entity), nil))
+	}
+
+	return cl.truncator(ctx)
+}
+
+// NewTableTruncator удаление всех записей таблицы в текущей транзакции
+func NewTableTruncator(executor db.Executor, table string) Truncator {
+	query := "delete from " + table
+
+	return func(ctx context.Context) error {
+		if _, err := executor.GetQuerier(ctx).ExecContext(ctx, query); err != nil {
+			return errs.NewDalError("seed.Truncate", fmt.Sprintf("truncate [%s]", table), err)
+		}
+
+		return nil
+	}
+}
+
+// DecodeFields преобразование полей фикстуры в структуру, имена полей сопоставляются
+// без учета регистра и символа "_" (created_at -> CreatedAt), строки RFC3339 преобразуются в time.Time
+func DecodeFields[T any](fields map[string]any, dest T) error {
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook: mapstructure.ComposeDecodeHookFunc(
+			mapstructure.StringToTimeHookFunc("2006-01-02T15:04:05Z07:00"),
+			mapstructure.StringToTimeDurationHookFunc(),
+		),
+		ErrorUnused:      true,
+		WeaklyTypedInput: true,
+		MatchName:        matchFieldName,
+		Result:           dest,
+	})
+	if err != nil {
+		return err
+	}
+	if reflect.ValueOf(dest).Kind() != reflect.Pointer {
+		return errs.NewInvalidArgumentError("dest", dest)
+	}
+
+	return decoder.Decode(fields)
+}
+
+func matchFieldName(mapKey, fieldName string) bool {
+	return strings.EqualFold(strings.ReplaceAll(mapKey, "_", ""), fieldName)
+}
@@ -0,0 +1,282 @@
+package seed
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/config"
+	"github.com/ElfAstAhe/go-service-template/pkg/db"
+	"github.com/ElfAstAhe/go-service-template/pkg/errs"
+	"github.com/ElfAstAhe/go-service-template/pkg/logger"
+)
+
+// Mode - режим загрузки фикстур
+type Mode string
+
+const (
+	// ModeLoad добавление записей к существующим данным
+	ModeLoad Mode = "load"
+	// ModeReload удаление всех записей загружаемых сущностей и повторная загрузка
+	ModeReload Mode = "reload"
+)
+
+// Refs - идентификаторы созданных записей по ключу "<entity>/<ref>"
+type Refs map[string]any
+
+// Get идентификатор созданной записи
+func (r Refs) Get(entity, ref string) (any, bool) {
+	res, ok := r[refKey(entity, ref)]
+
+	return res, ok
+}
+
+// Seeder - загрузка справочных и демонстрационных данных для dev и test окружений.
+// Все записи создаются через репозитории в рамках одной транзакции, порядок сущностей
+// определяется зависимостями (DependsOn и ссылки $ref). В prod окружении загрузка запрещена.
+type Seeder struct {
+	env       config.AppEnv
+	txManager db.TransactionManager
+	loaders   map[string]EntityLoader
+	log       logger.Logger
+}
+
+func NewSeeder(
+	env config.AppEnv,
+	txManager db.TransactionManager,
+	log logger.Logger,
+	loaders ...EntityLoader,
+) (*Seeder, error) {
+	res := &Seeder{
+		env:       env,
+		txManager: txManager,
+		loaders:   make(map[string]EntityLoader, len(loaders)),
+		log:       log.GetLogger("seeder"),
+	}
+	for _, loader := range loaders {
+		if err := res.Register(loader); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+// Register регистрация загрузчика сущности
+func (s *Seeder) Register(loader EntityLoader) error {
+	if loader == nil {
+		return errs.NewInvalidArgumentError("loader", nil)
+	}
+	if _, ok := s.loaders[loader.Entity()]; ok {
+		return errs.NewCommonError(fmt.Sprintf("seed loader [%s] already registered", loader.Entity()), nil)
+	}
+	s.loaders[loader.Entity()] = loader
+
+	return nil
+}
+
+// Seed загрузка фикстур в одной транзакции
+func (s *Seeder) Seed(ctx context.Context, mode Mode, fixtures ...*Fixture) (Refs, error) {
+	if s.env == config.AppEnvProduction {
+		return nil, errs.NewCommonError(fmt.Sprintf("seeding is not allowed in [%s] environment", s.env), nil)
+	}
+	if mode != ModeLoad && mode != ModeReload {
+		return nil, errs.NewInvalidArgumentError("mode", mode)
+	}
+
+	order, err := s.resolveOrder(fixtures)
+	if err != nil {
+		return nil, err
+	}
+
+	refs := make(Refs)
+	err = s.txManager.WithinTransaction(ctx, nil, func(ctx context.Context) error {
+		if mode == ModeReload {
+			// удаление в обратном порядке зависимостей
+			for i := len(order) - 1; i >= 0; i-- {
+				s.log.Debugf("seed truncate [%s]", order[i])
+				if err := s.loaders[order[i]].Truncate(ctx); err != nil {
+					return errs.NewCommonError(fmt.Sprintf("seed truncate [%s] failed", order[i]), err)
+				}
+			}
+		}
+		for _, entity := range order {
+			if err := s.loadEntity(ctx, entity, fixtures, refs); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.log.Infof("seed complete, entities [%d], records [%d]", len(order), countRecords(fixtures))
+
+	return refs, nil
+}
+
+func (s *Seeder) loadEntity(ctx context.Context, entity string, fixtures []*Fixture, refs Refs) error {
+	loader := s.loaders[entity]
+	for _, fixture := range fixtures {
+		if fixture.Entity != entity {
+			continue
+		}
+		for i, record := range fixture.Records {
+			fields, err := resolveFields(record.Fields, refs)
+			if err != nil {
+				return errs.NewCommonError(fmt.Sprintf("seed [%s] record [%d] from [%s]", entity, i, fixture.Source), err)
+			}
+			id, err := loader.Load(ctx, fields)
+			if err != nil {
+				return errs.NewCommonError(fmt.Sprintf("seed [%s] record [%d] from [%s] load failed", entity, i, fixture.Source), err)
+			}
+			if record.Ref != "" {
+				refs[refKey(entity, record.Ref)] = id
+			}
+		}
+		s.log.Debugf("seed [%s] loaded [%d] records from [%s]", entity, len(fixture.Records), fixture.Source)
+	}
+
+	return nil
+}
+
+// resolveOrder топологическая сортировка сущностей по зависимостям
+func (s *Seeder) resolveOrder(fixtures []*Fixture) ([]string, error) {
+	deps := make(map[string]map[string]struct{})
+	for _, fixture := range fixtures {
+		if err := fixture.Validate(); err != nil {
+			return nil, errs.NewCommonError(fmt.Sprintf("fixture [%s] invalid", fixture.Source), err)
+		}
+		if _, ok := s.loaders[fixture.Entity]; !ok {
+			return nil, errs.NewCommonError(fmt.Sprintf("seed loader [%s] not registered", fixture.Entity), nil)
+		}
+		entityDeps, ok := deps[fixture.Entity]
+		if !ok {
+			entityDeps = make(map[string]struct{})
+			deps[fixture.Entity] = entityDeps
+		}
+		for _, dep := range fixture.DependsOn {
+			entityDeps[dep] = struct{}{}
+		}
+		for _, record := range fixture.Records {
+			collectRefEntities(record.Fields, entityDeps)
+		}
+		// ссылки на записи той же сущности разрешаются в порядке следования записей
+		delete(entityDeps, fixture.Entity)
+	}
+
+	entities := make([]string, 0, len(deps))
+	for entity := range deps {
+		entities = append(entities, entity)
+	}
+	sort.Strings(entities)
+
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := make(map[string]int, len(deps))
+	res := make([]string, 0, len(deps))
+	var visit func(entity string, path []string) error
+	visit = func(entity string, path []string) error {
+		switch state[entity] {
+		case visited:
+			return nil
+		case visiting:
+			return errs.NewCommonError(fmt.Sprintf("seed dependency cycle %v", append(path, entity)), nil)
+		}
+		entityDeps, ok := deps[entity]
+		if !ok {
+			return errs.NewCommonError(fmt.Sprintf("seed dependency [%s] has no fixtures, required by %v", entity, path), nil)
+		}
+		state[entity] = visiting
+		depNames := make([]string, 0, len(entityDeps))
+		for dep := range entityDeps {
+			depNames = append(depNames, dep)
+		}
+		sort.Strings(depNames)
+		for _, dep := range depNames {
+			if err := visit(dep, append(path, entity)); err != nil {
+				return err
+			}
+		}
+		state[entity] = visited
+		res = append(res, entity)
+
+		return nil
+	}
+	for _, entity := range entities {
+		if err := visit(entity, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+func resolveFields(fields map[string]any, refs Refs) (map[string]any, error) {
+	if fields == nil {
+		return map[string]any{}, nil
+	}
+	res, err := resolveRefs(fields, refs)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(map[string]any), nil
+}
+
+// resolveRefs копия значения с заменой ссылок $ref на идентификаторы созданных записей
+func resolveRefs(value any, refs Refs) (any, error) {
+	switch v := value.(type) {
+	case string:
+		entity, ref, ok := parseRef(v)
+		if !ok {
+			return v, nil
+		}
+		id, ok := refs.Get(entity, ref)
+		if !ok {
+			return nil, errs.NewCommonError(fmt.Sprintf("unresolved reference [%s]", v), nil)
+		}
+
+		return id, nil
+	case map[string]any:
+		res := make(map[string]any, len(v))
+		for key, item := range v {
+			resolved, err := resolveRefs(item, refs)
+			if err != nil {
+				return nil, err
+			}
+			res[key] = resolved
+		}
+
+		return res, nil
+	case []any:
+		res := make([]any, len(v))
+		for i, item := range v {
+			resolved, err := resolveRefs(item, refs)
+			if err != nil {
+				return nil, err
+			}
+			res[i] = resolved
+		}
+
+		return res, nil
+	default:
+		return v, nil
+	}
+}
+
+func refKey(entity, ref string) string {
+	return entity + "/" + ref
+}
+
+func countRecords(fixtures []*Fixture) int {
+	var res int
+	for _, fixture := range fixtures {
+		res += len(fixture.Records)
+	}
+
+	return res
+}
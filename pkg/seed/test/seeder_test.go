@@ -0,0 +1,232 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/config"
+	"github.com/ElfAstAhe/go-service-template/pkg/db"
+	dbmocks "github.com/ElfAstAhe/go-service-template/pkg/db/mocks"
+	"github.com/ElfAstAhe/go-service-template/pkg/domain/mocks"
+	logmocks "github.com/ElfAstAhe/go-service-template/pkg/logger/mocks"
+	"github.com/ElfAstAhe/go-service-template/pkg/seed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type category struct {
+	ID   string
+	Code string
+}
+
+func (c *category) GetID() string         { return c.ID }
+func (c *category) SetID(id string)       { c.ID = id }
+func (c *category) IsExists() bool        { return c.ID != "" }
+func (c *category) BeforeCreate() error   { return nil }
+func (c *category) BeforeChange() error   { return nil }
+func (c *category) ValidateCreate() error { return nil }
+func (c *category) ValidateChange() error { return nil }
+
+type product struct {
+	ID         string
+	Code       string
+	CategoryID string
+	Price      float64
+	ValidFrom  time.Time
+}
+
+func (p *product) GetID() string         { return p.ID }
+func (p *product) SetID(id string)       { p.ID = id }
+func (p *product) IsExists() bool        { return p.ID != "" }
+func (p *product) BeforeCreate() error   { return nil }
+func (p *product) BeforeChange() error   { return nil }
+func (p *product) ValidateCreate() error { return nil }
+func (p *product) ValidateChange() error { return nil }
+
+const categoryFixture = `
+entity: category
+records:
+  - ref: food
+    fields:
+      code: FOOD
+`
+
+const productFixture = `{
+  "entity": "product",
+  "records": [
+    {"ref": "apple", "fields": {"code": "APPLE", "category_id": "$ref:category/food", "price": 1.5, "valid_from": "2026-01-02T03:04:05Z"}}
+  ]
+}`
+
+func newTestLogger(t *testing.T) *logmocks.MockLogger {
+	log := logmocks.NewMockLogger(t)
+	log.On("GetLogger", mock.Anything).Return(log).Maybe()
+	log.On("Debugf", mock.Anything, mock.Anything).Maybe()
+	log.On("Debugf", mock.Anything, mock.Anything, mock.Anything).Maybe()
+	log.On("Debugf", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Maybe()
+	log.On("Infof", mock.Anything, mock.Anything, mock.Anything).Maybe()
+
+	return log
+}
+
+func newTestTxManager(t *testing.T) *dbmocks.MockTransactionManager {
+	txManager := dbmocks.NewMockTransactionManager(t)
+	txManager.On("WithinTransaction", mock.Anything, mock.Anything, mock.Anything).
+		Return(func(ctx context.Context, _ *db.TransactionOptions, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).Maybe()
+
+	return txManager
+}
+
+func newTestFixtures(t *testing.T) []*seed.Fixture {
+	// файлы названы так, чтобы порядок чтения не совпадал с порядком зависимостей
+	res, err := seed.LoadFS(fstest.MapFS{
+		"a_product.json":  {Data: []byte(productFixture)},
+		"b_category.yaml": {Data: []byte(categoryFixture)},
+		"readme.md":       {Data: []byte("ignored")},
+	}, ".")
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+
+	return res
+}
+
+func TestSeeder_Seed_ResolvesReferences(t *testing.T) {
+	var calls []string
+
+	categoryRepo := mocks.NewMockCRUDRepository[*category, string](t)
+	categoryRepo.On("Create", mock.Anything, mock.Anything).
+		Return(func(_ context.Context, entity *category) (*category, error) {
+			calls = append(calls, "category:"+entity.Code)
+			entity.ID = "category-1"
+
+			return entity, nil
+		}).Once()
+	var created *product
+	productRepo := mocks.NewMockCRUDRepository[*product, string](t)
+	productRepo.On("Create", mock.Anything, mock.Anything).
+		Return(func(_ context.Context, entity *product) (*product, error) {
+			calls = append(calls, "product:"+entity.Code)
+			entity.ID = "product-1"
+			created = entity
+
+			return entity, nil
+		}).Once()
+
+	seeder, err := seed.NewSeeder(config.AppEnvTest, newTestTxManager(t), newTestLogger(t),
+		seed.NewCRUDLoader[*category, string]("category", categoryRepo, func() *category { return &category{} }, nil),
+		seed.NewCRUDLoader[*product, string]("product", productRepo, func() *product { return &product{} }, nil),
+	)
+	require.NoError(t, err)
+
+	refs, err := seeder.Seed(context.Background(), seed.ModeLoad, newTestFixtures(t)...)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"category:FOOD", "product:APPLE"}, calls, "Категории должны загружаться до товаров")
+	require.NotNil(t, created)
+	assert.Equal(t, "category-1", created.CategoryID)
+	assert.Equal(t, 1.5, created.Price)
+	assert.Equal(t, time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), created.ValidFrom.UTC())
+	id, ok := refs.Get("product", "apple")
+	assert.True(t, ok)
+	assert.Equal(t, "product-1", id)
+}
+
+func TestSeeder_Seed_ReloadTruncatesInReverseOrder(t *testing.T) {
+	var calls []string
+	truncator := func(entity string) seed.Truncator {
+		return func(ctx context.Context) error {
+			calls = append(calls, "truncate:"+entity)
+
+			return nil
+		}
+	}
+
+	categoryRepo := mocks.NewMockCRUDRepository[*category, string](t)
+	categoryRepo.On("Create", mock.Anything, mock.Anything).Return(&category{ID: "category-1"}, nil).Once()
+	productRepo := mocks.NewMockCRUDRepository[*product, string](t)
+	productRepo.On("Create", mock.Anything, mock.Anything).Return(&product{ID: "product-1"}, nil).Once()
+
+	seeder, err := seed.NewSeeder(config.AppEnvDevelopment, newTestTxManager(t), newTestLogger(t),
+		seed.NewCRUDLoader[*category, string]("category", categoryRepo, func() *category { return &category{} }, truncator("category")),
+		seed.NewCRUDLoader[*product, string]("product", productRepo, func() *product { return &product{} }, truncator("product")),
+	)
+	require.NoError(t, err)
+
+	_, err = seeder.Seed(context.Background(), seed.ModeReload, newTestFixtures(t)...)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"truncate:product", "truncate:category"}, calls)
+}
+
+func TestSeeder_Seed_RefusedInProd(t *testing.T) {
+	seeder, err := seed.NewSeeder(config.AppEnvProduction, dbmocks.NewMockTransactionManager(t), newTestLogger(t))
+	require.NoError(t, err)
+
+	_, err = seeder.Seed(context.Background(), seed.ModeLoad)
+	assert.Error(t, err)
+}
+
+func TestSeeder_Seed_Errors(t *testing.T) {
+	seeder, err := seed.NewSeeder(config.AppEnvTest, dbmocks.NewMockTransactionManager(t), newTestLogger(t),
+		seed.NewCRUDLoader[*category, string]("category", mocks.NewMockCRUDRepository[*category, string](t), func() *category { return &category{} }, nil),
+		seed.NewCRUDLoader[*product, string]("product", mocks.NewMockCRUDRepository[*product, string](t), func() *product { return &product{} }, nil),
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		fixtures []*seed.Fixture
+	}{
+		{
+			name:     "loader not registered",
+			fixtures: []*seed.Fixture{{Entity: "unknown"}},
+		},
+		{
+			name: "reference to entity without fixtures",
+			fixtures: []*seed.Fixture{{Entity: "product", Records: []*seed.Record{
+				{Fields: map[string]any{"category_id": "$ref:category/food"}},
+			}}},
+		},
+		{
+			name: "dependency cycle",
+			fixtures: []*seed.Fixture{
+				{Entity: "product", DependsOn: []string{"category"}},
+				{Entity: "category", DependsOn: []string{"product"}},
+			},
+		},
+		{
+			name: "duplicate ref",
+			fixtures: []*seed.Fixture{{Entity: "category", Records: []*seed.Record{
+				{Ref: "food"}, {Ref: "food"},
+			}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := seeder.Seed(context.Background(), seed.ModeLoad, tt.fixtures...)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestSeeder_Seed_UnknownField(t *testing.T) {
+	seeder, err := seed.NewSeeder(config.AppEnvTest, newTestTxManager(t), newTestLogger(t),
+		seed.NewCRUDLoader[*category, string]("category", mocks.NewMockCRUDRepository[*category, string](t), func() *category { return &category{} }, nil),
+	)
+	require.NoError(t, err)
+
+	_, err = seeder.Seed(context.Background(), seed.ModeLoad, &seed.Fixture{Entity: "category", Records: []*seed.Record{
+		{Fields: map[string]any{"unknown": fmt.Sprint(1)}},
+	}})
+	assert.Error(t, err)
+}
+
+func TestParseFixture_UnsupportedFormat(t *testing.T) {
+	_, err := seed.ParseFixture("fixture.xml", []byte("<entity/>"))
+	assert.Error(t, err)
+}
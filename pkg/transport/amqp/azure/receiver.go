@@ -87,6 +87,7 @@ func (r *Receiver) Receive(ctx context.Context, receiveOpts *amqp.ReceiveOptions
 	if azureMsg.ApplicationProperties != nil {
 		maps.Copy(resMsg.Props, azureMsg.ApplicationProperties)
 	}
+	fillStandardProps(resMsg.Props, azureMsg.Properties)
 
 	resMsg.Props[sysMsgKey] = azureMsg
 
@@ -280,3 +281,19 @@ func (r *Receiver) handleReceiverFailure(err error) {
 	r.link = nil // Сбрасываем локальный линк, чтобы на следующем Receive() лениво его пересоздать
 	r.mu.Unlock()
 }
+
+// fillStandardProps стандартные свойства из системных свойств AMQP, если отправитель не передал их в application properties
+func fillStandardProps(props map[string]any, msgProps *amqp.MessageProperties) {
+	if msgProps == nil {
+		return
+	}
+	if _, ok := props[pkgamqp.PropContentType]; !ok && msgProps.ContentType != nil {
+		props[pkgamqp.PropContentType] = *msgProps.ContentType
+	}
+	if _, ok := props[pkgamqp.PropMessageType]; !ok && msgProps.Subject != nil {
+		props[pkgamqp.PropMessageType] = *msgProps.Subject
+	}
+	if _, ok := props[pkgamqp.PropCorrelationID]; !ok && msgProps.CorrelationID != nil {
+		props[pkgamqp.PropCorrelationID] = fmt.Sprint(msgProps.CorrelationID)
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/Azure/go-amqp"
 	"github.com/ElfAstAhe/go-service-template/pkg/logger/mocks"
+	pkgamqp "github.com/ElfAstAhe/go-service-template/pkg/transport/amqp"
 	mocks3 "github.com/ElfAstAhe/go-service-template/pkg/transport/amqp/azure/mocks"
 	mocks2 "github.com/ElfAstAhe/go-service-template/pkg/transport/amqp/mocks"
 	"github.com/stretchr/testify/assert"
@@ -92,3 +93,21 @@ func TestReceiver_Receive_Failure_InvalidatesConnector(t *testing.T) {
 	mockConnector.AssertExpectations(t)
 	mockReceiverLink.AssertExpectations(t)
 }
+
+func TestFillStandardProps(t *testing.T) {
+	subject := "order.created"
+	contentType := "application/json"
+	props := map[string]any{pkgamqp.PropContentType: "text/plain"}
+
+	fillStandardProps(props, &amqp.MessageProperties{
+		Subject:       &subject,
+		ContentType:   &contentType,
+		CorrelationID: "corr-1",
+	})
+
+	assert.Equal(t, map[string]any{
+		pkgamqp.PropMessageType:   "order.created",
+		pkgamqp.PropContentType:   "text/plain",
+		pkgamqp.PropCorrelationID: "corr-1",
+	}, props)
+}
@@ -253,6 +253,16 @@ func (s *Sender) prepareMessage(msg pkgamqp.Message) *amqp.Message {
 	azureMsg.Properties = &amqp.MessageProperties{
 		ContentType: &jsonContentType,
 	}
+	// стандартные свойства переносятся в системные свойства AMQP
+	if contentType, ok := msg.GetProperties()[pkgamqp.PropContentType].(string); ok && contentType != "" {
+		azureMsg.Properties.ContentType = &contentType
+	}
+	if messageType, ok := msg.GetProperties()[pkgamqp.PropMessageType].(string); ok && messageType != "" {
+		azureMsg.Properties.Subject = &messageType
+	}
+	if correlationID, ok := msg.GetProperties()[pkgamqp.PropCorrelationID].(string); ok && correlationID != "" {
+		azureMsg.Properties.CorrelationID = correlationID
+	}
 	if len(msg.GetProperties()) > 0 {
 		azureMsg.ApplicationProperties = msg.GetProperties()
 	}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/Azure/go-amqp"
 	"github.com/ElfAstAhe/go-service-template/pkg/logger/mocks"
+	pkgamqp "github.com/ElfAstAhe/go-service-template/pkg/transport/amqp"
 	mocks3 "github.com/ElfAstAhe/go-service-template/pkg/transport/amqp/azure/mocks"
 	mocks2 "github.com/ElfAstAhe/go-service-template/pkg/transport/amqp/mocks"
 	"github.com/stretchr/testify/assert"
@@ -112,3 +113,22 @@ func TestSender_Publish_Retry_And_Invalidate(t *testing.T) {
 	mockConnector.AssertExpectations(t)
 	mockSenderLink.AssertExpectations(t)
 }
+
+func TestSender_PrepareMessage_StandardProps(t *testing.T) {
+	s := &Sender{}
+
+	azureMsg := s.prepareMessage(NewMessage([]byte("data"), map[string]any{
+		pkgamqp.PropMessageType:   "order.created",
+		pkgamqp.PropContentType:   "application/x-protobuf",
+		pkgamqp.PropCorrelationID: "corr-1",
+	}))
+
+	require.NotNil(t, azureMsg.Properties)
+	assert.Equal(t, "order.created", *azureMsg.Properties.Subject)
+	assert.Equal(t, "application/x-protobuf", *azureMsg.Properties.ContentType)
+	assert.Equal(t, "corr-1", azureMsg.Properties.CorrelationID)
+
+	plain := s.prepareMessage(NewMessage([]byte("data"), nil))
+	assert.Equal(t, jsonContentType, *plain.Properties.ContentType)
+	assert.Nil(t, plain.Properties.Subject)
+}
@@ -0,0 +1,372 @@
+package bridge
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	pb "github.com/ElfAstAhe/go-service-template/pkg/api/grpc/example/v1"
+	"github.com/ElfAstAhe/go-service-template/pkg/errs"
+	"github.com/ElfAstAhe/go-service-template/pkg/infra/pubsub"
+	loggermocks "github.com/ElfAstAhe/go-service-template/pkg/logger/mocks"
+	"github.com/ElfAstAhe/go-service-template/pkg/transport"
+	pkgamqp "github.com/ElfAstAhe/go-service-template/pkg/transport/amqp"
+	amqpmocks "github.com/ElfAstAhe/go-service-template/pkg/transport/amqp/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+type orderCreated struct {
+	ID     string `json:"id"`
+	Amount int    `json:"amount"`
+}
+
+type sendOpts struct{}
+
+type receiveOpts struct{}
+
+func newTestLogger() *loggermocks.MockLogger {
+	mockLog := &loggermocks.MockLogger{}
+	mockLog.On("GetLogger", mock.Anything).Return(mockLog)
+	mockLog.On("Debugf", mock.Anything, mock.Anything, mock.Anything).Maybe()
+	mockLog.On("Warnf", mock.Anything, mock.Anything).Maybe()
+	mockLog.On("Errorf", mock.Anything, mock.Anything).Maybe()
+
+	return mockLog
+}
+
+// funcObserver наблюдатель с результатом из функции
+type funcObserver[T any] struct {
+	name string
+	fn   func(ctx context.Context, value T) error
+}
+
+func (fo *funcObserver[T]) GetName() string {
+	return fo.name
+}
+
+func (fo *funcObserver[T]) OnNotify(ctx context.Context, value T) error {
+	return fo.fn(ctx, value)
+}
+
+func TestOutbound_PublishesSelectedEvents(t *testing.T) {
+	sender := amqpmocks.NewMockSender[*sendOpts](t)
+	sender.On("GetTargetName").Return("orders")
+
+	var sent pkgamqp.Message
+	sender.On("Publish", mock.Anything, mock.Anything, (*sendOpts)(nil)).
+		Run(func(args mock.Arguments) { sent = args.Get(1).(pkgamqp.Message) }).
+		Return(nil).Once()
+
+	outbound, err := NewOutbound[orderCreated, *sendOpts]("orders-out", "order.created", sender,
+		WithOutboundFilter[orderCreated, *sendOpts](func(e orderCreated) bool { return e.Amount > 0 }),
+		WithOutboundProperties[orderCreated, *sendOpts](func(e orderCreated) map[string]any {
+			return map[string]any{"order_id": e.ID, pkgamqp.PropMessageType: "ignored"}
+		}),
+	)
+	require.NoError(t, err)
+
+	dispatcher := pubsub.NewEventDispatcher[orderCreated]("orders", time.Second, newTestLogger())
+	dispatcher.Register(outbound)
+
+	ctx := transport.WithRequestID(context.Background(), "req-1")
+	require.NoError(t, dispatcher.NotifySync(ctx, orderCreated{ID: "o-1", Amount: 10}))
+	// отфильтровано, Publish не вызывается повторно
+	require.NoError(t, dispatcher.NotifySync(ctx, orderCreated{ID: "o-2"}))
+
+	require.NotNil(t, sent)
+	assert.Equal(t, "orders", sent.GetTargetName())
+	assert.JSONEq(t, `{"id":"o-1","amount":10}`, string(sent.GetPayload()))
+	assert.Equal(t, map[string]any{
+		pkgamqp.PropMessageType:   "order.created",
+		pkgamqp.PropContentType:   ContentTypeJSON,
+		pkgamqp.PropCorrelationID: "req-1",
+		"order_id":                "o-1",
+	}, sent.GetProperties())
+}
+
+func TestOutbound_ErrorsAreClassified(t *testing.T) {
+	sender := amqpmocks.NewMockSender[*sendOpts](t)
+	sender.On("GetTargetName").Return("orders").Maybe()
+	sender.On("Publish", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("link detached")).Once()
+
+	outbound, err := NewOutbound[orderCreated, *sendOpts]("orders-out", "order.created", sender)
+	require.NoError(t, err)
+
+	var permanent *errs.TlPermanentError
+	sendErr := outbound.OnNotify(context.Background(), orderCreated{ID: "o-1"})
+	require.Error(t, sendErr)
+	assert.False(t, errors.As(sendErr, &permanent))
+
+	protoOut, err := NewOutbound[orderCreated, *sendOpts]("proto-out", "order.created", sender,
+		WithOutboundCodec[orderCreated, *sendOpts](ProtoCodec{}),
+	)
+	require.NoError(t, err)
+	assert.ErrorAs(t, protoOut.OnNotify(context.Background(), orderCreated{ID: "o-1"}), &permanent)
+}
+
+func TestNewOutbound_Validation(t *testing.T) {
+	_, err := NewOutbound[orderCreated, *sendOpts]("out", "type", nil)
+	assert.True(t, transport.IsBadRequest(err))
+
+	_, err = NewOutbound[orderCreated, *sendOpts]("out", "", amqpmocks.NewMockSender[*sendOpts](t))
+	assert.True(t, transport.IsBadRequest(err))
+}
+
+func TestProtoCodec_RoundTrip(t *testing.T) {
+	value := pb.Test_builder{Id: "1", Code: "code", Name: "name"}.Build()
+
+	data, err := ProtoCodec{}.Marshal(value)
+	require.NoError(t, err)
+
+	decoded, err := decode[*pb.Test](ProtoCodec{}, data)
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(value, decoded))
+
+	_, err = ProtoCodec{}.Marshal("not proto")
+	assert.Error(t, err)
+}
+
+// scriptedReceiver выдает заданные сообщения, затем блокируется до отмены контекста
+type scriptedReceiver struct {
+	messages chan pkgamqp.Message
+	mu       sync.Mutex
+	settled  map[string]string
+	done     chan struct{}
+	expected int
+}
+
+func newScriptedReceiver(messages ...pkgamqp.Message) *scriptedReceiver {
+	res := &scriptedReceiver{
+		messages: make(chan pkgamqp.Message, len(messages)),
+		settled:  make(map[string]string),
+		done:     make(chan struct{}),
+		expected: len(messages),
+	}
+	for _, msg := range messages {
+		res.messages <- msg
+	}
+
+	return res
+}
+
+func (sr *scriptedReceiver) Receive(ctx context.Context, _ *receiveOpts) (pkgamqp.Message, error) {
+	select {
+	case msg := <-sr.messages:
+		return msg, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (sr *scriptedReceiver) record(msg pkgamqp.Message, outcome string) error {
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+	sr.settled[msg.GetProperties()["id"].(string)] = outcome
+	if len(sr.settled) == sr.expected {
+		close(sr.done)
+	}
+
+	return nil
+}
+
+func (sr *scriptedReceiver) Accept(_ context.Context, msg pkgamqp.Message) error {
+	return sr.record(msg, "accept")
+}
+
+func (sr *scriptedReceiver) Reject(_ context.Context, msg pkgamqp.Message, _ error) error {
+	return sr.record(msg, "reject")
+}
+
+func (sr *scriptedReceiver) Release(_ context.Context, msg pkgamqp.Message) error {
+	return sr.record(msg, "release")
+}
+
+func (sr *scriptedReceiver) Close(context.Context) error {
+	return nil
+}
+
+func (sr *scriptedReceiver) GetTargetName() string {
+	return "orders"
+}
+
+func inboundMessage(id string, messageType string, payload string) pkgamqp.Message {
+	return NewMessage("orders", []byte(payload), map[string]any{
+		"id":                      id,
+		pkgamqp.PropMessageType:   messageType,
+		pkgamqp.PropCorrelationID: "corr-" + id,
+	})
+}
+
+func TestInbound_MapsObserverResultsToSettlement(t *testing.T) {
+	receiver := newScriptedReceiver(
+		inboundMessage("ok", "order.created", `{"id":"o-1","amount":5}`),
+		inboundMessage("transient", "order.created", `{"id":"retry","amount":5}`),
+		inboundMessage("permanent", "order.created", `{"id":"invalid","amount":5}`),
+		inboundMessage("broken", "order.created", `{broken`),
+		inboundMessage("unknown", "order.deleted", `{}`),
+	)
+
+	var (
+		mu           sync.Mutex
+		received     []orderCreated
+		correlations []string
+	)
+	dispatcher := pubsub.NewEventDispatcher[orderCreated]("orders-in", time.Second, newTestLogger())
+	dispatcher.Register(&funcObserver[orderCreated]{name: "handler", fn: func(ctx context.Context, value orderCreated) error {
+		switch value.ID {
+		case "retry":
+			return errors.New("database unavailable")
+		case "invalid":
+			return errs.NewTlPermanentError("handler", errors.New("amount limit exceeded"))
+		}
+		mu.Lock()
+		received = append(received, value)
+		correlations = append(correlations, transport.RequestID(ctx))
+		mu.Unlock()
+
+		return nil
+	}})
+
+	inbound, err := NewInbound[*receiveOpts]("orders-in", receiver, newTestLogger())
+	require.NoError(t, err)
+	require.NoError(t, RegisterInbound[orderCreated](inbound, "order.created", JSONCodec{}, dispatcher))
+
+	require.NoError(t, inbound.Start(context.Background()))
+	assert.True(t, inbound.IsRunning())
+	select {
+	case <-receiver.done:
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for settlement")
+	}
+	require.NoError(t, inbound.Stop(context.Background()))
+	assert.False(t, inbound.IsRunning())
+
+	assert.Equal(t, map[string]string{
+		"ok":        "accept",
+		"transient": "release",
+		"permanent": "reject",
+		"broken":    "reject",
+		"unknown":   "reject",
+	}, receiver.settled)
+	assert.Equal(t, []orderCreated{{ID: "o-1", Amount: 5}}, received)
+	assert.Equal(t, []string{"corr-ok"}, correlations)
+}
+
+// panicCodec кодек, паникующий при разборе сообщения
+type panicCodec struct {
+	JSONCodec
+}
+
+func (panicCodec) Unmarshal([]byte, any) error {
+	panic("unexpected payload")
+}
+
+func TestInbound_RejectsPanickingRoute(t *testing.T) {
+	receiver := newScriptedReceiver(inboundMessage("panic", "order.created", `{"id":"o-1"}`))
+	dispatcher := pubsub.NewEventDispatcher[orderCreated]("orders-in", time.Second, newTestLogger())
+
+	inbound, err := NewInbound[*receiveOpts]("orders-in", receiver, newTestLogger())
+	require.NoError(t, err)
+	require.NoError(t, RegisterInbound[orderCreated](inbound, "order.created", panicCodec{}, dispatcher))
+
+	require.NoError(t, inbound.Start(context.Background()))
+	select {
+	case <-receiver.done:
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for settlement")
+	}
+	require.NoError(t, inbound.Stop(context.Background()))
+
+	assert.Equal(t, map[string]string{"panic": "reject"}, receiver.settled, "Сообщение, вызвавшее панику, отклоняется")
+}
+
+func TestInbound_RetriesReceiveErrors(t *testing.T) {
+	receiver := amqpmocks.NewMockReceiver[*receiveOpts](t)
+	receiver.On("GetTargetName").Return("orders").Maybe()
+	receiver.On("Receive", mock.Anything, mock.Anything).Return(nil, errors.New("connection lost")).Twice()
+
+	received := make(chan struct{})
+	receiver.On("Receive", mock.Anything, mock.Anything).
+		Run(func(mock.Arguments) { close(received) }).
+		Return(inboundMessage("ok", "order.created", `{"id":"o-1"}`), nil).Once()
+	receiver.On("Receive", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { <-args.Get(0).(context.Context).Done() }).
+		Return(nil, context.Canceled).Maybe()
+	accepted := make(chan struct{})
+	receiver.On("Accept", mock.Anything, mock.Anything).
+		Run(func(mock.Arguments) { close(accepted) }).
+		Return(nil).Once()
+
+	dispatcher := pubsub.NewEventDispatcher[orderCreated]("orders-in", time.Second, newTestLogger())
+	inbound, err := NewInbound[*receiveOpts]("orders-in", receiver, newTestLogger(),
+		WithInboundBackoff[*receiveOpts](time.Millisecond, 2*time.Millisecond),
+	)
+	require.NoError(t, err)
+	require.NoError(t, RegisterInbound[orderCreated](inbound, "order.created", JSONCodec{}, dispatcher))
+
+	require.NoError(t, inbound.Start(context.Background()))
+	for _, ch := range []chan struct{}{received, accepted} {
+		select {
+		case <-ch:
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for receive retry")
+		}
+	}
+	require.NoError(t, inbound.Stop(context.Background()))
+}
+
+func TestInbound_DelaysRelease(t *testing.T) {
+	receiver := amqpmocks.NewMockReceiver[*receiveOpts](t)
+	receiver.On("GetTargetName").Return("orders").Maybe()
+	receiver.On("Receive", mock.Anything, mock.Anything).
+		Return(inboundMessage("transient", "order.created", `{"id":"retry"}`), nil).Times(3)
+	receiver.On("Receive", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { <-args.Get(0).(context.Context).Done() }).
+		Return(nil, context.Canceled).Maybe()
+	var (
+		mu       sync.Mutex
+		releases []time.Time
+	)
+	done := make(chan struct{})
+	receiver.On("Release", mock.Anything, mock.Anything).
+		Run(func(mock.Arguments) {
+			mu.Lock()
+			defer mu.Unlock()
+			releases = append(releases, time.Now())
+			if len(releases) == 3 {
+				close(done)
+			}
+		}).
+		Return(nil).Times(3)
+
+	dispatcher := pubsub.NewEventDispatcher[orderCreated]("orders-in", time.Second, newTestLogger())
+	dispatcher.Register(&funcObserver[orderCreated]{name: "handler", fn: func(context.Context, orderCreated) error {
+		return errors.New("database unavailable")
+	}})
+	inbound, err := NewInbound[*receiveOpts]("orders-in", receiver, newTestLogger(),
+		WithInboundBackoff[*receiveOpts](20*time.Millisecond, 40*time.Millisecond),
+	)
+	require.NoError(t, err)
+	require.NoError(t, RegisterInbound[orderCreated](inbound, "order.created", JSONCodec{}, dispatcher))
+
+	start := time.Now()
+	require.NoError(t, inbound.Start(context.Background()))
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for release")
+	}
+	require.NoError(t, inbound.Stop(context.Background()))
+
+	mu.Lock()
+	defer mu.Unlock()
+	// задержка перед возвратом растет с каждым подряд возвращенным сообщением: 20, 40, 40 мс
+	assert.GreaterOrEqual(t, releases[0].Sub(start), 20*time.Millisecond)
+	assert.GreaterOrEqual(t, releases[1].Sub(releases[0]), 40*time.Millisecond)
+	assert.GreaterOrEqual(t, releases[2].Sub(releases[1]), 40*time.Millisecond)
+}
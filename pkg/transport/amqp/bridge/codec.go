@@ -0,0 +1,66 @@
+// Package bridge мост между in-process pubsub и AMQP: исходящий наблюдатель и входящий обработчик сообщений
+package bridge
+
+import (
+	"encoding/json"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/errs"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	ContentTypeJSON     = "application/json"
+	ContentTypeProtobuf = "application/x-protobuf"
+)
+
+// Codec сериализация событий в тело AMQP сообщения
+type Codec interface {
+	ContentType() string
+	Marshal(value any) ([]byte, error)
+	// Unmarshal target - указатель на значение либо proto.Message
+	Unmarshal(data []byte, target any) error
+}
+
+// JSONCodec - кодек JSON
+type JSONCodec struct{}
+
+var _ Codec = JSONCodec{}
+
+func (JSONCodec) ContentType() string {
+	return ContentTypeJSON
+}
+
+func (JSONCodec) Marshal(value any) ([]byte, error) {
+	return json.Marshal(value)
+}
+
+func (JSONCodec) Unmarshal(data []byte, target any) error {
+	return json.Unmarshal(data, target)
+}
+
+// ProtoCodec - кодек protobuf, события должны реализовывать proto.Message
+type ProtoCodec struct{}
+
+var _ Codec = ProtoCodec{}
+
+func (ProtoCodec) ContentType() string {
+	return ContentTypeProtobuf
+}
+
+func (ProtoCodec) Marshal(value any) ([]byte, error) {
+	msg, ok := value.(proto.Message)
+	if !ok {
+		return nil, errs.NewInvalidArgumentError("value", value)
+	}
+
+	return proto.Marshal(msg)
+}
+
+func (ProtoCodec) Unmarshal(data []byte, target any) error {
+	msg, ok := target.(proto.Message)
+	if !ok {
+		return errs.NewInvalidArgumentError("target", target)
+	}
+
+	return proto.Unmarshal(data, msg)
+}
@@ -0,0 +1,333 @@
+package bridge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/container"
+	"github.com/ElfAstAhe/go-service-template/pkg/errs"
+	"github.com/ElfAstAhe/go-service-template/pkg/infra/pubsub"
+	"github.com/ElfAstAhe/go-service-template/pkg/logger"
+	"github.com/ElfAstAhe/go-service-template/pkg/transport"
+	pkgamqp "github.com/ElfAstAhe/go-service-template/pkg/transport/amqp"
+	"github.com/ElfAstAhe/go-service-template/pkg/utils"
+)
+
+const (
+	DefaultInboundWorkers       = 1
+	DefaultInboundBaseDelay     = 200 * time.Millisecond
+	DefaultInboundMaxDelay      = 10 * time.Second
+	DefaultInboundSettleTimeout = 5 * time.Second
+)
+
+// inboundRoute декодирование тела и локальная публикация для одного типа сообщений
+type inboundRoute func(ctx context.Context, payload []byte) error
+
+// Inbound - обработчик входящих AMQP сообщений: чтение из Receiver, декодирование по типу сообщения,
+// синхронная публикация в локальный pubsub. Итог доставки определяет судьбу сообщения:
+// успех - Accept, errs.TlPermanentError (неизвестный тип, битое тело, постоянная ошибка наблюдателя) - Reject,
+// прочие ошибки - Release для повторной доставки брокером после задержки, растущей с каждым подряд возвращенным
+// сообщением (Release не увеличивает delivery-count, без задержки повторная доставка идет без пауз)
+//
+//	ReceiveOpts - параметры получения конкретного транспорта
+type Inbound[ReceiveOpts any] struct {
+	name          string
+	receiver      pkgamqp.Receiver[ReceiveOpts]
+	receiveOpts   ReceiveOpts
+	workers       int
+	baseDelay     time.Duration
+	maxDelay      time.Duration
+	settleTimeout time.Duration
+	mu            sync.RWMutex
+	routes        map[string]inboundRoute
+	running       atomic.Bool
+	cancel        context.CancelFunc
+	wg            sync.WaitGroup
+	logger        logger.Logger
+}
+
+var _ container.Runner = (*Inbound[any])(nil)
+
+type InboundOption[ReceiveOpts any] func(*Inbound[ReceiveOpts])
+
+// WithInboundWorkers число параллельных циклов чтения
+func WithInboundWorkers[ReceiveOpts any](workers int) InboundOption[ReceiveOpts] {
+	return func(in *Inbound[ReceiveOpts]) {
+		if workers > 0 {
+			in.workers = workers
+		}
+	}
+}
+
+func WithInboundReceiveOpts[ReceiveOpts any](receiveOpts ReceiveOpts) InboundOption[ReceiveOpts] {
+	return func(in *Inbound[ReceiveOpts]) {
+		in.receiveOpts = receiveOpts
+	}
+}
+
+// WithInboundBackoff задержка после ошибки чтения и перед возвратом сообщения брокеру (Release),
+// растет экспоненциально от baseDelay до maxDelay
+func WithInboundBackoff[ReceiveOpts any](baseDelay time.Duration, maxDelay time.Duration) InboundOption[ReceiveOpts] {
+	return func(in *Inbound[ReceiveOpts]) {
+		if baseDelay > 0 {
+			in.baseDelay = baseDelay
+		}
+		if maxDelay >= in.baseDelay {
+			in.maxDelay = maxDelay
+		}
+	}
+}
+
+// WithInboundSettleTimeout таймаут Accept/Reject/Release, не зависит от остановки обработчика
+func WithInboundSettleTimeout[ReceiveOpts any](timeout time.Duration) InboundOption[ReceiveOpts] {
+	return func(in *Inbound[ReceiveOpts]) {
+		if timeout > 0 {
+			in.settleTimeout = timeout
+		}
+	}
+}
+
+func NewInbound[ReceiveOpts any](
+	name string,
+	receiver pkgamqp.Receiver[ReceiveOpts],
+	log logger.Logger,
+	opts ...InboundOption[ReceiveOpts],
+) (*Inbound[ReceiveOpts], error) {
+	if utils.IsNil(receiver) {
+		return nil, errs.NewInvalidArgumentError("receiver", receiver)
+	}
+
+	res := &Inbound[ReceiveOpts]{
+		name:          name,
+		receiver:      receiver,
+		workers:       DefaultInboundWorkers,
+		baseDelay:     DefaultInboundBaseDelay,
+		maxDelay:      DefaultInboundMaxDelay,
+		settleTimeout: DefaultInboundSettleTimeout,
+		routes:        make(map[string]inboundRoute),
+		logger:        log.GetLogger(name),
+	}
+	for _, opt := range opts {
+		opt(res)
+	}
+
+	return res, nil
+}
+
+// RegisterInbound маршрут типа сообщения: тело декодируется в T и публикуется через NotifySync, повторная регистрация заменяет маршрут
+func RegisterInbound[T any, ReceiveOpts any](
+	in *Inbound[ReceiveOpts],
+	messageType string,
+	codec Codec,
+	publisher pubsub.SyncPublisher[T],
+) error {
+	if messageType == "" {
+		return errs.NewInvalidArgumentError("messageType", messageType)
+	}
+	if utils.IsNil(codec) {
+		return errs.NewInvalidArgumentError("codec", codec)
+	}
+	if utils.IsNil(publisher) {
+		return errs.NewInvalidArgumentError("publisher", publisher)
+	}
+
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	in.routes[messageType] = func(ctx context.Context, payload []byte) error {
+		value, err := decode[T](codec, payload)
+		if err != nil {
+			return errs.NewTlPermanentError("decode", err)
+		}
+
+		return publisher.NotifySync(ctx, value)
+	}
+
+	return nil
+}
+
+// decode указатели (в т.ч. proto сообщения) создаются заново, остальные типы декодируются по адресу
+func decode[T any](codec Codec, payload []byte) (T, error) {
+	var res T
+	typ := reflect.TypeFor[T]()
+	if typ.Kind() == reflect.Pointer {
+		res = reflect.New(typ.Elem()).Interface().(T)
+		err := codec.Unmarshal(payload, res)
+
+		return res, err
+	}
+	err := codec.Unmarshal(payload, &res)
+
+	return res, err
+}
+
+func (in *Inbound[ReceiveOpts]) GetName() string {
+	return in.name
+}
+
+func (in *Inbound[ReceiveOpts]) Start(ctx context.Context) error {
+	if !in.running.CompareAndSwap(false, true) {
+		return errs.NewCommonError("bridge inbound "+in.GetName()+" already running", nil)
+	}
+
+	var runCtx context.Context
+	runCtx, in.cancel = context.WithCancel(context.WithoutCancel(ctx))
+	for i := 0; i < in.workers; i++ {
+		in.wg.Add(1)
+		go in.consume(runCtx, i)
+	}
+
+	return nil
+}
+
+// Stop прекращение чтения, сообщения в обработке получают отмену контекста и возвращаются брокеру через Release
+func (in *Inbound[ReceiveOpts]) Stop(stopCtx context.Context) error {
+	if !in.running.CompareAndSwap(true, false) {
+		return nil
+	}
+	in.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		in.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-stopCtx.Done():
+		return errs.NewCommonError("bridge inbound "+in.GetName()+" stop timeout", stopCtx.Err())
+	}
+}
+
+func (in *Inbound[ReceiveOpts]) IsRunning() bool {
+	return in.running.Load()
+}
+
+func (in *Inbound[ReceiveOpts]) consume(ctx context.Context, workerIndex int) {
+	in.logger.Debugf("bridge inbound %s worker %d start", in.GetName(), workerIndex)
+	defer in.logger.Debugf("bridge inbound %s worker %d finish", in.GetName(), workerIndex)
+	defer in.wg.Done()
+
+	failures := 0
+	// подряд возвращенные брокеру сообщения
+	released := 0
+	for {
+		msg, err := in.receiver.Receive(ctx, in.receiveOpts)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			failures++
+			delay := in.backoff(failures)
+			in.logger.Warnf("bridge inbound %s receive from %s failed, retry in %v: %v", in.GetName(), in.receiver.GetTargetName(), delay, err)
+			if !sleepCtx(ctx, delay) {
+				return
+			}
+
+			continue
+		}
+		failures = 0
+
+		if in.handle(ctx, msg, released) {
+			released++
+		} else {
+			released = 0
+		}
+	}
+}
+
+// handle обработка сообщения, возвращает признак возврата сообщения брокеру (Release)
+func (in *Inbound[ReceiveOpts]) handle(ctx context.Context, msg pkgamqp.Message, released int) bool {
+	messageType := propString(msg, pkgamqp.PropMessageType)
+
+	in.mu.RLock()
+	route, ok := in.routes[messageType]
+	in.mu.RUnlock()
+
+	var err error
+	if !ok {
+		err = errs.NewTlPermanentError("handle", errs.NewDalNotFoundError("message type", messageType, nil))
+	} else {
+		handleCtx := ctx
+		if correlationID := propString(msg, pkgamqp.PropCorrelationID); correlationID != "" {
+			handleCtx = transport.WithRequestID(ctx, correlationID)
+		}
+		err = in.safeRoute(handleCtx, route, msg.GetPayload())
+	}
+
+	return in.settle(ctx, msg, messageType, err, released)
+}
+
+// safeRoute обработка сообщения с перехватом паники: сообщение, вызвавшее панику, отклоняется (Reject),
+// повторная доставка приведет к той же панике
+func (in *Inbound[ReceiveOpts]) safeRoute(ctx context.Context, route inboundRoute, payload []byte) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = errs.NewTlPermanentError("handle", fmt.Errorf("bridge inbound route panic recovery: %v", r))
+			in.logger.Errorf("bridge inbound %s panic recovery %v", in.GetName(), r)
+		}
+	}()
+
+	return route(ctx, payload)
+}
+
+// settle Accept/Reject/Release по итогу обработки, контекст отвязан от остановки обработчика.
+// Перед Release выдерживается задержка по числу подряд возвращенных сообщений, остановка обработчика ее прерывает.
+func (in *Inbound[ReceiveOpts]) settle(ctx context.Context, msg pkgamqp.Message, messageType string, cause error, released int) bool {
+	var (
+		err       error
+		permanent *errs.TlPermanentError
+	)
+	transient := cause != nil && !errors.As(cause, &permanent)
+	if transient {
+		delay := in.backoff(released + 1)
+		in.logger.Warnf("bridge inbound %s message type [%s] release in %v: %v", in.GetName(), messageType, delay, cause)
+		sleepCtx(ctx, delay)
+	}
+
+	settleCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), in.settleTimeout)
+	defer cancel()
+
+	switch {
+	case cause == nil:
+		err = in.receiver.Accept(settleCtx, msg)
+	case transient:
+		err = in.receiver.Release(settleCtx, msg)
+	default:
+		in.logger.Errorf("bridge inbound %s message type [%s] rejected: %v", in.GetName(), messageType, cause)
+		err = in.receiver.Reject(settleCtx, msg, cause)
+	}
+	if err != nil {
+		in.logger.Errorf("bridge inbound %s settle message type [%s] failed: %v", in.GetName(), messageType, err)
+	}
+
+	return transient
+}
+
+func (in *Inbound[ReceiveOpts]) backoff(failures int) time.Duration {
+	shift := min(failures-1, 30)
+	delay := in.baseDelay * time.Duration(1<<shift)
+	if delay <= 0 || delay > in.maxDelay {
+		return in.maxDelay
+	}
+
+	return delay
+}
+
+func sleepCtx(ctx context.Context, delay time.Duration) bool {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,45 @@
+package bridge
+
+import (
+	"github.com/ElfAstAhe/go-service-template/pkg/errs"
+	pkgamqp "github.com/ElfAstAhe/go-service-template/pkg/transport/amqp"
+)
+
+// Message - исходящее сообщение моста, не зависит от реализации транспорта
+type Message struct {
+	TargetName string
+	Payload    []byte
+	Props      map[string]any
+}
+
+var _ pkgamqp.Message = (*Message)(nil)
+
+func NewMessage(targetName string, payload []byte, props map[string]any) *Message {
+	return &Message{
+		TargetName: targetName,
+		Payload:    payload,
+		Props:      props,
+	}
+}
+
+func (m *Message) GetTargetName() string {
+	return m.TargetName
+}
+
+func (m *Message) GetPayload() []byte {
+	return m.Payload
+}
+
+func (m *Message) GetProperties() map[string]any {
+	return m.Props
+}
+
+func (m *Message) ExtractOriginalMessage() (any, error) {
+	return nil, errs.NewTlCommonError("ExtractOriginalMessage", "bridge message has no underlying transport message", nil)
+}
+
+func propString(msg pkgamqp.Message, key string) string {
+	res, _ := msg.GetProperties()[key].(string)
+
+	return res
+}
@@ -0,0 +1,151 @@
+package bridge
+
+import (
+	"context"
+	"maps"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/errs"
+	"github.com/ElfAstAhe/go-service-template/pkg/infra/pubsub"
+	"github.com/ElfAstAhe/go-service-template/pkg/transport"
+	pkgamqp "github.com/ElfAstAhe/go-service-template/pkg/transport/amqp"
+	"github.com/ElfAstAhe/go-service-template/pkg/utils"
+)
+
+// Outbound - наблюдатель pubsub, отправляющий выбранные события в AMQP через Sender
+//
+//	SendOpts - параметры отправки конкретного транспорта
+type Outbound[T any, SendOpts any] struct {
+	name        string
+	messageType string
+	sender      pkgamqp.Sender[SendOpts]
+	codec       Codec
+	sendOpts    SendOpts
+	filter      func(T) bool
+	typeOf      func(T) string
+	correlation func(context.Context, T) string
+	properties  func(T) map[string]any
+}
+
+var _ pubsub.Observer[string] = (*Outbound[string, any])(nil)
+
+type OutboundOption[T any, SendOpts any] func(*Outbound[T, SendOpts])
+
+// WithOutboundCodec кодек тела сообщения, по умолчанию JSONCodec
+func WithOutboundCodec[T any, SendOpts any](codec Codec) OutboundOption[T, SendOpts] {
+	return func(o *Outbound[T, SendOpts]) {
+		o.codec = codec
+	}
+}
+
+// WithOutboundFilter отбор событий для отправки, остальные пропускаются без ошибки
+func WithOutboundFilter[T any, SendOpts any](filter func(T) bool) OutboundOption[T, SendOpts] {
+	return func(o *Outbound[T, SendOpts]) {
+		o.filter = filter
+	}
+}
+
+// WithOutboundMessageType тип сообщения из события, пустой результат - тип из конструктора
+func WithOutboundMessageType[T any, SendOpts any](typeOf func(T) string) OutboundOption[T, SendOpts] {
+	return func(o *Outbound[T, SendOpts]) {
+		o.typeOf = typeOf
+	}
+}
+
+// WithOutboundCorrelation идентификатор корреляции, по умолчанию RequestID из контекста
+func WithOutboundCorrelation[T any, SendOpts any](correlation func(context.Context, T) string) OutboundOption[T, SendOpts] {
+	return func(o *Outbound[T, SendOpts]) {
+		o.correlation = correlation
+	}
+}
+
+// WithOutboundProperties дополнительные свойства сообщения, стандартные свойства не перекрываются
+func WithOutboundProperties[T any, SendOpts any](properties func(T) map[string]any) OutboundOption[T, SendOpts] {
+	return func(o *Outbound[T, SendOpts]) {
+		o.properties = properties
+	}
+}
+
+func WithOutboundSendOpts[T any, SendOpts any](sendOpts SendOpts) OutboundOption[T, SendOpts] {
+	return func(o *Outbound[T, SendOpts]) {
+		o.sendOpts = sendOpts
+	}
+}
+
+func NewOutbound[T any, SendOpts any](
+	name string,
+	messageType string,
+	sender pkgamqp.Sender[SendOpts],
+	opts ...OutboundOption[T, SendOpts],
+) (*Outbound[T, SendOpts], error) {
+	if utils.IsNil(sender) {
+		return nil, errs.NewInvalidArgumentError("sender", sender)
+	}
+	if messageType == "" {
+		return nil, errs.NewInvalidArgumentError("messageType", messageType)
+	}
+
+	res := &Outbound[T, SendOpts]{
+		name:        name,
+		messageType: messageType,
+		sender:      sender,
+		codec:       JSONCodec{},
+		correlation: func(ctx context.Context, _ T) string {
+			return transport.RequestID(ctx)
+		},
+	}
+	for _, opt := range opts {
+		opt(res)
+	}
+
+	return res, nil
+}
+
+func (o *Outbound[T, SendOpts]) GetName() string {
+	return o.name
+}
+
+// OnNotify ошибка сериализации постоянная (повтор не поможет), ошибка отправки возвращается как есть для повторов диспетчера
+func (o *Outbound[T, SendOpts]) OnNotify(ctx context.Context, event T) error {
+	if o.filter != nil && !o.filter(event) {
+		return nil
+	}
+
+	msg, err := o.buildMessage(ctx, event)
+	if err != nil {
+		return errs.NewTlPermanentError("OnNotify", err)
+	}
+	if err = o.sender.Publish(ctx, msg, o.sendOpts); err != nil {
+		return errs.NewTlCommonError("OnNotify", "bridge outbound publish to "+o.sender.GetTargetName()+" failed", err)
+	}
+
+	return nil
+}
+
+func (o *Outbound[T, SendOpts]) buildMessage(ctx context.Context, event T) (*Message, error) {
+	payload, err := o.codec.Marshal(event)
+	if err != nil {
+		return nil, errs.NewTlCommonError("buildMessage", "bridge outbound marshal event failed", err)
+	}
+
+	props := make(map[string]any)
+	if o.properties != nil {
+		maps.Copy(props, o.properties(event))
+	}
+	props[pkgamqp.PropMessageType] = o.resolveType(event)
+	props[pkgamqp.PropContentType] = o.codec.ContentType()
+	if correlationID := o.correlation(ctx, event); correlationID != "" {
+		props[pkgamqp.PropCorrelationID] = correlationID
+	}
+
+	return NewMessage(o.sender.GetTargetName(), payload, props), nil
+}
+
+func (o *Outbound[T, SendOpts]) resolveType(event T) string {
+	if o.typeOf != nil {
+		if res := o.typeOf(event); res != "" {
+			return res
+		}
+	}
+
+	return o.messageType
+}
@@ -7,3 +7,10 @@ type Message interface {
 	GetProperties() map[string]any
 	ExtractOriginalMessage() (any, error)
 }
+
+// Стандартные свойства сообщения, транспорт переносит их в системные свойства AMQP (subject, correlation-id, content-type)
+const (
+	PropMessageType   = "message_type"
+	PropCorrelationID = "correlation_id"
+	PropContentType   = "content_type"
+)
@@ -0,0 +1,189 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/errs"
+	"github.com/ElfAstAhe/go-service-template/pkg/infra/cache"
+	"github.com/go-chi/chi/v5"
+)
+
+// DefaultCacheAdminKeysLimit размер страницы ключей по умолчанию
+const DefaultCacheAdminKeysLimit = 100
+
+// DefaultCacheAdminJanitorTimeout ограничение времени ручного запуска janitor
+const DefaultCacheAdminJanitorTimeout = 30 * time.Second
+
+// CacheAdminRouter - административные эндпоинты кэшей реестра:
+//
+//	GET    /                      сводки всех кэшей
+//	GET    /{name}                сводка кэша
+//	GET    /{name}/keys           ключи (prefix, offset, limit)
+//	GET    /{name}/entry?key=     значение ключа в json
+//	DELETE /{name}/entry?key=     удаление ключа
+//	POST   /{name}/clear          очистка кэша
+//	POST   /{name}/janitor        внеочередная очистка просрочки
+//
+// Ключ передается параметром запроса, так как может содержать "/". Доступ ограничивается guard.
+type CacheAdminRouter struct {
+	router   *chi.Mux
+	registry *cache.Registry
+}
+
+var _ Router = (*CacheAdminRouter)(nil)
+
+// NewCacheAdminRouter guard - middleware контроля доступа, nil - без контроля
+func NewCacheAdminRouter(registry *cache.Registry, guard func(http.Handler) http.Handler) *CacheAdminRouter {
+	res := &CacheAdminRouter{
+		router:   chi.NewRouter(),
+		registry: registry,
+	}
+	if guard != nil {
+		res.router.Use(guard)
+	}
+	res.router.Get("/", res.getCaches)
+	res.router.Route("/{name}", func(r chi.Router) {
+		r.Get("/", res.getCache)
+		r.Get("/keys", res.getKeys)
+		r.Get("/entry", res.getEntry)
+		r.Delete("/entry", res.deleteEntry)
+		r.Post("/clear", res.postClear)
+		r.Post("/janitor", res.postJanitor)
+	})
+
+	return res
+}
+
+func (car *CacheAdminRouter) GetRouter() http.Handler {
+	return car.router
+}
+
+func (car *CacheAdminRouter) getCaches(rw http.ResponseWriter, _ *http.Request) {
+	RenderJSON(rw, http.StatusOK, car.registry.List(), mapCacheAdminStatus)
+}
+
+func (car *CacheAdminRouter) getCache(rw http.ResponseWriter, r *http.Request) {
+	adminCache, ok := car.cache(rw, r)
+	if !ok {
+		return
+	}
+
+	RenderJSON(rw, http.StatusOK, adminCache.Info(), mapCacheAdminStatus)
+}
+
+func (car *CacheAdminRouter) getKeys(rw http.ResponseWriter, r *http.Request) {
+	adminCache, ok := car.cache(rw, r)
+	if !ok {
+		return
+	}
+	page, err := adminCache.Keys(
+		r.URL.Query().Get("prefix"),
+		GetQueryIntDefault(r, "offset", 0),
+		GetQueryIntDefault(r, "limit", DefaultCacheAdminKeysLimit),
+	)
+	if err != nil {
+		RenderError(rw, err, mapCacheAdminStatus)
+
+		return
+	}
+
+	RenderJSON(rw, http.StatusOK, page, mapCacheAdminStatus)
+}
+
+func (car *CacheAdminRouter) getEntry(rw http.ResponseWriter, r *http.Request) {
+	adminCache, ok := car.cache(rw, r)
+	if !ok {
+		return
+	}
+	key, err := GetQueryString(r, "key")
+	if err != nil {
+		RenderError(rw, err, mapCacheAdminStatus)
+
+		return
+	}
+	value, found, err := adminCache.GetJSON(key)
+	if err != nil {
+		RenderError(rw, err, mapCacheAdminStatus)
+
+		return
+	}
+	if !found {
+		RenderError(rw, errs.NewDalNotFoundError("cache entry", key, nil), mapCacheAdminStatus)
+
+		return
+	}
+
+	RenderJSON(rw, http.StatusOK, json.RawMessage(value), mapCacheAdminStatus)
+}
+
+func (car *CacheAdminRouter) deleteEntry(rw http.ResponseWriter, r *http.Request) {
+	adminCache, ok := car.cache(rw, r)
+	if !ok {
+		return
+	}
+	key, err := GetQueryString(r, "key")
+	if err != nil {
+		RenderError(rw, err, mapCacheAdminStatus)
+
+		return
+	}
+	if err := adminCache.Delete(key); err != nil {
+		RenderError(rw, err, mapCacheAdminStatus)
+
+		return
+	}
+
+	RenderEmpty(rw, http.StatusNoContent)
+}
+
+func (car *CacheAdminRouter) postClear(rw http.ResponseWriter, r *http.Request) {
+	adminCache, ok := car.cache(rw, r)
+	if !ok {
+		return
+	}
+	adminCache.Clear()
+
+	RenderEmpty(rw, http.StatusNoContent)
+}
+
+func (car *CacheAdminRouter) postJanitor(rw http.ResponseWriter, r *http.Request) {
+	adminCache, ok := car.cache(rw, r)
+	if !ok {
+		return
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), DefaultCacheAdminJanitorTimeout)
+	defer cancel()
+	if err := adminCache.Janitor(ctx); err != nil {
+		RenderError(rw, err, mapCacheAdminStatus)
+
+		return
+	}
+
+	RenderJSON(rw, http.StatusOK, adminCache.Info(), mapCacheAdminStatus)
+}
+
+// cache кэш по имени из пути, при отсутствии ответ 404 уже отправлен
+func (car *CacheAdminRouter) cache(rw http.ResponseWriter, r *http.Request) (cache.AdminCache, bool) {
+	res, err := car.registry.Get(chi.URLParam(r, "name"))
+	if err != nil {
+		RenderError(rw, err, mapCacheAdminStatus)
+
+		return nil, false
+	}
+
+	return res, true
+}
+
+// mapCacheAdminStatus ошибки операций кэша (например, кэш не поддерживает обход ключей) - ошибка запроса
+func mapCacheAdminStatus(err error) int {
+	var cacheErr *errs.DalCacheError
+	if errors.As(err, &cacheErr) {
+		return http.StatusBadRequest
+	}
+
+	return MapToHTTPStatus(err)
+}
@@ -0,0 +1,111 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/infra/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type cacheAdminTestValue struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func newCacheAdminTestRouter(t *testing.T) (http.Handler, cache.Cache[string, *cacheAdminTestValue]) {
+	t.Helper()
+	c := cache.NewObjectManager[string, *cacheAdminTestValue](1, 0, nil, 100)
+	for i, key := range []string{"user/1", "user/2", "user/3", "order/1"} {
+		require.NoError(t, c.Set(key, &cacheAdminTestValue{ID: i, Name: key}, time.Hour))
+	}
+	registry := cache.NewRegistry()
+	cache.RegisterCache[string, *cacheAdminTestValue](registry, "test", c)
+
+	return NewCacheAdminRouter(registry, nil).GetRouter(), c
+}
+
+func serveCacheAdmin(handler http.Handler, method string, target string) *httptest.ResponseRecorder {
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(method, target, nil))
+
+	return rr
+}
+
+func TestCacheAdminRouter_Info(t *testing.T) {
+	router, _ := newCacheAdminTestRouter(t)
+
+	rr := serveCacheAdmin(router, http.MethodGet, "/")
+	require.Equal(t, http.StatusOK, rr.Code)
+	var list []cache.CacheInfo
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &list))
+	require.Len(t, list, 1)
+	assert.Equal(t, "test", list[0].Name)
+	assert.Equal(t, 4, list[0].Size)
+
+	assert.Equal(t, http.StatusOK, serveCacheAdmin(router, http.MethodGet, "/test").Code)
+	assert.Equal(t, http.StatusNotFound, serveCacheAdmin(router, http.MethodGet, "/unknown").Code)
+}
+
+func TestCacheAdminRouter_Keys(t *testing.T) {
+	router, _ := newCacheAdminTestRouter(t)
+
+	rr := serveCacheAdmin(router, http.MethodGet, "/test/keys?prefix=user/&offset=1&limit=1")
+	require.Equal(t, http.StatusOK, rr.Code)
+	var page cache.KeyPage
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &page))
+	assert.Equal(t, []string{"user/2"}, page.Keys)
+	assert.Equal(t, 3, page.Total)
+
+	rr = serveCacheAdmin(router, http.MethodGet, "/test/keys?offset=10")
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &page))
+	assert.Empty(t, page.Keys)
+	assert.Equal(t, 4, page.Total)
+
+	assert.Equal(t, http.StatusBadRequest, serveCacheAdmin(router, http.MethodGet, "/test/keys?offset=-1").Code)
+}
+
+func TestCacheAdminRouter_Entry(t *testing.T) {
+	router, c := newCacheAdminTestRouter(t)
+	target := "/test/entry?key=" + url.QueryEscape("user/2")
+
+	rr := serveCacheAdmin(router, http.MethodGet, target)
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{"id":1,"name":"user/2"}`, rr.Body.String())
+
+	assert.Equal(t, http.StatusBadRequest, serveCacheAdmin(router, http.MethodGet, "/test/entry").Code)
+	assert.Equal(t, http.StatusNotFound, serveCacheAdmin(router, http.MethodGet, "/test/entry?key=missing").Code)
+
+	assert.Equal(t, http.StatusNoContent, serveCacheAdmin(router, http.MethodDelete, target).Code)
+	_, ok, _ := c.Get("user/2")
+	assert.False(t, ok)
+}
+
+func TestCacheAdminRouter_ClearAndJanitor(t *testing.T) {
+	router, c := newCacheAdminTestRouter(t)
+	require.NoError(t, c.Set("short", &cacheAdminTestValue{}, time.Nanosecond))
+	time.Sleep(time.Millisecond)
+
+	assert.Equal(t, http.StatusOK, serveCacheAdmin(router, http.MethodPost, "/test/janitor").Code)
+	assert.Equal(t, 4, c.Size(), "Janitor удаляет истекшие значения")
+
+	assert.Equal(t, http.StatusNoContent, serveCacheAdmin(router, http.MethodPost, "/test/clear").Code)
+	assert.Zero(t, c.Size())
+}
+
+func TestCacheAdminRouter_Guard(t *testing.T) {
+	guard := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		})
+	}
+	router := NewCacheAdminRouter(cache.NewRegistry(), guard).GetRouter()
+
+	assert.Equal(t, http.StatusUnauthorized, serveCacheAdmin(router, http.MethodGet, "/").Code)
+}
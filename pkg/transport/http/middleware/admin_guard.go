@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/logger"
+)
+
+// HeaderXAdminToken токен доступа к административным эндпоинтам
+const HeaderXAdminToken string = "X-Admin-Token"
+
+// AdminGuard - проверка токена доступа к административным эндпоинтам (X-Admin-Token или Authorization: Bearer).
+// Без заданного токена доступ запрещен (404), эндпоинты не раскрываются.
+type AdminGuard struct {
+	token []byte
+	log   logger.Logger
+}
+
+func NewAdminGuard(token string, logger logger.Logger) *AdminGuard {
+	return &AdminGuard{
+		token: []byte(token),
+		log:   logger.GetLogger("http_admin_guard_middleware"),
+	}
+}
+
+func (ag *AdminGuard) Handle(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(ag.token) == 0 {
+			ag.log.Warnf("AdminGuard.Handle token not configured [%s %s] remote [%s]", r.Method, r.URL.Path, r.RemoteAddr)
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+		if subtle.ConstantTimeCompare([]byte(ag.requestToken(r)), ag.token) != 1 {
+			ag.log.Warnf("AdminGuard.Handle access denied [%s %s] remote [%s]", r.Method, r.URL.Path, r.RemoteAddr)
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (ag *AdminGuard) requestToken(r *http.Request) string {
+	if token := r.Header.Get(HeaderXAdminToken); token != "" {
+		return token
+	}
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if ok && strings.EqualFold(scheme, "Bearer") {
+		return strings.TrimSpace(token)
+	}
+
+	return ""
+}
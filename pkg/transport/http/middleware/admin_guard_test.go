@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/logger/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAdminGuard_Handle(t *testing.T) {
+	mockLog := mocks.NewMockLogger(t)
+	mockLog.On("GetLogger", mock.Anything).Return(mockLog)
+	mockLog.On("Warnf", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return().Maybe()
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	cases := []struct {
+		name    string
+		token   string
+		headers map[string]string
+		status  int
+	}{
+		{"no token configured", "", nil, http.StatusNotFound},
+		{"no token configured with empty bearer", "", map[string]string{"Authorization": "Bearer "}, http.StatusNotFound},
+		{"missing token", "secret", nil, http.StatusUnauthorized},
+		{"wrong token", "secret", map[string]string{HeaderXAdminToken: "other"}, http.StatusUnauthorized},
+		{"admin header", "secret", map[string]string{HeaderXAdminToken: "secret"}, http.StatusOK},
+		{"bearer", "secret", map[string]string{"Authorization": "Bearer secret"}, http.StatusOK},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/admin", nil)
+			for name, value := range tc.headers {
+				req.Header.Set(name, value)
+			}
+			rr := httptest.NewRecorder()
+			NewAdminGuard(tc.token, mockLog).Handle(next).ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.status, rr.Code)
+		})
+	}
+}
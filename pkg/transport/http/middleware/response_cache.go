@@ -0,0 +1,409 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/infra/cache"
+	"github.com/ElfAstAhe/go-service-template/pkg/logger"
+	pkghttp "github.com/ElfAstAhe/go-service-template/pkg/transport/http"
+)
+
+// Заголовки условных запросов и кэширования
+const (
+	HeaderETag            string = "ETag"
+	HeaderIfNoneMatch     string = "If-None-Match"
+	HeaderIfModifiedSince string = "If-Modified-Since"
+	HeaderLastModified    string = "Last-Modified"
+	HeaderCacheControl    string = "Cache-Control"
+	HeaderVary            string = "Vary"
+	HeaderSetCookie       string = "Set-Cookie"
+	HeaderAuthorization   string = "Authorization"
+	HeaderCookie          string = "Cookie"
+)
+
+// DefaultResponseCacheControl Cache-Control ответа по умолчанию: клиент хранит ответ, но перепроверяет его по ETag
+const DefaultResponseCacheControl = "no-cache"
+
+// DefaultResponseCacheMaxBody максимальный размер буферизуемого тела ответа, больший ответ передается без ETag и кэширования
+const DefaultResponseCacheMaxBody = 1 << 20
+
+// responseCacheTagPrefix префикс тега кэшированных ответов пути
+const responseCacheTagPrefix = "http:path:"
+
+// CachedResponse - сохраненный ответ
+type CachedResponse struct {
+	Status       int         `json:"status"`
+	Header       http.Header `json:"header"`
+	Body         []byte      `json:"body"`
+	ETag         string      `json:"etag"`
+	LastModified time.Time   `json:"last_modified"`
+}
+
+// ResponseCache - middleware условных GET запросов: строгий ETag по телу ответа, 304 для If-None-Match/If-Modified-Since,
+// при заданном хранилище (WithResponseCacheStore) ответы целиком сохраняются в cache.Cache.
+// Хранилище общее для всех клиентов: ответы на запросы с учетными данными (Authorization, Cookie) сохраняются
+// и отдаются из хранилища только с Cache-Control: public (RFC 9111, 3.5).
+// Обрабатываются только GET запросы маршрутов из matchers (nil - все GET запросы).
+type ResponseCache struct {
+	matchers     *pkghttp.PathMatchers
+	store        cache.Cache[string, *CachedResponse]
+	ttl          time.Duration
+	varyHeaders  []string
+	cacheControl string
+	maxBody      int
+	log          logger.Logger
+}
+
+type ResponseCacheOption func(*ResponseCache)
+
+// WithResponseCacheStore хранение ответов в store на ttl. Успешные небезопасные запросы (POST, PUT, PATCH, DELETE)
+// удаляют сохраненные ответы своего пути, прочие пути сбрасываются через InvalidatePath.
+func WithResponseCacheStore(store cache.Cache[string, *CachedResponse], ttl time.Duration) ResponseCacheOption {
+	return func(rc *ResponseCache) {
+		rc.store = store
+		rc.ttl = ttl
+	}
+}
+
+// WithResponseCacheVary заголовки запроса, различающие представления (по умолчанию Accept и Accept-Encoding)
+func WithResponseCacheVary(headers ...string) ResponseCacheOption {
+	return func(rc *ResponseCache) {
+		rc.varyHeaders = headers
+	}
+}
+
+// WithResponseCacheControl Cache-Control для ответов, в которых он не задан обработчиком, "" - не устанавливать
+func WithResponseCacheControl(value string) ResponseCacheOption {
+	return func(rc *ResponseCache) {
+		rc.cacheControl = value
+	}
+}
+
+// WithResponseCacheMaxBody максимальный размер буферизуемого тела ответа в байтах
+func WithResponseCacheMaxBody(maxBody int) ResponseCacheOption {
+	return func(rc *ResponseCache) {
+		rc.maxBody = maxBody
+	}
+}
+
+func NewResponseCache(logger logger.Logger, matchers *pkghttp.PathMatchers, opts ...ResponseCacheOption) *ResponseCache {
+	res := &ResponseCache{
+		matchers:     matchers,
+		varyHeaders:  []string{"Accept", "Accept-Encoding"},
+		cacheControl: DefaultResponseCacheControl,
+		maxBody:      DefaultResponseCacheMaxBody,
+		log:          logger.GetLogger("http_response_cache_middleware"),
+	}
+	for _, opt := range opts {
+		opt(res)
+	}
+
+	return res
+}
+
+func (rc *ResponseCache) Handle(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && (rc.matchers == nil || rc.matchers.Match(r.Method, r.URL.Path)):
+			rc.handleGet(next, w, r)
+		case rc.store != nil && isUnsafeMethod(r.Method):
+			rc.handleUnsafe(next, w, r)
+		default:
+			next.ServeHTTP(w, r)
+		}
+	})
+}
+
+// InvalidatePath удаление сохраненных ответов пути (все представления)
+func (rc *ResponseCache) InvalidatePath(path string) (int, error) {
+	if rc.store == nil {
+		return 0, nil
+	}
+
+	return rc.store.InvalidateTag(responseCacheTagPrefix + path)
+}
+
+func (rc *ResponseCache) handleGet(next http.Handler, w http.ResponseWriter, r *http.Request) {
+	reqCacheControl := r.Header.Get(HeaderCacheControl)
+	noStore := hasDirective(reqCacheControl, "no-store")
+	credentialed := hasCredentials(r)
+	key := rc.key(r)
+
+	// сохраненный ответ, no-cache запроса требует обращения к обработчику
+	if rc.store != nil && !noStore && !hasDirective(reqCacheControl, "no-cache") {
+		stored, ok, err := rc.store.Get(key)
+		if err != nil {
+			rc.log.Warnf("ResponseCache.Handle get stored response [%s] failed: %v", key, err)
+		}
+		// ответ без public мог быть сохранен для анонимного запроса и не предназначен клиенту с учетными данными
+		if ok && stored != nil && (!credentialed || hasDirective(stored.Header.Get(HeaderCacheControl), "public")) {
+			rc.write(w, r, stored)
+
+			return
+		}
+	}
+
+	bw := &bufferedResponseWriter{ResponseWriter: w, maxBody: rc.maxBody}
+	next.ServeHTTP(bw, r)
+	if bw.passthrough {
+		return
+	}
+
+	status := bw.statusCode()
+	if status != http.StatusOK {
+		bw.flush()
+
+		return
+	}
+
+	header := w.Header()
+	resp := &CachedResponse{
+		Status: status,
+		Body:   bw.buf.Bytes(),
+		ETag:   header.Get(HeaderETag),
+	}
+	if resp.ETag == "" {
+		resp.ETag = strongETag(resp.Body)
+		header.Set(HeaderETag, resp.ETag)
+	}
+	if lastModified, err := http.ParseTime(header.Get(HeaderLastModified)); err == nil {
+		resp.LastModified = lastModified
+	}
+	if header.Get(HeaderCacheControl) == "" && rc.cacheControl != "" {
+		header.Set(HeaderCacheControl, rc.cacheControl)
+	}
+	rc.addVary(header)
+
+	if rc.store != nil && !noStore && rc.storable(header, credentialed) {
+		resp.Header = header.Clone()
+		if err := rc.store.SetWithTags(key, resp, rc.ttl, responseCacheTagPrefix+r.URL.Path); err != nil {
+			rc.log.Warnf("ResponseCache.Handle store response [%s] failed: %v", key, err)
+		}
+	}
+
+	if notModified(r, resp) {
+		writeNotModified(w)
+
+		return
+	}
+	bw.flush()
+}
+
+func (rc *ResponseCache) handleUnsafe(next http.Handler, w http.ResponseWriter, r *http.Request) {
+	sw := &statusResponseWriter{ResponseWriter: w}
+	next.ServeHTTP(sw, r)
+	if sw.status >= http.StatusBadRequest {
+		return
+	}
+	if _, err := rc.InvalidatePath(r.URL.Path); err != nil {
+		rc.log.Warnf("ResponseCache.Handle invalidate path [%s] failed: %v", r.URL.Path, err)
+	}
+}
+
+// write ответ из сохраненного
+func (rc *ResponseCache) write(w http.ResponseWriter, r *http.Request, stored *CachedResponse) {
+	header := w.Header()
+	for name, values := range stored.Header {
+		header[name] = append([]string(nil), values...)
+	}
+	if notModified(r, stored) {
+		writeNotModified(w)
+
+		return
+	}
+	header.Set("Content-Length", strconv.Itoa(len(stored.Body)))
+	w.WriteHeader(stored.Status)
+	_, _ = w.Write(stored.Body)
+}
+
+// key ключ сохраненного ответа: метод, путь с параметрами и значения заголовков Vary
+func (rc *ResponseCache) key(r *http.Request) string {
+	var sb strings.Builder
+	sb.WriteString(r.Method)
+	sb.WriteByte(' ')
+	sb.WriteString(r.URL.RequestURI())
+	for _, name := range rc.varyHeaders {
+		sb.WriteByte('\n')
+		sb.WriteString(name)
+		sb.WriteByte(':')
+		sb.WriteString(r.Header.Get(name))
+	}
+
+	return sb.String()
+}
+
+func (rc *ResponseCache) addVary(header http.Header) {
+	for _, name := range rc.varyHeaders {
+		if !hasToken(header.Values(HeaderVary), name) {
+			header.Add(HeaderVary, name)
+		}
+	}
+}
+
+// storable ответ можно хранить в общем кэше, ответ на запрос с учетными данными - только с public
+func (rc *ResponseCache) storable(header http.Header, credentialed bool) bool {
+	cacheControl := header.Get(HeaderCacheControl)
+
+	return !hasDirective(cacheControl, "no-store") &&
+		!hasDirective(cacheControl, "private") &&
+		(!credentialed || hasDirective(cacheControl, "public")) &&
+		len(header.Values(HeaderSetCookie)) == 0
+}
+
+// hasCredentials запрос содержит учетные данные клиента
+func hasCredentials(r *http.Request) bool {
+	return r.Header.Get(HeaderAuthorization) != "" || r.Header.Get(HeaderCookie) != ""
+}
+
+// notModified проверка условного запроса: If-None-Match имеет приоритет над If-Modified-Since (RFC 9110)
+func notModified(r *http.Request, resp *CachedResponse) bool {
+	if inm := r.Header.Get(HeaderIfNoneMatch); inm != "" {
+		return etagMatch(inm, resp.ETag)
+	}
+	if resp.LastModified.IsZero() {
+		return false
+	}
+	ims, err := http.ParseTime(r.Header.Get(HeaderIfModifiedSince))
+	if err != nil {
+		return false
+	}
+
+	return !resp.LastModified.Truncate(time.Second).After(ims)
+}
+
+func writeNotModified(w http.ResponseWriter) {
+	header := w.Header()
+	// 304 не содержит тела и описывающих его заголовков
+	header.Del("Content-Type")
+	header.Del("Content-Length")
+	header.Del("Content-Encoding")
+	w.WriteHeader(http.StatusNotModified)
+}
+
+// etagMatch слабое сравнение списка If-None-Match с ETag ответа
+func etagMatch(ifNoneMatch string, etag string) bool {
+	if etag == "" {
+		return false
+	}
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// strongETag строгий ETag по содержимому тела
+func strongETag(body []byte) string {
+	sum := sha256.Sum256(body)
+
+	return `"` + base64.RawURLEncoding.EncodeToString(sum[:16]) + `"`
+}
+
+// hasDirective наличие директивы в значении Cache-Control
+func hasDirective(cacheControl string, directive string) bool {
+	for _, item := range strings.Split(cacheControl, ",") {
+		name, _, _ := strings.Cut(strings.TrimSpace(item), "=")
+		if strings.EqualFold(name, directive) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasToken(values []string, token string) bool {
+	for _, value := range values {
+		for _, item := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(item), token) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func isUnsafeMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// bufferedResponseWriter буферизация ответа до maxBody байт, больший ответ передается клиенту напрямую
+type bufferedResponseWriter struct {
+	http.ResponseWriter
+	buf         bytes.Buffer
+	status      int
+	maxBody     int
+	passthrough bool
+}
+
+func (bw *bufferedResponseWriter) WriteHeader(status int) {
+	if bw.status == 0 {
+		bw.status = status
+	}
+}
+
+func (bw *bufferedResponseWriter) Write(p []byte) (int, error) {
+	if bw.passthrough {
+		return bw.ResponseWriter.Write(p)
+	}
+	if bw.buf.Len()+len(p) > bw.maxBody {
+		bw.flush()
+		bw.passthrough = true
+
+		return bw.ResponseWriter.Write(p)
+	}
+
+	return bw.buf.Write(p)
+}
+
+func (bw *bufferedResponseWriter) statusCode() int {
+	if bw.status == 0 {
+		return http.StatusOK
+	}
+
+	return bw.status
+}
+
+// flush передача заголовка и буферизованного тела клиенту
+func (bw *bufferedResponseWriter) flush() {
+	bw.ResponseWriter.WriteHeader(bw.statusCode())
+	if bw.buf.Len() > 0 {
+		_, _ = bw.ResponseWriter.Write(bw.buf.Bytes())
+	}
+}
+
+// statusResponseWriter перехват кода ответа
+type statusResponseWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (sw *statusResponseWriter) WriteHeader(status int) {
+	if sw.status == 0 {
+		sw.status = status
+	}
+	sw.ResponseWriter.WriteHeader(status)
+}
+
+func (sw *statusResponseWriter) Write(p []byte) (int, error) {
+	if sw.status == 0 {
+		sw.status = http.StatusOK
+	}
+
+	return sw.ResponseWriter.Write(p)
+}
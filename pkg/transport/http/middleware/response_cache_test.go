@@ -0,0 +1,213 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/infra/cache"
+	"github.com/ElfAstAhe/go-service-template/pkg/logger/mocks"
+	pkghttp "github.com/ElfAstAhe/go-service-template/pkg/transport/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newResponseCacheTestLogger(t *testing.T) *mocks.MockLogger {
+	mockLog := mocks.NewMockLogger(t)
+	mockLog.On("GetLogger", mock.Anything).Return(mockLog)
+	mockLog.On("Warnf", mock.Anything, mock.Anything, mock.Anything).Return().Maybe()
+
+	return mockLog
+}
+
+func newResponseCacheTestHandler(calls *atomic.Int32, body *atomic.Value) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body.Load().(string)))
+	})
+}
+
+func serveResponseCache(handler http.Handler, method string, target string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, nil)
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	return rr
+}
+
+func TestResponseCache_ETag(t *testing.T) {
+	var calls atomic.Int32
+	var body atomic.Value
+	body.Store(`{"id":1}`)
+	handler := NewResponseCache(newResponseCacheTestLogger(t), nil).Handle(newResponseCacheTestHandler(&calls, &body))
+
+	rr := serveResponseCache(handler, http.MethodGet, "/api/test/1", nil)
+	require.Equal(t, http.StatusOK, rr.Code)
+	etag := rr.Header().Get(HeaderETag)
+	require.NotEmpty(t, etag)
+	assert.NotContains(t, etag, "W/", "ETag строгий")
+	assert.Equal(t, DefaultResponseCacheControl, rr.Header().Get(HeaderCacheControl))
+	assert.Equal(t, `{"id":1}`, rr.Body.String())
+
+	rr = serveResponseCache(handler, http.MethodGet, "/api/test/1", map[string]string{HeaderIfNoneMatch: etag})
+	assert.Equal(t, http.StatusNotModified, rr.Code)
+	assert.Empty(t, rr.Body.String())
+	assert.Equal(t, etag, rr.Header().Get(HeaderETag))
+
+	rr = serveResponseCache(handler, http.MethodGet, "/api/test/1", map[string]string{HeaderIfNoneMatch: `"other", W/` + etag})
+	assert.Equal(t, http.StatusNotModified, rr.Code, "If-None-Match сравнивается слабо по списку")
+
+	body.Store(`{"id":2}`)
+	rr = serveResponseCache(handler, http.MethodGet, "/api/test/1", map[string]string{HeaderIfNoneMatch: etag})
+	assert.Equal(t, http.StatusOK, rr.Code, "Измененное представление отдается целиком")
+	assert.NotEqual(t, etag, rr.Header().Get(HeaderETag))
+	assert.Equal(t, int32(4), calls.Load())
+}
+
+func TestResponseCache_IfModifiedSince(t *testing.T) {
+	modified := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	handler := NewResponseCache(newResponseCacheTestLogger(t), nil).Handle(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(HeaderLastModified, modified.Format(http.TimeFormat))
+		_, _ = w.Write([]byte("body"))
+	}))
+
+	rr := serveResponseCache(handler, http.MethodGet, "/", map[string]string{HeaderIfModifiedSince: modified.Format(http.TimeFormat)})
+	assert.Equal(t, http.StatusNotModified, rr.Code)
+
+	rr = serveResponseCache(handler, http.MethodGet, "/", map[string]string{HeaderIfModifiedSince: modified.Add(-time.Hour).Format(http.TimeFormat)})
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	rr = serveResponseCache(handler, http.MethodGet, "/", map[string]string{
+		HeaderIfModifiedSince: modified.Format(http.TimeFormat),
+		HeaderIfNoneMatch:     `"other"`,
+	})
+	assert.Equal(t, http.StatusOK, rr.Code, "If-None-Match имеет приоритет над If-Modified-Since")
+}
+
+func TestResponseCache_Store(t *testing.T) {
+	var calls atomic.Int32
+	var body atomic.Value
+	body.Store(`{"id":1}`)
+	store := cache.NewObjectManager[string, *CachedResponse](1, 0, nil, 100)
+	rc := NewResponseCache(newResponseCacheTestLogger(t), nil, WithResponseCacheStore(store, time.Minute))
+	handler := rc.Handle(newResponseCacheTestHandler(&calls, &body))
+
+	first := serveResponseCache(handler, http.MethodGet, "/api/test/1", nil)
+	second := serveResponseCache(handler, http.MethodGet, "/api/test/1", nil)
+	assert.Equal(t, int32(1), calls.Load(), "Повторный запрос отдается из кэша")
+	assert.Equal(t, first.Body.String(), second.Body.String())
+	assert.Equal(t, first.Header().Get(HeaderETag), second.Header().Get(HeaderETag))
+	assert.Equal(t, "application/json", second.Header().Get("Content-Type"))
+
+	rr := serveResponseCache(handler, http.MethodGet, "/api/test/1", map[string]string{HeaderIfNoneMatch: first.Header().Get(HeaderETag)})
+	assert.Equal(t, http.StatusNotModified, rr.Code)
+	assert.Equal(t, int32(1), calls.Load())
+
+	serveResponseCache(handler, http.MethodGet, "/api/test/1", map[string]string{"Accept-Encoding": "br"})
+	assert.Equal(t, int32(2), calls.Load(), "Представления различаются заголовками Vary")
+
+	serveResponseCache(handler, http.MethodGet, "/api/test/1", map[string]string{HeaderCacheControl: "no-cache"})
+	assert.Equal(t, int32(3), calls.Load(), "no-cache запроса обходит сохраненный ответ")
+
+	serveResponseCache(handler, http.MethodPut, "/api/test/1", nil)
+	assert.Equal(t, int32(4), calls.Load())
+	body.Store(`{"id":2}`)
+	rr = serveResponseCache(handler, http.MethodGet, "/api/test/1", nil)
+	assert.Equal(t, int32(5), calls.Load(), "Успешный PUT удаляет сохраненные ответы пути")
+	assert.Equal(t, `{"id":2}`, rr.Body.String())
+}
+
+func TestResponseCache_NotStored(t *testing.T) {
+	store := cache.NewObjectManager[string, *CachedResponse](1, 0, nil, 100)
+	handler := NewResponseCache(newResponseCacheTestLogger(t), nil, WithResponseCacheStore(store, time.Minute)).Handle(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/private":
+				w.Header().Set(HeaderCacheControl, "private")
+			case "/missing":
+				w.WriteHeader(http.StatusNotFound)
+			}
+			_, _ = w.Write([]byte("body"))
+		}))
+
+	rr := serveResponseCache(handler, http.MethodGet, "/private", nil)
+	assert.Equal(t, "private", rr.Header().Get(HeaderCacheControl))
+	rr = serveResponseCache(handler, http.MethodGet, "/missing", nil)
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Empty(t, rr.Header().Get(HeaderETag))
+	serveResponseCache(handler, http.MethodGet, "/public", map[string]string{HeaderCacheControl: "no-store"})
+
+	assert.Zero(t, store.Size())
+}
+
+func TestResponseCache_Credentials(t *testing.T) {
+	var calls atomic.Int32
+	store := cache.NewObjectManager[string, *CachedResponse](1, 0, nil, 100)
+	handler := NewResponseCache(newResponseCacheTestLogger(t), nil, WithResponseCacheStore(store, time.Minute)).Handle(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			if r.URL.Path == "/public" {
+				w.Header().Set(HeaderCacheControl, "public, no-cache")
+			}
+			_, _ = w.Write([]byte("user:" + r.Header.Get(HeaderAuthorization) + r.Header.Get(HeaderCookie)))
+		}))
+	alice := map[string]string{HeaderAuthorization: "Bearer alice"}
+	bob := map[string]string{HeaderAuthorization: "Bearer bob"}
+	cookie := map[string]string{HeaderCookie: "session=carol"}
+
+	rr := serveResponseCache(handler, http.MethodGet, "/me", alice)
+	assert.Equal(t, "user:Bearer alice", rr.Body.String())
+	rr = serveResponseCache(handler, http.MethodGet, "/me", bob)
+	assert.Equal(t, "user:Bearer bob", rr.Body.String(), "Ответ другому клиенту не отдается из хранилища")
+	rr = serveResponseCache(handler, http.MethodGet, "/me", cookie)
+	assert.Equal(t, "user:session=carol", rr.Body.String())
+	assert.Equal(t, int32(3), calls.Load())
+	assert.Zero(t, store.Size(), "Ответы на запросы с учетными данными без public не сохраняются")
+
+	// сохраненный для анонимного запроса ответ не отдается клиенту с учетными данными
+	rr = serveResponseCache(handler, http.MethodGet, "/me", nil)
+	assert.Equal(t, "user:", rr.Body.String())
+	assert.Equal(t, 1, store.Size())
+	rr = serveResponseCache(handler, http.MethodGet, "/me", alice)
+	assert.Equal(t, "user:Bearer alice", rr.Body.String())
+	assert.Equal(t, int32(5), calls.Load())
+
+	// ответ с public общий для всех клиентов
+	serveResponseCache(handler, http.MethodGet, "/public", alice)
+	rr = serveResponseCache(handler, http.MethodGet, "/public", bob)
+	assert.Equal(t, "user:Bearer alice", rr.Body.String())
+	serveResponseCache(handler, http.MethodGet, "/public", nil)
+	assert.Equal(t, int32(6), calls.Load())
+}
+
+func TestResponseCache_PathMatchers(t *testing.T) {
+	var calls atomic.Int32
+	var body atomic.Value
+	body.Store("body")
+	matchers := pkghttp.NewHTTPPathMatchers([]*pkghttp.PathMatcher{
+		pkghttp.NewPathMatcher(http.MethodGet, "/api/test/{id}", `^/api/test/[^/]+$`),
+	})
+	handler := NewResponseCache(newResponseCacheTestLogger(t), matchers).Handle(newResponseCacheTestHandler(&calls, &body))
+
+	assert.NotEmpty(t, serveResponseCache(handler, http.MethodGet, "/api/test/1", nil).Header().Get(HeaderETag))
+	assert.Empty(t, serveResponseCache(handler, http.MethodGet, "/api/other", nil).Header().Get(HeaderETag))
+}
+
+func TestResponseCache_MaxBody(t *testing.T) {
+	handler := NewResponseCache(newResponseCacheTestLogger(t), nil, WithResponseCacheMaxBody(4)).Handle(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("abc"))
+			_, _ = w.Write([]byte("defgh"))
+		}))
+
+	rr := serveResponseCache(handler, http.MethodGet, "/", nil)
+	assert.Equal(t, "abcdefgh", rr.Body.String())
+	assert.Empty(t, rr.Header().Get(HeaderETag), "Ответ больше ограничения передается без буферизации")
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/ElfAstAhe/go-service-template/pkg/container"
 	"github.com/ElfAstAhe/go-service-template/pkg/errs"
+	"github.com/ElfAstAhe/go-service-template/pkg/infra/metrics"
 	"github.com/ElfAstAhe/go-service-template/pkg/logger"
 )
 
@@ -16,9 +17,12 @@ type TimerDispatcher func(ctx context.Context, eventTime time.Time) error
 
 type BaseSchedulerConfig struct {
 	// schedule
+	// StartInterval задержка первого запуска, при заданном Schedule 0 - первый запуск по расписанию
 	StartInterval    time.Duration
 	ScheduleInterval time.Duration
-	StopTimeout      time.Duration
+	// Schedule расписание запусков, nil - фиксированный интервал ScheduleInterval
+	Schedule    Schedule
+	StopTimeout time.Duration
 }
 
 func NewBaseSchedulerConfig(
@@ -33,6 +37,27 @@ func NewBaseSchedulerConfig(
 	}
 }
 
+// NewBaseSchedulerScheduleConfig конфигурация с произвольным расписанием (например, ParseCron("0 3 * * *", time.UTC))
+func NewBaseSchedulerScheduleConfig(
+	startInterval time.Duration,
+	schedule Schedule,
+	stopTimeout time.Duration,
+) *BaseSchedulerConfig {
+	return &BaseSchedulerConfig{
+		StartInterval: startInterval,
+		Schedule:      schedule,
+		StopTimeout:   stopTimeout,
+	}
+}
+
+func (bsc *BaseSchedulerConfig) GetSchedule() Schedule {
+	if bsc.Schedule != nil {
+		return bsc.Schedule
+	}
+
+	return NewIntervalSchedule(bsc.ScheduleInterval)
+}
+
 type BaseScheduler struct {
 	name string
 	// context
@@ -43,6 +68,9 @@ type BaseScheduler struct {
 	// schedule
 	timer           *time.Timer
 	timerDispatcher TimerDispatcher
+	schedule        Schedule
+	// nextRun время следующего запуска (unix nano), 0 - не запланирован
+	nextRun atomic.Int64
 	// config
 	config *BaseSchedulerConfig
 	// logging
@@ -69,6 +97,9 @@ func NewBaseScheduler(
 		running:         new(atomic.Bool),
 	}
 	res.running.Store(false)
+	if config != nil {
+		res.schedule = config.GetSchedule()
+	}
 
 	return res
 }
@@ -85,16 +116,14 @@ func (bs *BaseScheduler) Start(ctx context.Context) error {
 	bs.ctx, bs.cancel = context.WithCancel(ctx)
 	// timer
 	if bs.timer == nil {
-		bs.timer = time.NewTimer(bs.GetConfig().StartInterval)
-	} else {
-		if !bs.timer.Stop() {
-			select {
-			case <-bs.timer.C:
-			default:
-			}
-		}
-		bs.timer.Reset(bs.GetConfig().StartInterval)
+		bs.timer = time.NewTimer(0)
 	}
+	now := time.Now()
+	firstRun := now.Add(bs.GetConfig().StartInterval)
+	if bs.GetConfig().StartInterval <= 0 && bs.GetConfig().Schedule != nil {
+		firstRun = bs.schedule.Next(now)
+	}
+	bs.resetTimer(firstRun)
 	// dispatcher
 	bs.GetWaitGroup().Add(1)
 	go bs.timerEventListener()
@@ -107,15 +136,6 @@ func (bs *BaseScheduler) Stop(stopCtx context.Context) error {
 		return errs.NewCommonError(fmt.Sprintf("scheduler %s is not running", bs.GetName()), nil)
 	}
 
-	// timer
-	if bs.timer != nil {
-		if !bs.timer.Stop() {
-			select {
-			case <-bs.timer.C:
-			default:
-			}
-		}
-	}
 	// cancel ctx
 	if bs.GetContextCancel() != nil {
 		bs.GetContextCancel()()
@@ -136,6 +156,8 @@ func (bs *BaseScheduler) Stop(stopCtx context.Context) error {
 	case <-stopCtx.Done():
 		bs.GetLogger().Debugf("scheduler %s stopped by stop context, force stopping", bs.GetName())
 	}
+	// timer сбрасывается после выхода слушателя, иначе слушатель успевает перевзвести его на следующий запуск
+	bs.resetTimer(time.Time{})
 
 	return nil
 }
@@ -154,16 +176,61 @@ func (bs *BaseScheduler) timerEventListener() {
 		case eventTime := <-bs.timer.C:
 			bs.GetLogger().Debugf("scheduler %s timer event listener, time event fired: %s", bs.GetName(), eventTime.Format(time.DateTime))
 			if bs.timerDispatcher != nil {
-				if err := bs.timerDispatcher(bs.ctx, eventTime); err != nil {
+				err := bs.timerDispatcher(bs.ctx, eventTime)
+				if err != nil {
 					bs.GetLogger().Errorf("scheduler %s time event %s dispatcher failed: %v", bs.GetName(), eventTime.Format(time.DateTime), err)
 				}
+				metrics.ObserveSchedulerRun(bs.GetName(), err)
 			} else {
 				bs.GetLogger().Warnf("scheduler %s time event %s dispatcher not applied", bs.GetName(), eventTime.Format(time.DateTime))
 			}
 
-			bs.timer.Reset(bs.GetConfig().ScheduleInterval)
+			if bs.GetContext().Err() != nil {
+				return
+			}
+			// следующий запуск считается от окончания текущего, пропущенные за время работы слоты не наверстываются
+			bs.resetTimer(bs.schedule.Next(time.Now()))
+		}
+	}
+}
+
+// resetTimer перевод таймера на время next, нулевое время - остановка без следующего запуска
+func (bs *BaseScheduler) resetTimer(next time.Time) {
+	if !bs.timer.Stop() {
+		select {
+		case <-bs.timer.C:
+		default:
+		}
+	}
+
+	if next.IsZero() {
+		bs.nextRun.Store(0)
+		metrics.ObserveSchedulerNextRun(bs.GetName(), next)
+		if bs.IsRunning() {
+			bs.GetLogger().Warnf("scheduler %s schedule has no next run", bs.GetName())
 		}
+
+		return
 	}
+
+	bs.nextRun.Store(next.UnixNano())
+	metrics.ObserveSchedulerNextRun(bs.GetName(), next)
+	bs.GetLogger().Debugf("scheduler %s next run at %s", bs.GetName(), next.Format(time.RFC3339))
+	bs.timer.Reset(max(time.Until(next), 0))
+}
+
+// NextRun время следующего запуска, нулевое время - планировщик остановлен либо расписание исчерпано
+func (bs *BaseScheduler) NextRun() time.Time {
+	next := bs.nextRun.Load()
+	if next == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(0, next)
+}
+
+func (bs *BaseScheduler) GetSchedule() Schedule {
+	return bs.schedule
 }
 
 func (bs *BaseScheduler) GetName() string {
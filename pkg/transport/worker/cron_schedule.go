@@ -0,0 +1,290 @@
+package worker
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/errs"
+)
+
+// cronYearLimit глубина поиска следующего запуска (для невыполнимых выражений вроде 30 февраля)
+const cronYearLimit = 5
+
+// cronDescriptors сокращения в формате с секундами
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 0 1 1 *",
+	"@annually": "0 0 0 1 1 *",
+	"@monthly":  "0 0 0 1 * *",
+	"@weekly":   "0 0 0 * * 0",
+	"@daily":    "0 0 0 * * *",
+	"@midnight": "0 0 0 * * *",
+	"@hourly":   "0 0 * * * *",
+}
+
+type cronBounds struct {
+	name  string
+	min   int
+	max   int
+	names map[string]int
+	// rangeEnd замена значения конца диапазона, меньшего начала (SUN в конце диапазона - 7)
+	rangeEnd map[int]int
+}
+
+var (
+	cronSeconds = cronBounds{name: "second", min: 0, max: 59}
+	cronMinutes = cronBounds{name: "minute", min: 0, max: 59}
+	cronHours   = cronBounds{name: "hour", min: 0, max: 23}
+	cronDays    = cronBounds{name: "day of month", min: 1, max: 31}
+	cronMonths  = cronBounds{name: "month", min: 1, max: 12, names: map[string]int{
+		"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+		"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+	}}
+	// 7 - тоже воскресенье, в том числе в конце диапазона (MON-SUN, FRI-0)
+	cronWeekdays = cronBounds{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+	}, rangeEnd: map[int]int{0: 7}}
+)
+
+// CronSchedule расписание по cron выражению: 5 полей (минута час день месяц день_недели) или 6 полей (с секундами первым полем).
+// Поддерживаются *, ?, списки, диапазоны, шаги, имена месяцев и дней недели, сокращения @yearly/@monthly/@weekly/@daily/@hourly.
+// Если ограничены и день месяца, и день недели, запуск происходит при совпадении любого из них (как в классическом cron)
+type CronSchedule struct {
+	expr     string
+	location *time.Location
+	second   uint64
+	minute   uint64
+	hour     uint64
+	day      uint64
+	month    uint64
+	weekday  uint64
+	// dayStar, weekdayStar поле не ограничено
+	dayStar     bool
+	weekdayStar bool
+}
+
+var _ Schedule = (*CronSchedule)(nil)
+
+// ParseSchedule разбор расписания: cron выражение, сокращение @hourly и т.п., либо "@every <duration>".
+// Часовой пояс задается префиксом TZ=<zone> или CRON_TZ=<zone>, по умолчанию UTC
+func ParseSchedule(spec string) (Schedule, error) {
+	trimmed := strings.TrimSpace(spec)
+	if rest, ok := strings.CutPrefix(trimmed, "@every "); ok {
+		interval, err := time.ParseDuration(strings.TrimSpace(rest))
+		if err != nil || interval <= 0 {
+			return nil, errs.NewInvalidArgumentErrorChain("schedule", spec, err)
+		}
+
+		return NewIntervalSchedule(interval), nil
+	}
+
+	return ParseCron(trimmed, nil)
+}
+
+// ParseCron разбор cron выражения, location == nil - пояс из префикса TZ=/CRON_TZ= либо UTC
+func ParseCron(expr string, location *time.Location) (*CronSchedule, error) {
+	spec := strings.TrimSpace(expr)
+	if strings.HasPrefix(spec, "TZ=") || strings.HasPrefix(spec, "CRON_TZ=") {
+		zone, rest, _ := strings.Cut(spec, " ")
+		_, zoneName, _ := strings.Cut(zone, "=")
+		loc, err := time.LoadLocation(zoneName)
+		if err != nil {
+			return nil, errs.NewInvalidArgumentErrorChain("cron time zone", zoneName, err)
+		}
+		if location == nil {
+			location = loc
+		}
+		spec = strings.TrimSpace(rest)
+	}
+	if location == nil {
+		location = time.UTC
+	}
+	if descriptor, ok := cronDescriptors[strings.ToLower(spec)]; ok {
+		spec = descriptor
+	}
+
+	fields := strings.Fields(spec)
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6:
+	default:
+		return nil, errs.NewInvalidArgumentErrorChain("cron", expr,
+			errs.NewCommonError(fmt.Sprintf("expected 5 or 6 fields, got %d", len(fields)), nil))
+	}
+
+	res := &CronSchedule{
+		expr:        expr,
+		location:    location,
+		dayStar:     isCronStar(fields[3]),
+		weekdayStar: isCronStar(fields[5]),
+	}
+	targets := []*uint64{&res.second, &res.minute, &res.hour, &res.day, &res.month, &res.weekday}
+	bounds := []cronBounds{cronSeconds, cronMinutes, cronHours, cronDays, cronMonths, cronWeekdays}
+	for i, field := range fields {
+		mask, err := parseCronField(field, bounds[i])
+		if err != nil {
+			return nil, errs.NewInvalidArgumentErrorChain("cron", expr, err)
+		}
+		*targets[i] = mask
+	}
+	// воскресенье 7 -> 0
+	if res.weekday&(1<<7) != 0 {
+		res.weekday = res.weekday&^(1<<7) | 1
+	}
+
+	return res, nil
+}
+
+// MustParseCron для выражений-констант
+func MustParseCron(expr string, location *time.Location) *CronSchedule {
+	res, err := ParseCron(expr, location)
+	if err != nil {
+		panic(err)
+	}
+
+	return res
+}
+
+func isCronStar(field string) bool {
+	return strings.HasPrefix(field, "*") || strings.HasPrefix(field, "?")
+}
+
+func parseCronField(field string, bounds cronBounds) (uint64, error) {
+	var mask uint64
+	for _, part := range strings.Split(field, ",") {
+		partMask, err := parseCronPart(part, bounds)
+		if err != nil {
+			return 0, err
+		}
+		mask |= partMask
+	}
+
+	return mask, nil
+}
+
+// parseCronPart элемент списка: *, ?, a, a-b, */n, a/n, a-b/n
+func parseCronPart(part string, bounds cronBounds) (uint64, error) {
+	rangePart, stepPart, hasStep := strings.Cut(part, "/")
+	step := 1
+	if hasStep {
+		value, err := strconv.Atoi(stepPart)
+		if err != nil || value <= 0 {
+			return 0, cronFieldError(bounds, part)
+		}
+		step = value
+	}
+
+	var start, end int
+	switch {
+	case rangePart == "*" || rangePart == "?":
+		start, end = bounds.min, bounds.max
+	case strings.Contains(rangePart, "-"):
+		from, to, _ := strings.Cut(rangePart, "-")
+		var err error
+		if start, err = parseCronValue(from, bounds); err != nil {
+			return 0, err
+		}
+		if end, err = parseCronValue(to, bounds); err != nil {
+			return 0, err
+		}
+		if alias, ok := bounds.rangeEnd[end]; ok && start > end {
+			end = alias
+		}
+	default:
+		value, err := parseCronValue(rangePart, bounds)
+		if err != nil {
+			return 0, err
+		}
+		start, end = value, value
+		// a/n - от a до конца диапазона
+		if hasStep {
+			end = bounds.max
+		}
+	}
+	if start > end {
+		return 0, cronFieldError(bounds, part)
+	}
+
+	var mask uint64
+	for value := start; value <= end; value += step {
+		mask |= 1 << uint(value)
+	}
+
+	return mask, nil
+}
+
+func parseCronValue(value string, bounds cronBounds) (int, error) {
+	if named, ok := bounds.names[strings.ToUpper(value)]; ok {
+		return named, nil
+	}
+	res, err := strconv.Atoi(value)
+	if err != nil || res < bounds.min || res > bounds.max {
+		return 0, cronFieldError(bounds, value)
+	}
+
+	return res, nil
+}
+
+func cronFieldError(bounds cronBounds, value string) error {
+	return errs.NewCommonError(fmt.Sprintf("invalid %s value [%s], allowed %d-%d", bounds.name, value, bounds.min, bounds.max), nil)
+}
+
+// Next ближайшее время строго после after в часовом поясе расписания
+func (cs *CronSchedule) Next(after time.Time) time.Time {
+	loc := cs.location
+	t := after.In(loc).Truncate(time.Second).Add(time.Second)
+	limit := t.Year() + cronYearLimit
+
+	for t.Year() <= limit {
+		year, month, day := t.Date()
+		switch {
+		case !hasBit(cs.month, int(month)):
+			t = advance(t, time.Date(year, month+1, 1, 0, 0, 0, 0, loc), 24*time.Hour)
+		case !cs.dayMatches(t):
+			t = advance(t, time.Date(year, month, day+1, 0, 0, 0, 0, loc), time.Hour)
+		case !hasBit(cs.hour, t.Hour()):
+			t = advance(t, time.Date(year, month, day, t.Hour()+1, 0, 0, 0, loc), time.Hour)
+		case !hasBit(cs.minute, t.Minute()):
+			t = advance(t, t.Truncate(time.Minute).Add(time.Minute), time.Minute)
+		case !hasBit(cs.second, t.Second()):
+			t = t.Add(time.Second)
+		default:
+			return t
+		}
+	}
+
+	return time.Time{}
+}
+
+// advance защита от зацикливания при переходе на летнее время: time.Date в "пропущенном" часе может вернуть время раньше текущего
+func advance(current time.Time, next time.Time, step time.Duration) time.Time {
+	if next.After(current) {
+		return next
+	}
+
+	return current.Add(step).Truncate(time.Minute)
+}
+
+func (cs *CronSchedule) dayMatches(t time.Time) bool {
+	dayOK := hasBit(cs.day, t.Day())
+	weekdayOK := hasBit(cs.weekday, int(t.Weekday()))
+	if cs.dayStar || cs.weekdayStar {
+		return dayOK && weekdayOK
+	}
+
+	return dayOK || weekdayOK
+}
+
+func (cs *CronSchedule) GetLocation() *time.Location {
+	return cs.location
+}
+
+func (cs *CronSchedule) String() string {
+	return cs.expr
+}
+
+func hasBit(mask uint64, value int) bool {
+	return mask&(1<<uint(value)) != 0
+}
@@ -0,0 +1,62 @@
+package worker
+
+import (
+	"context"
+	"time"
+)
+
+// Leader - признак лидерства реплики (см. lock.LeaderElector)
+type Leader interface {
+	// IsLeader текущая реплика удерживает лидерство
+	IsLeader() bool
+	// LeaderContext контекст текущего срока лидерства, отменяется при потере лидерства
+	LeaderContext() context.Context
+}
+
+// NewLeaderTimerDispatcher обертка TimerDispatcher, выполняется только пока реплика удерживает лидерство,
+// при потере лидерства контекст выполнения отменяется.
+//
+// Пример использования:
+//
+//	scheduler := worker.NewBaseScheduler(name, worker.NewLeaderTimerDispatcher(elector, dispatcher), conf, log)
+func NewLeaderTimerDispatcher(leader Leader, dispatcher TimerDispatcher) TimerDispatcher {
+	return func(ctx context.Context, eventTime time.Time) error {
+		if !leader.IsLeader() {
+			return nil
+		}
+
+		leaderCtx, cancel := leaderScopedContext(ctx, leader)
+		defer cancel()
+
+		return dispatcher(leaderCtx, eventTime)
+	}
+}
+
+// NewLeaderDataProvider обертка DispatcherDataProvider для BaseSchedulerDispatcher,
+// данные на обработку выдаются только пока реплика удерживает лидерство.
+//
+// Пример использования:
+//
+//	dispatcher := worker.NewBaseSchedulerDispatcher(name, conf, worker.NewLeaderDataProvider(elector, provider), handler, log)
+func NewLeaderDataProvider[D comparable](leader Leader, provider DispatcherDataProvider[D]) DispatcherDataProvider[D] {
+	return func(ctx context.Context, eventTime time.Time) ([]D, error) {
+		if !leader.IsLeader() {
+			return nil, nil
+		}
+
+		leaderCtx, cancel := leaderScopedContext(ctx, leader)
+		defer cancel()
+
+		return provider(leaderCtx, eventTime)
+	}
+}
+
+func leaderScopedContext(ctx context.Context, leader Leader) (context.Context, context.CancelFunc) {
+	res, cancel := context.WithCancel(ctx)
+	stop := context.AfterFunc(leader.LeaderContext(), cancel)
+
+	return res, func() {
+		stop()
+		cancel()
+	}
+}
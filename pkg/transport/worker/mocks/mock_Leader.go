@@ -0,0 +1,128 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockLeader creates a new instance of MockLeader. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockLeader(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockLeader {
+	mock := &MockLeader{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockLeader is an autogenerated mock type for the Leader type
+type MockLeader struct {
+	mock.Mock
+}
+
+type MockLeader_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockLeader) EXPECT() *MockLeader_Expecter {
+	return &MockLeader_Expecter{mock: &_m.Mock}
+}
+
+// IsLeader provides a mock function for the type MockLeader
+func (_mock *MockLeader) IsLeader() bool {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsLeader")
+	}
+
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func() bool); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	return r0
+}
+
+// MockLeader_IsLeader_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsLeader'
+type MockLeader_IsLeader_Call struct {
+	*mock.Call
+}
+
+// IsLeader is a helper method to define mock.On call
+func (_e *MockLeader_Expecter) IsLeader() *MockLeader_IsLeader_Call {
+	return &MockLeader_IsLeader_Call{Call: _e.mock.On("IsLeader")}
+}
+
+func (_c *MockLeader_IsLeader_Call) Run(run func()) *MockLeader_IsLeader_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockLeader_IsLeader_Call) Return(b bool) *MockLeader_IsLeader_Call {
+	_c.Call.Return(b)
+	return _c
+}
+
+func (_c *MockLeader_IsLeader_Call) RunAndReturn(run func() bool) *MockLeader_IsLeader_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LeaderContext provides a mock function for the type MockLeader
+func (_mock *MockLeader) LeaderContext() context.Context {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for LeaderContext")
+	}
+
+	var r0 context.Context
+	if returnFunc, ok := ret.Get(0).(func() context.Context); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(context.Context)
+		}
+	}
+	return r0
+}
+
+// MockLeader_LeaderContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LeaderContext'
+type MockLeader_LeaderContext_Call struct {
+	*mock.Call
+}
+
+// LeaderContext is a helper method to define mock.On call
+func (_e *MockLeader_Expecter) LeaderContext() *MockLeader_LeaderContext_Call {
+	return &MockLeader_LeaderContext_Call{Call: _e.mock.On("LeaderContext")}
+}
+
+func (_c *MockLeader_LeaderContext_Call) Run(run func()) *MockLeader_LeaderContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockLeader_LeaderContext_Call) Return(context1 context.Context) *MockLeader_LeaderContext_Call {
+	_c.Call.Return(context1)
+	return _c
+}
+
+func (_c *MockLeader_LeaderContext_Call) RunAndReturn(run func() context.Context) *MockLeader_LeaderContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,89 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockSchedule creates a new instance of MockSchedule. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSchedule(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSchedule {
+	mock := &MockSchedule{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockSchedule is an autogenerated mock type for the Schedule type
+type MockSchedule struct {
+	mock.Mock
+}
+
+type MockSchedule_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSchedule) EXPECT() *MockSchedule_Expecter {
+	return &MockSchedule_Expecter{mock: &_m.Mock}
+}
+
+// Next provides a mock function for the type MockSchedule
+func (_mock *MockSchedule) Next(after time.Time) time.Time {
+	ret := _mock.Called(after)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Next")
+	}
+
+	var r0 time.Time
+	if returnFunc, ok := ret.Get(0).(func(time.Time) time.Time); ok {
+		r0 = returnFunc(after)
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+	return r0
+}
+
+// MockSchedule_Next_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Next'
+type MockSchedule_Next_Call struct {
+	*mock.Call
+}
+
+// Next is a helper method to define mock.On call
+//   - after time.Time
+func (_e *MockSchedule_Expecter) Next(after any) *MockSchedule_Next_Call {
+	return &MockSchedule_Next_Call{Call: _e.mock.On("Next", after)}
+}
+
+func (_c *MockSchedule_Next_Call) Run(run func(after time.Time)) *MockSchedule_Next_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 time.Time
+		if args[0] != nil {
+			arg0 = args[0].(time.Time)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSchedule_Next_Call) Return(time1 time.Time) *MockSchedule_Next_Call {
+	_c.Call.Return(time1)
+	return _c
+}
+
+func (_c *MockSchedule_Next_Call) RunAndReturn(run func(after time.Time) time.Time) *MockSchedule_Next_Call {
+	_c.Call.Return(run)
+	return _c
+}
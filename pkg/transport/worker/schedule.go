@@ -0,0 +1,40 @@
+package worker
+
+import (
+	"time"
+)
+
+// Schedule расписание запусков планировщика
+type Schedule interface {
+	// Next время следующего запуска строго после after, нулевое время - запусков больше нет
+	Next(after time.Time) time.Time
+}
+
+// IntervalSchedule фиксированный интервал между окончанием предыдущего запуска и началом следующего
+type IntervalSchedule struct {
+	interval time.Duration
+}
+
+var _ Schedule = (*IntervalSchedule)(nil)
+
+func NewIntervalSchedule(interval time.Duration) *IntervalSchedule {
+	return &IntervalSchedule{
+		interval: interval,
+	}
+}
+
+func (is *IntervalSchedule) Next(after time.Time) time.Time {
+	if is.interval <= 0 {
+		return time.Time{}
+	}
+
+	return after.Add(is.interval)
+}
+
+func (is *IntervalSchedule) GetInterval() time.Duration {
+	return is.interval
+}
+
+func (is *IntervalSchedule) String() string {
+	return "@every " + is.interval.String()
+}
@@ -0,0 +1,101 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	loggermocks "github.com/ElfAstAhe/go-service-template/pkg/logger/mocks"
+	"github.com/ElfAstAhe/go-service-template/pkg/transport/worker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newSchedulerLogger() *loggermocks.MockLogger {
+	mockLog := &loggermocks.MockLogger{}
+	mockLog.On("GetLogger", mock.Anything).Return(mockLog)
+	mockLog.On("Debugf", mock.Anything, mock.Anything).Maybe()
+	mockLog.On("Warnf", mock.Anything, mock.Anything).Maybe()
+	mockLog.On("Errorf", mock.Anything, mock.Anything).Maybe()
+
+	return mockLog
+}
+
+func TestBaseScheduler_CronSchedule(t *testing.T) {
+	schedule, err := worker.ParseCron("* * * * * *", nil)
+	require.NoError(t, err)
+
+	fired := make(chan time.Time, 4)
+	scheduler := worker.NewBaseScheduler("cron-scheduler", func(_ context.Context, eventTime time.Time) error {
+		fired <- eventTime
+		return nil
+	}, worker.NewBaseSchedulerScheduleConfig(0, schedule, time.Second), newSchedulerLogger())
+
+	assert.True(t, scheduler.NextRun().IsZero())
+	require.NoError(t, scheduler.Start(context.Background()))
+
+	next := scheduler.NextRun()
+	require.False(t, next.IsZero())
+	assert.Equal(t, 0, next.Nanosecond())
+	assert.WithinDuration(t, time.Now(), next, time.Second)
+
+	select {
+	case <-fired:
+	case <-time.After(3 * time.Second):
+		t.Fatal("timeout waiting for cron run")
+	}
+	assert.Eventually(t, func() bool {
+		return scheduler.NextRun().After(next)
+	}, time.Second, 10*time.Millisecond)
+
+	require.NoError(t, scheduler.Stop(context.Background()))
+	assert.True(t, scheduler.NextRun().IsZero())
+}
+
+func TestBaseScheduler_IntervalKeepsStartInterval(t *testing.T) {
+	fired := make(chan struct{}, 8)
+	scheduler := worker.NewBaseScheduler("interval-scheduler", func(context.Context, time.Time) error {
+		fired <- struct{}{}
+		return nil
+	}, worker.NewBaseSchedulerConfig(0, 20*time.Millisecond, time.Second), newSchedulerLogger())
+
+	_, isInterval := scheduler.GetSchedule().(*worker.IntervalSchedule)
+	assert.True(t, isInterval)
+
+	require.NoError(t, scheduler.Start(context.Background()))
+	for range 2 {
+		select {
+		case <-fired:
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for interval run")
+		}
+	}
+	require.NoError(t, scheduler.Stop(context.Background()))
+}
+
+func TestBaseScheduler_StopDuringRun(t *testing.T) {
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	scheduler := worker.NewBaseScheduler("stop-during-run", func(context.Context, time.Time) error {
+		select {
+		case started <- struct{}{}:
+		default:
+		}
+		<-release
+
+		return nil
+	}, worker.NewBaseSchedulerConfig(0, 10*time.Millisecond, time.Second), newSchedulerLogger())
+
+	require.NoError(t, scheduler.Start(context.Background()))
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for run")
+	}
+
+	// запуск завершается уже во время остановки
+	time.AfterFunc(20*time.Millisecond, func() { close(release) })
+	require.NoError(t, scheduler.Stop(context.Background()))
+	assert.True(t, scheduler.NextRun().IsZero(), "Остановленный планировщик не должен сообщать следующий запуск")
+}
@@ -0,0 +1,132 @@
+package test
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/ElfAstAhe/go-service-template/pkg/transport/worker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustTime(t *testing.T, value string, loc *time.Location) time.Time {
+	t.Helper()
+	res, err := time.ParseInLocation(time.DateTime, value, loc)
+	require.NoError(t, err)
+
+	return res
+}
+
+func TestParseCron_Next(t *testing.T) {
+	testCases := []struct {
+		name  string
+		expr  string
+		after string
+		want  string
+	}{
+		{name: "daily at 03:00", expr: "0 3 * * *", after: "2026-03-10 03:00:00", want: "2026-03-11 03:00:00"},
+		{name: "daily before time", expr: "0 3 * * *", after: "2026-03-10 02:59:59", want: "2026-03-10 03:00:00"},
+		{name: "weekdays at 9", expr: "0 9 * * MON-FRI", after: "2026-03-13 10:00:00", want: "2026-03-16 09:00:00"},
+		{name: "every 15 minutes", expr: "*/15 * * * *", after: "2026-03-10 10:16:00", want: "2026-03-10 10:30:00"},
+		{name: "with seconds", expr: "30 */5 * * * *", after: "2026-03-10 10:00:31", want: "2026-03-10 10:05:30"},
+		{name: "list and range", expr: "0 8-10,18 * * *", after: "2026-03-10 10:30:00", want: "2026-03-10 18:00:00"},
+		{name: "month names", expr: "0 0 1 JAN,JUL *", after: "2026-03-10 00:00:00", want: "2026-07-01 00:00:00"},
+		{name: "sunday as 7", expr: "0 12 * * 7", after: "2026-03-10 00:00:00", want: "2026-03-15 12:00:00"},
+		{name: "range ending on sunday", expr: "0 12 * * FRI-SUN", after: "2026-03-10 00:00:00", want: "2026-03-13 12:00:00"},
+		{name: "range ending on sunday includes sunday", expr: "0 12 * * FRI-SUN", after: "2026-03-14 12:00:00", want: "2026-03-15 12:00:00"},
+		{name: "whole week ending on sunday", expr: "0 12 * * MON-SUN", after: "2026-03-14 12:00:00", want: "2026-03-15 12:00:00"},
+		{name: "range ending on sunday as 0", expr: "0 12 * * 6-0", after: "2026-03-10 00:00:00", want: "2026-03-14 12:00:00"},
+		{name: "range ending on sunday with step", expr: "0 12 * * MON-SUN/2", after: "2026-03-14 12:00:00", want: "2026-03-15 12:00:00"},
+		{name: "day of month or weekday", expr: "0 0 13 * FRI", after: "2026-03-10 00:00:00", want: "2026-03-13 00:00:00"},
+		{name: "leap day", expr: "0 0 29 2 *", after: "2026-03-10 00:00:00", want: "2028-02-29 00:00:00"},
+		{name: "step from value", expr: "0 10/6 * * *", after: "2026-03-10 16:00:00", want: "2026-03-10 22:00:00"},
+		{name: "hourly descriptor", expr: "@hourly", after: "2026-03-10 10:00:00", want: "2026-03-10 11:00:00"},
+		{name: "weekly descriptor", expr: "@weekly", after: "2026-03-10 10:00:00", want: "2026-03-15 00:00:00"},
+		{name: "yearly descriptor", expr: "@yearly", after: "2026-03-10 10:00:00", want: "2027-01-01 00:00:00"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			schedule, err := worker.ParseCron(tc.expr, nil)
+			require.NoError(t, err)
+
+			next := schedule.Next(mustTime(t, tc.after, time.UTC))
+			assert.Equal(t, mustTime(t, tc.want, time.UTC), next)
+		})
+	}
+}
+
+func TestParseCron_TimeZone(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+
+	schedule, err := worker.ParseCron("CRON_TZ=Europe/Moscow 0 3 * * *", nil)
+	require.NoError(t, err)
+	assert.Equal(t, moscow, schedule.GetLocation())
+
+	next := schedule.Next(mustTime(t, "2026-03-10 00:00:00", time.UTC))
+	assert.Equal(t, mustTime(t, "2026-03-11 03:00:00", moscow), next)
+	assert.Equal(t, "2026-03-11 00:00:00", next.UTC().Format(time.DateTime))
+
+	explicit, err := worker.ParseCron("0 3 * * *", moscow)
+	require.NoError(t, err)
+	assert.True(t, next.Equal(explicit.Next(mustTime(t, "2026-03-10 00:00:00", time.UTC))))
+}
+
+func TestParseCron_DaylightSaving(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	// 2026-03-08 02:30 не существует (переход на летнее время)
+	schedule, err := worker.ParseCron("30 2 * * *", newYork)
+	require.NoError(t, err)
+
+	next := schedule.Next(mustTime(t, "2026-03-08 00:00:00", newYork))
+	assert.Equal(t, mustTime(t, "2026-03-09 02:30:00", newYork), next)
+}
+
+func TestParseCron_Invalid(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"* * * * FRI-MON",
+		"* * * FOO *",
+		"TZ=Mars/Base * * * * *",
+	} {
+		_, err := worker.ParseCron(expr, nil)
+		assert.Error(t, err, expr)
+	}
+}
+
+func TestParseCron_Impossible(t *testing.T) {
+	schedule, err := worker.ParseCron("0 0 30 2 *", nil)
+	require.NoError(t, err)
+
+	assert.True(t, schedule.Next(time.Now()).IsZero())
+}
+
+func TestParseSchedule(t *testing.T) {
+	every, err := worker.ParseSchedule("@every 90s")
+	require.NoError(t, err)
+	interval, ok := every.(*worker.IntervalSchedule)
+	require.True(t, ok)
+	assert.Equal(t, 90*time.Second, interval.GetInterval())
+
+	after := mustTime(t, "2026-03-10 10:00:00", time.UTC)
+	assert.Equal(t, after.Add(90*time.Second), every.Next(after))
+
+	cron, err := worker.ParseSchedule("TZ=UTC @daily")
+	require.NoError(t, err)
+	assert.Equal(t, mustTime(t, "2026-03-11 00:00:00", time.UTC), cron.Next(after))
+
+	_, err = worker.ParseSchedule("@every soon")
+	assert.Error(t, err)
+}